@@ -3,29 +3,23 @@ package fishaudio
 import (
 	"context"
 	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
-// Credits represents the user's API credit balance.
-type Credits struct {
-	ID             string `json:"_id"`
-	UserID         string `json:"user_id"`
-	Credit         string `json:"credit"`
-	CreatedAt      string `json:"created_at"`
-	UpdatedAt      string `json:"updated_at"`
-	HasPhoneSHA256 *bool  `json:"has_phone_sha256,omitempty"`
-	HasFreeCredit  *bool  `json:"has_free_credit,omitempty"`
+// Expired reports whether the package expired at or before now.
+func (p *Package) Expired(now time.Time) bool {
+	return p.ExpiresAt != nil && !p.ExpiresAt.IsZero() && !now.Before(p.ExpiresAt.Time)
 }
 
-// Package represents the user's prepaid package information.
-type Package struct {
-	ID         string  `json:"_id"`
-	UserID     string  `json:"user_id"`
-	Type       string  `json:"type"`
-	Total      int     `json:"total"`
-	Balance    int     `json:"balance"`
-	CreatedAt  string  `json:"created_at"`
-	UpdatedAt  string  `json:"updated_at"`
-	FinishedAt *string `json:"finished_at,omitempty"`
+// Active reports whether the package has balance left and has not expired
+// at now.
+func (p *Package) Active(now time.Time) bool {
+	return p.Balance > 0 && !p.Expired(now)
 }
 
 // GetCreditsParams contains parameters for getting credits.
@@ -37,6 +31,12 @@ type GetCreditsParams struct {
 // AccountService provides account and billing operations.
 type AccountService struct {
 	client *Client
+
+	// Cache for GetCreditsCached
+	creditsMu     sync.Mutex
+	credits       *Credits
+	creditsAt     time.Time
+	creditsFlight singleflight.Group
 }
 
 // GetCredits returns the API credit balance.
@@ -59,7 +59,8 @@ func (s *AccountService) GetCredits(ctx context.Context, params *GetCreditsParam
 	return &result, nil
 }
 
-// GetPackage returns the user's package information.
+// GetPackage returns the user's current package. Use ListPackages for
+// accounts with more than one.
 //
 // Example:
 //
@@ -73,3 +74,33 @@ func (s *AccountService) GetPackage(ctx context.Context) (*Package, error) {
 
 	return &result, nil
 }
+
+// ListPackages returns all of the user's packages, including finished and
+// expired ones, fetching as many pages as needed.
+//
+// Example:
+//
+//	pkgs, err := client.Account.ListPackages(ctx)
+//	for _, pkg := range pkgs {
+//	    if pkg.Active(time.Now()) && pkg.ExpiresAt != nil {
+//	        fmt.Printf("%d left, expires %s\n", pkg.Balance, pkg.ExpiresAt.Format(time.DateOnly))
+//	    }
+//	}
+func (s *AccountService) ListPackages(ctx context.Context) ([]Package, error) {
+	return NewPager(1, func(ctx context.Context, pageNumber int) (*PaginatedResponse[Package], error) {
+		query := url.Values{}
+		query.Set("page_size", strconv.Itoa(packagesPageSize))
+		query.Set("page_number", strconv.Itoa(pageNumber))
+
+		var result PaginatedResponse[Package]
+		if err := s.client.doJSONRequest(ctx, http.MethodGet, "/wallet/self/packages?"+query.Encode(), nil, &result, nil); err != nil {
+			return nil, err
+		}
+		result.PageSize = packagesPageSize
+		result.PageNumber = pageNumber
+		return &result, nil
+	}).All(ctx)
+}
+
+// packagesPageSize is the page size ListPackages requests.
+const packagesPageSize = 100
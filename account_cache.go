@@ -0,0 +1,73 @@
+package fishaudio
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultCreditsCacheTTL is the TTL GetCreditsCached uses when given zero.
+const DefaultCreditsCacheTTL = 30 * time.Second
+
+// GetCreditsCached returns the credit balance, fetching it only if the
+// cached balance is older than ttl. Concurrent calls that miss the cache
+// share a single request, so it is safe to call on every request in hot
+// paths such as budget checks. A ttl of zero or less uses
+// DefaultCreditsCacheTTL.
+//
+// Each client, including each InWorkspace copy, has its own cache.
+//
+// Example:
+//
+//	credits, err := client.Account.GetCreditsCached(ctx, time.Minute)
+//	if err != nil {
+//	    return err
+//	}
+//	if ok, _ := credits.Covers(estimatedCost); !ok {
+//	    return errInsufficientCredits
+//	}
+func (s *AccountService) GetCreditsCached(ctx context.Context, ttl time.Duration) (*Credits, error) {
+	if ttl <= 0 {
+		ttl = DefaultCreditsCacheTTL
+	}
+
+	s.creditsMu.Lock()
+	if s.credits != nil && time.Since(s.creditsAt) < ttl {
+		credits := *s.credits
+		s.creditsMu.Unlock()
+		return &credits, nil
+	}
+	s.creditsMu.Unlock()
+
+	// The shared fetch outlives any one caller's cancellation, so a caller
+	// giving up doesn't fail the others; the client timeout still bounds it
+	ch := s.creditsFlight.DoChan("credits", func() (interface{}, error) {
+		credits, err := s.GetCredits(context.WithoutCancel(ctx), nil)
+		if err != nil {
+			return nil, err
+		}
+		s.creditsMu.Lock()
+		s.credits = credits
+		s.creditsAt = time.Now()
+		s.creditsMu.Unlock()
+		return credits, nil
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		credits := *res.Val.(*Credits)
+		return &credits, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// InvalidateCredits clears the balance cached by GetCreditsCached, e.g.
+// after a top-up.
+func (s *AccountService) InvalidateCredits() {
+	s.creditsMu.Lock()
+	s.credits = nil
+	s.creditsMu.Unlock()
+}
@@ -0,0 +1,117 @@
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAccountService_GetCreditsCached(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"credit":"%d.00"}`, n)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	first, err := client.Account.GetCreditsCached(ctx, time.Hour)
+	if err != nil {
+		t.Fatalf("GetCreditsCached() error = %v", err)
+	}
+	first.Credit = "mutated"
+	second, _ := client.Account.GetCreditsCached(ctx, time.Hour)
+	if second.Credit != "1.00" || requests.Load() != 1 {
+		t.Errorf("cached Credit = %q after %d requests, want 1.00 after 1", second.Credit, requests.Load())
+	}
+
+	client.Account.InvalidateCredits()
+	third, _ := client.Account.GetCreditsCached(ctx, time.Hour)
+	if third.Credit != "2.00" {
+		t.Errorf("Credit after InvalidateCredits = %q, want 2.00", third.Credit)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	fourth, _ := client.Account.GetCreditsCached(ctx, time.Millisecond)
+	if fourth.Credit != "3.00" {
+		t.Errorf("Credit after TTL = %q, want 3.00", fourth.Credit)
+	}
+}
+
+func TestAccountService_GetCreditsCached_SingleFlight(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"credit":"5.00"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	// One caller gives up early; the others still get the shared result
+	cancelled, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		ctx := context.Background()
+		if i == 0 {
+			ctx = cancelled
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = client.Account.GetCreditsCached(ctx, 0)
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("cancelled caller err = %v, want context.Canceled", errs[0])
+	}
+	for i, err := range errs[1:] {
+		if err != nil {
+			t.Errorf("caller %d err = %v", i+1, err)
+		}
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("requests = %d, want 1", n)
+	}
+}
+
+func TestAccountService_GetCreditsCached_ErrorNotCached(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"credit":"1.00"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if _, err := client.Account.GetCreditsCached(context.Background(), 0); err == nil {
+		t.Fatal("GetCreditsCached() error = nil, want server error")
+	}
+	credits, err := client.Account.GetCreditsCached(context.Background(), 0)
+	if err != nil || credits.Credit != "1.00" {
+		t.Errorf("GetCreditsCached() = %v, %v; want fresh fetch", credits, err)
+	}
+}
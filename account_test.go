@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestAccountService_GetCredits_DefaultParams(t *testing.T) {
@@ -160,8 +161,8 @@ func TestCredits_Fields(t *testing.T) {
 		ID:             "id",
 		UserID:         "user",
 		Credit:         "100.00",
-		CreatedAt:      "2024-01-01",
-		UpdatedAt:      "2024-01-02",
+		CreatedAt:      Timestamp{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		UpdatedAt:      Timestamp{Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
 		HasFreeCredit:  &hasFreeCredit,
 		HasPhoneSHA256: &hasPhone,
 	}
@@ -178,22 +179,93 @@ func TestCredits_Fields(t *testing.T) {
 }
 
 func TestPackage_Fields(t *testing.T) {
-	finishedAt := "2024-12-31"
+	finishedAt := Timestamp{Time: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)}
 	pkg := Package{
 		ID:         "id",
 		UserID:     "user",
 		Type:       "basic",
 		Total:      500,
 		Balance:    250,
-		CreatedAt:  "2024-01-01",
-		UpdatedAt:  "2024-01-02",
+		CreatedAt:  Timestamp{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		UpdatedAt:  Timestamp{Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
 		FinishedAt: &finishedAt,
 	}
 
 	if pkg.ID != "id" {
 		t.Errorf("ID = %q, want %q", pkg.ID, "id")
 	}
-	if pkg.FinishedAt == nil || *pkg.FinishedAt != "2024-12-31" {
+	if pkg.FinishedAt == nil || pkg.FinishedAt.Format("2006-01-02") != "2024-12-31" {
 		t.Errorf("FinishedAt = %v, want %q", pkg.FinishedAt, "2024-12-31")
 	}
 }
+
+func TestPackage_Expired(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	past := Timestamp{Time: now.Add(-time.Hour)}
+	future := Timestamp{Time: now.Add(time.Hour)}
+
+	tests := []struct {
+		name    string
+		pkg     Package
+		expired bool
+		active  bool
+	}{
+		{"no expiry", Package{Balance: 10}, false, true},
+		{"future expiry", Package{Balance: 10, ExpiresAt: &future}, false, true},
+		{"past expiry", Package{Balance: 10, ExpiresAt: &past}, true, false},
+		{"used up", Package{Balance: 0, ExpiresAt: &future}, false, false},
+		{"unparsed expiry", Package{Balance: 10, ExpiresAt: &Timestamp{Raw: "soon"}}, false, true},
+	}
+	for _, tt := range tests {
+		if got := tt.pkg.Expired(now); got != tt.expired {
+			t.Errorf("%s: Expired() = %v, want %v", tt.name, got, tt.expired)
+		}
+		if got := tt.pkg.Active(now); got != tt.active {
+			t.Errorf("%s: Active() = %v, want %v", tt.name, got, tt.active)
+		}
+	}
+}
+
+func TestAccountService_ListPackages(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wallet/self/packages" {
+			t.Errorf("Path = %q, want %q", r.URL.Path, "/wallet/self/packages")
+		}
+		page := r.URL.Query().Get("page_number")
+		pages = append(pages, page)
+		if got := r.URL.Query().Get("page_size"); got != "100" {
+			t.Errorf("page_size = %q, want 100", got)
+		}
+
+		// 101 packages: a full first page, then the rest
+		w.Header().Set("Content-Type", "application/json")
+		if page == "2" {
+			_, _ = w.Write([]byte(`{"total":101,"items":[
+				{"_id":"p2","type":"topup","total":500,"balance":0,"finished_at":"2024-05-01"}
+			]}`))
+			return
+		}
+		items := []string{`{"_id":"p1","type":"monthly","total":1000,"balance":400,"auto_renew":true,"expires_at":"2024-07-01T00:00:00Z"}`}
+		for len(items) < 100 {
+			items = append(items, `{"_id":"filler"}`)
+		}
+		_, _ = w.Write([]byte(`{"total":101,"items":[` + strings.Join(items, ",") + `]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	pkgs, err := client.Account.ListPackages(context.Background())
+	if err != nil {
+		t.Fatalf("ListPackages() error = %v", err)
+	}
+	if len(pkgs) != 101 || len(pages) != 2 || pages[0] != "1" || pages[1] != "2" {
+		t.Fatalf("got %d packages from pages %v, want 101 from [1 2]", len(pkgs), pages)
+	}
+	if pkgs[0].AutoRenew == nil || !*pkgs[0].AutoRenew || pkgs[0].ExpiresAt == nil || pkgs[0].ExpiresAt.Month() != time.July {
+		t.Errorf("pkgs[0] = %+v, want auto-renewing package expiring in July", pkgs[0])
+	}
+	if last := pkgs[100]; last.AutoRenew != nil || last.FinishedAt == nil {
+		t.Errorf("pkgs[100] = %+v, want finished package without renewal info", last)
+	}
+}
@@ -0,0 +1,205 @@
+package fishaudio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Transaction is one entry in the wallet history, such as a top-up or the
+// credits consumed by a request.
+type Transaction struct {
+	ID     string `json:"_id"`
+	UserID string `json:"user_id"`
+	// Type is the kind of transaction, e.g. "charge" or "consume".
+	Type string `json:"type"`
+	// Product is the product the credits were spent on, e.g. "tts" or "asr".
+	Product string `json:"product"`
+	// Credit is the credit amount of the transaction. Consumption is
+	// negative.
+	Credit      string    `json:"credit"`
+	Description string    `json:"description"`
+	CreatedAt   Timestamp `json:"created_at"`
+
+	// Extra holds response fields not known to this SDK version.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a Transaction, collecting unknown fields in Extra.
+func (t *Transaction) UnmarshalJSON(data []byte) error {
+	type transaction Transaction
+	if err := json.Unmarshal(data, (*transaction)(t)); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, t)
+	t.Extra = extra
+	return err
+}
+
+// ListTransactionsParams contains parameters for listing wallet
+// transactions.
+type ListTransactionsParams struct {
+	// PageSize is the number of results per page. Default: 10.
+	PageSize int
+	// PageNumber is the page number (1-indexed). Default: 1.
+	PageNumber int
+	// Product filters by product, e.g. "tts".
+	Product string
+	// Since filters to transactions on or after this time.
+	Since time.Time
+	// Until filters to transactions before this time.
+	Until time.Time
+}
+
+// ListTransactions lists the wallet history, newest first.
+//
+// Example:
+//
+//	txs, err := client.Account.ListTransactions(ctx, &fishaudio.ListTransactionsParams{
+//	    Since: time.Now().AddDate(0, -1, 0),
+//	})
+//	for _, tx := range txs.Items {
+//	    fmt.Printf("%s %s %s\n", tx.CreatedAt.Format(time.DateOnly), tx.Product, tx.Credit)
+//	}
+func (s *AccountService) ListTransactions(ctx context.Context, params *ListTransactionsParams) (*PaginatedResponse[Transaction], error) {
+	if params == nil {
+		params = &ListTransactionsParams{}
+	}
+
+	query := url.Values{}
+
+	pageSize := params.PageSize
+	if pageSize == 0 {
+		pageSize = 10
+	}
+	query.Set("page_size", strconv.Itoa(pageSize))
+
+	pageNumber := params.PageNumber
+	if pageNumber == 0 {
+		pageNumber = 1
+	}
+	query.Set("page_number", strconv.Itoa(pageNumber))
+
+	if params.Product != "" {
+		query.Set("product", params.Product)
+	}
+	setTimeRange(query, params.Since, params.Until)
+
+	path := "/wallet/self/transactions?" + query.Encode()
+	var result PaginatedResponse[Transaction]
+	if err := s.client.doJSONRequest(ctx, http.MethodGet, path, nil, &result, nil); err != nil {
+		return nil, err
+	}
+	result.PageSize = pageSize
+	result.PageNumber = pageNumber
+
+	return &result, nil
+}
+
+// TransactionsPager returns a Pager over the transactions matching params,
+// starting at params.PageNumber.
+//
+// Example:
+//
+//	txs, err := client.Account.TransactionsPager(&fishaudio.ListTransactionsParams{
+//	    PageSize: 100,
+//	    Product:  "tts",
+//	}).All(ctx)
+func (s *AccountService) TransactionsPager(params *ListTransactionsParams) *Pager[Transaction] {
+	var p ListTransactionsParams
+	if params != nil {
+		p = *params
+	}
+	return NewPager(p.PageNumber, func(ctx context.Context, pageNumber int) (*PaginatedResponse[Transaction], error) {
+		p.PageNumber = pageNumber
+		return s.ListTransactions(ctx, &p)
+	})
+}
+
+// UsageRecord is the credits consumed by one product on one day.
+type UsageRecord struct {
+	// Date is the UTC day the usage is aggregated over.
+	Date    Timestamp `json:"date"`
+	Product string    `json:"product"`
+	Credit  string    `json:"credit"`
+	// Count is the number of billed requests.
+	Count int `json:"count"`
+
+	// Extra holds response fields not known to this SDK version.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a UsageRecord, collecting unknown fields in Extra.
+func (r *UsageRecord) UnmarshalJSON(data []byte) error {
+	type usageRecord UsageRecord
+	if err := json.Unmarshal(data, (*usageRecord)(r)); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, r)
+	r.Extra = extra
+	return err
+}
+
+// Usage is the credit consumption returned by GetUsage.
+type Usage struct {
+	// Items holds one record per day and product, oldest first.
+	Items []UsageRecord `json:"items"`
+}
+
+// GetUsageParams contains parameters for getting usage.
+type GetUsageParams struct {
+	// Product filters by product, e.g. "tts".
+	Product string
+	// Since is the first day to include. Default: chosen by the API.
+	Since time.Time
+	// Until is the day after the last day to include. Default: today.
+	Until time.Time
+}
+
+// GetUsage returns the credits consumed per day and product.
+//
+// Example:
+//
+//	usage, err := client.Account.GetUsage(ctx, &fishaudio.GetUsageParams{
+//	    Since: time.Now().AddDate(0, 0, -30),
+//	})
+//	for _, r := range usage.Items {
+//	    fmt.Printf("%s %-4s %s credits\n", r.Date.Format(time.DateOnly), r.Product, r.Credit)
+//	}
+func (s *AccountService) GetUsage(ctx context.Context, params *GetUsageParams) (*Usage, error) {
+	if params == nil {
+		params = &GetUsageParams{}
+	}
+
+	query := url.Values{}
+	if params.Product != "" {
+		query.Set("product", params.Product)
+	}
+	setTimeRange(query, params.Since, params.Until)
+
+	path := "/wallet/self/usage"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var result Usage
+	if err := s.client.doJSONRequest(ctx, http.MethodGet, path, nil, &result, nil); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// setTimeRange adds the non-zero bounds of a time range to query in
+// RFC 3339.
+func setTimeRange(query url.Values, since, until time.Time) {
+	if !since.IsZero() {
+		query.Set("start_time", since.UTC().Format(time.RFC3339))
+	}
+	if !until.IsZero() {
+		query.Set("end_time", until.UTC().Format(time.RFC3339))
+	}
+}
@@ -0,0 +1,126 @@
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAccountService_ListTransactions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wallet/self/transactions" {
+			t.Errorf("Path = %q, want %q", r.URL.Path, "/wallet/self/transactions")
+		}
+		q := r.URL.Query()
+		checks := map[string]string{
+			"page_size":   "10",
+			"page_number": "2",
+			"product":     "tts",
+			"start_time":  "2024-03-01T00:00:00Z",
+			"end_time":    "",
+		}
+		for key, want := range checks {
+			if got := q.Get(key); got != want {
+				t.Errorf("%s = %q, want %q", key, got, want)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"total":11,"items":[{"_id":"tx1","type":"consume","product":"tts","credit":"-0.25","created_at":"2024-03-02T08:00:00Z","request_id":"r1"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	txs, err := client.Account.ListTransactions(context.Background(), &ListTransactionsParams{
+		PageNumber: 2,
+		Product:    "tts",
+		Since:      time.Date(2024, 3, 1, 1, 0, 0, 0, time.FixedZone("CET", 3600)),
+	})
+	if err != nil {
+		t.Fatalf("ListTransactions() error = %v", err)
+	}
+
+	if len(txs.Items) != 1 || txs.Items[0].Credit != "-0.25" || txs.Items[0].CreatedAt.Day() != 2 {
+		t.Fatalf("Items = %+v", txs.Items)
+	}
+	if _, ok := txs.Items[0].Extra["request_id"]; !ok {
+		t.Errorf("Extra = %v, want request_id", txs.Items[0].Extra)
+	}
+	if txs.HasNext() {
+		t.Error("HasNext() = true on the last page")
+	}
+}
+
+func TestAccountService_TransactionsPager(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page_number") == "1" {
+			_, _ = w.Write([]byte(`{"total":3,"items":[{"_id":"a"},{"_id":"b"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"total":3,"items":[{"_id":"c"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	txs, err := client.Account.TransactionsPager(&ListTransactionsParams{PageSize: 2}).All(context.Background())
+	if err != nil {
+		t.Fatalf("All() error = %v", err)
+	}
+	if len(txs) != 3 || txs[2].ID != "c" {
+		t.Errorf("All() = %+v, want 3 transactions", txs)
+	}
+}
+
+func TestAccountService_GetUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wallet/self/usage" {
+			t.Errorf("Path = %q, want %q", r.URL.Path, "/wallet/self/usage")
+		}
+		if got := r.URL.Query().Get("end_time"); got != "2024-04-01T00:00:00Z" {
+			t.Errorf("end_time = %q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[
+			{"date":"2024-03-01","product":"tts","credit":"1.5","count":12},
+			{"date":"2024-03-01","product":"asr","credit":"0.75","count":3}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	usage, err := client.Account.GetUsage(context.Background(), &GetUsageParams{
+		Until: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("GetUsage() error = %v", err)
+	}
+	if len(usage.Items) != 2 {
+		t.Fatalf("len(Items) = %d, want 2", len(usage.Items))
+	}
+	r := usage.Items[0]
+	if r.Product != "tts" || r.Credit != "1.5" || r.Count != 12 || r.Date.Month() != time.March {
+		t.Errorf("Items[0] = %+v", r)
+	}
+}
+
+func TestAccountService_GetUsage_NoParams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "" {
+			t.Errorf("RawQuery = %q, want empty", r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	_, err := client.Account.GetUsage(context.Background(), nil)
+	var authErr *AuthenticationError
+	if !errors.As(err, &authErr) {
+		t.Errorf("GetUsage() err = %v, want *AuthenticationError", err)
+	}
+}
@@ -0,0 +1,84 @@
+package fishaudio
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// CreditAlert reports a credit balance crossing a WatchCredits threshold.
+type CreditAlert struct {
+	// Credits is the balance response that triggered the alert.
+	Credits *Credits
+	// Balance is the parsed balance.
+	Balance MicroCredits
+	// Threshold is the threshold that was crossed.
+	Threshold MicroCredits
+	// Below is true when the balance dropped below Threshold and false when
+	// it recovered to Threshold or above.
+	Below bool
+}
+
+// WatchCredits polls the credit balance every interval and calls callback
+// when it crosses threshold: once when it drops below, including on the
+// first poll, and once when it recovers. Each wait is jittered by up to 10%
+// so many watchers don't poll in lockstep. An interval of zero or less
+// polls every minute.
+//
+// Transient errors (rate limits, server errors, and network failures) are
+// retried at the next poll. WatchCredits blocks until ctx is done, returning
+// ctx's error, or until a request fails with a non-transient error.
+//
+// Example:
+//
+//	go func() {
+//	    err := client.Account.WatchCredits(ctx, 50*fishaudio.OneCredit, 5*time.Minute,
+//	        func(alert fishaudio.CreditAlert) {
+//	            if alert.Below {
+//	                notify("Fish Audio balance low: " + alert.Balance.String())
+//	            }
+//	        })
+//	    log.Printf("credit watcher stopped: %v", err)
+//	}()
+func (s *AccountService) WatchCredits(ctx context.Context, threshold MicroCredits, interval time.Duration, callback func(CreditAlert)) error {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	below := false
+	for {
+		credits, err := s.GetCredits(ctx, nil)
+		switch {
+		case err == nil:
+			balance, err := credits.Amount()
+			if err != nil {
+				return err
+			}
+			if now := balance < threshold; now != below {
+				below = now
+				callback(CreditAlert{Credits: credits, Balance: balance, Threshold: threshold, Below: below})
+			}
+		case ctx.Err() != nil:
+			// The error is from the cancelled request; report ctx below
+		case !isTransient(err):
+			return err
+		}
+
+		timer := time.NewTimer(jitter(interval))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// jitter returns d randomly adjusted by up to 10% either way.
+func jitter(d time.Duration) time.Duration {
+	spread := int64(d / 10)
+	if spread <= 0 {
+		return d
+	}
+	return d + time.Duration(rand.Int64N(2*spread+1)-spread)
+}
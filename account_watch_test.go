@@ -0,0 +1,108 @@
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAccountService_WatchCredits(t *testing.T) {
+	// The balance drops below 10, a poll fails, then it is topped up
+	responses := []string{"12.00", "9.50", "", "8.00", "20.00", "15.00"}
+	var mu sync.Mutex
+	polls := 0
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if polls >= len(responses) {
+			cancel()
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		credit := responses[polls]
+		polls++
+		if credit == "" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"credit":%q}`, credit)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	var alerts []CreditAlert
+	err := client.Account.WatchCredits(ctx, 10*OneCredit, time.Millisecond, func(a CreditAlert) {
+		alerts = append(alerts, a)
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("WatchCredits() err = %v, want context.Canceled", err)
+	}
+
+	if len(alerts) != 2 {
+		t.Fatalf("got %d alerts, want 2: %+v", len(alerts), alerts)
+	}
+	if !alerts[0].Below || alerts[0].Balance != 9_500_000 || alerts[0].Threshold != 10*OneCredit {
+		t.Errorf("alerts[0] = %+v, want drop to 9.5", alerts[0])
+	}
+	if alerts[1].Below || alerts[1].Credits.Credit != "20.00" {
+		t.Errorf("alerts[1] = %+v, want recovery to 20", alerts[1])
+	}
+}
+
+func TestAccountService_WatchCredits_BelowOnFirstPoll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"credit":"1.00"}`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	alerts := 0
+	err := client.Account.WatchCredits(ctx, 5*OneCredit, time.Millisecond, func(CreditAlert) { alerts++ })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WatchCredits() err = %v, want context.DeadlineExceeded", err)
+	}
+	if alerts != 1 {
+		t.Errorf("got %d alerts, want 1", alerts)
+	}
+}
+
+func TestAccountService_WatchCredits_PermanentError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	err := client.Account.WatchCredits(context.Background(), OneCredit, time.Millisecond, func(CreditAlert) {
+		t.Error("callback called on error")
+	})
+	var authErr *AuthenticationError
+	if !errors.As(err, &authErr) {
+		t.Errorf("WatchCredits() err = %v, want *AuthenticationError", err)
+	}
+}
+
+func TestJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if got := jitter(time.Second); got < 900*time.Millisecond || got > 1100*time.Millisecond {
+			t.Fatalf("jitter(1s) = %v, want within 10%%", got)
+		}
+	}
+	if got := jitter(5); got != 5 {
+		t.Errorf("jitter(5ns) = %v, want 5ns", got)
+	}
+}
@@ -0,0 +1,51 @@
+// Package agenttools exposes Fish Audio speech synthesis and transcription
+// as tools for LLM agents. Tool has the same method set as LangChainGo's
+// tools.Tool, so the tools here can be passed to LangChainGo agents
+// directly, and to other frameworks with a thin wrapper.
+//
+// Example with LangChainGo:
+//
+//	agentTools := []tools.Tool{
+//	    &agenttools.Speak{Client: client, Dir: "out"},
+//	    &agenttools.Transcribe{Client: client, Root: "recordings"},
+//	}
+//	agent := agents.NewOneShotAgent(llm, agentTools)
+package agenttools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// Tool is a capability an agent can call with a text input.
+type Tool interface {
+	// Name is the tool name shown to the model.
+	Name() string
+	// Description tells the model when and how to use the tool.
+	Description() string
+	// Call runs the tool with the model's input and returns its answer.
+	Call(ctx context.Context, input string) (string, error)
+}
+
+// parseInput reads a tool input that is either plain text or a JSON object,
+// as models produce both. It returns the field named key of an object, or
+// the whole input otherwise, along with the object's string fields.
+func parseInput(input, key string) (string, map[string]string) {
+	input = strings.TrimSpace(input)
+	var fields map[string]interface{}
+	if !strings.HasPrefix(input, "{") || json.Unmarshal([]byte(input), &fields) != nil {
+		return input, nil
+	}
+
+	strs := map[string]string{}
+	for k, v := range fields {
+		if s, ok := v.(string); ok {
+			strs[k] = s
+		}
+	}
+	if value, ok := strs[key]; ok {
+		return value, strs
+	}
+	return input, strs
+}
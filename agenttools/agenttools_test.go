@@ -0,0 +1,31 @@
+package agenttools
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		input  string
+		value  string
+		fields map[string]string
+	}{
+		{"  Hello there \n", "Hello there", nil},
+		{`{"text": "Hi", "voice": "abc", "n": 1}`, "Hi", map[string]string{"text": "Hi", "voice": "abc"}},
+		{`{"other": "x"}`, `{"other": "x"}`, map[string]string{"other": "x"}},
+		{`{not json`, `{not json`, nil},
+	}
+	for _, tt := range tests {
+		value, fields := parseInput(tt.input, "text")
+		if value != tt.value || !reflect.DeepEqual(fields, tt.fields) {
+			t.Errorf("parseInput(%q) = %q, %v, want %q, %v", tt.input, value, fields, tt.value, tt.fields)
+		}
+	}
+}
+
+// Both tools must satisfy Tool, and with it LangChainGo's tools.Tool.
+var (
+	_ Tool = (*Speak)(nil)
+	_ Tool = (*Transcribe)(nil)
+)
@@ -0,0 +1,87 @@
+package agenttools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+)
+
+// Speak is a Tool that synthesizes speech from the model's input and
+// saves it to a file. The input is the text to speak, or a JSON object
+// with "text" and an optional "voice" (a voice model ID).
+type Speak struct {
+	// Client performs the synthesis (required).
+	Client *fishaudio.Client
+
+	// Params is the template for each request; its Text is replaced by
+	// the input. Default: the client's defaults, MP3 output.
+	Params *fishaudio.StreamParams
+
+	// Dir is the directory audio files are written to. Default: the
+	// system temporary directory.
+	Dir string
+
+	// Save, if set, replaces writing a file: it receives the audio and
+	// returns the text reported to the model, e.g. a URL after uploading.
+	Save func(ctx context.Context, audio []byte) (string, error)
+}
+
+// Name implements Tool.
+func (t *Speak) Name() string { return "text_to_speech" }
+
+// Description implements Tool.
+func (t *Speak) Description() string {
+	return `Converts text to spoken audio and returns where the audio was saved. ` +
+		`Input: the text to speak, or a JSON object {"text": "...", "voice": "<voice model ID>"}.`
+}
+
+// Call implements Tool.
+func (t *Speak) Call(ctx context.Context, input string) (string, error) {
+	text, fields := parseInput(input, "text")
+	if text == "" {
+		return "", errors.New("agenttools: no text to speak")
+	}
+
+	var params fishaudio.StreamParams
+	if t.Params != nil {
+		params = *t.Params
+	}
+	params.Text = text
+	if voice := fields["voice"]; voice != "" {
+		params.ReferenceID = voice
+	}
+	if params.Format == "" {
+		params.Format = fishaudio.AudioFormatMP3
+	}
+
+	stream, err := t.Client.TTS.Stream(ctx, &params)
+	if err != nil {
+		return "", err
+	}
+	audio, err := stream.Collect()
+	if err != nil {
+		return "", err
+	}
+
+	if t.Save != nil {
+		return t.Save(ctx, audio)
+	}
+
+	f, err := os.CreateTemp(t.Dir, "speech-*."+string(params.Format))
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(audio); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	return fmt.Sprintf("Saved speech audio to %s", f.Name()), nil
+}
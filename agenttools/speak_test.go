@@ -0,0 +1,79 @@
+package agenttools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+)
+
+func newSpeechServer(t *testing.T, wantVoice string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["text"] != "Hello" {
+			t.Errorf("text = %v, want Hello", body["text"])
+		}
+		if got, _ := body["reference_id"].(string); got != wantVoice {
+			t.Errorf("reference_id = %q, want %q", got, wantVoice)
+		}
+		_, _ = w.Write([]byte("audio"))
+	}))
+}
+
+func TestSpeak_Call(t *testing.T) {
+	server := newSpeechServer(t, "template-voice")
+	defer server.Close()
+
+	dir := t.TempDir()
+	tool := &Speak{
+		Client: fishaudio.NewClient(fishaudio.WithAPIKey("test-key"), fishaudio.WithBaseURL(server.URL)),
+		Params: &fishaudio.StreamParams{ReferenceID: "template-voice"},
+		Dir:    dir,
+	}
+	out, err := tool.Call(context.Background(), "Hello")
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+
+	path := strings.TrimPrefix(out, "Saved speech audio to ")
+	if !strings.HasPrefix(path, dir) || !strings.HasSuffix(path, ".mp3") {
+		t.Fatalf("Call() = %q, want an mp3 path in %s", out, dir)
+	}
+	if audio, _ := os.ReadFile(path); string(audio) != "audio" {
+		t.Errorf("file contents = %q, want %q", audio, "audio")
+	}
+}
+
+func TestSpeak_Call_JSONAndSave(t *testing.T) {
+	server := newSpeechServer(t, "json-voice")
+	defer server.Close()
+
+	var saved []byte
+	tool := &Speak{
+		Client: fishaudio.NewClient(fishaudio.WithAPIKey("test-key"), fishaudio.WithBaseURL(server.URL)),
+		Save: func(ctx context.Context, audio []byte) (string, error) {
+			saved = audio
+			return "https://cdn.example.com/speech.mp3", nil
+		},
+	}
+	out, err := tool.Call(context.Background(), `{"text": "Hello", "voice": "json-voice"}`)
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if out != "https://cdn.example.com/speech.mp3" || string(saved) != "audio" {
+		t.Errorf("Call() = %q, saved %q", out, saved)
+	}
+}
+
+func TestSpeak_Call_Empty(t *testing.T) {
+	tool := &Speak{Client: fishaudio.NewClient(fishaudio.WithAPIKey("test-key"))}
+	if _, err := tool.Call(context.Background(), "  "); err == nil {
+		t.Error("Call() error = nil, want error for empty input")
+	}
+}
@@ -0,0 +1,92 @@
+package agenttools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+)
+
+// Transcribe is a Tool that transcribes audio named by the model's input:
+// an http or https URL, or a file path under Root. The input may also be a
+// JSON object with "audio" and an optional "language".
+type Transcribe struct {
+	// Client performs the transcription (required).
+	Client *fishaudio.Client
+
+	// Params is the template for each request. Its ResponseFormat is
+	// ignored; the tool returns plain text.
+	Params *fishaudio.TranscribeParams
+
+	// Root is the directory the model may read audio files from. Paths are
+	// resolved relative to it and may not escape it. If empty, only URLs
+	// are accepted.
+	Root string
+}
+
+// Name implements Tool.
+func (t *Transcribe) Name() string { return "speech_to_text" }
+
+// Description implements Tool.
+func (t *Transcribe) Description() string {
+	return `Transcribes speech in an audio file and returns the text. ` +
+		`Input: an audio URL or file path, or a JSON object {"audio": "...", "language": "en"}.`
+}
+
+// Call implements Tool.
+func (t *Transcribe) Call(ctx context.Context, input string) (string, error) {
+	audio, fields := parseInput(input, "audio")
+	if audio == "" {
+		return "", errors.New("agenttools: no audio to transcribe")
+	}
+
+	var params fishaudio.TranscribeParams
+	if t.Params != nil {
+		params = *t.Params
+	}
+	params.ResponseFormat = ""
+	if lang := fields["language"]; lang != "" {
+		params.Language = fishaudio.Language(lang)
+	}
+
+	var result *fishaudio.ASRResponse
+	var err error
+	if u, perr := url.Parse(audio); perr == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		result, err = t.Client.ASR.TranscribeURL(ctx, audio, &params)
+	} else {
+		path, perr := t.resolve(audio)
+		if perr != nil {
+			return "", perr
+		}
+		result, err = t.Client.ASR.TranscribeFile(ctx, path, &params)
+	}
+	if err != nil {
+		return "", err
+	}
+	return result.Text, nil
+}
+
+// resolve returns the path of name under Root, rejecting paths that
+// escape it.
+func (t *Transcribe) resolve(name string) (string, error) {
+	if t.Root == "" {
+		return "", errors.New("agenttools: reading local files is disabled; pass an http or https URL")
+	}
+	root, err := filepath.Abs(t.Root)
+	if err != nil {
+		return "", err
+	}
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	path = filepath.Clean(path)
+	if rel, err := filepath.Rel(root, path); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("agenttools: %q is outside the allowed directory", name)
+	}
+	return path, nil
+}
@@ -0,0 +1,99 @@
+package agenttools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+)
+
+func newTranscribeServer(t *testing.T, check func(r *http.Request)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Errorf("ParseMultipartForm error = %v", err)
+			return
+		}
+		check(r)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(fishaudio.ASRResponse{Text: "hello world"})
+	}))
+}
+
+func TestTranscribe_Call_URL(t *testing.T) {
+	server := newTranscribeServer(t, func(r *http.Request) {
+		if got := r.FormValue("audio_url"); got != "https://example.com/a.mp3" {
+			t.Errorf("audio_url = %q", got)
+		}
+		if got := r.FormValue("language"); got != "de" {
+			t.Errorf("language = %q, want de", got)
+		}
+		if _, ok := r.MultipartForm.Value["response_format"]; ok {
+			t.Error("response_format should not be sent")
+		}
+	})
+	defer server.Close()
+
+	tool := &Transcribe{
+		Client: fishaudio.NewClient(fishaudio.WithAPIKey("test-key"), fishaudio.WithBaseURL(server.URL)),
+		Params: &fishaudio.TranscribeParams{Language: "en", ResponseFormat: fishaudio.ASRResponseSRT},
+	}
+	out, err := tool.Call(context.Background(), `{"audio": "https://example.com/a.mp3", "language": "de"}`)
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if out != "hello world" {
+		t.Errorf("Call() = %q, want %q", out, "hello world")
+	}
+}
+
+func TestTranscribe_Call_File(t *testing.T) {
+	server := newTranscribeServer(t, func(r *http.Request) {
+		if _, _, err := r.FormFile("audio"); err != nil {
+			t.Errorf("FormFile error = %v", err)
+		}
+	})
+	defer server.Close()
+
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "note.wav"), []byte("RIFF....WAVE"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tool := &Transcribe{
+		Client: fishaudio.NewClient(fishaudio.WithAPIKey("test-key"), fishaudio.WithBaseURL(server.URL)),
+		Root:   root,
+	}
+	out, err := tool.Call(context.Background(), "note.wav")
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if out != "hello world" {
+		t.Errorf("Call() = %q, want %q", out, "hello world")
+	}
+}
+
+func TestTranscribe_Resolve(t *testing.T) {
+	root := t.TempDir()
+	tool := &Transcribe{Root: root}
+
+	if got, err := tool.resolve("sub/a.wav"); err != nil || got != filepath.Join(root, "sub", "a.wav") {
+		t.Errorf("resolve(sub/a.wav) = %q, %v", got, err)
+	}
+	if got, err := tool.resolve(filepath.Join(root, "a.wav")); err != nil || got != filepath.Join(root, "a.wav") {
+		t.Errorf("resolve(absolute inside root) = %q, %v", got, err)
+	}
+	for _, name := range []string{"../secret.wav", "/etc/passwd", "sub/../../x"} {
+		if _, err := tool.resolve(name); err == nil {
+			t.Errorf("resolve(%q) error = nil, want outside root", name)
+		}
+	}
+
+	if _, err := (&Transcribe{}).resolve("a.wav"); err == nil {
+		t.Error("resolve() with no Root error = nil, want files disabled")
+	}
+}
@@ -8,18 +8,12 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
 )
 
-// ASRSegment represents a timestamped segment of transcribed text.
-type ASRSegment struct {
-	// Text is the transcribed text for this segment.
-	Text string `json:"text"`
-	// Start is the segment start time in seconds.
-	Start float64 `json:"start"`
-	// End is the segment end time in seconds.
-	End float64 `json:"end"`
-}
-
 // ASRResponse contains the result of speech-to-text transcription.
 type ASRResponse struct {
 	// Text is the complete transcription of the audio.
@@ -28,14 +22,96 @@ type ASRResponse struct {
 	Duration float64 `json:"duration"`
 	// Segments contains timestamped text segments.
 	Segments []ASRSegment `json:"segments"`
+	// Formatted is the response body as returned by the server when
+	// TranscribeParams.ResponseFormat is text, SRT, or WebVTT. It is empty
+	// for JSON responses.
+	Formatted string `json:"-"`
+	// Usage is what the request was billed, or nil if the response carried
+	// no usage headers.
+	Usage *RequestUsage `json:"-"`
+
+	// Extra holds response fields not known to this SDK version.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes an ASRResponse, collecting unknown fields in Extra.
+func (r *ASRResponse) UnmarshalJSON(data []byte) error {
+	type response ASRResponse
+	if err := json.Unmarshal(data, (*response)(r)); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, r)
+	r.Extra = extra
+	return err
+}
+
+// ASRResponseFormat specifies the format of a transcription response.
+type ASRResponseFormat string
+
+const (
+	// ASRResponseJSON returns the structured transcript. This is the default.
+	ASRResponseJSON ASRResponseFormat = "json"
+	// ASRResponseText returns the transcript as plain text.
+	ASRResponseText ASRResponseFormat = "text"
+	// ASRResponseSRT returns the transcript as a SubRip subtitle file.
+	ASRResponseSRT ASRResponseFormat = "srt"
+	// ASRResponseVTT returns the transcript as a WebVTT subtitle file.
+	ASRResponseVTT ASRResponseFormat = "vtt"
+)
+
+// Hotword is a word or phrase the recognizer should favor, such as a
+// product name or domain jargon.
+type Hotword struct {
+	// Text is the word or phrase.
+	Text string `json:"text"`
+	// Weight is how strongly to favor the text. Zero uses the server default.
+	Weight float64 `json:"weight,omitempty"`
 }
 
 // TranscribeParams contains parameters for ASR transcription.
 type TranscribeParams struct {
-	// Language is the language code (e.g., "en", "zh"). Auto-detected if empty.
-	Language string
+	// Language is the spoken language, e.g. LanguageEnglish. Auto-detected if empty.
+	Language Language
 	// IncludeTimestamps indicates whether to include timestamp information. Default: true.
 	IncludeTimestamps *bool
+	// Hotwords are words and phrases to boost during recognition.
+	Hotwords []Hotword
+	// Punctuate requests automatic punctuation. Server default if nil.
+	Punctuate *bool
+	// Truecase requests restoring capitalization. Server default if nil.
+	Truecase *bool
+	// Filename is the filename sent with the audio, e.g. "call.opus".
+	// Default: detected from the audio, or "audio.mp3" if the format is unknown.
+	Filename string
+	// ContentType is the MIME type sent with the audio, e.g. "audio/ogg".
+	// Default: detected from the audio, or "application/octet-stream".
+	ContentType string
+	// ResponseFormat is the format the server returns the transcript in.
+	// For formats other than JSON, the body is returned in
+	// ASRResponse.Formatted and only Text is filled for ASRResponseText.
+	// Default: ASRResponseJSON.
+	ResponseFormat ASRResponseFormat
+	// Extra holds additional form fields to send, for server options this
+	// SDK version doesn't support yet. Keys must not be fields the SDK
+	// writes itself, such as "language".
+	Extra map[string]string
+}
+
+// transcribeFields are the form fields written by the SDK, which Extra
+// must not repeat.
+var transcribeFields = []string{"audio", "audio_url", "language", "ignore_timestamps", "hotwords", "punctuate", "truecase", "response_format"}
+
+// validate checks the language and the Extra keys.
+func (p *TranscribeParams) validate() error {
+	if err := p.Language.validate(); err != nil {
+		return err
+	}
+	for key := range p.Extra {
+		if key == "" || slices.Contains(transcribeFields, key) {
+			return newValidationError("extra field %q is reserved; set it with its TranscribeParams field", key)
+		}
+	}
+	return nil
 }
 
 // ASRService provides speech-to-text operations.
@@ -49,31 +125,135 @@ type ASRService struct {
 //
 //	audio, _ := os.ReadFile("audio.mp3")
 //	result, err := client.ASR.Transcribe(ctx, audio, &fishaudio.TranscribeParams{
-//	    Language: "en",
+//	    Language: fishaudio.LanguageEnglish,
 //	})
 //	fmt.Println(result.Text)
 func (s *ASRService) Transcribe(ctx context.Context, audio []byte, params *TranscribeParams) (*ASRResponse, error) {
+	return s.transcribe(ctx, bytes.NewReader(audio), newAudioFile(detectAudioFormat(audio)), params)
+}
+
+// TranscribeReader converts audio read from r to text. The request body is
+// streamed as it is read, so large recordings are never held in memory.
+// The container format is detected from the first bytes, as in TranscribeFile.
+// Because the body length is unknown, it is sent with chunked encoding.
+//
+// Example:
+//
+//	f, _ := os.Open("meeting.wav")
+//	defer f.Close()
+//	result, err := client.ASR.TranscribeReader(ctx, f, nil)
+func (s *ASRService) TranscribeReader(ctx context.Context, r io.Reader, params *TranscribeParams) (*ASRResponse, error) {
+	r, ext, contentType, err := sniffAudio(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audio: %w", err)
+	}
+	return s.transcribe(ctx, r, newAudioFile(ext, contentType), params)
+}
+
+// TranscribeURL converts audio hosted at audioURL to text. The server
+// fetches the audio itself, so recordings in object storage or on a CDN are
+// not downloaded and re-uploaded by the caller. The URL must be reachable
+// from the Fish Audio API, e.g. a presigned S3 URL.
+//
+// Example:
+//
+//	result, err := client.ASR.TranscribeURL(ctx, presignedURL, &fishaudio.TranscribeParams{
+//	    Language: fishaudio.LanguageEnglish,
+//	})
+func (s *ASRService) TranscribeURL(ctx context.Context, audioURL string, params *TranscribeParams) (*ASRResponse, error) {
 	if params == nil {
 		params = &TranscribeParams{}
 	}
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
 
-	// Build multipart form
-	var buf bytes.Buffer
-	writer := multipart.NewWriter(&buf)
+	u, err := url.Parse(audioURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid audio URL %q: must be an absolute http or https URL", audioURL)
+	}
 
-	// Add audio file
-	part, err := writer.CreateFormFile("audio", "audio.mp3")
+	return s.send(ctx, params.ResponseFormat, func(writer *multipart.Writer) error {
+		if err := writer.WriteField("audio_url", audioURL); err != nil {
+			return fmt.Errorf("failed to write audio_url: %w", err)
+		}
+		return writeTranscribeFields(writer, params)
+	})
+}
+
+// TranscribeFile converts the audio file at path, opened with the client's
+// Storage, to text. The container
+// (WAV, MP3, Ogg, or FLAC) is detected from the file contents so the upload
+// carries an accurate filename and content type, and the file is streamed
+// rather than read into memory.
+//
+// Example:
+//
+//	result, err := client.ASR.TranscribeFile(ctx, "interview.flac", &fishaudio.TranscribeParams{
+//	    Language: fishaudio.LanguageEnglish,
+//	})
+func (s *ASRService) TranscribeFile(ctx context.Context, path string, params *TranscribeParams) (*ASRResponse, error) {
+	f, err := s.client.files().Open(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %w", err)
+		return nil, err
 	}
-	if _, err := part.Write(audio); err != nil {
-		return nil, fmt.Errorf("failed to write audio: %w", err)
+	defer func() { _ = f.Close() }()
+
+	r, ext, contentType, err := sniffAudio(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audio: %w", err)
+	}
+
+	file := formFile{name: filepath.Base(path), contentType: "application/octet-stream"}
+	if ext != "" {
+		file.name = strings.TrimSuffix(file.name, filepath.Ext(file.name)) + "." + ext
+		file.contentType = contentType
+	}
+
+	return s.transcribe(ctx, r, file, params)
+}
+
+// transcribe uploads audio with the transcription fields.
+func (s *ASRService) transcribe(ctx context.Context, audio io.Reader, file formFile, params *TranscribeParams) (*ASRResponse, error) {
+	if params == nil {
+		params = &TranscribeParams{}
+	}
+	if err := params.validate(); err != nil {
+		return nil, err
 	}
+	upload, err := s.client.upload(audio)
+	if err != nil {
+		return nil, err
+	}
+	defer upload.close()
+	return s.send(ctx, params.ResponseFormat, func(writer *multipart.Writer) error {
+		return writeTranscribeForm(writer, upload, file, params)
+	}, upload)
+}
+
+// writeTranscribeForm writes the audio and transcription fields.
+func writeTranscribeForm(writer *multipart.Writer, audio io.Reader, file formFile, params *TranscribeParams) error {
+	if params.Filename != "" {
+		file.name = params.Filename
+	}
+	if params.ContentType != "" {
+		file.contentType = params.ContentType
+	}
+
+	// Add audio file
+	if err := writeFilePart(writer, "audio", file, audio); err != nil {
+		return fmt.Errorf("failed to write audio: %w", err)
+	}
+
+	return writeTranscribeFields(writer, params)
+}
 
+// writeTranscribeFields writes the transcription option fields.
+func writeTranscribeFields(writer *multipart.Writer, params *TranscribeParams) error {
 	// Add language if specified
 	if params.Language != "" {
-		if err := writer.WriteField("language", params.Language); err != nil {
-			return nil, fmt.Errorf("failed to write language: %w", err)
+		if err := writer.WriteField("language", string(params.Language)); err != nil {
+			return fmt.Errorf("failed to write language: %w", err)
 		}
 	}
 
@@ -83,41 +263,89 @@ func (s *ASRService) Transcribe(ctx context.Context, audio []byte, params *Trans
 		includeTimestamps = *params.IncludeTimestamps
 	}
 	if err := writer.WriteField("ignore_timestamps", fmt.Sprintf("%t", !includeTimestamps)); err != nil {
-		return nil, fmt.Errorf("failed to write ignore_timestamps: %w", err)
+		return fmt.Errorf("failed to write ignore_timestamps: %w", err)
 	}
 
-	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close writer: %w", err)
+	// Add hotwords as a JSON array
+	if len(params.Hotwords) > 0 {
+		hotwords, err := json.Marshal(params.Hotwords)
+		if err != nil {
+			return fmt.Errorf("failed to encode hotwords: %w", err)
+		}
+		if err := writer.WriteField("hotwords", string(hotwords)); err != nil {
+			return fmt.Errorf("failed to write hotwords: %w", err)
+		}
 	}
 
-	// Create request
-	url := s.client.baseURL + "/v1/asr"
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &buf)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	// Add text formatting flags only when set so the server default applies otherwise
+	if params.Punctuate != nil {
+		if err := writer.WriteField("punctuate", fmt.Sprintf("%t", *params.Punctuate)); err != nil {
+			return fmt.Errorf("failed to write punctuate: %w", err)
+		}
+	}
+	if params.Truecase != nil {
+		if err := writer.WriteField("truecase", fmt.Sprintf("%t", *params.Truecase)); err != nil {
+			return fmt.Errorf("failed to write truecase: %w", err)
+		}
 	}
 
-	req.Header.Set("Authorization", "Bearer "+s.client.apiKey)
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.Header.Set("User-Agent", "fish-audio/go/"+Version)
+	if params.ResponseFormat != "" {
+		if err := writer.WriteField("response_format", string(params.ResponseFormat)); err != nil {
+			return fmt.Errorf("failed to write response_format: %w", err)
+		}
+	}
 
-	// Execute request
-	resp, err := s.client.httpClient.Do(req)
+	keys := make([]string, 0, len(params.Extra))
+	for key := range params.Extra {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		if err := writer.WriteField(key, params.Extra[key]); err != nil {
+			return fmt.Errorf("failed to write %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// send posts a multipart transcription body produced by write, which
+// reads from uploads, and decodes the response according to format.
+func (s *ASRService) send(ctx context.Context, format ASRResponseFormat, write func(*multipart.Writer) error, uploads ...*replayReader) (*ASRResponse, error) {
+	budget := s.client.budget
+	if budget != nil {
+		if _, err := budget.reserve(""); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := s.client.doMultipartRequest(ctx, http.MethodPost, "/v1/asr", write, uploads...)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
+	if budget != nil {
+		budget.record(parseUsage(resp.Header))
+	}
 
-	if resp.StatusCode >= 400 {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, newAPIError(resp.StatusCode, resp.Status, string(bodyBytes))
+	if format != "" && format != ASRResponseJSON {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+		result := &ASRResponse{Formatted: string(body), Usage: parseUsage(resp.Header)}
+		if format == ASRResponseText {
+			result.Text = strings.TrimSpace(result.Formatted)
+		}
+		return result, nil
 	}
 
 	// Parse response
 	var result ASRResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := decodeResponse(resp, &result); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
+	result.Usage = parseUsage(resp.Header)
 
 	return &result, nil
 }
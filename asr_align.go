@@ -0,0 +1,352 @@
+package fishaudio
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// AlignedSpan is a word or sentence of aligned text and when it is spoken.
+type AlignedSpan struct {
+	// Text is the span as written in the original text.
+	Text string
+	// Offset is the byte offset of Text in the original text.
+	Offset int
+	// Start is when the span starts in the audio.
+	Start time.Duration
+	// End is when the span ends in the audio.
+	End time.Duration
+}
+
+// Alignment is the result of AlignTextToAudio.
+type Alignment struct {
+	// Words holds each word of the text in order. CJK characters, which
+	// are written without spaces, are one word each.
+	Words []AlignedSpan
+	// Sentences holds each sentence of the text in order.
+	Sentences []AlignedSpan
+	// Coverage is the fraction of words recognized verbatim in the audio.
+	// The remaining words are timed from their neighbors; a low coverage
+	// means the audio does not say the text.
+	Coverage float64
+	// Transcript is the transcription the alignment is based on.
+	Transcript *ASRResponse
+}
+
+// AlignTextToAudio returns word and sentence timings of text in audio,
+// typically speech synthesized from that same text, for captions that
+// follow the original wording and punctuation rather than the transcript.
+//
+// The audio is transcribed with timestamps, recognized words are timed
+// within their segments by length, and the words of text are matched to
+// them with an edit-distance alignment, so misrecognized words still get
+// the timing of the word they were heard as. Words with no counterpart are
+// spread over the gap between their neighbors. params may set the
+// language; timestamps are always included and ResponseFormat is ignored.
+//
+// Example:
+//
+//	audio, _ := client.TTS.Convert(ctx, &fishaudio.ConvertParams{Text: script})
+//	alignment, err := client.ASR.AlignTextToAudio(ctx, script, audio, nil)
+//	if err != nil {
+//	    return err
+//	}
+//	for _, s := range alignment.Sentences {
+//	    fmt.Printf("%v-%v %s\n", s.Start, s.End, s.Text)
+//	}
+func (s *ASRService) AlignTextToAudio(ctx context.Context, text string, audio []byte, params *TranscribeParams) (*Alignment, error) {
+	p := TranscribeParams{}
+	if params != nil {
+		p = *params
+	}
+	includeTimestamps := true
+	p.IncludeTimestamps = &includeTimestamps
+	p.ResponseFormat = ""
+
+	transcript, err := s.Transcribe(ctx, audio, &p)
+	if err != nil {
+		return nil, err
+	}
+	return alignTranscript(text, transcript), nil
+}
+
+// alignTranscript aligns the words of text to the words of transcript.
+func alignTranscript(text string, transcript *ASRResponse) *Alignment {
+	words := tokenizeWords(text)
+	heard := timedWords(transcript)
+	pairs, matched := alignTokens(words, heard)
+
+	alignment := &Alignment{Words: make([]AlignedSpan, len(words)), Transcript: transcript}
+	for i, w := range words {
+		alignment.Words[i] = AlignedSpan{Text: w.text, Offset: w.offset, Start: -1, End: -1}
+		if j := pairs[i]; j >= 0 {
+			alignment.Words[i].Start, alignment.Words[i].End = heard[j].start, heard[j].end
+		}
+	}
+	if len(words) > 0 {
+		alignment.Coverage = float64(matched) / float64(len(words))
+	}
+
+	end := segmentTime(transcript.Duration / 1000)
+	if len(heard) > 0 && heard[len(heard)-1].end > end {
+		end = heard[len(heard)-1].end
+	}
+	fillGaps(alignment.Words, end)
+	alignment.Sentences = sentenceSpans(text, alignment.Words)
+	return alignment
+}
+
+// segmentTime converts a transcript time in seconds, rounding away the
+// float error in values such as 2.4.
+func segmentTime(seconds float64) time.Duration {
+	return time.Duration(math.Round(seconds * float64(time.Second)))
+}
+
+// wordToken is a word of text with its normalized form for matching.
+type wordToken struct {
+	text   string
+	norm   string
+	offset int
+	start  time.Duration
+	end    time.Duration
+}
+
+// tokenizeWords splits s into words: runs of letters, digits, and inner
+// apostrophes, with each Han, Hiragana, or Katakana character on its own.
+func tokenizeWords(s string) []wordToken {
+	var tokens []wordToken
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			word := strings.Trim(s[start:end], "'’")
+			if word != "" {
+				offset := start + strings.Index(s[start:end], word)
+				tokens = append(tokens, wordToken{text: word, norm: normalizeWord(word), offset: offset})
+			}
+			start = -1
+		}
+	}
+
+	for i, r := range s {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana):
+			flush(i)
+			tokens = append(tokens, wordToken{text: string(r), norm: string(r), offset: i})
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) || r == '\'' || r == '’':
+			if start < 0 {
+				start = i
+			}
+		default:
+			flush(i)
+		}
+	}
+	flush(len(s))
+	return tokens
+}
+
+// normalizeWord folds case and drops apostrophes, so "Don't" matches
+// "dont" as a recognizer may write it.
+func normalizeWord(word string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\'' || r == '’' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, word)
+}
+
+// timedWords returns the words of the transcript segments, each timed by
+// its share of the characters in its segment. A transcript without
+// segments is treated as one segment spanning the audio.
+func timedWords(r *ASRResponse) []wordToken {
+	segments := r.Segments
+	if len(segments) == 0 && strings.TrimSpace(r.Text) != "" {
+		segments = []ASRSegment{{Text: r.Text, End: r.Duration / 1000}}
+	}
+
+	var words []wordToken
+	for _, seg := range segments {
+		tokens := tokenizeWords(seg.Text)
+		total := 0
+		for _, t := range tokens {
+			total += utf8.RuneCountInString(t.norm)
+		}
+		start := segmentTime(seg.Start)
+		span := segmentTime(seg.End) - start
+		done := 0
+		for _, t := range tokens {
+			t.start = start + span*time.Duration(done)/time.Duration(total)
+			done += utf8.RuneCountInString(t.norm)
+			t.end = start + span*time.Duration(done)/time.Duration(total)
+			words = append(words, t)
+		}
+	}
+	return words
+}
+
+// alignBand is the minimum number of cells searched on each side of the
+// diagonal in alignTokens.
+const alignBand = 64
+
+// alignTokens aligns words to heard with the fewest insertions, deletions,
+// and substitutions. It returns the index in heard paired with each word,
+// or -1, and the number of pairs that match exactly. Only a band around
+// the diagonal is searched, so long texts align in linear memory.
+func alignTokens(words, heard []wordToken) ([]int, int) {
+	n, m := len(words), len(heard)
+	pairs := make([]int, n)
+	for i := range pairs {
+		pairs[i] = -1
+	}
+	if n == 0 || m == 0 {
+		return pairs, 0
+	}
+
+	band := alignBand + abs(n-m)
+	lo := func(i int) int { return max(0, i*m/n-band) }
+	hi := func(i int) int { return min(m, i*m/n+band) }
+
+	// cost[i][j-lo(i)] is the edit distance of words[:i] and heard[:j];
+	// cells outside the band are unreachable
+	const unreachable = 1 << 30
+	cost := make([][]int32, n+1)
+	at := func(i, j int) int {
+		if j < lo(i) || j > hi(i) {
+			return unreachable
+		}
+		return int(cost[i][j-lo(i)])
+	}
+	for i := 0; i <= n; i++ {
+		cost[i] = make([]int32, hi(i)-lo(i)+1)
+		for j := lo(i); j <= hi(i); j++ {
+			var c int
+			switch {
+			case i == 0:
+				c = j
+			case j == 0:
+				c = i
+			default:
+				sub := 1
+				if words[i-1].norm == heard[j-1].norm {
+					sub = 0
+				}
+				c = min(at(i-1, j-1)+sub, at(i-1, j)+1, at(i, j-1)+1)
+			}
+			cost[i][j-lo(i)] = int32(min(c, unreachable))
+		}
+	}
+
+	matched := 0
+	for i, j := n, m; i > 0 && j > 0; {
+		sub := 1
+		if words[i-1].norm == heard[j-1].norm {
+			sub = 0
+		}
+		switch at(i, j) {
+		case at(i-1, j-1) + sub:
+			pairs[i-1] = j - 1
+			matched += 1 - sub
+			i, j = i-1, j-1
+		case at(i-1, j) + 1:
+			i--
+		default:
+			j--
+		}
+	}
+	return pairs, matched
+}
+
+// fillGaps times the words without a start, marked -1, by spreading each
+// run of them over the gap between the surrounding timed words, by length.
+// end is the end of the audio.
+func fillGaps(words []AlignedSpan, end time.Duration) {
+	for i := 0; i < len(words); {
+		if words[i].Start >= 0 {
+			i++
+			continue
+		}
+		j := i
+		for j < len(words) && words[j].Start < 0 {
+			j++
+		}
+
+		from, to := time.Duration(0), end
+		if i > 0 {
+			from = words[i-1].End
+		}
+		if j < len(words) {
+			to = words[j].Start
+		}
+		to = max(to, from)
+
+		total := 0
+		for _, w := range words[i:j] {
+			total += utf8.RuneCountInString(w.Text)
+		}
+		done := 0
+		for k := i; k < j; k++ {
+			words[k].Start = from + (to-from)*time.Duration(done)/time.Duration(total)
+			done += utf8.RuneCountInString(words[k].Text)
+			words[k].End = from + (to-from)*time.Duration(done)/time.Duration(total)
+		}
+		i = j
+	}
+}
+
+// sentenceSpans splits text into sentences at terminal punctuation and
+// times each by its first and last word. Sentences without words are
+// skipped.
+func sentenceSpans(text string, words []AlignedSpan) []AlignedSpan {
+	var sentences []AlignedSpan
+	next := 0
+	add := func(start, end int) {
+		var first, last *AlignedSpan
+		for ; next < len(words) && words[next].Offset < end; next++ {
+			if first == nil {
+				first = &words[next]
+			}
+			last = &words[next]
+		}
+		if first == nil {
+			return
+		}
+		raw := text[start:end]
+		trimmed := strings.TrimSpace(raw)
+		sentences = append(sentences, AlignedSpan{
+			Text:   trimmed,
+			Offset: start + strings.Index(raw, trimmed),
+			Start:  first.Start,
+			End:    last.End,
+		})
+	}
+
+	start := 0
+	for i, r := range text {
+		if !strings.ContainsRune(".!?。！？…", r) {
+			continue
+		}
+		after := i + utf8.RuneLen(r)
+		// Latin terminators end a sentence only before a space, so "3.5"
+		// and "e.g" stay whole; CJK ones always do
+		if r < 0x2000 {
+			if following, _ := utf8.DecodeRuneInString(text[after:]); after < len(text) && !unicode.IsSpace(following) {
+				continue
+			}
+		}
+		add(start, after)
+		start = after
+	}
+	add(start, len(text))
+	return sentences
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,155 @@
+package fishaudio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// spanTimes returns the text and times of spans in milliseconds.
+func spanTimes(spans []AlignedSpan) []string {
+	out := make([]string, len(spans))
+	for i, s := range spans {
+		out[i] = fmt.Sprintf("%s@%d-%d", s.Text, s.Start.Milliseconds(), s.End.Milliseconds())
+	}
+	return out
+}
+
+func TestASRService_AlignTextToAudio(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm error = %v", err)
+		}
+		if got := r.FormValue("ignore_timestamps"); got != "false" {
+			t.Errorf("ignore_timestamps = %q, want false", got)
+		}
+		if got := r.FormValue("language"); got != "en" {
+			t.Errorf("language = %q, want en", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ASRResponse{
+			Text:     "hello world how are you",
+			Duration: 3000,
+			Segments: []ASRSegment{
+				{Text: "hello world", Start: 0, End: 1},
+				{Text: "how are you", Start: 1.5, End: 2.4},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	off := false
+	alignment, err := client.ASR.AlignTextToAudio(context.Background(), "Hello, world! How are you?", []byte("audio"),
+		&TranscribeParams{Language: LanguageEnglish, IncludeTimestamps: &off, ResponseFormat: ASRResponseSRT})
+	if err != nil {
+		t.Fatalf("AlignTextToAudio() error = %v", err)
+	}
+
+	wantWords := []string{"Hello@0-500", "world@500-1000", "How@1500-1800", "are@1800-2100", "you@2100-2400"}
+	if got := spanTimes(alignment.Words); !reflect.DeepEqual(got, wantWords) {
+		t.Errorf("Words = %v, want %v", got, wantWords)
+	}
+	wantSentences := []string{"Hello, world!@0-1000", "How are you?@1500-2400"}
+	if got := spanTimes(alignment.Sentences); !reflect.DeepEqual(got, wantSentences) {
+		t.Errorf("Sentences = %v, want %v", got, wantSentences)
+	}
+	if alignment.Sentences[1].Offset != 14 {
+		t.Errorf("Sentences[1].Offset = %d, want 14", alignment.Sentences[1].Offset)
+	}
+	if alignment.Coverage != 1 {
+		t.Errorf("Coverage = %v, want 1", alignment.Coverage)
+	}
+}
+
+func TestAlignTranscript_Mismatches(t *testing.T) {
+	transcript := &ASRResponse{
+		Duration: 4000,
+		Segments: []ASRSegment{
+			// "Dr." was read out, "Smyth" misheard, and "today" dropped
+			{Text: "doctor smith will see you", Start: 0, End: 2.5},
+			{Text: "thanks", Start: 3, End: 3.6},
+		},
+	}
+	alignment := alignTranscript("Dr. Smyth will see you today. Thanks.", transcript)
+
+	want := []string{
+		"Dr@0-714", "Smyth@714-1309", "will@1309-1785", "see@1785-2142", "you@2142-2500",
+		"today@2500-3000", "Thanks@3000-3600",
+	}
+	if got := spanTimes(alignment.Words); !reflect.DeepEqual(got, want) {
+		t.Errorf("Words = %v, want %v", got, want)
+	}
+	// "Dr." ends a sentence; punctuation alone can't tell abbreviations apart
+	wantSentences := []string{"Dr.@0-714", "Smyth will see you today.@714-3000", "Thanks.@3000-3600"}
+	if got := spanTimes(alignment.Sentences); !reflect.DeepEqual(got, wantSentences) {
+		t.Errorf("Sentences = %v, want %v", got, wantSentences)
+	}
+	if math.Abs(alignment.Coverage-4.0/7) > 1e-9 {
+		t.Errorf("Coverage = %v, want 4/7", alignment.Coverage)
+	}
+}
+
+func TestAlignTranscript_CJK(t *testing.T) {
+	transcript := &ASRResponse{
+		Duration: 2000,
+		Segments: []ASRSegment{{Text: "你好世界", Start: 0, End: 0.8}, {Text: "再见", Start: 1, End: 1.4}},
+	}
+	alignment := alignTranscript("你好，世界。再见！", transcript)
+
+	wantWords := []string{"你@0-200", "好@200-400", "世@400-600", "界@600-800", "再@1000-1200", "见@1200-1400"}
+	if got := spanTimes(alignment.Words); !reflect.DeepEqual(got, wantWords) {
+		t.Errorf("Words = %v, want %v", got, wantWords)
+	}
+	wantSentences := []string{"你好，世界。@0-800", "再见！@1000-1400"}
+	if got := spanTimes(alignment.Sentences); !reflect.DeepEqual(got, wantSentences) {
+		t.Errorf("Sentences = %v, want %v", got, wantSentences)
+	}
+}
+
+func TestAlignTranscript_NoSegments(t *testing.T) {
+	alignment := alignTranscript("one two", &ASRResponse{Text: "one two", Duration: 1200})
+	want := []string{"one@0-600", "two@600-1200"}
+	if got := spanTimes(alignment.Words); !reflect.DeepEqual(got, want) {
+		t.Errorf("Words = %v, want %v", got, want)
+	}
+
+	alignment = alignTranscript("", &ASRResponse{Text: "one", Duration: 1000})
+	if len(alignment.Words) != 0 || len(alignment.Sentences) != 0 || alignment.Coverage != 0 {
+		t.Errorf("alignment of empty text = %+v, want empty", alignment)
+	}
+}
+
+func TestAlignTranscript_Long(t *testing.T) {
+	// Words dropped throughout a long text must not derail the banded
+	// alignment
+	var text, heard []string
+	for i := 0; i < 2000; i++ {
+		word := fmt.Sprintf("w%d", i)
+		text = append(text, word)
+		if i%10 != 0 {
+			heard = append(heard, word)
+		}
+	}
+	transcript := &ASRResponse{Segments: []ASRSegment{{Text: strings.Join(heard, " "), End: 1800}}}
+	alignment := alignTranscript(strings.Join(text, " "), transcript)
+
+	if math.Abs(alignment.Coverage-0.9) > 1e-9 {
+		t.Errorf("Coverage = %v, want 0.9", alignment.Coverage)
+	}
+	for i := 1; i < len(alignment.Words); i++ {
+		if alignment.Words[i].Start < alignment.Words[i-1].End {
+			t.Fatalf("word %d starts at %v before word %d ends at %v", i, alignment.Words[i].Start, i-1, alignment.Words[i-1].End)
+		}
+	}
+	if last := alignment.Words[len(alignment.Words)-1]; last.End != 1800*time.Second {
+		t.Errorf("last word ends at %v, want 30m0s", last.End)
+	}
+}
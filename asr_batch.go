@@ -0,0 +1,217 @@
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// AudioInput is one item in a batch transcription.
+type AudioInput struct {
+	// ID identifies the input in results and progress reports.
+	ID string
+	// Audio is the audio to transcribe.
+	Audio []byte
+	// Reader is the audio to transcribe when Audio is empty. If it
+	// implements io.Seeker it is rewound before each retry; otherwise, when
+	// retries are enabled, it is first copied to a temporary file so a
+	// failed upload can be replayed.
+	Reader io.Reader
+	// Path is a file to transcribe when Audio and Reader are empty.
+	Path string
+	// Params overrides BatchOptions.Params for this input.
+	Params *TranscribeParams
+}
+
+// BatchOptions configures TranscribeBatch.
+type BatchOptions struct {
+	// Concurrency is the number of inputs transcribed at once. Default: 4.
+	Concurrency int
+
+	// MaxRetries is the number of times a failed input is retried when the
+	// error is transient (rate limits, server errors, and network failures).
+	// Default: 2. Set to a negative value to disable retries.
+	MaxRetries int
+
+	// RetryDelay is the wait before the first retry, doubled for each
+	// subsequent one. Default: 1 second.
+	RetryDelay time.Duration
+
+	// Params applies to every input without its own Params.
+	Params *TranscribeParams
+
+	// OnProgress, if set, is called after each input finishes. Calls are
+	// serialized, so the callback needs no locking of its own.
+	OnProgress func(BatchProgress)
+}
+
+// BatchResult is the outcome of one input in a batch transcription.
+type BatchResult struct {
+	// Input is the input this result belongs to.
+	Input AudioInput
+	// Response is the transcription, or nil if Err is set.
+	Response *ASRResponse
+	// Err is the error from the last attempt, if all attempts failed.
+	Err error
+	// Attempts is the number of requests made for the input.
+	Attempts int
+}
+
+// BatchProgress reports the state of a batch transcription.
+type BatchProgress struct {
+	// Total is the number of inputs in the batch.
+	Total int
+	// Completed is the number of inputs finished, successfully or not.
+	Completed int
+	// Failed is the number of inputs that failed after all retries.
+	Failed int
+	// Last is the result that triggered this report.
+	Last BatchResult
+}
+
+// TranscribeBatch transcribes many inputs with bounded concurrency,
+// retrying transient failures per input. Results are returned in input
+// order; a failed input doesn't stop the others. The error is non-nil only
+// if ctx is cancelled, in which case unfinished inputs report ctx's error,
+// or if a worker or OnProgress panics, reported as a *PanicError.
+//
+// Example:
+//
+//	results, err := client.ASR.TranscribeBatch(ctx, []fishaudio.AudioInput{
+//	    {ID: "call-1", Path: "calls/1.wav"},
+//	    {ID: "call-2", Path: "calls/2.wav"},
+//	}, &fishaudio.BatchOptions{
+//	    Concurrency: 8,
+//	    OnProgress: func(p fishaudio.BatchProgress) {
+//	        log.Printf("%d/%d done, %d failed", p.Completed, p.Total, p.Failed)
+//	    },
+//	})
+func (s *ASRService) TranscribeBatch(ctx context.Context, inputs []AudioInput, opts *BatchOptions) ([]BatchResult, error) {
+	var o BatchOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = 4
+	}
+	if o.MaxRetries == 0 {
+		o.MaxRetries = 2
+	}
+	if o.MaxRetries < 0 {
+		o.MaxRetries = 0
+	}
+	if o.RetryDelay <= 0 {
+		o.RetryDelay = time.Second
+	}
+
+	results := make([]BatchResult, len(inputs))
+	progress := BatchProgress{Total: len(inputs)}
+	var mu sync.Mutex
+
+	var g errgroup.Group
+	g.SetLimit(o.Concurrency)
+	for i, input := range inputs {
+		g.Go(s.client.guard("batch worker", func() error {
+			result := s.transcribeInput(ctx, input, &o)
+
+			mu.Lock()
+			defer mu.Unlock()
+			results[i] = result
+			progress.Completed++
+			if result.Err != nil {
+				progress.Failed++
+			}
+			if o.OnProgress != nil {
+				progress.Last = result
+				o.OnProgress(progress)
+			}
+			return nil
+		}))
+	}
+	if err := g.Wait(); err != nil {
+		return results, err
+	}
+
+	return results, ctx.Err()
+}
+
+// transcribeInput transcribes one input, retrying transient failures.
+func (s *ASRService) transcribeInput(ctx context.Context, input AudioInput, o *BatchOptions) BatchResult {
+	result := BatchResult{Input: input}
+
+	params := input.Params
+	if params == nil {
+		params = o.Params
+	}
+
+	var replay *replayReader
+	if input.Audio == nil && input.Reader != nil {
+		var err error
+		replay, err = newReplayReader(input.Reader, o.MaxRetries > 0)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		defer replay.close()
+	}
+
+	delay := o.RetryDelay
+	for {
+		if err := ctx.Err(); err != nil {
+			result.Err = err
+			return result
+		}
+
+		result.Attempts++
+		var resp *ASRResponse
+		var err error
+		switch {
+		case replay != nil:
+			var r io.Reader
+			if r, err = replay.rewind(); err == nil {
+				resp, err = s.TranscribeReader(ctx, r, params)
+			}
+		case input.Audio == nil && input.Path != "":
+			resp, err = s.TranscribeFile(ctx, input.Path, params)
+		default:
+			resp, err = s.Transcribe(ctx, input.Audio, params)
+		}
+		if err == nil {
+			result.Response = resp
+			result.Err = nil
+			return result
+		}
+		result.Err = err
+
+		if result.Attempts > o.MaxRetries || !isTransient(err) {
+			return result
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return result
+		}
+		delay *= 2
+	}
+}
+
+// isTransient reports whether a failed request may succeed if retried.
+func isTransient(err error) bool {
+	var rateLimitErr *RateLimitError
+	var serverErr *ServerError
+	if errors.As(err, &rateLimitErr) || errors.As(err, &serverErr) {
+		return true
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		// context.DeadlineExceeded also satisfies net.Error
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
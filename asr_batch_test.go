@@ -0,0 +1,248 @@
+package fishaudio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestASRService_TranscribeBatch(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	var mu sync.Mutex
+	attempts := map[string]int{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Errorf("ParseMultipartForm error = %v", err)
+			return
+		}
+		file, _, _ := r.FormFile("audio")
+		var buf [16]byte
+		n2, _ := file.Read(buf[:])
+		audio := string(buf[:n2])
+
+		mu.Lock()
+		attempts[audio]++
+		attempt := attempts[audio]
+		mu.Unlock()
+
+		switch {
+		case audio == "flaky" && attempt == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		case audio == "bad":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ASRResponse{Text: audio})
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "file.wav")
+	if err := os.WriteFile(path, []byte("from file"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	inputs := []AudioInput{
+		{ID: "a", Audio: []byte("one")},
+		{ID: "b", Audio: []byte("flaky")},
+		{ID: "c", Audio: []byte("bad")},
+		{ID: "d", Path: path},
+		{ID: "e", Audio: []byte("two")},
+	}
+
+	var reports []BatchProgress
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	results, err := client.ASR.TranscribeBatch(context.Background(), inputs, &BatchOptions{
+		Concurrency: 2,
+		RetryDelay:  time.Millisecond,
+		OnProgress:  func(p BatchProgress) { reports = append(reports, p) },
+	})
+	if err != nil {
+		t.Fatalf("TranscribeBatch() error = %v", err)
+	}
+
+	if got := maxInFlight.Load(); got > 2 {
+		t.Errorf("max concurrent requests = %d, want at most 2", got)
+	}
+
+	wantText := map[string]string{"a": "one", "b": "flaky", "d": "from file", "e": "two"}
+	for i, result := range results {
+		if result.Input.ID != inputs[i].ID {
+			t.Errorf("results[%d].Input.ID = %q, want %q", i, result.Input.ID, inputs[i].ID)
+		}
+		if want, ok := wantText[result.Input.ID]; ok {
+			if result.Err != nil || result.Response.Text != want {
+				t.Errorf("results[%d] = %+v, want text %q", i, result, want)
+			}
+		}
+	}
+
+	if results[1].Attempts != 2 {
+		t.Errorf("flaky Attempts = %d, want 2", results[1].Attempts)
+	}
+	var validationErr *ValidationError
+	if !errors.As(results[2].Err, &validationErr) || results[2].Attempts != 1 {
+		t.Errorf("bad result = %v after %d attempts, want ValidationError without retries", results[2].Err, results[2].Attempts)
+	}
+
+	if len(reports) != len(inputs) {
+		t.Fatalf("got %d progress reports, want %d", len(reports), len(inputs))
+	}
+	last := reports[len(reports)-1]
+	if last.Completed != 5 || last.Failed != 1 || last.Total != 5 {
+		t.Errorf("final progress = %+v, want 5/5 completed with 1 failed", last)
+	}
+}
+
+func TestASRService_TranscribeBatch_RetriesExhausted(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	results, err := client.ASR.TranscribeBatch(context.Background(), []AudioInput{{Audio: []byte("a")}}, &BatchOptions{
+		MaxRetries: 3,
+		RetryDelay: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("TranscribeBatch() error = %v", err)
+	}
+	if results[0].Attempts != 4 || calls.Load() != 4 {
+		t.Errorf("Attempts = %d, calls = %d, want 4", results[0].Attempts, calls.Load())
+	}
+	var rateLimitErr *RateLimitError
+	if !errors.As(results[0].Err, &rateLimitErr) {
+		t.Errorf("Err = %v, want *RateLimitError", results[0].Err)
+	}
+}
+
+func TestASRService_TranscribeBatch_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Cancel while the first input is waiting to retry
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	results, err := client.ASR.TranscribeBatch(ctx, []AudioInput{{Audio: []byte("a")}, {Audio: []byte("b")}}, &BatchOptions{
+		Concurrency: 1,
+		RetryDelay:  time.Hour,
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("TranscribeBatch() err = %v, want %v", err, context.Canceled)
+	}
+	for i, result := range results {
+		if result.Err == nil {
+			t.Errorf("results[%d].Err = nil, want an error", i)
+		}
+	}
+}
+
+func TestASRService_TranscribeBatch_ReplaysReader(t *testing.T) {
+	audio := bytes.Repeat([]byte("speech "), 1000)
+
+	tests := []struct {
+		name   string
+		reader func() io.Reader
+	}{
+		{"seekable", func() io.Reader {
+			// Start past a prefix to check the reader rewinds to where it
+			// was, not to zero
+			r := bytes.NewReader(append([]byte("skip"), audio...))
+			_, _ = r.Seek(4, io.SeekStart)
+			return r
+		}},
+		{"unseekable", func() io.Reader {
+			return io.MultiReader(bytes.NewReader(audio))
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseMultipartForm(10 << 20); err != nil {
+					t.Errorf("ParseMultipartForm error = %v", err)
+					return
+				}
+				file, _, _ := r.FormFile("audio")
+				got, _ := io.ReadAll(file)
+				if !bytes.Equal(got, audio) {
+					t.Errorf("attempt %d uploaded %d bytes, want the full %d", calls.Load()+1, len(got), len(audio))
+				}
+				if calls.Add(1) == 1 {
+					w.WriteHeader(http.StatusBadGateway)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(ASRResponse{Text: "ok"})
+			}))
+			defer server.Close()
+
+			client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			results, err := client.ASR.TranscribeBatch(context.Background(), []AudioInput{
+				{ID: "r", Reader: tt.reader()},
+			}, &BatchOptions{RetryDelay: time.Millisecond})
+			if err != nil {
+				t.Fatalf("TranscribeBatch() error = %v", err)
+			}
+			if results[0].Err != nil {
+				t.Fatalf("result error = %v", results[0].Err)
+			}
+			if results[0].Attempts != 2 {
+				t.Errorf("Attempts = %d, want 2", results[0].Attempts)
+			}
+		})
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"rate limit", &RateLimitError{APIError: &APIError{StatusCode: 429}}, true},
+		{"server", &ServerError{APIError: &APIError{StatusCode: 503}}, true},
+		{"validation", &ValidationError{APIError: &APIError{StatusCode: 422}}, false},
+		{"deadline", context.DeadlineExceeded, false},
+		{"cancelled", context.Canceled, false},
+		{"other", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransient(tt.err); got != tt.want {
+				t.Errorf("isTransient(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package fishaudio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ChannelSegment is a transcript segment attributed to one channel of a
+// multichannel recording.
+type ChannelSegment struct {
+	ASRSegment
+	// Channel is the zero-based channel index, e.g. 0 for the left channel
+	// of a stereo call recording.
+	Channel int
+}
+
+// ChannelTranscript is the result of TranscribeChannels.
+type ChannelTranscript struct {
+	// Channels holds each channel's transcription, indexed by channel.
+	Channels []*ASRResponse
+	// Segments holds the segments of all channels ordered by start time,
+	// with ties broken by channel.
+	Segments []ChannelSegment
+	// Usage is the combined usage of all channels, or nil if the responses
+	// carried no usage headers.
+	Usage *RequestUsage
+}
+
+// TranscribeChannels transcribes each channel of a PCM WAV file separately
+// and merges the results into one chronological, channel-attributed
+// transcript. It suits call recordings where each party is on its own
+// channel, such as agent on the left and customer on the right.
+//
+// Channels are transcribed concurrently. Segments are only available when
+// timestamps are included, which is the default. A non-JSON ResponseFormat
+// in params is ignored.
+//
+// Example:
+//
+//	audio, _ := os.ReadFile("call.wav")
+//	result, err := client.ASR.TranscribeChannels(ctx, audio, nil)
+//	if err != nil {
+//	    return err
+//	}
+//	speakers := []string{"Agent", "Customer"}
+//	for _, seg := range result.Segments {
+//	    fmt.Printf("[%.1fs] %s: %s\n", seg.Start, speakers[seg.Channel], seg.Text)
+//	}
+func (s *ASRService) TranscribeChannels(ctx context.Context, audio []byte, params *TranscribeParams) (*ChannelTranscript, error) {
+	f, pcm, err := parseWAV(audio)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil && params.ResponseFormat != "" {
+		p := *params
+		p.ResponseFormat = ""
+		params = &p
+	}
+
+	channels := splitChannels(f, pcm)
+	mono := pcmFormat{sampleRate: f.sampleRate, channels: 1, bitsPerSample: f.bitsPerSample}
+	result := &ChannelTranscript{Channels: make([]*ASRResponse, len(channels))}
+
+	g, gctx := errgroup.WithContext(ctx)
+	for i, channel := range channels {
+		g.Go(s.client.guard("channel worker", func() error {
+			resp, err := s.Transcribe(gctx, encodeWAV(mono, channel), params)
+			if err != nil {
+				return fmt.Errorf("channel %d: %w", i, err)
+			}
+			result.Channels[i] = resp
+			return nil
+		}))
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	for i, resp := range result.Channels {
+		result.Usage = addUsage(result.Usage, resp.Usage)
+		for _, seg := range resp.Segments {
+			result.Segments = append(result.Segments, ChannelSegment{ASRSegment: seg, Channel: i})
+		}
+	}
+	sort.SliceStable(result.Segments, func(a, b int) bool {
+		sa, sb := result.Segments[a], result.Segments[b]
+		if sa.Start != sb.Start {
+			return sa.Start < sb.Start
+		}
+		return sa.Channel < sb.Channel
+	})
+
+	return result, nil
+}
+
+// splitChannels deinterleaves pcm into one buffer per channel.
+func splitChannels(f pcmFormat, pcm []byte) [][]byte {
+	size := f.bitsPerSample / 8
+	align := f.blockAlign()
+	frames := len(pcm) / align
+
+	out := make([][]byte, f.channels)
+	for c := range out {
+		out[c] = make([]byte, 0, frames*size)
+		for i := 0; i < frames; i++ {
+			pos := i*align + c*size
+			out[c] = append(out[c], pcm[pos:pos+size]...)
+		}
+	}
+	return out
+}
@@ -0,0 +1,103 @@
+package fishaudio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestASRService_TranscribeChannels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Errorf("ParseMultipartForm error = %v", err)
+			return
+		}
+		if _, ok := r.MultipartForm.Value["response_format"]; ok {
+			t.Error("channels should be requested as JSON")
+		}
+		file, _, _ := r.FormFile("audio")
+		data, _ := io.ReadAll(file)
+		f, pcm, err := parseWAV(data)
+		if err != nil || f.channels != 1 {
+			t.Errorf("upload is not mono WAV: %+v, %v", f, err)
+			return
+		}
+
+		// Left carries 'L' samples, right carries 'R' samples
+		resp := ASRResponse{Text: "agent", Segments: []ASRSegment{
+			{Text: "hello", Start: 0, End: 1},
+			{Text: "anything else?", Start: 3, End: 4},
+		}}
+		if bytes.Equal(pcm, []byte("RRRR")) {
+			resp = ASRResponse{Text: "customer", Segments: []ASRSegment{
+				{Text: "hi", Start: 1, End: 2},
+				{Text: "no", Start: 3, End: 3.5},
+			}}
+		} else if !bytes.Equal(pcm, []byte("LLLL")) {
+			t.Errorf("unexpected channel data %q", pcm)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Usage-Credits", "0.25")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	stereo := pcmFormat{sampleRate: 8000, channels: 2, bitsPerSample: 16}
+	audio := encodeWAV(stereo, []byte("LLRRLLRR"))
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	result, err := client.ASR.TranscribeChannels(context.Background(), audio, &TranscribeParams{ResponseFormat: ASRResponseSRT})
+	if err != nil {
+		t.Fatalf("TranscribeChannels() error = %v", err)
+	}
+
+	if len(result.Channels) != 2 || result.Channels[0].Text != "agent" || result.Channels[1].Text != "customer" {
+		t.Fatalf("Channels = %+v, want agent then customer", result.Channels)
+	}
+	var got []string
+	var channels []int
+	for _, seg := range result.Segments {
+		got = append(got, seg.Text)
+		channels = append(channels, seg.Channel)
+	}
+	if want := []string{"hello", "hi", "anything else?", "no"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("segment order = %v, want %v", got, want)
+	}
+	if want := []int{0, 1, 0, 1}; !reflect.DeepEqual(channels, want) {
+		t.Errorf("segment channels = %v, want %v", channels, want)
+	}
+	if result.Usage == nil || result.Usage.Credits != OneCredit/2 {
+		t.Errorf("Usage = %+v, want both channels' credits", result.Usage)
+	}
+}
+
+func TestASRService_TranscribeChannels_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	audio := encodeWAV(pcmFormat{sampleRate: 8000, channels: 2, bitsPerSample: 16}, make([]byte, 8))
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	_, err := client.ASR.TranscribeChannels(context.Background(), audio, nil)
+	var serverErr *ServerError
+	if !errors.As(err, &serverErr) {
+		t.Errorf("TranscribeChannels() err = %v, want *ServerError", err)
+	}
+}
+
+func TestSplitChannels(t *testing.T) {
+	f := pcmFormat{sampleRate: 8000, channels: 3, bitsPerSample: 8}
+	got := splitChannels(f, []byte("abcABCx"))
+	want := [][]byte{[]byte("aA"), []byte("bB"), []byte("cC")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("splitChannels() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,208 @@
+package fishaudio
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// LongAudioOptions configures TranscribeLong.
+type LongAudioOptions struct {
+	// ChunkDuration is the maximum duration of each chunk sent to the API.
+	// Default: 5 minutes.
+	ChunkDuration time.Duration
+
+	// SilenceSearch is how far before each cut point to look for the
+	// quietest moment, so words aren't split between chunks. Zero cuts at
+	// exactly ChunkDuration. Only 16-bit audio is searched.
+	SilenceSearch time.Duration
+
+	// Concurrency is the number of chunks transcribed at once. Default: 4.
+	Concurrency int
+}
+
+// silenceFrame is the window over which loudness is measured when
+// searching for a cut point.
+const silenceFrame = 20 * time.Millisecond
+
+// TranscribeLong transcribes audio longer than the API accepts in one
+// request. The audio must be a PCM WAV file; it is split into chunks of at
+// most ChunkDuration, which are transcribed concurrently and merged into one
+// response with segment timestamps offset to the position in the original.
+//
+// Chunk texts are joined with a space. Chunks are always requested as JSON
+// so they can be merged; a text, SRT, or WebVTT ResponseFormat is rendered
+// locally from the merged transcript into Formatted.
+//
+// Example:
+//
+//	audio, _ := os.ReadFile("meeting.wav")
+//	result, err := client.ASR.TranscribeLong(ctx, audio, nil, &fishaudio.LongAudioOptions{
+//	    ChunkDuration: 2 * time.Minute,
+//	    SilenceSearch: 5 * time.Second,
+//	})
+func (s *ASRService) TranscribeLong(ctx context.Context, audio []byte, params *TranscribeParams, opts *LongAudioOptions) (*ASRResponse, error) {
+	var o LongAudioOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.ChunkDuration <= 0 {
+		o.ChunkDuration = 5 * time.Minute
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = 4
+	}
+
+	format, pcm, err := parseWAV(audio)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseFormat ASRResponseFormat
+	if params != nil && params.ResponseFormat != "" {
+		responseFormat = params.ResponseFormat
+		p := *params
+		p.ResponseFormat = ""
+		params = &p
+	}
+
+	chunks := splitPCM(format, pcm, o.ChunkDuration, o.SilenceSearch)
+	results := make([]*ASRResponse, len(chunks))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(o.Concurrency)
+	for i, chunk := range chunks {
+		g.Go(s.client.guard("chunk worker", func() error {
+			result, err := s.Transcribe(gctx, encodeWAV(format, chunk.pcm), params)
+			if err != nil {
+				return fmt.Errorf("chunk %d: %w", i, err)
+			}
+			results[i] = result
+			return nil
+		}))
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	merged := mergeTranscripts(chunks, results)
+	merged.Formatted = renderTranscript(merged, responseFormat)
+	return merged, nil
+}
+
+// renderTranscript returns r in a non-JSON response format, or "" for JSON.
+func renderTranscript(r *ASRResponse, format ASRResponseFormat) string {
+	switch format {
+	case ASRResponseText:
+		return r.Text
+	case ASRResponseSRT:
+		return r.ToSRT()
+	case ASRResponseVTT:
+		return r.ToVTT()
+	}
+	return ""
+}
+
+// pcmChunk is a slice of the original audio and its position in it.
+type pcmChunk struct {
+	pcm    []byte
+	offset time.Duration
+}
+
+// splitPCM cuts pcm into chunks of at most maxDuration. With a non-zero
+// search window, each cut moves back to the quietest frame within it.
+func splitPCM(f pcmFormat, pcm []byte, maxDuration, search time.Duration) []pcmChunk {
+	align := f.blockAlign()
+	maxBytes := bytesFor(f, maxDuration)
+	if maxBytes <= 0 || len(pcm) <= maxBytes {
+		return []pcmChunk{{pcm: pcm}}
+	}
+
+	var chunks []pcmChunk
+	for start := 0; start < len(pcm); {
+		end := start + maxBytes
+		if end >= len(pcm) {
+			end = len(pcm)
+		} else if cut := quietestCut(f, pcm, start, end, bytesFor(f, search)); cut > start {
+			end = cut
+		}
+		end -= (end - start) % align
+
+		chunks = append(chunks, pcmChunk{pcm: pcm[start:end], offset: f.duration(start)})
+		start = end
+	}
+	return chunks
+}
+
+// bytesFor returns the number of whole sample frames covering d.
+func bytesFor(f pcmFormat, d time.Duration) int {
+	frames := int(d.Seconds() * float64(f.sampleRate))
+	return frames * f.blockAlign()
+}
+
+// quietestCut returns the start of the quietest frame in the window before
+// end, or end if there is nothing to search.
+func quietestCut(f pcmFormat, pcm []byte, start, end, window int) int {
+	if window <= 0 || f.bitsPerSample != 16 {
+		return end
+	}
+	frame := bytesFor(f, silenceFrame)
+	if frame <= 0 {
+		return end
+	}
+
+	from := end - window
+	if from < start+frame {
+		from = start + frame
+	}
+
+	best, bestEnergy := end, math.MaxFloat64
+	for pos := end - frame; pos >= from; pos -= frame {
+		if e := energy16(pcm[pos : pos+frame]); e < bestEnergy {
+			best, bestEnergy = pos, e
+		}
+	}
+	return best
+}
+
+// energy16 returns the mean square of 16-bit little-endian samples.
+func energy16(pcm []byte) float64 {
+	n := len(pcm) / 2
+	if n == 0 {
+		return 0
+	}
+	var sum float64
+	for i := 0; i < n; i++ {
+		v := float64(int16(binary.LittleEndian.Uint16(pcm[2*i:])))
+		sum += v * v
+	}
+	return sum / float64(n)
+}
+
+// mergeTranscripts combines chunk results, offsetting segment timestamps by
+// each chunk's position.
+func mergeTranscripts(chunks []pcmChunk, results []*ASRResponse) *ASRResponse {
+	merged := &ASRResponse{}
+	var texts []string
+	for i, result := range results {
+		if text := strings.TrimSpace(result.Text); text != "" {
+			texts = append(texts, text)
+		}
+		merged.Duration += result.Duration
+		merged.Usage = addUsage(merged.Usage, result.Usage)
+
+		offset := chunks[i].offset.Seconds()
+		for _, seg := range result.Segments {
+			seg.Start += offset
+			seg.End += offset
+			merged.Segments = append(merged.Segments, seg)
+		}
+	}
+	merged.Text = strings.Join(texts, " ")
+	return merged
+}
@@ -0,0 +1,192 @@
+package fishaudio
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// tone returns 16-bit mono PCM at 1 kHz of the given duration and amplitude.
+func tone(d time.Duration, amplitude int16) []byte {
+	samples := int(d.Seconds() * 1000)
+	pcm := make([]byte, samples*2)
+	for i := 0; i < samples; i++ {
+		v := amplitude
+		if i%2 == 1 {
+			v = -amplitude
+		}
+		binary.LittleEndian.PutUint16(pcm[2*i:], uint16(v))
+	}
+	return pcm
+}
+
+var testPCMFormat = pcmFormat{sampleRate: 1000, channels: 1, bitsPerSample: 16}
+
+func TestSplitPCM_ExactCuts(t *testing.T) {
+	pcm := tone(2500*time.Millisecond, 1000)
+	chunks := splitPCM(testPCMFormat, pcm, time.Second, 0)
+
+	if len(chunks) != 3 {
+		t.Fatalf("got %d chunks, want 3", len(chunks))
+	}
+	wantOffsets := []time.Duration{0, time.Second, 2 * time.Second}
+	wantLens := []int{2000, 2000, 1000}
+	for i, chunk := range chunks {
+		if chunk.offset != wantOffsets[i] {
+			t.Errorf("chunks[%d].offset = %v, want %v", i, chunk.offset, wantOffsets[i])
+		}
+		if len(chunk.pcm) != wantLens[i] {
+			t.Errorf("len(chunks[%d].pcm) = %d, want %d", i, len(chunk.pcm), wantLens[i])
+		}
+	}
+}
+
+func TestSplitPCM_SilenceSearch(t *testing.T) {
+	// Loud audio with a quiet gap from 700ms to 800ms
+	var pcm []byte
+	pcm = append(pcm, tone(700*time.Millisecond, 10000)...)
+	pcm = append(pcm, tone(100*time.Millisecond, 0)...)
+	pcm = append(pcm, tone(700*time.Millisecond, 10000)...)
+
+	chunks := splitPCM(testPCMFormat, pcm, time.Second, 500*time.Millisecond)
+	if len(chunks) != 2 {
+		t.Fatalf("got %d chunks, want 2", len(chunks))
+	}
+	cut := chunks[1].offset
+	if cut < 700*time.Millisecond || cut >= 800*time.Millisecond {
+		t.Errorf("cut at %v, want within the silent gap [700ms, 800ms)", cut)
+	}
+	if got := len(chunks[0].pcm) + len(chunks[1].pcm); got != len(pcm) {
+		t.Errorf("chunks cover %d bytes, want %d", got, len(pcm))
+	}
+}
+
+func TestSplitPCM_Short(t *testing.T) {
+	pcm := tone(500*time.Millisecond, 1000)
+	chunks := splitPCM(testPCMFormat, pcm, time.Second, time.Second)
+	if len(chunks) != 1 || len(chunks[0].pcm) != len(pcm) {
+		t.Errorf("expected a single chunk covering the audio, got %d", len(chunks))
+	}
+}
+
+func TestASRService_TranscribeLong(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Errorf("ParseMultipartForm error = %v", err)
+			return
+		}
+		file, _, err := r.FormFile("audio")
+		if err != nil {
+			t.Errorf("FormFile(audio) error = %v", err)
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		var header [44]byte
+		_, _ = file.Read(header[:])
+		dataSize := binary.LittleEndian.Uint32(header[40:44])
+		seconds := float64(dataSize) / 2000
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ASRResponse{
+			Text:     "part",
+			Duration: seconds * 1000,
+			Segments: []ASRSegment{{Text: "part", Start: 0.1, End: seconds}},
+		})
+	}))
+	defer server.Close()
+
+	audio := encodeWAV(testPCMFormat, tone(2500*time.Millisecond, 1000))
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	result, err := client.ASR.TranscribeLong(context.Background(), audio, nil, &LongAudioOptions{
+		ChunkDuration: time.Second,
+		Concurrency:   2,
+	})
+	if err != nil {
+		t.Fatalf("TranscribeLong() error = %v", err)
+	}
+
+	if calls.Load() != 3 {
+		t.Errorf("requests = %d, want 3", calls.Load())
+	}
+	if result.Text != "part part part" {
+		t.Errorf("Text = %q, want %q", result.Text, "part part part")
+	}
+	if result.Duration != 2500 {
+		t.Errorf("Duration = %v, want 2500", result.Duration)
+	}
+	wantStarts := []float64{0.1, 1.1, 2.1}
+	if len(result.Segments) != len(wantStarts) {
+		t.Fatalf("got %d segments, want %d", len(result.Segments), len(wantStarts))
+	}
+	for i, want := range wantStarts {
+		if got := result.Segments[i].Start; got < want-1e-9 || got > want+1e-9 {
+			t.Errorf("Segments[%d].Start = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestASRService_TranscribeLong_ResponseFormat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Errorf("ParseMultipartForm error = %v", err)
+			return
+		}
+		if _, ok := r.MultipartForm.Value["response_format"]; ok {
+			t.Error("chunks should be requested as JSON")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ASRResponse{
+			Text:     "part",
+			Duration: 1000,
+			Segments: []ASRSegment{{Text: "part", Start: 0, End: 1}},
+		})
+	}))
+	defer server.Close()
+
+	audio := encodeWAV(testPCMFormat, tone(2*time.Second, 1000))
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	result, err := client.ASR.TranscribeLong(context.Background(), audio,
+		&TranscribeParams{ResponseFormat: ASRResponseSRT}, &LongAudioOptions{ChunkDuration: time.Second})
+	if err != nil {
+		t.Fatalf("TranscribeLong() error = %v", err)
+	}
+	want := "1\n00:00:00,000 --> 00:00:01,000\npart\n\n2\n00:00:01,000 --> 00:00:02,000\npart\n\n"
+	if result.Formatted != want {
+		t.Errorf("Formatted = %q, want %q", result.Formatted, want)
+	}
+}
+
+func TestASRService_TranscribeLong_ChunkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	audio := encodeWAV(testPCMFormat, tone(1500*time.Millisecond, 1000))
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	_, err := client.ASR.TranscribeLong(context.Background(), audio, nil, &LongAudioOptions{ChunkDuration: time.Second})
+	var serverErr *ServerError
+	if !errors.As(err, &serverErr) {
+		t.Errorf("TranscribeLong() err = %v, want *ServerError", err)
+	}
+}
+
+func TestASRService_TranscribeLong_NotWAV(t *testing.T) {
+	client := NewClient(WithAPIKey("test-key"))
+	_, err := client.ASR.TranscribeLong(context.Background(), []byte("ID3 not a wav"), nil, nil)
+	if !errors.Is(err, errNotPCMWAV) {
+		t.Errorf("TranscribeLong() err = %v, want %v", err, errNotPCMWAV)
+	}
+}
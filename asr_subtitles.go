@@ -0,0 +1,120 @@
+package fishaudio
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SubtitleFormat specifies a subtitle file format.
+type SubtitleFormat string
+
+const (
+	// SubtitleSRT is the SubRip (.srt) format.
+	SubtitleSRT SubtitleFormat = "srt"
+	// SubtitleVTT is the WebVTT (.vtt) format.
+	SubtitleVTT SubtitleFormat = "vtt"
+)
+
+// SubtitleOptions configures subtitle export.
+type SubtitleOptions struct {
+	// MaxLineLength is the maximum number of characters per line. Longer
+	// segment text is wrapped at word boundaries. Default: 42.
+	MaxLineLength int
+}
+
+// ToSRT returns the segments as a SubRip subtitle file.
+func (r *ASRResponse) ToSRT() string {
+	return r.Subtitles(SubtitleSRT, nil)
+}
+
+// ToVTT returns the segments as a WebVTT subtitle file.
+func (r *ASRResponse) ToVTT() string {
+	return r.Subtitles(SubtitleVTT, nil)
+}
+
+// Subtitles returns the segments as a subtitle file in the given format,
+// one cue per segment. Segments without text are skipped. If the response
+// has no segments (for example, when timestamps were not requested), the
+// whole text becomes a single cue spanning Duration.
+//
+// Example:
+//
+//	result, _ := client.ASR.Transcribe(ctx, audio, nil)
+//	vtt := result.Subtitles(fishaudio.SubtitleVTT, &fishaudio.SubtitleOptions{MaxLineLength: 32})
+//	os.WriteFile("captions.vtt", []byte(vtt), 0o644)
+func (r *ASRResponse) Subtitles(format SubtitleFormat, opts *SubtitleOptions) string {
+	maxLen := 42
+	if opts != nil && opts.MaxLineLength > 0 {
+		maxLen = opts.MaxLineLength
+	}
+
+	segments := r.Segments
+	if len(segments) == 0 && strings.TrimSpace(r.Text) != "" {
+		segments = []ASRSegment{{Text: r.Text, End: r.Duration / 1000}}
+	}
+
+	var b strings.Builder
+	if format == SubtitleVTT {
+		b.WriteString("WEBVTT\n\n")
+	}
+
+	cue := 0
+	for _, seg := range segments {
+		text := strings.TrimSpace(seg.Text)
+		if text == "" {
+			continue
+		}
+		cue++
+
+		if format == SubtitleSRT {
+			fmt.Fprintf(&b, "%d\n", cue)
+		}
+		fmt.Fprintf(&b, "%s --> %s\n", formatCueTime(seg.Start, format), formatCueTime(seg.End, format))
+		for _, line := range wrapText(text, maxLen) {
+			b.WriteString(line)
+			b.WriteByte('\n')
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// formatCueTime formats seconds as HH:MM:SS,mmm for SRT or HH:MM:SS.mmm for VTT.
+func formatCueTime(seconds float64, format SubtitleFormat) string {
+	if seconds < 0 {
+		seconds = 0
+	}
+	ms := int64(seconds*1000 + 0.5)
+	sep := ","
+	if format == SubtitleVTT {
+		sep = "."
+	}
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
+
+// wrapText breaks text into lines of at most maxLen characters, splitting at
+// spaces where possible and inside words only when a word is too long, as
+// with scripts that don't separate words.
+func wrapText(text string, maxLen int) []string {
+	var lines []string
+	var line []rune
+	for _, word := range strings.Fields(text) {
+		w := []rune(word)
+		if len(line) > 0 && len(line)+1+len(w) <= maxLen {
+			line = append(append(line, ' '), w...)
+			continue
+		}
+		if len(line) > 0 {
+			lines = append(lines, string(line))
+		}
+		for len(w) > maxLen {
+			lines = append(lines, string(w[:maxLen]))
+			w = w[maxLen:]
+		}
+		line = w
+	}
+	if len(line) > 0 {
+		lines = append(lines, string(line))
+	}
+	return lines
+}
@@ -0,0 +1,76 @@
+package fishaudio
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestASRResponse_ToSRT(t *testing.T) {
+	resp := &ASRResponse{Segments: []ASRSegment{
+		{Text: "Hello there.", Start: 0, End: 1.5},
+		{Text: " ", Start: 1.5, End: 2},
+		{Text: "General Kenobi!", Start: 3661.25, End: 3662.0004},
+	}}
+
+	want := "1\n00:00:00,000 --> 00:00:01,500\nHello there.\n\n" +
+		"2\n01:01:01,250 --> 01:01:02,000\nGeneral Kenobi!\n\n"
+	if got := resp.ToSRT(); got != want {
+		t.Errorf("ToSRT() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestASRResponse_ToVTT(t *testing.T) {
+	resp := &ASRResponse{Segments: []ASRSegment{
+		{Text: "Hello there.", Start: 0.5, End: 1.5},
+	}}
+
+	want := "WEBVTT\n\n00:00:00.500 --> 00:00:01.500\nHello there.\n\n"
+	if got := resp.ToVTT(); got != want {
+		t.Errorf("ToVTT() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestASRResponse_Subtitles_NoSegments(t *testing.T) {
+	resp := &ASRResponse{Text: "Only text", Duration: 2500}
+
+	want := "1\n00:00:00,000 --> 00:00:02,500\nOnly text\n\n"
+	if got := resp.ToSRT(); got != want {
+		t.Errorf("ToSRT() = %q, want %q", got, want)
+	}
+	if got := (&ASRResponse{}).ToVTT(); got != "WEBVTT\n\n" {
+		t.Errorf("empty ToVTT() = %q, want %q", got, "WEBVTT\n\n")
+	}
+}
+
+func TestASRResponse_Subtitles_Wrapping(t *testing.T) {
+	resp := &ASRResponse{Segments: []ASRSegment{
+		{Text: "the quick brown fox jumps over the lazy dog", Start: 0, End: 1},
+	}}
+
+	want := "1\n00:00:00,000 --> 00:00:01,000\nthe quick brown\nfox jumps over\nthe lazy dog\n\n"
+	if got := resp.Subtitles(SubtitleSRT, &SubtitleOptions{MaxLineLength: 15}); got != want {
+		t.Errorf("Subtitles() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		maxLen int
+		want   []string
+	}{
+		{"fits", "short line", 20, []string{"short line"}},
+		{"collapses spaces", "a   b\nc", 20, []string{"a b c"}},
+		{"long word", "abcdefghij k", 4, []string{"abcd", "efgh", "ij k"}},
+		{"no spaces", "你好世界你好世界", 3, []string{"你好世", "界你好", "世界"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapText(tt.text, tt.maxLen); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wrapText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,9 +3,16 @@ package fishaudio
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestASRService_Transcribe_Success(t *testing.T) {
@@ -145,6 +152,212 @@ func TestASRService_Transcribe_IgnoreTimestamps(t *testing.T) {
 	}
 }
 
+func TestASRService_Transcribe_TextFormatting(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        *TranscribeParams
+		wantPunctuate []string
+		wantTruecase  []string
+	}{
+		{
+			name:   "unset omits fields",
+			params: &TranscribeParams{},
+		},
+		{
+			name:          "enabled",
+			params:        &TranscribeParams{Punctuate: boolPtr(true), Truecase: boolPtr(true)},
+			wantPunctuate: []string{"true"},
+			wantTruecase:  []string{"true"},
+		},
+		{
+			name:          "disabled",
+			params:        &TranscribeParams{Punctuate: boolPtr(false), Truecase: boolPtr(false)},
+			wantPunctuate: []string{"false"},
+			wantTruecase:  []string{"false"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseMultipartForm(10 << 20); err != nil {
+					t.Fatalf("ParseMultipartForm error = %v", err)
+				}
+				if got := r.MultipartForm.Value["punctuate"]; !reflect.DeepEqual(got, tt.wantPunctuate) {
+					t.Errorf("punctuate = %v, want %v", got, tt.wantPunctuate)
+				}
+				if got := r.MultipartForm.Value["truecase"]; !reflect.DeepEqual(got, tt.wantTruecase) {
+					t.Errorf("truecase = %v, want %v", got, tt.wantTruecase)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(ASRResponse{Text: "Ok."})
+			}))
+			defer server.Close()
+
+			client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			if _, err := client.ASR.Transcribe(context.Background(), []byte("audio"), tt.params); err != nil {
+				t.Fatalf("Transcribe() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestASRService_Transcribe_Hotwords(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm error = %v", err)
+		}
+		want := `[{"text":"Fish Audio","weight":2.5},{"text":"Kubernetes"}]`
+		if got := r.FormValue("hotwords"); got != want {
+			t.Errorf("hotwords = %q, want %q", got, want)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ASRResponse{Text: "ok"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	_, err := client.ASR.Transcribe(context.Background(), []byte("audio"), &TranscribeParams{
+		Hotwords: []Hotword{{Text: "Fish Audio", Weight: 2.5}, {Text: "Kubernetes"}},
+	})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+}
+
+func TestASRService_Transcribe_Extra(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm error = %v", err)
+		}
+		if got := r.FormValue("diarize"); got != "true" {
+			t.Errorf("diarize = %q, want true", got)
+		}
+		if got := r.FormValue("max_speakers"); got != "2" {
+			t.Errorf("max_speakers = %q, want 2", got)
+		}
+		if got := r.MultipartForm.Value["language"]; !reflect.DeepEqual(got, []string{"en"}) {
+			t.Errorf("language = %v, want [en]", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ASRResponse{Text: "ok"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	_, err := client.ASR.Transcribe(context.Background(), []byte("audio"), &TranscribeParams{
+		Language: LanguageEnglish,
+		Extra:    map[string]string{"diarize": "true", "max_speakers": "2"},
+	})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+
+	// Fields the SDK writes can't be overridden
+	for _, key := range []string{"language", "audio_url", ""} {
+		_, err := client.ASR.TranscribeURL(context.Background(), "https://cdn.example.com/a.mp3", &TranscribeParams{
+			Extra: map[string]string{key: "x"},
+		})
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("TranscribeURL() with extra %q error = %v, want *ValidationError", key, err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, want reserved extra fields rejected before sending", requests)
+	}
+}
+
+func TestASRService_Transcribe_ResponseFormat(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    ASRResponseFormat
+		body      string
+		wantText  string
+		formatted string
+	}{
+		{"text", ASRResponseText, "hello world\n", "hello world", "hello world\n"},
+		{"srt", ASRResponseSRT, "1\n00:00:00,000 --> 00:00:01,000\nhello\n\n", "", "1\n00:00:00,000 --> 00:00:01,000\nhello\n\n"},
+		{"vtt", ASRResponseVTT, "WEBVTT\n\n00:00:00.000 --> 00:00:01.000\nhello\n\n", "", "WEBVTT\n\n00:00:00.000 --> 00:00:01.000\nhello\n\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseMultipartForm(10 << 20); err != nil {
+					t.Fatalf("ParseMultipartForm error = %v", err)
+				}
+				if got := r.FormValue("response_format"); got != string(tt.format) {
+					t.Errorf("response_format = %q, want %q", got, tt.format)
+				}
+				w.Header().Set("Content-Type", "text/plain")
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			result, err := client.ASR.Transcribe(context.Background(), []byte("audio"), &TranscribeParams{
+				ResponseFormat: tt.format,
+			})
+			if err != nil {
+				t.Fatalf("Transcribe() error = %v", err)
+			}
+			if result.Formatted != tt.formatted {
+				t.Errorf("Formatted = %q, want %q", result.Formatted, tt.formatted)
+			}
+			if result.Text != tt.wantText {
+				t.Errorf("Text = %q, want %q", result.Text, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestASRService_Transcribe_DefaultResponseFormat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm error = %v", err)
+		}
+		if _, ok := r.MultipartForm.Value["response_format"]; ok {
+			t.Error("response_format should be omitted by default")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ASRResponse{Text: "ok"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	result, err := client.ASR.Transcribe(context.Background(), []byte("audio"), nil)
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if result.Text != "ok" || result.Formatted != "" {
+		t.Errorf("result = %+v, want Text %q and no Formatted", result, "ok")
+	}
+}
+
+func TestASRService_Transcribe_NoHotwords(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm error = %v", err)
+		}
+		if _, ok := r.MultipartForm.Value["hotwords"]; ok {
+			t.Error("hotwords should be omitted when empty")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ASRResponse{Text: "ok"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if _, err := client.ASR.Transcribe(context.Background(), []byte("audio"), nil); err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+}
+
 func TestASRService_Transcribe_NilParams(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		err := r.ParseMultipartForm(10 << 20)
@@ -276,6 +489,322 @@ func TestASRService_Transcribe_ContextCancellation(t *testing.T) {
 	}
 }
 
+func TestASRService_TranscribeReader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength != -1 {
+			t.Errorf("ContentLength = %d, want -1 (streamed)", r.ContentLength)
+		}
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm error = %v", err)
+		}
+
+		file, _, err := r.FormFile("audio")
+		if err != nil {
+			t.Fatalf("FormFile(audio) error = %v", err)
+		}
+		defer func() { _ = file.Close() }()
+		audio, _ := io.ReadAll(file)
+		if string(audio) != "streamed audio" {
+			t.Errorf("audio = %q, want %q", audio, "streamed audio")
+		}
+		if lang := r.FormValue("language"); lang != "en" {
+			t.Errorf("language = %q, want %q", lang, "en")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ASRResponse{Text: "ok"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	result, err := client.ASR.TranscribeReader(context.Background(), strings.NewReader("streamed audio"), &TranscribeParams{Language: "en"})
+	if err != nil {
+		t.Fatalf("TranscribeReader() error = %v", err)
+	}
+	if result.Text != "ok" {
+		t.Errorf("Text = %q, want %q", result.Text, "ok")
+	}
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestASRService_TranscribeReader_ReadError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+	}))
+	defer server.Close()
+
+	readErr := errors.New("disk failure")
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	_, err := client.ASR.TranscribeReader(context.Background(), errReader{readErr}, nil)
+	if !errors.Is(err, readErr) {
+		t.Errorf("TranscribeReader() err = %v, want %v", err, readErr)
+	}
+}
+
+func TestASRService_TranscribeReader_EarlyResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Reject without reading the body
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	// A reader that never ends would block forever if the pipe weren't closed
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.ASR.TranscribeReader(context.Background(), infiniteReader{}, nil)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("TranscribeReader() did not return after the server responded")
+	}
+}
+
+type infiniteReader struct{}
+
+func (infiniteReader) Read(p []byte) (int, error) { return len(p), nil }
+
+func TestASRService_TranscribeFile(t *testing.T) {
+	tests := []struct {
+		name            string
+		file            string
+		content         string
+		wantFilename    string
+		wantContentType string
+	}{
+		{"wav", "clip.wav", "RIFF\x24\x00\x00\x00WAVEfmt data", "clip.wav", "audio/wav"},
+		{"misnamed flac", "clip.mp3", "fLaC\x00\x00\x00\x22data", "clip.flac", "audio/flac"},
+		{"unknown", "clip.bin", "not audio at all", "clip.bin", "application/octet-stream"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseMultipartForm(10 << 20); err != nil {
+					t.Fatalf("ParseMultipartForm error = %v", err)
+				}
+				file, header, err := r.FormFile("audio")
+				if err != nil {
+					t.Fatalf("FormFile(audio) error = %v", err)
+				}
+				defer func() { _ = file.Close() }()
+
+				if header.Filename != tt.wantFilename {
+					t.Errorf("filename = %q, want %q", header.Filename, tt.wantFilename)
+				}
+				if ct := header.Header.Get("Content-Type"); ct != tt.wantContentType {
+					t.Errorf("Content-Type = %q, want %q", ct, tt.wantContentType)
+				}
+				if data, _ := io.ReadAll(file); string(data) != tt.content {
+					t.Errorf("audio = %q, want %q", data, tt.content)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(ASRResponse{Text: "ok"})
+			}))
+			defer server.Close()
+
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			result, err := client.ASR.TranscribeFile(context.Background(), path, nil)
+			if err != nil {
+				t.Fatalf("TranscribeFile() error = %v", err)
+			}
+			if result.Text != "ok" {
+				t.Errorf("Text = %q, want %q", result.Text, "ok")
+			}
+		})
+	}
+}
+
+func TestASRService_Transcribe_DetectsFormat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm error = %v", err)
+		}
+		_, header, err := r.FormFile("audio")
+		if err != nil {
+			t.Fatalf("FormFile(audio) error = %v", err)
+		}
+		if header.Filename != "audio.wav" {
+			t.Errorf("filename = %q, want %q", header.Filename, "audio.wav")
+		}
+		if ct := header.Header.Get("Content-Type"); ct != "audio/wav" {
+			t.Errorf("Content-Type = %q, want %q", ct, "audio/wav")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ASRResponse{Text: "ok"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if _, err := client.ASR.Transcribe(context.Background(), []byte("RIFF\x24\x00\x00\x00WAVEfmt "), nil); err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+}
+
+func TestASRService_Transcribe_FilenameAndContentType(t *testing.T) {
+	tests := []struct {
+		name            string
+		params          *TranscribeParams
+		wantFilename    string
+		wantContentType string
+	}{
+		{"both", &TranscribeParams{Filename: "call.opus", ContentType: "audio/ogg"}, "call.opus", "audio/ogg"},
+		{"filename only", &TranscribeParams{Filename: "call.opus"}, "call.opus", "audio/wav"},
+		{"content type only", &TranscribeParams{ContentType: "audio/x-wav"}, "audio.wav", "audio/x-wav"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseMultipartForm(10 << 20); err != nil {
+					t.Fatalf("ParseMultipartForm error = %v", err)
+				}
+				_, header, err := r.FormFile("audio")
+				if err != nil {
+					t.Fatalf("FormFile(audio) error = %v", err)
+				}
+				if header.Filename != tt.wantFilename {
+					t.Errorf("filename = %q, want %q", header.Filename, tt.wantFilename)
+				}
+				if ct := header.Header.Get("Content-Type"); ct != tt.wantContentType {
+					t.Errorf("Content-Type = %q, want %q", ct, tt.wantContentType)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(ASRResponse{Text: "ok"})
+			}))
+			defer server.Close()
+
+			client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			if _, err := client.ASR.Transcribe(context.Background(), []byte("RIFF\x24\x00\x00\x00WAVEfmt "), tt.params); err != nil {
+				t.Fatalf("Transcribe() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestASRService_Transcribe_RetriesUpload(t *testing.T) {
+	audio := []byte(strings.Repeat("speech ", 10000))
+	path := filepath.Join(t.TempDir(), "speech.wav")
+	if err := os.WriteFile(path, audio, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		transcribe func(*Client) (*ASRResponse, error)
+	}{
+		{"bytes", func(c *Client) (*ASRResponse, error) {
+			return c.ASR.Transcribe(context.Background(), audio, nil)
+		}},
+		{"seekable reader", func(c *Client) (*ASRResponse, error) {
+			return c.ASR.TranscribeReader(context.Background(), strings.NewReader(string(audio)), nil)
+		}},
+		{"unseekable reader", func(c *Client) (*ASRResponse, error) {
+			return c.ASR.TranscribeReader(context.Background(), io.MultiReader(strings.NewReader(string(audio))), nil)
+		}},
+		{"file", func(c *Client) (*ASRResponse, error) {
+			return c.ASR.TranscribeFile(context.Background(), path, nil)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if requests == 1 {
+					// Fail partway through the upload
+					_, _ = io.CopyN(io.Discard, r.Body, 1024)
+					w.WriteHeader(http.StatusBadGateway)
+					return
+				}
+				if err := r.ParseMultipartForm(10 << 20); err != nil {
+					t.Errorf("ParseMultipartForm error = %v", err)
+					return
+				}
+				file, _, _ := r.FormFile("audio")
+				got, _ := io.ReadAll(file)
+				if string(got) != string(audio) {
+					t.Errorf("retry uploaded %d bytes, want the full %d", len(got), len(audio))
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(ASRResponse{Text: "ok"})
+			}))
+			defer server.Close()
+
+			client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithRetryPolicy(BackoffPolicy{Delay: time.Millisecond}))
+			result, err := tt.transcribe(client)
+			if err != nil {
+				t.Fatalf("transcribe error = %v", err)
+			}
+			if result.Text != "ok" || requests != 2 {
+				t.Errorf("got %q after %d requests, want ok after 2", result.Text, requests)
+			}
+		})
+	}
+}
+
+func TestASRService_TranscribeFile_Missing(t *testing.T) {
+	client := NewClient(WithAPIKey("test-key"))
+	_, err := client.ASR.TranscribeFile(context.Background(), filepath.Join(t.TempDir(), "missing.wav"), nil)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("TranscribeFile() err = %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+func TestASRService_TranscribeURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm error = %v", err)
+		}
+		if got := r.FormValue("audio_url"); got != "https://cdn.example.com/call.wav?sig=abc" {
+			t.Errorf("audio_url = %q", got)
+		}
+		if len(r.MultipartForm.File["audio"]) != 0 {
+			t.Error("expected no audio file part")
+		}
+		if got := r.FormValue("language"); got != "en" {
+			t.Errorf("language = %q, want %q", got, "en")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ASRResponse{Text: "ok"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	result, err := client.ASR.TranscribeURL(context.Background(), "https://cdn.example.com/call.wav?sig=abc", &TranscribeParams{Language: "en"})
+	if err != nil {
+		t.Fatalf("TranscribeURL() error = %v", err)
+	}
+	if result.Text != "ok" {
+		t.Errorf("Text = %q, want %q", result.Text, "ok")
+	}
+}
+
+func TestASRService_TranscribeURL_Invalid(t *testing.T) {
+	client := NewClient(WithAPIKey("test-key"))
+	for _, u := range []string{"", "file:///etc/passwd", "/relative/path.wav", "https://"} {
+		if _, err := client.ASR.TranscribeURL(context.Background(), u, nil); err == nil {
+			t.Errorf("TranscribeURL(%q) error = nil, want error", u)
+		}
+	}
+}
+
 // boolPtr returns a pointer to a bool value.
 func boolPtr(b bool) *bool {
 	return &b
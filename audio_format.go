@@ -0,0 +1,72 @@
+package fishaudio
+
+import (
+	"bytes"
+	"io"
+)
+
+// sniffLen is the number of leading bytes needed to detect an audio container.
+const sniffLen = 12
+
+// defaultAudioFile is used when the audio format is unknown.
+var defaultAudioFile = formFile{name: "audio.mp3", contentType: "application/octet-stream"}
+
+// newAudioFile returns the file description for audio detected as ext,
+// falling back to defaultAudioFile for unknown formats.
+func newAudioFile(ext, contentType string) formFile {
+	if ext == "" {
+		return defaultAudioFile
+	}
+	return formFile{name: "audio." + ext, contentType: contentType}
+}
+
+// detectAudioFormat identifies the container of audio from its leading bytes
+// and returns its file extension and MIME type, or empty strings if unknown.
+func detectAudioFormat(header []byte) (ext, contentType string) {
+	switch {
+	case len(header) >= 12 && bytes.Equal(header[0:4], []byte("RIFF")) && bytes.Equal(header[8:12], []byte("WAVE")):
+		return "wav", "audio/wav"
+	case bytes.HasPrefix(header, []byte("OggS")):
+		return "ogg", "audio/ogg"
+	case bytes.HasPrefix(header, []byte("fLaC")):
+		return "flac", "audio/flac"
+	case bytes.HasPrefix(header, []byte("ID3")):
+		return "mp3", "audio/mpeg"
+	case len(header) >= 2 && header[0] == 0xFF && header[1]&0xE0 == 0xE0:
+		// MPEG audio frame sync
+		return "mp3", "audio/mpeg"
+	}
+	return "", ""
+}
+
+// sniffAudio reads the start of r to detect its format. It returns a reader
+// that yields the full stream, including the bytes consumed while sniffing.
+// A reader that can seek is seeked back and returned itself, so it can
+// still be rewound to retry an upload.
+func sniffAudio(r io.Reader) (io.Reader, string, string, error) {
+	if rs, ok := r.(io.ReadSeeker); ok {
+		// Pipes and terminals implement io.Seeker but fail to seek
+		if start, err := rs.Seek(0, io.SeekCurrent); err == nil {
+			header := make([]byte, sniffLen)
+			n, err := io.ReadFull(rs, header)
+			if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+				return nil, "", "", err
+			}
+			if _, err := rs.Seek(start, io.SeekStart); err != nil {
+				return nil, "", "", err
+			}
+			ext, contentType := detectAudioFormat(header[:n])
+			return rs, ext, contentType, nil
+		}
+	}
+
+	header := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, header)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, "", "", err
+	}
+	header = header[:n]
+
+	ext, contentType := detectAudioFormat(header)
+	return io.MultiReader(bytes.NewReader(header), r), ext, contentType, nil
+}
@@ -0,0 +1,62 @@
+package fishaudio
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDetectAudioFormat(t *testing.T) {
+	tests := []struct {
+		name            string
+		header          []byte
+		wantExt         string
+		wantContentType string
+	}{
+		{"wav", []byte("RIFF\x24\x00\x00\x00WAVEfmt "), "wav", "audio/wav"},
+		{"ogg", []byte("OggS\x00\x02"), "ogg", "audio/ogg"},
+		{"flac", []byte("fLaC\x00\x00\x00\x22"), "flac", "audio/flac"},
+		{"mp3 with ID3", []byte("ID3\x04\x00"), "mp3", "audio/mpeg"},
+		{"mp3 frame sync", []byte{0xFF, 0xFB, 0x90, 0x00}, "mp3", "audio/mpeg"},
+		{"riff but not wave", []byte("RIFF\x24\x00\x00\x00AVI "), "", ""},
+		{"unknown", []byte("hello world!"), "", ""},
+		{"empty", nil, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ext, contentType := detectAudioFormat(tt.header)
+			if ext != tt.wantExt || contentType != tt.wantContentType {
+				t.Errorf("detectAudioFormat() = %q, %q, want %q, %q", ext, contentType, tt.wantExt, tt.wantContentType)
+			}
+		})
+	}
+}
+
+func TestSniffAudio_PreservesStream(t *testing.T) {
+	input := "OggS" + strings.Repeat("x", 100)
+	r, ext, _, err := sniffAudio(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("sniffAudio() error = %v", err)
+	}
+	if ext != "ogg" {
+		t.Errorf("ext = %q, want %q", ext, "ogg")
+	}
+	data, _ := io.ReadAll(r)
+	if string(data) != input {
+		t.Errorf("stream was not preserved: got %d bytes, want %d", len(data), len(input))
+	}
+}
+
+func TestSniffAudio_ShortInput(t *testing.T) {
+	r, ext, _, err := sniffAudio(strings.NewReader("ab"))
+	if err != nil {
+		t.Fatalf("sniffAudio() error = %v", err)
+	}
+	if ext != "" {
+		t.Errorf("ext = %q, want empty", ext)
+	}
+	if data, _ := io.ReadAll(r); string(data) != "ab" {
+		t.Errorf("data = %q, want %q", data, "ab")
+	}
+}
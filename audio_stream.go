@@ -33,7 +33,21 @@ type AudioStream struct {
 	buf       []byte
 	chunkSize int
 	err       error
+	eof       bool
 	closed    bool
+	// reuse reads every chunk into the same buffer (see WithChunkReuse).
+	reuse bool
+}
+
+// WithChunkReuse makes audio streams reuse their chunk buffers instead of
+// allocating one per chunk, which cuts garbage when many streams run at
+// once. The slice returned by Bytes, and the chunk passed to ConvertFunc
+// and StreamWebSocketFunc callbacks, is then only valid until the next
+// call to Next or Read; copy it to keep it longer.
+func WithChunkReuse() ClientOption {
+	return func(c *Client) {
+		c.reuseChunks = true
+	}
 }
 
 // newAudioStream creates a new AudioStream from an HTTP response.
@@ -44,30 +58,51 @@ func newAudioStream(resp *http.Response) *AudioStream {
 	}
 }
 
+// audioStream creates an AudioStream from resp that reuses its chunk
+// buffer if WithChunkReuse is set.
+func (c *Client) audioStream(resp *http.Response) *AudioStream {
+	stream := newAudioStream(resp)
+	stream.reuse = c.chunkReuse()
+	return stream
+}
+
+// chunkReuse reports whether streams may reuse chunk buffers. It is safe
+// to call on a nil client.
+func (c *Client) chunkReuse() bool {
+	return c != nil && c.reuseChunks
+}
+
 // Next advances to the next chunk of audio data.
 // It returns false when there are no more chunks or an error occurred.
+//
+// With WithChunkReuse, the chunk buffer is reused, so the slice returned by
+// Bytes is only valid until the next call to Next.
 func (s *AudioStream) Next() bool {
-	if s.closed || s.err != nil {
+	if s.closed || s.eof || s.err != nil {
 		return false
 	}
 
-	s.buf = make([]byte, s.chunkSize)
-	n, err := s.resp.Body.Read(s.buf)
-	if err != nil {
+	if !s.reuse || cap(s.buf) < s.chunkSize {
+		s.buf = make([]byte, s.chunkSize)
+	}
+	for {
+		n, err := s.resp.Body.Read(s.buf[:s.chunkSize])
+		s.buf = s.buf[:n]
 		if err == io.EOF {
-			s.closed = true
-			return false
+			s.eof = true
+		} else if err != nil {
+			// Deliver any data read with the error first
+			s.err = err
+		}
+		if n > 0 || err != nil {
+			return n > 0
 		}
-		s.err = err
-		return false
 	}
-
-	s.buf = s.buf[:n]
-	return true
 }
 
 // Bytes returns the current chunk of audio data.
-// Only valid after a successful call to Next().
+// Only valid after a successful call to Next(); with WithChunkReuse, only
+// until the next call.
 func (s *AudioStream) Bytes() []byte {
 	return s.buf
 }
@@ -103,6 +138,23 @@ func (s *AudioStream) Close() error {
 	return nil
 }
 
+// Usage returns what the request was billed, or nil if the response
+// carried no usage headers.
+//
+// Example:
+//
+//	stream, _ := client.TTS.Stream(ctx, params)
+//	audio, _ := stream.Collect()
+//	if usage := stream.Usage(); usage != nil {
+//	    billing.Record(tenantID, usage.Credits)
+//	}
+func (s *AudioStream) Usage() *RequestUsage {
+	if s.resp == nil {
+		return nil
+	}
+	return parseUsage(s.resp.Header)
+}
+
 // Read implements io.Reader interface.
 func (s *AudioStream) Read(p []byte) (n int, err error) {
 	if s.closed {
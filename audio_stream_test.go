@@ -2,9 +2,11 @@ package fishaudio
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"net/http"
 	"testing"
+	"testing/iotest"
 )
 
 // mockReadCloser is a simple mock for http.Response.Body
@@ -66,6 +68,85 @@ func TestAudioStream_Next_And_Bytes(t *testing.T) {
 	}
 }
 
+func TestAudioStream_Next_DataWithEOF(t *testing.T) {
+	body := newMockReadCloser([]byte("final"))
+	stream := newAudioStream(&http.Response{
+		Body: struct {
+			io.Reader
+			io.Closer
+		}{iotest.DataErrReader(body), body},
+	})
+
+	if !stream.Next() || string(stream.Bytes()) != "final" {
+		t.Fatalf("Next() chunk = %q, err = %v, want %q", stream.Bytes(), stream.Err(), "final")
+	}
+	if stream.Next() {
+		t.Errorf("Next() = true after EOF, chunk = %q", stream.Bytes())
+	}
+	if err := stream.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+
+	_ = stream.Close()
+	if !body.closed {
+		t.Error("body should be closed after Close()")
+	}
+}
+
+func TestAudioStream_Next_DataWithError(t *testing.T) {
+	errBroken := errors.New("connection reset")
+	stream := newAudioStream(&http.Response{
+		Body: io.NopCloser(io.MultiReader(
+			bytes.NewReader([]byte("partial")),
+			iotest.ErrReader(errBroken),
+		)),
+	})
+
+	var collected bytes.Buffer
+	for stream.Next() {
+		collected.Write(stream.Bytes())
+	}
+	if collected.String() != "partial" {
+		t.Errorf("collected = %q, want %q", collected.String(), "partial")
+	}
+	if !errors.Is(stream.Err(), errBroken) {
+		t.Errorf("Err() = %v, want %v", stream.Err(), errBroken)
+	}
+}
+
+func TestAudioStream_Next_ReusesBuffer(t *testing.T) {
+	client := NewClient(WithChunkReuse())
+	stream := client.audioStream(&http.Response{Body: newMockReadCloser([]byte("aaaabbbb"))})
+	stream.chunkSize = 4
+
+	if !stream.Next() {
+		t.Fatal("Next() = false")
+	}
+	first := stream.Bytes()
+	if !stream.Next() {
+		t.Fatal("Next() = false")
+	}
+	if &first[0] != &stream.Bytes()[0] {
+		t.Error("Next() allocated a new chunk buffer")
+	}
+	if string(stream.Bytes()) != "bbbb" {
+		t.Errorf("chunk = %q, want %q", stream.Bytes(), "bbbb")
+	}
+}
+
+func TestAudioStream_Next_KeepsChunks(t *testing.T) {
+	stream := NewClient().audioStream(&http.Response{Body: newMockReadCloser([]byte("aaaabbbb"))})
+	stream.chunkSize = 4
+
+	var chunks [][]byte
+	for stream.Next() {
+		chunks = append(chunks, stream.Bytes())
+	}
+	if len(chunks) != 2 || string(chunks[0]) != "aaaa" || string(chunks[1]) != "bbbb" {
+		t.Errorf("chunks = %q, want [aaaa bbbb] still intact", chunks)
+	}
+}
+
 func TestAudioStream_Next_Empty(t *testing.T) {
 	resp := &http.Response{
 		Body: newMockReadCloser([]byte{}),
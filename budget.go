@@ -0,0 +1,191 @@
+package fishaudio
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrBudgetExceeded is matched by errors.Is for every *BudgetExceededError.
+var ErrBudgetExceeded = errors.New("budget exceeded")
+
+// BudgetExceededError is returned instead of sending a request that would
+// exceed a BudgetGuard limit.
+type BudgetExceededError struct {
+	// Resource is the exhausted limit: "characters" or "credits".
+	Resource string
+	// Used is today's usage of Resource. Credits are in MicroCredits.
+	Used int64
+	// Limit is the configured daily limit of Resource.
+	Limit int64
+}
+
+func (e *BudgetExceededError) Error() string {
+	if e.Resource == "credits" {
+		return fmt.Sprintf("daily credit budget exceeded: used %s of %s", MicroCredits(e.Used), MicroCredits(e.Limit))
+	}
+	return fmt.Sprintf("daily %s budget exceeded: used %d of %d", e.Resource, e.Used, e.Limit)
+}
+
+func (e *BudgetExceededError) Is(target error) bool { return target == ErrBudgetExceeded }
+
+func (e *BudgetExceededError) IsFishAudioError() {}
+
+// BudgetGuard enforces daily spending limits on the client it is installed
+// on with WithBudgetGuard. It tracks usage locally: TTS characters are
+// counted from the request text, and credits from the usage headers of
+// TTS and ASR responses. Call Reconcile with a fresh balance to also
+// account for spending the headers don't cover, such as other processes
+// sharing the API key.
+//
+// Live WebSocket sessions count each text chunk as it is sent, and end
+// with a *BudgetExceededError once a chunk would exceed a limit. A
+// BudgetGuard is safe for concurrent use and may be shared by several
+// clients.
+//
+// Example:
+//
+//	guard := &fishaudio.BudgetGuard{
+//	    MaxCharactersPerDay: 200_000,
+//	    MaxCreditsPerDay:    50 * fishaudio.OneCredit,
+//	}
+//	client := fishaudio.NewClient(fishaudio.WithBudgetGuard(guard))
+//	_, err := client.TTS.Convert(ctx, params)
+//	if errors.Is(err, fishaudio.ErrBudgetExceeded) {
+//	    // Degrade gracefully until tomorrow
+//	}
+type BudgetGuard struct {
+	// MaxCharactersPerDay limits the TTS characters sent per day. Zero
+	// means no limit.
+	MaxCharactersPerDay int
+
+	// MaxCreditsPerDay limits the credits spent per day. Zero means no
+	// limit. A request is rejected once the limit is reached; since its
+	// cost is only known afterwards, the last request may overshoot.
+	MaxCreditsPerDay MicroCredits
+
+	// Location sets when the day resets. Default: UTC.
+	Location *time.Location
+
+	mu           sync.Mutex
+	day          string
+	characters   int
+	credits      MicroCredits
+	startBalance *MicroCredits
+
+	// now is time.Now, replaceable in tests.
+	now func() time.Time
+}
+
+// Usage returns today's tracked usage.
+func (g *BudgetGuard) Usage() (characters int, credits MicroCredits) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.rollover()
+	return g.characters, g.credits
+}
+
+// Reconcile updates today's credit usage from a balance fetched with
+// GetCredits. The first balance of each day is the baseline; later ones
+// raise the tracked usage to the drop from that baseline if it is higher.
+//
+// Example:
+//
+//	credits, err := client.Account.GetCreditsCached(ctx, time.Minute)
+//	if err == nil {
+//	    _ = guard.Reconcile(credits)
+//	}
+func (g *BudgetGuard) Reconcile(credits *Credits) error {
+	balance, err := credits.Amount()
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.rollover()
+	if g.startBalance == nil {
+		g.startBalance = &balance
+		return nil
+	}
+	if spent := *g.startBalance - balance; spent > g.credits {
+		g.credits = spent
+	}
+	return nil
+}
+
+// budgetGuard returns the client's BudgetGuard, or nil if there is none.
+// c may be nil.
+func (c *Client) budgetGuard() *BudgetGuard {
+	if c == nil {
+		return nil
+	}
+	return c.budget
+}
+
+// reserve checks the limits and counts the characters of text, returning a
+// *BudgetExceededError if a limit is reached. It returns the day the
+// characters were counted on, for release.
+func (g *BudgetGuard) reserve(text string) (string, error) {
+	chars := utf8.RuneCountInString(text)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.rollover()
+	if g.MaxCreditsPerDay > 0 && g.credits >= g.MaxCreditsPerDay {
+		return "", &BudgetExceededError{Resource: "credits", Used: int64(g.credits), Limit: int64(g.MaxCreditsPerDay)}
+	}
+	if g.MaxCharactersPerDay > 0 && g.characters+chars > g.MaxCharactersPerDay {
+		return "", &BudgetExceededError{Resource: "characters", Used: int64(g.characters), Limit: int64(g.MaxCharactersPerDay)}
+	}
+	g.characters += chars
+	return g.day, nil
+}
+
+// release uncounts the characters of a request that failed, reserved on
+// day. Characters reserved before the day rolled over were never in
+// today's count, so they are left alone.
+func (g *BudgetGuard) release(day, text string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.rollover()
+	if day != g.day {
+		return
+	}
+	g.characters -= utf8.RuneCountInString(text)
+	if g.characters < 0 {
+		g.characters = 0
+	}
+}
+
+// record counts the credits of a completed request.
+func (g *BudgetGuard) record(u *RequestUsage) {
+	if u == nil {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.rollover()
+	g.credits += u.Credits
+}
+
+// rollover resets the counters when the day changes. g.mu must be held.
+func (g *BudgetGuard) rollover() {
+	now := time.Now
+	if g.now != nil {
+		now = g.now
+	}
+	loc := g.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	day := now().In(loc).Format(time.DateOnly)
+	if day != g.day {
+		g.day = day
+		g.characters = 0
+		g.credits = 0
+		g.startBalance = nil
+	}
+}
@@ -0,0 +1,189 @@
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBudgetGuard_Characters(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte("audio"))
+	}))
+	defer server.Close()
+
+	guard := &BudgetGuard{MaxCharactersPerDay: 10}
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithBudgetGuard(guard))
+	ctx := context.Background()
+
+	if _, err := client.TTS.Convert(ctx, &ConvertParams{Text: "héllo"}); err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	_, err := client.TTS.Convert(ctx, &ConvertParams{Text: "world!"})
+	var budgetErr *BudgetExceededError
+	if !errors.As(err, &budgetErr) || !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("Convert() err = %v, want *BudgetExceededError", err)
+	}
+	if budgetErr.Resource != "characters" || budgetErr.Used != 5 || budgetErr.Limit != 10 {
+		t.Errorf("err = %+v", budgetErr)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("requests = %d, want 1 (rejected call not sent)", n)
+	}
+
+	if _, err := client.TTS.Convert(ctx, &ConvertParams{Text: "world"}); err != nil {
+		t.Errorf("Convert() within budget error = %v", err)
+	}
+}
+
+func TestBudgetGuard_FailedRequestReleased(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	guard := &BudgetGuard{MaxCharactersPerDay: 10}
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithBudgetGuard(guard))
+	_, _ = client.TTS.Convert(context.Background(), &ConvertParams{Text: "hello"})
+	if chars, _ := guard.Usage(); chars != 0 {
+		t.Errorf("characters = %d, want 0 after failed request", chars)
+	}
+}
+
+func TestBudgetGuard_Credits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Usage-Credits", "0.6")
+		_, _ = w.Write([]byte(`{"text":"hi"}`))
+	}))
+	defer server.Close()
+
+	guard := &BudgetGuard{MaxCreditsPerDay: OneCredit}
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithBudgetGuard(guard))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.ASR.Transcribe(ctx, []byte("audio"), nil); err != nil {
+			t.Fatalf("Transcribe() #%d error = %v", i, err)
+		}
+	}
+	_, err := client.ASR.Transcribe(ctx, []byte("audio"), nil)
+	var budgetErr *BudgetExceededError
+	if !errors.As(err, &budgetErr) || budgetErr.Resource != "credits" {
+		t.Fatalf("Transcribe() err = %v, want credit budget error", err)
+	}
+	if got := budgetErr.Error(); got != "daily credit budget exceeded: used 1.2 of 1" {
+		t.Errorf("Error() = %q", got)
+	}
+	if _, err := client.TTS.Stream(ctx, &StreamParams{Text: "x"}); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("Stream() err = %v, want ErrBudgetExceeded", err)
+	}
+}
+
+func TestBudgetGuard_Reconcile(t *testing.T) {
+	guard := &BudgetGuard{MaxCreditsPerDay: 5 * OneCredit}
+	if err := guard.Reconcile(&Credits{Credit: "100"}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	guard.record(&RequestUsage{Credits: OneCredit})
+	_ = guard.Reconcile(&Credits{Credit: "96.5"})
+	if _, credits := guard.Usage(); credits != 3_500_000 {
+		t.Errorf("credits = %v, want 3.5 from balance drop", credits)
+	}
+	_ = guard.Reconcile(&Credits{Credit: "99"})
+	if _, credits := guard.Usage(); credits != 3_500_000 {
+		t.Errorf("credits = %v, want 3.5 kept when balance rises", credits)
+	}
+	if err := guard.Reconcile(&Credits{Credit: "?"}); err == nil {
+		t.Error("Reconcile() error = nil for unparseable balance")
+	}
+}
+
+func TestBudgetGuard_DayRollover(t *testing.T) {
+	now := time.Date(2024, 5, 1, 23, 0, 0, 0, time.UTC)
+	guard := &BudgetGuard{MaxCharactersPerDay: 3, now: func() time.Time { return now }}
+
+	day, err := guard.reserve("abc")
+	if err != nil {
+		t.Fatalf("reserve() error = %v", err)
+	}
+	if _, err := guard.reserve("d"); err == nil {
+		t.Fatal("reserve() error = nil over the limit")
+	}
+	now = now.Add(2 * time.Hour)
+	if _, err := guard.reserve("d"); err != nil {
+		t.Errorf("reserve() after midnight error = %v", err)
+	}
+
+	// Releasing yesterday's reservation leaves today's count alone
+	guard.release(day, "abc")
+	if chars, _ := guard.Usage(); chars != 1 {
+		t.Errorf("characters = %d after releasing yesterday's text, want 1", chars)
+	}
+
+	// In UTC+2 it was already the next day at 23:00 UTC
+	guard = &BudgetGuard{Location: time.FixedZone("EET", 2*3600), now: func() time.Time { return now }}
+	_, _ = guard.reserve("abc")
+	now = now.Add(-2 * time.Hour)
+	if chars, _ := guard.Usage(); chars != 3 {
+		t.Errorf("characters = %d, want 3 (same local day)", chars)
+	}
+}
+
+func TestBudgetGuard_WebSocket(t *testing.T) {
+	server := newEchoLiveServer(t)
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		stream func(*Client, <-chan string) (*WebSocketAudioStream, error)
+	}{
+		{"direct", func(c *Client, textChan <-chan string) (*WebSocketAudioStream, error) {
+			return c.TTS.StreamWebSocket(context.Background(), textChan, nil, nil)
+		}},
+		{"pool", func(c *Client, textChan <-chan string) (*WebSocketAudioStream, error) {
+			pool := c.TTS.NewSessionPool(context.Background(), &SessionPoolOptions{Size: 1})
+			t.Cleanup(func() { _ = pool.Close() })
+			return pool.Stream(context.Background(), textChan, nil)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard := &BudgetGuard{MaxCharactersPerDay: 8}
+			client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithBudgetGuard(guard))
+
+			textChan := make(chan string, 3)
+			textChan <- "hello"
+			textChan <- "abc"
+			textChan <- "world"
+			close(textChan)
+			stream, err := tt.stream(client, textChan)
+			if err != nil {
+				t.Fatalf("stream error = %v", err)
+			}
+			audio, err := stream.Collect()
+			if !errors.Is(err, ErrBudgetExceeded) {
+				t.Fatalf("Collect() = %q, %v, want ErrBudgetExceeded", audio, err)
+			}
+			if chars, _ := guard.Usage(); chars != 8 {
+				t.Errorf("characters = %d, want 8 counted from the sent text", chars)
+			}
+
+			// Once the limit is reached, new sessions are still checked per chunk
+			textChan = make(chan string, 1)
+			textChan <- "x"
+			close(textChan)
+			if stream, err = tt.stream(client, textChan); err == nil {
+				_, err = stream.Collect()
+			}
+			if !errors.Is(err, ErrBudgetExceeded) {
+				t.Errorf("second session error = %v, want ErrBudgetExceeded", err)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"os"
 	"time"
@@ -25,9 +26,27 @@ const (
 // Client is the Fish Audio API client.
 type Client struct {
 	apiKey     string
+	workspace  string
 	baseURL    string
 	timeout    time.Duration
 	httpClient *http.Client
+	budget     *BudgetGuard
+	ttsCache   TTSCache
+	storage    Storage
+	presets    *Presets
+
+	deprecations *deprecationNotifier
+	metricsHook  func(RequestMetrics)
+	tagHeader    bool
+
+	rethrowPanics    bool
+	retryPolicy      RetryPolicy
+	msgpackResponses bool
+	reuseChunks      bool
+	wireLog          *wireLogger
+
+	ttsDefaults    *TTSConfig
+	ttsDefaultsErr error
 
 	// Services
 	TTS     *TTSService
@@ -75,61 +94,112 @@ func (c *Client) Close() error {
 	return nil
 }
 
-// doRequest performs an HTTP request with authentication.
+// InWorkspace returns a copy of the client whose requests are scoped to
+// the workspace with the given ID. The copy shares the HTTP client, so it
+// is cheap to create per request.
+//
+// Example:
+//
+//	teamClient := client.InWorkspace("ws-123")
+//	voices, err := teamClient.Voices.List(ctx, &fishaudio.ListVoicesParams{SelfOnly: true})
+func (c *Client) InWorkspace(id string) *Client {
+	scoped := *c
+	scoped.workspace = id
+	scoped.TTS = &TTSService{client: &scoped}
+	scoped.ASR = &ASRService{client: &scoped}
+	scoped.Voices = &VoicesService{client: &scoped}
+	scoped.Account = &AccountService{client: &scoped}
+	return &scoped
+}
+
+// files returns the storage for file inputs and outputs.
+func (c *Client) files() Storage {
+	if c.storage == nil {
+		return OSStorage{}
+	}
+	return c.storage
+}
+
+// authorize sets the authentication and workspace headers.
+func (c *Client) authorize(header http.Header) {
+	header.Set("Authorization", "Bearer "+c.apiKey)
+	if c.workspace != "" {
+		header.Set(headerWorkspace, c.workspace)
+	}
+}
+
+// doRequest performs an HTTP request with authentication, retrying it as
+// the retry policy decides.
 func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, opts *RequestOptions) (*http.Response, error) {
 	url := c.baseURL + path
 
-	var bodyReader io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		bodyReader = bytes.NewReader(jsonBody)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("User-Agent", "fish-audio/go/"+Version)
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(jsonBody)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
 
-	// Apply request options
-	if opts != nil {
-		for k, v := range opts.AdditionalHeaders {
-			req.Header.Set(k, v)
+		// Set headers
+		c.authorize(req.Header)
+		req.Header.Set("User-Agent", "fish-audio/go/"+Version)
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
 		}
-		if len(opts.AdditionalQueryParams) > 0 {
-			q := req.URL.Query()
-			for k, v := range opts.AdditionalQueryParams {
-				q.Add(k, v)
+
+		// Apply request options
+		if opts != nil {
+			for k, v := range opts.AdditionalHeaders {
+				req.Header.Set(k, v)
+			}
+			if len(opts.AdditionalQueryParams) > 0 {
+				q := req.URL.Query()
+				for k, v := range opts.AdditionalQueryParams {
+					q.Add(k, v)
+				}
+				req.URL.RawQuery = q.Encode()
 			}
-			req.URL.RawQuery = q.Encode()
 		}
-	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
+		resp, err := c.send(req)
+		if err != nil {
+			err = withRequest(err, req, attempt, start)
+		} else if resp.StatusCode >= 400 {
+			err = withRequest(newAPIError(resp.StatusCode, resp.Status, errorBody(resp)), req, attempt, start)
+		} else {
+			return resp, nil
+		}
 
-	if resp.StatusCode >= 400 {
-		defer func() { _ = resp.Body.Close() }()
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, newAPIError(resp.StatusCode, resp.Status, string(bodyBytes))
+		if retry, err := c.retryWait(ctx, resp, err, attempt); !retry {
+			return nil, err
+		}
 	}
-
-	return resp, nil
 }
 
 // doJSONRequest performs an HTTP request and decodes the JSON response.
 func (c *Client) doJSONRequest(ctx context.Context, method, path string, body interface{}, result interface{}, opts *RequestOptions) error {
+	if c.msgpackResponses && result != nil {
+		accept := &RequestOptions{AdditionalHeaders: map[string]string{"Accept": acceptMsgpack}}
+		if opts != nil {
+			maps.Copy(accept.AdditionalHeaders, opts.AdditionalHeaders)
+			accept.AdditionalQueryParams = opts.AdditionalQueryParams
+		}
+		opts = accept
+	}
+
 	resp, err := c.doRequest(ctx, method, path, body, opts)
 	if err != nil {
 		return err
@@ -137,7 +207,7 @@ func (c *Client) doJSONRequest(ctx context.Context, method, path string, body in
 	defer func() { _ = resp.Body.Close() }()
 
 	if result != nil {
-		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		if err := decodeResponse(resp, result); err != nil {
 			return fmt.Errorf("failed to decode response: %w", err)
 		}
 	}
@@ -0,0 +1,43 @@
+package cloudstorage
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
+)
+
+// AzureWriter uploads to an Azure block blob, staging blocks as audio
+// arrives and committing them at the end.
+type AzureWriter struct {
+	// Blob is the destination blob.
+	Blob *blockblob.Client
+	// Options, if set, is called to customize the upload, e.g. to set the
+	// access tier. HTTPHeaders.BlobContentType is already set.
+	Options func(*blockblob.UploadStreamOptions)
+}
+
+// NewAzureWriter creates an AzureWriter for the blob.
+func NewAzureWriter(blob *blockblob.Client) *AzureWriter {
+	return &AzureWriter{Blob: blob}
+}
+
+// WriteBlob implements fishaudio.BlobWriter.
+func (w *AzureWriter) WriteBlob(ctx context.Context, r io.Reader, contentType string) error {
+	opts := &blockblob.UploadStreamOptions{
+		HTTPHeaders: &blob.HTTPHeaders{BlobContentType: &contentType},
+	}
+	if w.Options != nil {
+		w.Options(opts)
+	}
+
+	_, err := w.Blob.UploadStream(ctx, r, opts)
+	var respErr *azcore.ResponseError
+	if errors.As(err, &respErr) {
+		return statusError(respErr.StatusCode, err)
+	}
+	return err
+}
@@ -0,0 +1,61 @@
+package cloudstorage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
+	fishaudio "github.com/fishaudio/fish-audio-go"
+)
+
+func newTestBlob(t *testing.T, url string) *blockblob.Client {
+	opts := &blockblob.ClientOptions{}
+	opts.Retry = policy.RetryOptions{MaxRetries: -1}
+	blob, err := blockblob.NewClientWithNoCredential(url+"/audio/episode.mp3?sig=test", opts)
+	if err != nil {
+		t.Fatalf("NewClientWithNoCredential() error = %v", err)
+	}
+	return blob
+}
+
+func TestAzureWriter(t *testing.T) {
+	store := newFakeStore(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	writer := NewAzureWriter(newTestBlob(t, store.URL))
+	if err := writer.WriteBlob(context.Background(), strings.NewReader("audio"), "audio/mpeg"); err != nil {
+		t.Fatalf("WriteBlob() error = %v", err)
+	}
+
+	var uploaded bool
+	for _, req := range store.requests {
+		if req.path != "/audio/episode.mp3" {
+			t.Errorf("path = %q", req.path)
+		}
+		if strings.Contains(req.body, "audio") && req.method == http.MethodPut {
+			uploaded = true
+		}
+		if req.header.Get("X-Ms-Blob-Content-Type") == "audio/mpeg" {
+			return
+		}
+	}
+	t.Errorf("no request set the blob content type (uploaded: %v): %+v", uploaded, store.requests)
+}
+
+func TestAzureWriter_Error(t *testing.T) {
+	store := newFakeStore(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-ms-error-code", "AuthorizationFailure")
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	err := NewAzureWriter(newTestBlob(t, store.URL)).WriteBlob(context.Background(), strings.NewReader("audio"), "audio/mpeg")
+	var statusErr *fishaudio.BlobStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusForbidden || statusErr.Temporary() {
+		t.Errorf("WriteBlob() err = %v, want permanent *BlobStatusError 403", err)
+	}
+}
@@ -0,0 +1,26 @@
+// Package cloudstorage provides fishaudio.BlobWriter implementations for
+// Amazon S3, Google Cloud Storage, and Azure Blob Storage built on each
+// provider's SDK. Unlike fishaudio.PresignedURLWriter, they upload in
+// parts as audio arrives, so memory use doesn't grow with the audio.
+//
+// It lives in its own module so the core SDK doesn't depend on the cloud
+// SDKs.
+//
+// Example:
+//
+//	writer := cloudstorage.NewS3Writer(s3.NewFromConfig(cfg), "podcasts", "episodes/42.mp3")
+//	err := client.TTS.ConvertToStorage(ctx, &fishaudio.ConvertParams{Text: script}, writer)
+package cloudstorage
+
+import (
+	fishaudio "github.com/fishaudio/fish-audio-go"
+)
+
+// statusError wraps a provider error that carries an HTTP status so
+// fishaudio.TTSService.ConvertToStorage can tell whether to retry it.
+func statusError(code int, err error) error {
+	if code == 0 {
+		return err
+	}
+	return &fishaudio.BlobStatusError{StatusCode: code, Err: err}
+}
@@ -0,0 +1,52 @@
+package cloudstorage
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+)
+
+// fakeStore records the requests a storage SDK makes, answering each with
+// respond.
+type fakeStore struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []recordedRequest
+}
+
+type recordedRequest struct {
+	method, path, query string
+	header              http.Header
+	body                string
+}
+
+func newFakeStore(t *testing.T, respond func(w http.ResponseWriter, r *http.Request)) *fakeStore {
+	s := &fakeStore{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		s.requests = append(s.requests, recordedRequest{r.Method, r.URL.Path, r.URL.RawQuery, r.Header.Clone(), string(body)})
+		s.mu.Unlock()
+		respond(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestStatusError(t *testing.T) {
+	cause := errors.New("throttled")
+	err := statusError(http.StatusServiceUnavailable, cause)
+
+	var statusErr *fishaudio.BlobStatusError
+	if !errors.As(err, &statusErr) || !statusErr.Temporary() || !errors.Is(err, cause) {
+		t.Errorf("statusError() = %v, want temporary BlobStatusError wrapping the cause", err)
+	}
+	if err := statusError(0, cause); err != cause {
+		t.Errorf("statusError(0) = %v, want the cause unchanged", err)
+	}
+}
@@ -0,0 +1,54 @@
+package cloudstorage
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+)
+
+// GCSWriter uploads to a Google Cloud Storage object.
+type GCSWriter struct {
+	// Object is the destination object.
+	Object *storage.ObjectHandle
+	// Writer, if set, is called to customize the upload, e.g. to set
+	// metadata or the chunk size. ContentType is already set.
+	Writer func(*storage.Writer)
+}
+
+// NewGCSWriter creates a GCSWriter for the object bucket/name.
+func NewGCSWriter(client *storage.Client, bucket, name string) *GCSWriter {
+	return &GCSWriter{Object: client.Bucket(bucket).Object(name)}
+}
+
+// WriteBlob implements fishaudio.BlobWriter.
+func (w *GCSWriter) WriteBlob(ctx context.Context, r io.Reader, contentType string) error {
+	// Cancelling the writer's context aborts the upload, so a failed read
+	// doesn't leave a partial object
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	obj := w.Object.NewWriter(ctx)
+	obj.ContentType = contentType
+	if w.Writer != nil {
+		w.Writer(obj)
+	}
+
+	if _, err := io.Copy(obj, r); err != nil {
+		cancel()
+		_ = obj.Close()
+		return gcsError(err)
+	}
+	return gcsError(obj.Close())
+}
+
+// gcsError converts a GCS error with an HTTP status to a status error.
+func gcsError(err error) error {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return statusError(apiErr.Code, err)
+	}
+	return err
+}
@@ -0,0 +1,57 @@
+package cloudstorage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	fishaudio "github.com/fishaudio/fish-audio-go"
+	"google.golang.org/api/option"
+)
+
+func newTestGCSClient(t *testing.T, url string) *storage.Client {
+	t.Setenv("STORAGE_EMULATOR_HOST", strings.TrimPrefix(url, "http://"))
+	client, err := storage.NewClient(context.Background(), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("storage.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestGCSWriter(t *testing.T) {
+	store := newFakeStore(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"bucket": "podcasts", "name": "episodes/42.mp3"}`))
+	})
+
+	writer := NewGCSWriter(newTestGCSClient(t, store.URL), "podcasts", "episodes/42.mp3")
+	if err := writer.WriteBlob(context.Background(), strings.NewReader("audio bytes"), "audio/mpeg"); err != nil {
+		t.Fatalf("WriteBlob() error = %v", err)
+	}
+
+	if len(store.requests) != 1 {
+		t.Fatalf("requests = %d, want 1", len(store.requests))
+	}
+	req := store.requests[0]
+	if !strings.Contains(req.path, "/b/podcasts/o") || !strings.Contains(req.body, "audio bytes") || !strings.Contains(req.body, `"contentType":"audio/mpeg"`) {
+		t.Errorf("request = %s %s %q", req.method, req.path, req.body)
+	}
+}
+
+func TestGCSWriter_Error(t *testing.T) {
+	store := newFakeStore(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": {"code": 404, "message": "bucket not found"}}`))
+	})
+
+	err := NewGCSWriter(newTestGCSClient(t, store.URL), "missing", "k").WriteBlob(context.Background(), strings.NewReader("audio"), "audio/mpeg")
+	var statusErr *fishaudio.BlobStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("WriteBlob() err = %v, want *BlobStatusError 404", err)
+	}
+}
@@ -0,0 +1,50 @@
+package cloudstorage
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3Writer uploads to an Amazon S3 object, or to any S3-compatible store,
+// using multipart uploads for long audio.
+type S3Writer struct {
+	// Uploader performs the upload.
+	Uploader *manager.Uploader
+	// Bucket and Key name the object.
+	Bucket string
+	Key    string
+	// Input, if set, is called to customize the request, e.g. to set an
+	// ACL or metadata. Bucket, Key, Body, and ContentType are already set.
+	Input func(*s3.PutObjectInput)
+}
+
+// NewS3Writer creates an S3Writer for the object bucket/key.
+func NewS3Writer(client *s3.Client, bucket, key string) *S3Writer {
+	return &S3Writer{Uploader: manager.NewUploader(client), Bucket: bucket, Key: key}
+}
+
+// WriteBlob implements fishaudio.BlobWriter.
+func (w *S3Writer) WriteBlob(ctx context.Context, r io.Reader, contentType string) error {
+	input := &s3.PutObjectInput{
+		Bucket:      &w.Bucket,
+		Key:         &w.Key,
+		Body:        r,
+		ContentType: &contentType,
+	}
+	if w.Input != nil {
+		w.Input(input)
+	}
+
+	_, err := w.Uploader.Upload(ctx, input)
+	if err != nil {
+		var respErr interface{ HTTPStatusCode() int }
+		if errors.As(err, &respErr) {
+			return statusError(respErr.HTTPStatusCode(), err)
+		}
+	}
+	return err
+}
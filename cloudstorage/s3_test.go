@@ -0,0 +1,59 @@
+package cloudstorage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	fishaudio "github.com/fishaudio/fish-audio-go"
+)
+
+func newTestS3Client(url string) *s3.Client {
+	return s3.New(s3.Options{
+		BaseEndpoint: aws.String(url),
+		UsePathStyle: true,
+		Region:       "us-east-1",
+		Credentials:  credentials.NewStaticCredentialsProvider("key", "secret", ""),
+		Retryer:      aws.NopRetryer{},
+	})
+}
+
+func TestS3Writer(t *testing.T) {
+	store := newFakeStore(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	writer := NewS3Writer(newTestS3Client(store.URL), "podcasts", "episodes/42.mp3")
+	writer.Input = func(in *s3.PutObjectInput) { in.ACL = types.ObjectCannedACLPublicRead }
+	if err := writer.WriteBlob(context.Background(), strings.NewReader("audio"), "audio/mpeg"); err != nil {
+		t.Fatalf("WriteBlob() error = %v", err)
+	}
+
+	if len(store.requests) != 1 {
+		t.Fatalf("requests = %d, want 1", len(store.requests))
+	}
+	req := store.requests[0]
+	if req.method != http.MethodPut || req.path != "/podcasts/episodes/42.mp3" || !strings.Contains(req.body, "audio") {
+		t.Errorf("request = %s %s %q", req.method, req.path, req.body)
+	}
+	if req.header.Get("Content-Type") != "audio/mpeg" || req.header.Get("X-Amz-Acl") != "public-read" {
+		t.Errorf("headers = %v", req.header)
+	}
+}
+
+func TestS3Writer_Error(t *testing.T) {
+	store := newFakeStore(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`<Error><Code>SlowDown</Code><Message>slow down</Message></Error>`))
+	})
+
+	err := NewS3Writer(newTestS3Client(store.URL), "b", "k").WriteBlob(context.Background(), strings.NewReader("audio"), "audio/mpeg")
+	var statusErr *fishaudio.BlobStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("WriteBlob() err = %v, want *BlobStatusError 503", err)
+	}
+}
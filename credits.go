@@ -0,0 +1,136 @@
+package fishaudio
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MicroCredits is an exact credit amount in millionths of a credit. Being
+// an integer, it can be added and compared without the rounding errors of
+// float64.
+type MicroCredits int64
+
+// OneCredit is one credit in MicroCredits.
+const OneCredit MicroCredits = 1_000_000
+
+// creditDecimals is the number of decimal places MicroCredits holds.
+const creditDecimals = 6
+
+// ParseCredits parses a decimal credit amount such as "100.50" or "-0.25".
+// Digits beyond the sixth decimal place are truncated.
+//
+// Example:
+//
+//	budget, _ := fishaudio.ParseCredits("25.00")
+func ParseCredits(s string) (MicroCredits, error) {
+	str := strings.TrimSpace(s)
+	neg := strings.HasPrefix(str, "-")
+	str = strings.TrimPrefix(strings.TrimPrefix(str, "-"), "+")
+
+	whole, frac, _ := strings.Cut(str, ".")
+	if whole == "" && frac == "" || !isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("invalid credit amount %q", s)
+	}
+	if len(frac) > creditDecimals {
+		frac = frac[:creditDecimals]
+	}
+	frac += strings.Repeat("0", creditDecimals-len(frac))
+
+	// The magnitude limit is one more for negative amounts, so the
+	// minimum MicroCredits value parses
+	limit := uint64(math.MaxInt64)
+	if neg {
+		limit++
+	}
+	f, _ := strconv.ParseUint(frac, 10, 64)
+	var w uint64
+	if whole != "" {
+		var err error
+		if w, err = strconv.ParseUint(whole, 10, 64); err != nil || w > (limit-f)/uint64(OneCredit) {
+			return 0, fmt.Errorf("credit amount %q out of range", s)
+		}
+	}
+
+	u := w*uint64(OneCredit) + f
+	if neg {
+		// Negate as unsigned, which is exact for the minimum value
+		return MicroCredits(-u), nil
+	}
+	return MicroCredits(u), nil
+}
+
+// isDigits reports whether s consists only of ASCII digits.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats m as a decimal without trailing zeros, e.g. "100.5".
+func (m MicroCredits) String() string {
+	sign := ""
+	u := uint64(m)
+	if m < 0 {
+		sign = "-"
+		// Negate as unsigned; -m overflows for math.MinInt64
+		u = -uint64(m)
+	}
+	whole, frac := u/uint64(OneCredit), u%uint64(OneCredit)
+	if frac == 0 {
+		return sign + strconv.FormatUint(whole, 10)
+	}
+	fs := strings.TrimRight(fmt.Sprintf("%06d", frac), "0")
+	return sign + strconv.FormatUint(whole, 10) + "." + fs
+}
+
+// Float64 returns m in credits. Use it for display only; compare
+// MicroCredits values directly.
+func (m MicroCredits) Float64() float64 {
+	return float64(m) / float64(OneCredit)
+}
+
+// Amount returns the credit balance as MicroCredits.
+//
+// Example:
+//
+//	credits, _ := client.Account.GetCredits(ctx, nil)
+//	balance, err := credits.Amount()
+//	if err == nil && balance < 10*fishaudio.OneCredit {
+//	    log.Println("balance below 10 credits")
+//	}
+func (c *Credits) Amount() (MicroCredits, error) {
+	return ParseCredits(c.Credit)
+}
+
+// Below reports whether the balance is less than threshold.
+func (c *Credits) Below(threshold MicroCredits) (bool, error) {
+	amount, err := c.Amount()
+	if err != nil {
+		return false, err
+	}
+	return amount < threshold, nil
+}
+
+// Covers reports whether the balance is at least cost.
+func (c *Credits) Covers(cost MicroCredits) (bool, error) {
+	amount, err := c.Amount()
+	if err != nil {
+		return false, err
+	}
+	return amount >= cost, nil
+}
+
+// Amount returns the credit amount of the transaction as MicroCredits.
+func (t *Transaction) Amount() (MicroCredits, error) {
+	return ParseCredits(t.Credit)
+}
+
+// Amount returns the credits consumed as MicroCredits.
+func (r *UsageRecord) Amount() (MicroCredits, error) {
+	return ParseCredits(r.Credit)
+}
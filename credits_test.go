@@ -0,0 +1,100 @@
+package fishaudio
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseCredits(t *testing.T) {
+	tests := []struct {
+		in   string
+		want MicroCredits
+	}{
+		{"100.50", 100_500_000},
+		{"0.1", 100_000},
+		{"-0.25", -250_000},
+		{"+3", 3 * OneCredit},
+		{"42", 42 * OneCredit},
+		{".5", 500_000},
+		{"7.", 7 * OneCredit},
+		{"0.0000019", 1},
+		{" 1.000001 ", 1_000_001},
+		{"9223372036854.775807", math.MaxInt64},
+		{"-9223372036854.775808", math.MinInt64},
+	}
+	for _, tt := range tests {
+		got, err := ParseCredits(tt.in)
+		if err != nil {
+			t.Errorf("ParseCredits(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseCredits(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", ".", "-", "abc", "1.2.3", "1e3", "1,5", "99999999999999999999", "9223372036854.775808", "-9223372036854.775809"} {
+		if _, err := ParseCredits(in); err == nil {
+			t.Errorf("ParseCredits(%q) error = nil, want error", in)
+		}
+	}
+}
+
+func TestParseCredits_Exact(t *testing.T) {
+	// 0.1 + 0.2 is not 0.3 in float64
+	a, _ := ParseCredits("0.1")
+	b, _ := ParseCredits("0.2")
+	c, _ := ParseCredits("0.3")
+	if a+b != c {
+		t.Errorf("0.1 + 0.2 = %v, want %v", a+b, c)
+	}
+}
+
+func TestMicroCredits_String(t *testing.T) {
+	tests := []struct {
+		in   MicroCredits
+		want string
+	}{
+		{0, "0"},
+		{100_500_000, "100.5"},
+		{-250_000, "-0.25"},
+		{1, "0.000001"},
+		{5 * OneCredit, "5"},
+		{math.MaxInt64, "9223372036854.775807"},
+		{math.MinInt64, "-9223372036854.775808"},
+	}
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("MicroCredits(%d).String() = %q, want %q", int64(tt.in), got, tt.want)
+		}
+	}
+}
+
+func TestCredits_Compare(t *testing.T) {
+	c := &Credits{Credit: "9.999999"}
+	if below, err := c.Below(10 * OneCredit); err != nil || !below {
+		t.Errorf("Below(10) = %v, %v; want true", below, err)
+	}
+	if covers, err := c.Covers(9 * OneCredit); err != nil || !covers {
+		t.Errorf("Covers(9) = %v, %v; want true", covers, err)
+	}
+	if covers, _ := c.Covers(10 * OneCredit); covers {
+		t.Error("Covers(10) = true, want false")
+	}
+
+	bad := &Credits{Credit: "n/a"}
+	if _, err := bad.Below(OneCredit); err == nil {
+		t.Error("Below() error = nil for unparseable balance")
+	}
+}
+
+func TestTransaction_Amount(t *testing.T) {
+	tx := &Transaction{Credit: "-1.25"}
+	if got, err := tx.Amount(); err != nil || got != -1_250_000 {
+		t.Errorf("Amount() = %v, %v; want -1.25", got, err)
+	}
+	r := &UsageRecord{Credit: "0.5"}
+	if got, err := r.Amount(); err != nil || got.Float64() != 0.5 {
+		t.Errorf("Amount() = %v, %v; want 0.5", got, err)
+	}
+}
@@ -0,0 +1,168 @@
+package fishaudio
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DeprecationWarning is a notice from the API that something a request
+// used, such as a model or endpoint, is deprecated or will be retired.
+type DeprecationWarning struct {
+	// Method and Path identify the first request the warning was seen on.
+	Method string
+	Path   string
+	// Deprecation is the raw Deprecation header, if any.
+	Deprecation string
+	// DeprecatedAt is when the feature was or will be deprecated, if the
+	// Deprecation header gives a date.
+	DeprecatedAt time.Time
+	// Sunset is when the feature stops working, from the Sunset header.
+	Sunset time.Time
+	// Message holds the text of the Warning headers, joined by "; ".
+	Message string
+	// Link is the URL of the deprecation or sunset notice, from the Link
+	// header.
+	Link string
+	// Tags are the tags of the first request's context. See WithTag.
+	Tags map[string]string
+}
+
+// String returns a one-line description of the warning.
+func (w DeprecationWarning) String() string {
+	var b strings.Builder
+	b.WriteString(w.Method + " " + w.Path + " is deprecated")
+	if !w.Sunset.IsZero() {
+		b.WriteString(", sunset " + w.Sunset.Format(time.DateOnly))
+	}
+	if w.Message != "" {
+		b.WriteString(": " + w.Message)
+	}
+	if w.Link != "" {
+		b.WriteString(" (see " + w.Link + ")")
+	}
+	return b.String()
+}
+
+// WithDeprecationHandler calls fn once for each unique deprecation warning
+// the API sends in Deprecation, Sunset, or Warning headers, so retiring
+// models and endpoints are noticed before they stop working. fn is called
+// synchronously with the response and must not block.
+//
+// Example:
+//
+//	client := fishaudio.NewClient(fishaudio.WithDeprecationHandler(func(w fishaudio.DeprecationWarning) {
+//	    alerts.Notify("fish-audio: " + w.String())
+//	}))
+func WithDeprecationHandler(fn func(DeprecationWarning)) ClientOption {
+	return func(c *Client) {
+		c.deprecations = &deprecationNotifier{fn: fn, seen: make(map[string]bool)}
+	}
+}
+
+// WithDeprecationLogger logs each unique deprecation warning to logger at
+// warn level. See WithDeprecationHandler.
+func WithDeprecationLogger(logger *slog.Logger) ClientOption {
+	return WithDeprecationHandler(func(w DeprecationWarning) {
+		attrs := []slog.Attr{slog.String("method", w.Method), slog.String("path", w.Path)}
+		if !w.Sunset.IsZero() {
+			attrs = append(attrs, slog.Time("sunset", w.Sunset))
+		}
+		if w.Message != "" {
+			attrs = append(attrs, slog.String("message", w.Message))
+		}
+		if w.Link != "" {
+			attrs = append(attrs, slog.String("link", w.Link))
+		}
+		for k, v := range w.Tags {
+			attrs = append(attrs, slog.String("tag."+k, v))
+		}
+		logger.LogAttrs(context.Background(), slog.LevelWarn, "fish audio deprecation warning", attrs...)
+	})
+}
+
+// deprecationNotifier reports deprecation warnings once each. It is shared
+// by copies of a Client.
+type deprecationNotifier struct {
+	fn   func(DeprecationWarning)
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+// checkDeprecation reports the deprecation warning of resp to a request
+// with ctx, if any and not reported before.
+func (c *Client) checkDeprecation(ctx context.Context, method, path string, resp *http.Response) {
+	n := c.deprecations
+	if n == nil || resp == nil {
+		return
+	}
+	w, ok := parseDeprecation(resp.Header)
+	if !ok {
+		return
+	}
+
+	// The same warning on other paths, e.g. other voice IDs, is not new
+	key := strings.Join([]string{w.Deprecation, w.Sunset.String(), w.Message, w.Link}, "\x00")
+	n.mu.Lock()
+	seen := n.seen[key]
+	n.seen[key] = true
+	n.mu.Unlock()
+	if seen {
+		return
+	}
+
+	w.Method, w.Path, w.Tags = method, path, Tags(ctx)
+	n.fn(w)
+}
+
+// parseDeprecation reads the Deprecation (RFC 9745), Sunset (RFC 8594),
+// Warning, and related Link headers of a response.
+func parseDeprecation(h http.Header) (DeprecationWarning, bool) {
+	w := DeprecationWarning{Deprecation: h.Get("Deprecation")}
+	if d := w.Deprecation; strings.HasPrefix(d, "@") {
+		if sec, err := strconv.ParseInt(d[1:], 10, 64); err == nil {
+			w.DeprecatedAt = time.Unix(sec, 0).UTC()
+		}
+	} else if t, err := http.ParseTime(d); err == nil {
+		w.DeprecatedAt = t
+	}
+	if t, err := http.ParseTime(h.Get("Sunset")); err == nil {
+		w.Sunset = t
+	}
+
+	var messages []string
+	for _, v := range h.Values("Warning") {
+		messages = append(messages, warningText(v))
+	}
+	w.Message = strings.Join(messages, "; ")
+
+	if w.Deprecation == "" && w.Sunset.IsZero() && w.Message == "" {
+		return w, false
+	}
+	for _, v := range h.Values("Link") {
+		for _, link := range strings.Split(v, ",") {
+			target, params, _ := strings.Cut(link, ";")
+			if strings.Contains(params, `rel="deprecation"`) || strings.Contains(params, `rel="sunset"`) ||
+				strings.Contains(params, "rel=deprecation") || strings.Contains(params, "rel=sunset") {
+				w.Link = strings.Trim(strings.TrimSpace(target), "<>")
+				break
+			}
+		}
+	}
+	return w, true
+}
+
+// warningText returns the quoted text of a Warning header value such as
+// `299 - "Model speech-1.5 is deprecated"`, or the value itself.
+func warningText(v string) string {
+	start := strings.IndexByte(v, '"')
+	end := strings.LastIndexByte(v, '"')
+	if start < 0 || end <= start {
+		return strings.TrimSpace(v)
+	}
+	return v[start+1 : end]
+}
@@ -0,0 +1,72 @@
+package fishaudio
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithDeprecationHandler(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/model/") {
+			w.Header().Set("Deprecation", "@1767225600")
+			w.Header().Set("Sunset", "Wed, 01 Jul 2026 00:00:00 GMT")
+			w.Header().Add("Link", `<https://docs.fish.audio/changelog>; rel="sunset"`)
+			w.Header().Add("Warning", `299 - "GET /model/{id} is replaced by /v2/model/{id}"`)
+		}
+		if r.URL.Path == "/v1/tts" {
+			w.Header().Add("Warning", `299 - "model speech-1.5 is deprecated"`)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var warnings []DeprecationWarning
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithDeprecationHandler(func(w DeprecationWarning) {
+		warnings = append(warnings, w)
+	}))
+	ctx := context.Background()
+
+	_, _ = client.Voices.Get(ctx, "voice-1")
+	_, _ = client.Voices.Get(ctx, "voice-2")
+	_, _ = client.InWorkspace("team").Voices.Get(ctx, "voice-3")
+	_, _ = client.Account.GetPackage(ctx)
+	for i := 0; i < 2; i++ {
+		_, _ = client.TTS.Convert(ctx, &ConvertParams{Text: "Hi", Model: ModelSpeech15})
+	}
+
+	if len(warnings) != 2 {
+		t.Fatalf("got %d warnings, want 2: %+v", len(warnings), warnings)
+	}
+	w := warnings[0]
+	if w.Method != http.MethodGet || w.Path != "/model/voice-1" || w.Link != "https://docs.fish.audio/changelog" ||
+		!w.DeprecatedAt.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) || !w.Sunset.Equal(time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)) ||
+		w.Message != "GET /model/{id} is replaced by /v2/model/{id}" {
+		t.Errorf("warnings[0] = %+v", w)
+	}
+	if got, want := warnings[1].String(), "POST /v1/tts is deprecated: model speech-1.5 is deprecated"; got != want {
+		t.Errorf("warnings[1].String() = %q, want %q", got, want)
+	}
+}
+
+func TestWithDeprecationLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithDeprecationLogger(logger))
+	_, _ = client.Account.GetPackage(context.Background())
+
+	if got := logs.String(); !strings.Contains(got, "level=WARN") || !strings.Contains(got, "path=/wallet/self/package") {
+		t.Errorf("log = %q", got)
+	}
+}
@@ -0,0 +1,160 @@
+// Package discord sends Fish Audio TTS to Discord voice channels. It
+// produces the 20 ms Opus packets that Discord voice connections expect,
+// such as discordgo's VoiceConnection.OpusSend, without depending on a
+// particular Discord library.
+//
+// Example with discordgo:
+//
+//	vc, _ := session.ChannelVoiceJoin(guildID, channelID, false, true)
+//	_ = vc.Speaking(true)
+//	defer func() { _ = vc.Speaking(false) }()
+//	err := discord.Speak(ctx, client, vc.OpusSend, textChan, &fishaudio.StreamParams{ReferenceID: voiceID}, nil)
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"time"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+	"github.com/fishaudio/fish-audio-go/oggopus"
+)
+
+// FrameDuration is the duration of each Opus packet sent to Discord.
+const FrameDuration = 20 * time.Millisecond
+
+// trailingSilence is the number of silence frames sent after the audio so
+// Discord's decoder doesn't interpolate into the next transmission.
+const trailingSilence = 5
+
+// SilenceFrame is a 20 ms Opus packet of silence.
+var SilenceFrame = []byte{0xF8, 0xFF, 0xFE}
+
+// Send reads Ogg Opus audio from r and sends its packets on out, one per
+// FrameDuration. If the next packet hasn't arrived in time, e.g. while
+// speech is still being generated, SilenceFrame is sent instead so the
+// stream keeps its timing. Send returns when r is exhausted or ctx is done.
+//
+// Discord plays packets as 48 kHz stereo; mono packets are upmixed by the
+// decoder. Packets that aren't 20 ms long are rejected with an error.
+func Send(ctx context.Context, out chan<- []byte, r io.Reader) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	packets := make(chan []byte, 50)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(packets)
+		defer func() {
+			// r may be the caller's reader; report its panics as errors
+			if v := recover(); v != nil {
+				readErr <- &fishaudio.PanicError{Goroutine: "discord reader", Value: v, Stack: debug.Stack()}
+			}
+		}()
+		readErr <- readPackets(ctx, r, packets)
+	}()
+
+	var ticker *time.Ticker
+	defer func() {
+		if ticker != nil {
+			ticker.Stop()
+		}
+	}()
+
+	for {
+		var packet []byte
+		ok := true
+		if ticker == nil {
+			// Start the clock with the first packet
+			select {
+			case packet, ok = <-packets:
+				if ok {
+					ticker = time.NewTicker(FrameDuration)
+				}
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		} else {
+			select {
+			case <-ticker.C:
+				select {
+				case packet, ok = <-packets:
+				default:
+					packet = SilenceFrame
+				}
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if !ok {
+			if err := <-readErr; err != nil {
+				return err
+			}
+			for i := 0; i < trailingSilence && ticker != nil; i++ {
+				if err := send(ctx, out, SilenceFrame); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if err := send(ctx, out, packet); err != nil {
+			return err
+		}
+	}
+}
+
+// readPackets reads packets from r into packets until EOF.
+func readPackets(ctx context.Context, r io.Reader, packets chan<- []byte) error {
+	reader := oggopus.NewReader(r)
+	for {
+		packet, err := reader.ReadPacket()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if d := oggopus.PacketDuration(packet); d != FrameDuration {
+			return fmt.Errorf("discord: got %v Opus packet, want %v", d, FrameDuration)
+		}
+
+		select {
+		case packets <- packet:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// send sends packet on out unless ctx is done first.
+func send(ctx context.Context, out chan<- []byte, packet []byte) error {
+	select {
+	case out <- packet:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Speak streams text from textChan through a live TTS session and sends
+// the speech on out as it is generated, as Send does. params.Format is
+// overridden to Opus. It returns when the session ends or ctx is done.
+func Speak(ctx context.Context, client *fishaudio.Client, out chan<- []byte, textChan <-chan string, params *fishaudio.StreamParams, opts *fishaudio.WebSocketOptions) error {
+	var p fishaudio.StreamParams
+	if params != nil {
+		p = *params
+	}
+	p.Format = fishaudio.AudioFormatOpus
+
+	stream, err := client.TTS.StreamWebSocket(ctx, textChan, &p, opts)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stream.Close() }()
+
+	return Send(ctx, out, stream)
+}
@@ -0,0 +1,160 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+	"github.com/fishaudio/fish-audio-go/fishaudiotest"
+)
+
+// celt20ms is the TOC byte of a one-frame, 20 ms CELT packet.
+const celt20ms = 0xFC
+
+// oggPage builds an Ogg page holding one packet.
+func oggPage(headerType byte, packet []byte) []byte {
+	var b bytes.Buffer
+	b.WriteString("OggS\x00")
+	b.WriteByte(headerType)
+	b.Write(make([]byte, 20)) // granule, serial, sequence, CRC
+	b.WriteByte(1)
+	b.WriteByte(byte(len(packet)))
+	b.Write(packet)
+	return b.Bytes()
+}
+
+// oggHeaders returns the identification and comment pages.
+func oggHeaders() []byte {
+	head := append([]byte("OpusHead\x01\x02\x00\x00"), binary.LittleEndian.AppendUint32(nil, 48000)...)
+	head = append(head, 0, 0, 0)
+	return append(oggPage(0x02, head), oggPage(0, []byte("OpusTags\x00\x00\x00\x00\x00\x00\x00\x00"))...)
+}
+
+// collect sends r and returns everything written to the voice channel.
+func collect(t *testing.T, r io.Reader) ([][]byte, error) {
+	t.Helper()
+	out := make(chan []byte)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- Send(context.Background(), out, r)
+		close(out)
+	}()
+
+	var frames [][]byte
+	for f := range out {
+		frames = append(frames, f)
+	}
+	return frames, <-errc
+}
+
+func TestSend(t *testing.T) {
+	stream := oggHeaders()
+	for i := 0; i < 3; i++ {
+		stream = append(stream, oggPage(0, []byte{celt20ms, byte(i)})...)
+	}
+
+	start := time.Now()
+	frames, err := collect(t, bytes.NewReader(stream))
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if len(frames) != 3+trailingSilence {
+		t.Fatalf("sent %d frames, want %d", len(frames), 3+trailingSilence)
+	}
+	for i := 0; i < 3; i++ {
+		if frames[i][1] != byte(i) {
+			t.Errorf("frame %d = %x", i, frames[i])
+		}
+	}
+	for _, f := range frames[3:] {
+		if !bytes.Equal(f, SilenceFrame) {
+			t.Errorf("trailing frame = %x, want silence", f)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 2*FrameDuration {
+		t.Errorf("sent in %v, want paced at %v per frame", elapsed, FrameDuration)
+	}
+}
+
+func TestSend_Underrun(t *testing.T) {
+	pr, pw := io.Pipe()
+	go func() {
+		_, _ = pw.Write(oggHeaders())
+		_, _ = pw.Write(oggPage(0, []byte{celt20ms, 1}))
+		time.Sleep(5 * FrameDuration)
+		_, _ = pw.Write(oggPage(0x04, []byte{celt20ms, 2}))
+		_ = pw.Close()
+	}()
+
+	frames, err := collect(t, pr)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	silent := 0
+	for _, f := range frames[1:] {
+		if bytes.Equal(f, SilenceFrame) {
+			silent++
+			continue
+		}
+		break
+	}
+	if silent < 2 {
+		t.Errorf("got %d silence frames during the stall, want several", silent)
+	}
+	if last := frames[len(frames)-trailingSilence-1]; !bytes.Equal(last, []byte{celt20ms, 2}) {
+		t.Errorf("frame after stall = %x, want second packet", last)
+	}
+}
+
+func TestSend_WrongFrameSize(t *testing.T) {
+	stream := append(oggHeaders(), oggPage(0, []byte{0x90})...) // 10 ms
+	if _, err := collect(t, bytes.NewReader(stream)); err == nil {
+		t.Error("Send() error = nil for a 10 ms packet")
+	}
+}
+
+func TestSend_Empty(t *testing.T) {
+	frames, err := collect(t, bytes.NewReader(oggHeaders()))
+	if err != nil || len(frames) != 0 {
+		t.Errorf("Send() = %d frames, %v; want nothing", len(frames), err)
+	}
+}
+
+func TestSend_Cancel(t *testing.T) {
+	pr, _ := io.Pipe()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := Send(ctx, make(chan []byte), pr); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Send() err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestSpeak(t *testing.T) {
+	server := fishaudiotest.NewLiveServer(fishaudiotest.LiveScript{
+		Audio: func(string) []byte {
+			return append(oggHeaders(), oggPage(0, []byte{celt20ms, 0})...)
+		},
+	})
+	defer server.Close()
+
+	client := fishaudio.NewClient(fishaudio.WithAPIKey("test-key"), fishaudio.WithBaseURL(server.URL))
+	textChan := make(chan string, 1)
+	textChan <- "Hello"
+	close(textChan)
+
+	out := make(chan []byte, 10)
+	if err := Speak(context.Background(), client, out, textChan, nil, nil); err != nil {
+		t.Fatalf("Speak() error = %v", err)
+	}
+	if len(out) != 1+trailingSilence {
+		t.Errorf("sent %d frames, want %d", len(out), 1+trailingSilence)
+	}
+	if sessions := server.Sessions(); sessions[0].Start["format"] != "opus" {
+		t.Errorf("format = %v, want opus", sessions[0].Start["format"])
+	}
+}
@@ -0,0 +1,242 @@
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DiskCacheOptions configures a DiskCache.
+type DiskCacheOptions struct {
+	// MaxBytes is the total size of cached audio above which the least
+	// recently used entries are evicted. Default: 1 GiB.
+	MaxBytes int64
+
+	// TTL evicts entries not used for this long. Default: no expiry.
+	TTL time.Duration
+}
+
+// DiskCache is a TTSCache that stores audio as files in a directory, so
+// cached prompts survive restarts. It is size-bounded with least recently
+// used eviction.
+//
+// Several processes may share a directory: entries are written to a
+// temporary file and renamed into place, so readers never see partial
+// audio, and eviction tolerates files removed by another process.
+// Recency is tracked with file modification times.
+//
+// Example:
+//
+//	cache, err := fishaudio.NewDiskCache("/var/cache/fishaudio", &fishaudio.DiskCacheOptions{
+//	    MaxBytes: 512 << 20,
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	client := fishaudio.NewClient(fishaudio.WithTTSCache(cache))
+type DiskCache struct {
+	dir  string
+	opts DiskCacheOptions
+
+	// size approximates the cache size; it is recomputed from the
+	// directory whenever it exceeds MaxBytes, since other processes may
+	// have added or removed entries.
+	mu   sync.Mutex
+	size int64
+}
+
+// diskCacheExt is the extension of cache entry files.
+const diskCacheExt = ".audio"
+
+// NewDiskCache opens or creates a cache in dir.
+func NewDiskCache(dir string, opts *DiskCacheOptions) (*DiskCache, error) {
+	c := &DiskCache{dir: dir}
+	if opts != nil {
+		c.opts = *opts
+	}
+	if c.opts.MaxBytes <= 0 {
+		c.opts.MaxBytes = 1 << 30
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	entries, err := c.scan()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		c.size += e.size
+	}
+	return c, nil
+}
+
+// Get implements TTSCache.
+func (c *DiskCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	path, err := c.path(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if c.expired(info.ModTime(), time.Now()) {
+		c.remove(path, info.Size())
+		return nil, false, nil
+	}
+
+	audio, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		// Evicted by another process since Stat
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	return audio, true, nil
+}
+
+// Set implements TTSCache.
+func (c *DiskCache) Set(ctx context.Context, key string, audio []byte) error {
+	path, err := c.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := OSStorage{}.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(audio); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.size += int64(len(audio))
+	over := c.size > c.opts.MaxBytes
+	c.mu.Unlock()
+
+	if over {
+		return c.evict()
+	}
+	return nil
+}
+
+// Clear removes every entry.
+func (c *DiskCache) Clear() error {
+	entries, err := c.scan()
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		c.remove(e.path, e.size)
+	}
+	return nil
+}
+
+// diskEntry is a cache file found by scan.
+type diskEntry struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// scan lists the cache entries in the directory.
+func (c *DiskCache) scan() ([]diskEntry, error) {
+	var entries []diskEntry
+	err := filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), diskCacheExt) || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+		info, err := d.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		entries = append(entries, diskEntry{path: path, size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	return entries, err
+}
+
+// evict removes expired entries, then the least recently used ones until
+// the cache fits in MaxBytes.
+func (c *DiskCache) evict() error {
+	entries, err := c.scan()
+	if err != nil {
+		return err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+
+	var size int64
+	for _, e := range entries {
+		size += e.size
+	}
+	now := time.Now()
+	for _, e := range entries {
+		if size <= c.opts.MaxBytes && !c.expired(e.modTime, now) {
+			break
+		}
+		if err := os.Remove(e.path); err == nil || errors.Is(err, fs.ErrNotExist) {
+			size -= e.size
+		}
+	}
+
+	c.mu.Lock()
+	c.size = size
+	c.mu.Unlock()
+	return nil
+}
+
+// remove deletes an entry and updates the size estimate.
+func (c *DiskCache) remove(path string, size int64) {
+	if err := os.Remove(path); err == nil {
+		c.mu.Lock()
+		c.size -= size
+		c.mu.Unlock()
+	}
+}
+
+// expired reports whether an entry last used at modTime has expired.
+func (c *DiskCache) expired(modTime, now time.Time) bool {
+	return c.opts.TTL > 0 && now.Sub(modTime) > c.opts.TTL
+}
+
+// path returns the file of key, sharded by its first two characters to
+// keep directories small.
+func (c *DiskCache) path(key string) (string, error) {
+	if len(key) < 3 || strings.ContainsAny(key, `/\.`) {
+		return "", errors.New("invalid cache key")
+	}
+	return filepath.Join(c.dir, key[:2], key+diskCacheExt), nil
+}
@@ -0,0 +1,112 @@
+package fishaudio
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func diskCacheKey(c byte) string {
+	return strings.Repeat(string(c), 64)
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	cache, err := NewDiskCache(dir, nil)
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+
+	if _, ok, err := cache.Get(ctx, diskCacheKey('a')); ok || err != nil {
+		t.Errorf("Get(missing) = %v, %v, want miss", ok, err)
+	}
+	if err := cache.Set(ctx, diskCacheKey('a'), []byte("audio")); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	// A new cache over the same directory sees the entry
+	reopened, err := NewDiskCache(dir, nil)
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+	if audio, ok, err := reopened.Get(ctx, diskCacheKey('a')); !ok || err != nil || string(audio) != "audio" {
+		t.Errorf("Get() = %q, %v, %v, want cached audio", audio, ok, err)
+	}
+
+	if err := reopened.Clear(); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if _, ok, _ := cache.Get(ctx, diskCacheKey('a')); ok {
+		t.Error("Get() after Clear() hit")
+	}
+}
+
+func TestDiskCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	cache, _ := NewDiskCache(dir, &DiskCacheOptions{MaxBytes: 35})
+
+	old := time.Now().Add(-time.Hour)
+	for i, c := range []byte("abc") {
+		_ = cache.Set(ctx, diskCacheKey(c), []byte("0123456789"))
+		path, _ := cache.path(diskCacheKey(c))
+		stamp := old.Add(time.Duration(i) * time.Minute)
+		_ = os.Chtimes(path, stamp, stamp)
+	}
+	// Using "a" makes "b" the least recently used
+	_, _, _ = cache.Get(ctx, diskCacheKey('a'))
+	_ = cache.Set(ctx, diskCacheKey('d'), []byte("0123456789"))
+
+	for c, want := range map[byte]bool{'a': true, 'b': false, 'c': true, 'd': true} {
+		if _, ok, _ := cache.Get(ctx, diskCacheKey(c)); ok != want {
+			t.Errorf("Get(%c) hit = %v, want %v", c, ok, want)
+		}
+	}
+}
+
+func TestDiskCache_TTL(t *testing.T) {
+	ctx := context.Background()
+	cache, _ := NewDiskCache(t.TempDir(), &DiskCacheOptions{TTL: time.Minute})
+
+	_ = cache.Set(ctx, diskCacheKey('a'), []byte("audio"))
+	path, _ := cache.path(diskCacheKey('a'))
+	stale := time.Now().Add(-2 * time.Minute)
+	_ = os.Chtimes(path, stale, stale)
+
+	if _, ok, _ := cache.Get(ctx, diskCacheKey('a')); ok {
+		t.Error("Get() hit an expired entry")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expired entry not removed: %v", err)
+	}
+}
+
+func TestDiskCache_InvalidKey(t *testing.T) {
+	cache, _ := NewDiskCache(t.TempDir(), nil)
+	for _, key := range []string{"", "ab", "../../etc/passwd", `a\b\c`} {
+		if err := cache.Set(context.Background(), key, nil); err == nil {
+			t.Errorf("Set(%q) error = nil, want invalid key", key)
+		}
+	}
+}
+
+func TestDiskCache_WithClient(t *testing.T) {
+	dir := t.TempDir()
+	cache, _ := NewDiskCache(dir, nil)
+	key := ttsCacheKey(ModelS2Pro, &ttsRequest{Text: "Hi"})
+	_ = cache.Set(context.Background(), key, []byte("cached"))
+
+	// No server: a hit must not make a request
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL("http://127.0.0.1:0"), WithTTSCache(cache))
+	audio, err := client.TTS.Convert(context.Background(), &ConvertParams{Text: "Hi"})
+	if err != nil || string(audio) != "cached" {
+		t.Errorf("Convert() = %q, %v, want cached audio", audio, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, key[:2], key+diskCacheExt)); err != nil {
+		t.Errorf("entry file: %v", err)
+	}
+}
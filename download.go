@@ -0,0 +1,211 @@
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DownloadOptions configures DownloadAudio.
+type DownloadOptions struct {
+	// Offset is the first byte to download. Default: 0.
+	Offset int64
+	// Length is the number of bytes to download. Default: 0, the rest of
+	// the file.
+	Length int64
+	// MaxResumes is the number of times an interrupted download is resumed
+	// from where it stopped with a Range request, if the server supports
+	// them and identifies the file with a strong ETag or a Last-Modified
+	// date. Default: 3. Set to a negative value to disable resuming.
+	MaxResumes int
+}
+
+// DownloadAudio streams stored audio, such as a voice sample's Sample.Audio,
+// by URL. Relative URLs are resolved against the API base URL and sent with
+// credentials; absolute URLs, such as CDN links, are fetched without them.
+//
+// Offset and Length request part of the file with a Range header. If the
+// connection drops, the download resumes where it stopped, with If-Range
+// so a file that changed in between fails the stream instead of splicing
+// two versions together.
+//
+// Example:
+//
+//	stream, err := client.DownloadAudio(ctx, voice.Samples[0].Audio, nil)
+//	if err != nil {
+//	    return err
+//	}
+//	defer stream.Close()
+//	_, err = io.Copy(file, stream)
+func (c *Client) DownloadAudio(ctx context.Context, url string, opts *DownloadOptions) (*AudioStream, error) {
+	var o DownloadOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Offset < 0 || o.Length < 0 {
+		return nil, newValidationError("download offset and length must not be negative")
+	}
+	if o.MaxResumes == 0 {
+		o.MaxResumes = 3
+	}
+
+	body := &resumableBody{offset: o.Offset, end: -1}
+	if o.Length > 0 {
+		body.end = o.Offset + o.Length
+	}
+	resp, err := c.getRange(ctx, url, body.offset, body.end, "")
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusPartialContent:
+	case body.offset > 0:
+		// The server ignored the range; skip to the offset
+		if _, err := io.CopyN(io.Discard, resp.Body, body.offset); err != nil {
+			_ = resp.Body.Close()
+			return nil, fmt.Errorf("failed to skip to offset %d: %w", body.offset, err)
+		}
+	}
+	body.body = resp.Body
+
+	// If-Range requires a strong ETag or a date; without one, a resumed
+	// request could splice two versions of the file together
+	validator := resp.Header.Get("ETag")
+	if validator == "" || strings.HasPrefix(validator, "W/") {
+		validator = resp.Header.Get("Last-Modified")
+	}
+	if o.MaxResumes > 0 && validator != "" && (resp.StatusCode == http.StatusPartialContent || resp.Header.Get("Accept-Ranges") == "bytes") {
+		body.resumes = o.MaxResumes
+		body.fetch = func(from int64) (*http.Response, error) {
+			return c.getRange(ctx, url, from, body.end, validator)
+		}
+	}
+
+	resp.Body = body
+	return c.audioStream(resp), nil
+}
+
+// getRange requests bytes [from, end) of url, or from the start to the end
+// of the file when from is 0 and end is -1. ifRange is sent as If-Range if
+// set.
+func (c *Client) getRange(ctx context.Context, url string, from, end int64, ifRange string) (*http.Response, error) {
+	headers := map[string]string{}
+	if from > 0 || end >= 0 {
+		last := ""
+		if end >= 0 {
+			last = strconv.FormatInt(end-1, 10)
+		}
+		headers["Range"] = fmt.Sprintf("bytes=%d-%s", from, last)
+	}
+	if ifRange != "" {
+		headers["If-Range"] = ifRange
+	}
+
+	if strings.HasPrefix(url, "/") {
+		return c.doRequest(ctx, http.MethodGet, url, nil, &RequestOptions{AdditionalHeaders: headers})
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "fish-audio/go/"+Version)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	c.wire().request("http", req)
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	c.wire().response("http", req.Method, req.URL, start, resp, err)
+	if err != nil {
+		return nil, withRequest(err, req, 1, start)
+	}
+	if resp.StatusCode >= 400 {
+		defer func() { _ = resp.Body.Close() }()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, withRequest(newAPIError(resp.StatusCode, resp.Status, string(body)), req, 1, start)
+	}
+	return resp, nil
+}
+
+// resumableBody reads a download, requesting the rest with fetch when the
+// connection fails.
+type resumableBody struct {
+	body    io.ReadCloser
+	fetch   func(from int64) (*http.Response, error)
+	offset  int64 // position of the next byte in the file
+	end     int64 // end of the requested range, or -1 for the end of the file
+	resumes int
+	err     error // the error that ended the download
+}
+
+func (b *resumableBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	if b.end >= 0 {
+		if b.offset >= b.end {
+			return 0, io.EOF
+		}
+		if remaining := b.end - b.offset; int64(len(p)) > remaining {
+			p = p[:remaining]
+		}
+	}
+
+	for {
+		n, err := b.body.Read(p)
+		b.offset += int64(n)
+		if err == nil || errors.Is(err, io.EOF) || b.resumes <= 0 || b.fetch == nil {
+			return n, err
+		}
+
+		// The connection failed mid-download; request the rest
+		b.resumes--
+		_ = b.body.Close()
+		resp, ferr := b.fetch(b.offset)
+		if ferr != nil {
+			b.err = err
+			return n, err
+		}
+		if resp.StatusCode != http.StatusPartialContent {
+			_ = resp.Body.Close()
+			b.err = fmt.Errorf("cannot resume download at byte %d: the file changed or the server ignored the range (HTTP %d)", b.offset, resp.StatusCode)
+			return n, b.err
+		}
+		if start, ok := contentRangeStart(resp.Header.Get("Content-Range")); !ok || start != b.offset {
+			_ = resp.Body.Close()
+			b.err = fmt.Errorf("cannot resume download at byte %d: the server sent Content-Range %q", b.offset, resp.Header.Get("Content-Range"))
+			return n, b.err
+		}
+		b.body = resp.Body
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+// contentRangeStart returns the first byte of a Content-Range header such
+// as "bytes 100-199/1000".
+func contentRangeStart(header string) (int64, bool) {
+	rng, ok := strings.CutPrefix(header, "bytes ")
+	if !ok {
+		return 0, false
+	}
+	first, _, ok := strings.Cut(rng, "-")
+	if !ok {
+		return 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	return start, err == nil
+}
+
+func (b *resumableBody) Close() error {
+	return b.body.Close()
+}
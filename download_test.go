@@ -0,0 +1,196 @@
+package fishaudio
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newRangeServer serves content with Range support. The first cut
+// responses send only half of what they promise and drop the connection.
+// etag returns the current ETag.
+func newRangeServer(t *testing.T, content []byte, cut int32, etag func() string) (*httptest.Server, *[]string) {
+	t.Helper()
+	var ranges []string
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		w.Header().Set("ETag", etag())
+		if atomic.AddInt32(&requests, 1) <= cut {
+			start := 0
+			if rng := r.Header.Get("Range"); rng != "" {
+				start, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"))
+				w.Header().Set("Content-Range", "bytes "+strconv.Itoa(start)+"-"+strconv.Itoa(len(content)-1)+"/"+strconv.Itoa(len(content)))
+				w.Header().Set("Content-Length", strconv.Itoa(len(content)-start))
+				w.WriteHeader(http.StatusPartialContent)
+			} else {
+				w.Header().Set("Accept-Ranges", "bytes")
+				w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			}
+			_, _ = w.Write(content[start : start+(len(content)-start)/2])
+			w.(http.Flusher).Flush()
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			_ = conn.Close()
+			return
+		}
+		http.ServeContent(w, r, "sample.wav", time.Time{}, bytes.NewReader(content))
+	}))
+	return server, &ranges
+}
+
+func TestClient_DownloadAudio_Resume(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	server, ranges := newRangeServer(t, content, 2, func() string { return `"v1"` })
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	stream, err := client.DownloadAudio(context.Background(), "/sample.wav", nil)
+	if err != nil {
+		t.Fatalf("DownloadAudio() error = %v", err)
+	}
+	audio, err := stream.Collect()
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if !bytes.Equal(audio, content) {
+		t.Errorf("downloaded %d bytes, want the %d byte file", len(audio), len(content))
+	}
+	if want := []string{"", "bytes=5000-", "bytes=7500-"}; strings.Join(*ranges, ",") != strings.Join(want, ",") {
+		t.Errorf("ranges = %q, want %q", *ranges, want)
+	}
+}
+
+func TestClient_DownloadAudio_Range(t *testing.T) {
+	content := []byte("0123456789abcdefghij")
+	server, ranges := newRangeServer(t, content, 0, func() string { return `"v1"` })
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	stream, err := client.DownloadAudio(context.Background(), server.URL+"/sample.wav", &DownloadOptions{Offset: 5, Length: 10})
+	if err != nil {
+		t.Fatalf("DownloadAudio() error = %v", err)
+	}
+	if audio, err := stream.Collect(); err != nil || string(audio) != "56789abcde" {
+		t.Errorf("Collect() = %q, %v, want bytes 5-14", audio, err)
+	}
+	if (*ranges)[0] != "bytes=5-14" {
+		t.Errorf("Range = %q, want bytes=5-14", (*ranges)[0])
+	}
+}
+
+func TestClient_DownloadAudio_RangeIgnored(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("0123456789"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	stream, err := client.DownloadAudio(context.Background(), "/sample.wav", &DownloadOptions{Offset: 3, Length: 4})
+	if err != nil {
+		t.Fatalf("DownloadAudio() error = %v", err)
+	}
+	if audio, err := stream.Collect(); err != nil || string(audio) != "3456" {
+		t.Errorf("Collect() = %q, %v, want 3456", audio, err)
+	}
+}
+
+func TestClient_DownloadAudio_Changed(t *testing.T) {
+	content := bytes.Repeat([]byte("x"), 1000)
+	var version atomic.Int32
+	server, _ := newRangeServer(t, content, 1, func() string { return `"v` + strconv.Itoa(int(version.Add(1))) + `"` })
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	stream, err := client.DownloadAudio(context.Background(), "/sample.wav", nil)
+	if err != nil {
+		t.Fatalf("DownloadAudio() error = %v", err)
+	}
+	if _, err := stream.Collect(); err == nil || !strings.Contains(err.Error(), "file changed") {
+		t.Errorf("Collect() error = %v, want the file changed error", err)
+	}
+
+	// Without resuming, the interruption itself is returned
+	server2, ranges := newRangeServer(t, content, 1, func() string { return `"v1"` })
+	defer server2.Close()
+	client = NewClient(WithAPIKey("test-key"), WithBaseURL(server2.URL))
+	stream, err = client.DownloadAudio(context.Background(), "/sample.wav", &DownloadOptions{MaxResumes: -1})
+	if err != nil {
+		t.Fatalf("DownloadAudio() error = %v", err)
+	}
+	if _, err := stream.Collect(); err == nil || len(*ranges) != 1 {
+		t.Errorf("Collect() error = %v after %d requests, want the interruption and no resume", err, len(*ranges))
+	}
+}
+
+func TestClient_DownloadAudio_NoValidator(t *testing.T) {
+	content := bytes.Repeat([]byte("x"), 1000)
+	for _, etag := range []string{"", `W/"v1"`} {
+		server, ranges := newRangeServer(t, content, 1, func() string { return etag })
+		defer server.Close()
+
+		client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+		stream, err := client.DownloadAudio(context.Background(), "/sample.wav", nil)
+		if err != nil {
+			t.Fatalf("DownloadAudio() error = %v", err)
+		}
+		if _, err := stream.Collect(); err == nil || len(*ranges) != 1 {
+			t.Errorf("ETag %q: Collect() error = %v after %d requests, want the interruption and no resume", etag, err, len(*ranges))
+		}
+	}
+}
+
+func TestClient_DownloadAudio_MisalignedResume(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 100)
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if requests.Add(1) == 1 {
+			w.Header().Set("Accept-Ranges", "bytes")
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			_, _ = w.Write(content[:500])
+			w.(http.Flusher).Flush()
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			_ = conn.Close()
+			return
+		}
+		// Answer the resume with the file from the start
+		w.Header().Set("Content-Range", "bytes 0-999/1000")
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	stream, err := client.DownloadAudio(context.Background(), "/sample.wav", nil)
+	if err != nil {
+		t.Fatalf("DownloadAudio() error = %v", err)
+	}
+	if _, err := stream.Collect(); err == nil || !strings.Contains(err.Error(), "Content-Range") {
+		t.Errorf("Collect() error = %v, want the misaligned Content-Range error", err)
+	}
+}
+
+func TestContentRangeStart(t *testing.T) {
+	tests := []struct {
+		header string
+		start  int64
+		ok     bool
+	}{
+		{"bytes 100-199/1000", 100, true},
+		{"bytes 0-0/*", 0, true},
+		{"", 0, false},
+		{"bytes */1000", 0, false},
+		{"items 1-2/3", 0, false},
+	}
+	for _, tt := range tests {
+		if start, ok := contentRangeStart(tt.header); start != tt.start || ok != tt.ok {
+			t.Errorf("contentRangeStart(%q) = %d, %v, want %d, %v", tt.header, start, ok, tt.start, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package fishaudio
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// TranslateFunc translates the texts of transcript segments, returning one
+// translation per text in the same order. All segments are passed at once
+// so a translator such as an LLM can use the surrounding context. An empty
+// translation leaves its segment silent.
+type TranslateFunc func(ctx context.Context, texts []string) ([]string, error)
+
+// DubOptions configures Dub.
+type DubOptions struct {
+	// Transcribe sets the transcription of the source, e.g. its Language.
+	// Timestamps are always included.
+	Transcribe *TranscribeParams
+	// Model is the TTS model for the dubbed speech. Default: the TTS default.
+	Model Model
+	// SampleRate is the sample rate of the dubbed track in Hz.
+	// Default: 44100.
+	SampleRate int
+	// MaxSpeed is the fastest speech rate used to fit a translation into
+	// the time of its source segment. Translations that are still too long
+	// run over into the following silence or speech. Range: 1.0-2.0.
+	// Default: 1.5.
+	MaxSpeed float64
+	// Concurrency is the number of segments synthesized at once. Default: 4.
+	Concurrency int
+}
+
+// DubSegment is a segment of a dubbed track.
+type DubSegment struct {
+	// Source is the transcribed segment of the source audio.
+	Source ASRSegment
+	// Text is the translation that was synthesized.
+	Text string
+	// Start is where the synthesized speech starts in the track.
+	Start time.Duration
+	// End is where the synthesized speech ends in the track.
+	End time.Duration
+	// Speed is the speech rate the translation was synthesized at.
+	Speed float64
+}
+
+// DubResult is the result of Dub.
+type DubResult struct {
+	// Audio is the dubbed track, a 16-bit mono PCM WAV file as long as the
+	// source, or longer if the last translation runs over.
+	Audio []byte
+	// Segments holds the synthesized segments in source order. Segments
+	// with an empty translation are left out.
+	Segments []DubSegment
+	// Transcript is the transcription of the source.
+	Transcript *ASRResponse
+}
+
+// Dub replaces the speech in source with a translation spoken by voice, a
+// voice model ID. The source is transcribed with timestamps, translate
+// turns the segment texts into the target language, and each translation
+// is synthesized and placed at the start time of its segment, so the
+// dubbed track stays in sync with the original video.
+//
+// A translation that runs longer than the time until the next segment is
+// synthesized again, faster, up to MaxSpeed. That costs a second TTS
+// request for the segment but keeps the pitch natural, unlike stretching
+// the audio.
+//
+// Example:
+//
+//	source, _ := os.ReadFile("interview.mp3")
+//	result, err := client.Dub(ctx, source, func(ctx context.Context, texts []string) ([]string, error) {
+//	    return translateToSpanish(ctx, texts)
+//	}, "spanish-voice-id", nil)
+//	if err != nil {
+//	    return err
+//	}
+//	os.WriteFile("interview.es.wav", result.Audio, 0o644)
+func (c *Client) Dub(ctx context.Context, source []byte, translate TranslateFunc, voice string, opts *DubOptions) (*DubResult, error) {
+	var o DubOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.SampleRate <= 0 {
+		o.SampleRate = 44100
+	}
+	if o.MaxSpeed <= 0 {
+		o.MaxSpeed = 1.5
+	}
+	o.MaxSpeed = math.Max(1, math.Min(2, o.MaxSpeed))
+	if o.Concurrency <= 0 {
+		o.Concurrency = 4
+	}
+
+	var params TranscribeParams
+	if o.Transcribe != nil {
+		params = *o.Transcribe
+	}
+	includeTimestamps := true
+	params.IncludeTimestamps = &includeTimestamps
+	params.ResponseFormat = ""
+
+	transcript, err := c.ASR.Transcribe(ctx, source, &params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transcribe source: %w", err)
+	}
+	segments := transcript.Segments
+
+	texts := make([]string, len(segments))
+	for i, seg := range segments {
+		texts[i] = seg.Text
+	}
+	translations, err := translate(ctx, texts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate: %w", err)
+	}
+	if len(translations) != len(texts) {
+		return nil, fmt.Errorf("translate returned %d texts for %d segments", len(translations), len(texts))
+	}
+
+	f := pcmFormat{sampleRate: o.SampleRate, channels: 1, bitsPerSample: 16}
+	end := segmentTime(transcript.Duration / 1000)
+	speech := make([][]byte, len(segments))
+	speeds := make([]float64, len(segments))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(o.Concurrency)
+	for i, seg := range segments {
+		if strings.TrimSpace(translations[i]) == "" {
+			continue
+		}
+
+		// The segment may use the time until the next one starts
+		slot := end - segmentTime(seg.Start)
+		if i+1 < len(segments) {
+			slot = segmentTime(segments[i+1].Start) - segmentTime(seg.Start)
+		}
+		g.Go(c.guard("dub worker", func() error {
+			pcm, speed, err := c.dubSegment(gctx, translations[i], voice, f, slot, &o)
+			if err != nil {
+				return fmt.Errorf("failed to synthesize segment %d: %w", i, err)
+			}
+			speech[i], speeds[i] = pcm, speed
+			return nil
+		}))
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	result := &DubResult{Transcript: transcript}
+	track := make([]byte, bytesFor(f, end))
+	for i, seg := range segments {
+		if speech[i] == nil {
+			continue
+		}
+		offset := bytesFor(f, max(0, segmentTime(seg.Start)))
+		if n := offset + len(speech[i]); n > len(track) {
+			track = append(track, make([]byte, n-len(track))...)
+		}
+		mixPCM(track[offset:], speech[i])
+
+		start := f.duration(offset)
+		result.Segments = append(result.Segments, DubSegment{
+			Source: seg,
+			Text:   translations[i],
+			Start:  start,
+			End:    start + f.duration(len(speech[i])),
+			Speed:  speeds[i],
+		})
+	}
+	result.Audio = encodeWAV(f, track)
+	return result, nil
+}
+
+// dubSegment synthesizes text as 16-bit PCM in format f, again at a faster
+// speed if it runs longer than slot. It returns the audio and its speed.
+func (c *Client) dubSegment(ctx context.Context, text, voice string, f pcmFormat, slot time.Duration, o *DubOptions) ([]byte, float64, error) {
+	synthesize := func(speed float64) ([]byte, error) {
+		return c.TTS.Convert(ctx, &ConvertParams{
+			Text:        text,
+			Model:       o.Model,
+			ReferenceID: voice,
+			Format:      AudioFormatPCM,
+			Speed:       speed,
+			Config:      &TTSConfig{SampleRate: f.sampleRate},
+		})
+	}
+
+	speed := 1.0
+	pcm, err := synthesize(0)
+	if err != nil {
+		return nil, 0, err
+	}
+	if took := f.duration(len(pcm)); slot > 0 && took > slot && o.MaxSpeed > 1 {
+		speed = math.Min(o.MaxSpeed, float64(took)/float64(slot))
+		if pcm, err = synthesize(speed); err != nil {
+			return nil, 0, err
+		}
+	}
+	return pcm[:len(pcm)-len(pcm)%2], speed, nil
+}
+
+// mixPCM adds 16-bit little-endian PCM src into dst, clipping the sum.
+func mixPCM(dst, src []byte) {
+	for i := 0; i+1 < len(src) && i+1 < len(dst); i += 2 {
+		a := float64(int16(binary.LittleEndian.Uint16(dst[i:])))
+		b := float64(int16(binary.LittleEndian.Uint16(src[i:])))
+		binary.LittleEndian.PutUint16(dst[i:], uint16(clamp16(a+b)))
+	}
+}
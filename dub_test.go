@@ -0,0 +1,156 @@
+package fishaudio
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newDubServer returns a server that transcribes any audio as segments
+// and synthesizes 100 ms of constant PCM per character, divided by the
+// requested speed.
+func newDubServer(t *testing.T, segments []ASRSegment, duration float64) (*httptest.Server, *[]float64) {
+	var mu sync.Mutex
+	var speeds []float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/asr":
+			_ = r.ParseMultipartForm(10 << 20)
+			if got := r.FormValue("ignore_timestamps"); got != "false" {
+				t.Errorf("ignore_timestamps = %q, want false", got)
+			}
+			_ = json.NewEncoder(w).Encode(ASRResponse{Duration: duration, Segments: segments})
+		case "/v1/tts":
+			var req ttsRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("decode request: %v", err)
+			}
+			if req.Format != AudioFormatPCM || req.SampleRate != 8000 || req.ReferenceID != "voice-es" {
+				t.Errorf("request = %+v, want PCM at 8000 Hz with voice-es", req)
+			}
+			speed := 1.0
+			if req.Prosody != nil {
+				speed = req.Prosody.Speed
+			}
+			mu.Lock()
+			speeds = append(speeds, speed)
+			mu.Unlock()
+
+			frames := int(float64(len([]rune(req.Text))) * 800 / speed)
+			v := make([]int16, frames)
+			for i := range v {
+				v[i] = 1000
+			}
+			_, _ = w.Write(samples16(v...))
+		}
+	}))
+	return server, &speeds
+}
+
+func TestClient_Dub(t *testing.T) {
+	server, speeds := newDubServer(t, []ASRSegment{
+		{Text: "hello", Start: 0, End: 1},
+		{Text: "this is long", Start: 1, End: 2},
+		{Text: "skipped", Start: 3, End: 3.5},
+		{Text: "bye", Start: 4, End: 4.5},
+	}, 5000)
+	defer server.Close()
+
+	translate := func(ctx context.Context, texts []string) ([]string, error) {
+		if strings.Join(texts, "|") != "hello|this is long|skipped|bye" {
+			t.Errorf("texts = %q", texts)
+		}
+		// 0.4 s; 2.2 s in a 2 s slot; silent; 0.5 s
+		return []string{"hola", "esto es bastante largo", "", "adiós"}, nil
+	}
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	result, err := client.Dub(context.Background(), []byte("source"), translate, "voice-es", &DubOptions{SampleRate: 8000})
+	if err != nil {
+		t.Fatalf("Dub() error = %v", err)
+	}
+
+	f, pcm, err := parseWAV(result.Audio)
+	if err != nil {
+		t.Fatalf("parseWAV() error = %v", err)
+	}
+	if f.sampleRate != 8000 || f.channels != 1 || f.duration(len(pcm)) != 5*time.Second {
+		t.Errorf("track = %+v lasting %v, want 8000 Hz mono lasting 5s", f, f.duration(len(pcm)))
+	}
+
+	want := []struct {
+		text       string
+		start, end time.Duration
+		speed      float64
+	}{
+		{"hola", 0, 400 * time.Millisecond, 1},
+		{"esto es bastante largo", time.Second, 3 * time.Second, 1.1},
+		{"adiós", 4 * time.Second, 4500 * time.Millisecond, 1},
+	}
+	if len(result.Segments) != len(want) {
+		t.Fatalf("len(Segments) = %d, want %d", len(result.Segments), len(want))
+	}
+	for i, w := range want {
+		got := result.Segments[i]
+		if got.Text != w.text || got.Start != w.start || math.Abs(float64(got.End-w.end)) > float64(time.Millisecond) || math.Abs(got.Speed-w.speed) > 1e-9 {
+			t.Errorf("Segments[%d] = %q %v-%v at %v, want %q %v-%v at %v",
+				i, got.Text, got.Start, got.End, got.Speed, w.text, w.start, w.end, w.speed)
+		}
+	}
+	if len(*speeds) != 4 {
+		t.Errorf("TTS requests = %d, want 4 (one retry)", len(*speeds))
+	}
+
+	// Speech is placed at its segment start; the gaps stay silent
+	v := decode16(pcm)
+	for _, check := range []struct {
+		at   time.Duration
+		want int16
+	}{{100 * time.Millisecond, 1000}, {700 * time.Millisecond, 0}, {2500 * time.Millisecond, 1000}, {3500 * time.Millisecond, 0}, {4200 * time.Millisecond, 1000}} {
+		if got := v[int(check.at.Seconds()*8000)]; got != check.want {
+			t.Errorf("sample at %v = %d, want %d", check.at, got, check.want)
+		}
+	}
+}
+
+func TestClient_Dub_MaxSpeed(t *testing.T) {
+	// 3 s of speech in a 1 s slot is capped at MaxSpeed and runs over,
+	// extending the track past the source
+	server, speeds := newDubServer(t, []ASRSegment{{Text: "hi", Start: 0.5, End: 1}}, 1500)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	result, err := client.Dub(context.Background(), []byte("source"), func(ctx context.Context, texts []string) ([]string, error) {
+		return []string{strings.Repeat("x", 30)}, nil
+	}, "voice-es", &DubOptions{SampleRate: 8000, MaxSpeed: 1.25})
+	if err != nil {
+		t.Fatalf("Dub() error = %v", err)
+	}
+
+	if got := (*speeds)[len(*speeds)-1]; got != 1.25 {
+		t.Errorf("retry speed = %v, want 1.25", got)
+	}
+	f, pcm, _ := parseWAV(result.Audio)
+	if got := f.duration(len(pcm)); got != 2900*time.Millisecond {
+		t.Errorf("track lasts %v, want 2.9s", got)
+	}
+}
+
+func TestClient_Dub_TranslateMismatch(t *testing.T) {
+	server, _ := newDubServer(t, []ASRSegment{{Text: "a", End: 1}, {Text: "b", Start: 1, End: 2}}, 2000)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	_, err := client.Dub(context.Background(), []byte("source"), func(ctx context.Context, texts []string) ([]string, error) {
+		return []string{"only one"}, nil
+	}, "voice-es", &DubOptions{SampleRate: 8000})
+	if err == nil || !strings.Contains(err.Error(), "1 texts for 2 segments") {
+		t.Errorf("Dub() error = %v, want a count mismatch", err)
+	}
+}
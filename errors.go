@@ -1,6 +1,16 @@
 package fishaudio
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrStreamClosed is returned when reading from a stream after Close.
+var ErrStreamClosed = errors.New("stream closed")
 
 // FishAudioError is the base interface for all Fish Audio SDK errors.
 type FishAudioError interface {
@@ -10,17 +20,95 @@ type FishAudioError interface {
 
 // APIError is raised when the API returns an error response.
 type APIError struct {
+	// StatusCode is the HTTP status, or zero for errors raised client-side
+	// before a request was sent.
 	StatusCode int
 	Message    string
 	Body       string
+
+	// Method and Path identify the failed request, e.g. "POST" and
+	// "/v1/tts". They are empty for errors raised client-side.
+	Method string
+	Path   string
+	// Attempts is the number of times the request was sent, including
+	// retries. See WithRetryPolicy.
+	Attempts int
+	// Duration is the time from the first attempt until the error,
+	// including waits between retries.
+	Duration time.Duration
 }
 
 func (e *APIError) Error() string {
-	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
+	if e.StatusCode == 0 {
+		return e.Message
+	}
+	msg := fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
+	if e.Method != "" {
+		msg += " (" + requestSummary(e.Method, e.Path, e.Attempts, e.Duration) + ")"
+	}
+	return msg
 }
 
 func (e *APIError) IsFishAudioError() {}
 
+// apiError lets errors.As find the APIError embedded in the typed errors.
+func (e *APIError) apiError() *APIError { return e }
+
+// RequestError is raised when an API request gets no response, such as on
+// a network error or timeout. It wraps the transport error.
+type RequestError struct {
+	// Method, Path, Attempts, and Duration describe the request as for
+	// APIError.
+	Method   string
+	Path     string
+	Attempts int
+	Duration time.Duration
+	// Err is the error of the last attempt.
+	Err error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("request failed (%s): %v", requestSummary(e.Method, e.Path, e.Attempts, e.Duration), e.Err)
+}
+
+func (e *RequestError) Unwrap() error { return e.Err }
+
+func (e *RequestError) IsFishAudioError() {}
+
+// requestSummary describes a request for error messages, as in
+// "GET /model/abc, 2 attempts, 1.25s".
+func requestSummary(method, path string, attempts int, d time.Duration) string {
+	if d >= time.Millisecond {
+		d = d.Round(time.Millisecond)
+	} else {
+		d = d.Round(time.Microsecond)
+	}
+	plural := "s"
+	if attempts == 1 {
+		plural = ""
+	}
+	return fmt.Sprintf("%s %s, %d attempt%s, %s", method, path, attempts, plural, d)
+}
+
+// withRequest records the request that failed with err after attempts
+// attempts since start. API errors get the request fields set; other
+// errors are wrapped in a *RequestError.
+func withRequest(err error, req *http.Request, attempts int, start time.Time) error {
+	var api interface{ apiError() *APIError }
+	if errors.As(err, &api) {
+		e := api.apiError()
+		e.Method, e.Path, e.Attempts, e.Duration = req.Method, req.URL.Path, attempts, time.Since(start)
+		return err
+	}
+	return &RequestError{
+		Method:   req.Method,
+		Path:     req.URL.Path,
+		Attempts: attempts,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+}
+
 // AuthenticationError is raised when authentication fails (401).
 type AuthenticationError struct {
 	*APIError
@@ -44,6 +132,64 @@ type RateLimitError struct {
 // ValidationError is raised when request validation fails (422).
 type ValidationError struct {
 	*APIError
+	// Fields lists the invalid request fields, if the API reported them.
+	// It is empty for errors raised client-side.
+	Fields []FieldError
+}
+
+// FieldError describes an invalid request field, from the detail array of
+// a 422 response.
+type FieldError struct {
+	// Location is the path to the field, such as ["body", "prosody",
+	// "speed"]. List indexes are formatted as numbers.
+	Location []string
+	// Message describes the problem, e.g. "Input should be less than 2".
+	Message string
+	// Type is the machine-readable kind of problem, e.g. "less_than".
+	Type string
+}
+
+// Field returns the dotted path of the field within its part of the
+// request, e.g. "prosody.speed" or "references.0.audio".
+func (e FieldError) Field() string {
+	loc := e.Location
+	if len(loc) > 1 {
+		switch loc[0] {
+		case "body", "query", "path", "header", "cookie":
+			loc = loc[1:]
+		}
+	}
+	return strings.Join(loc, ".")
+}
+
+// parseFieldErrors parses the detail array of a 422 response body. It
+// returns nil if the body has no such array.
+func parseFieldErrors(body string) []FieldError {
+	var resp struct {
+		Detail []struct {
+			Loc  []interface{} `json:"loc"`
+			Msg  string        `json:"msg"`
+			Type string        `json:"type"`
+		} `json:"detail"`
+	}
+	if json.Unmarshal([]byte(body), &resp) != nil {
+		return nil
+	}
+	var fields []FieldError
+	for _, d := range resp.Detail {
+		field := FieldError{Message: d.Msg, Type: d.Type}
+		for _, part := range d.Loc {
+			field.Location = append(field.Location, fmt.Sprint(part))
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// newValidationError returns a ValidationError for input rejected
+// client-side, with no status code.
+func newValidationError(format string, args ...interface{}) error {
+	return &ValidationError{APIError: &APIError{Message: fmt.Sprintf(format, args...)}}
 }
 
 // ServerError is raised when the server encounters an error (5xx).
@@ -54,14 +200,134 @@ type ServerError struct {
 // WebSocketError is raised when WebSocket connection or streaming fails.
 type WebSocketError struct {
 	Message string
+	// Code is the server-provided error code, if any.
+	Code string
+	// Detail is the server-provided error description, if any.
+	Detail string
 }
 
 func (e *WebSocketError) Error() string {
-	return e.Message
+	msg := e.Message
+	if e.Code != "" {
+		msg += " (code " + e.Code + ")"
+	}
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return msg
 }
 
 func (e *WebSocketError) IsFishAudioError() {}
 
+// TimeoutError is raised when a WebSocket session stops responding within
+// the configured deadline (for example, when pongs stop arriving or a
+// frame cannot be written).
+type TimeoutError struct {
+	*WebSocketError
+	// Op is the operation that timed out: "pong", "read", or "write".
+	Op string
+}
+
+// Timeout reports that the error is a timeout, matching net.Error.
+func (e *TimeoutError) Timeout() bool { return true }
+
+// MessageTooLargeError is raised when a server frame exceeds
+// WebSocketOptions.MaxMessageSize.
+type MessageTooLargeError struct {
+	*WebSocketError
+	// Limit is the configured MaxMessageSize in bytes.
+	Limit int64
+}
+
+// FrameError is raised when a live session receives a frame it cannot
+// decode: malformed msgpack or JSON, or an audio frame whose audio has an
+// unexpected type. Frames with unknown events, and fields of an unexpected
+// type in other frames, are skipped instead of ending the session.
+type FrameError struct {
+	*WebSocketError
+	// Event is the frame's event, if it could be read.
+	Event string
+	// Size is the frame size in bytes.
+	Size int
+	// Err describes what was wrong with the frame.
+	Err error
+}
+
+func (e *FrameError) Unwrap() error { return e.Err }
+
+// DiscontinuityError describes audio chunks missing from a live session
+// whose server numbers its audio frames. It is delivered as a
+// WebSocketEventDiscontinuity event, or ends the stream if
+// WebSocketOptions.FailOnGap is set, so consumers such as telephony
+// bridges can fill the gap with comfort noise instead of playing a glitch.
+type DiscontinuityError struct {
+	*WebSocketError
+	// Expected is the sequence number of the first missing chunk.
+	Expected int64
+	// Got is the sequence number of the chunk that follows the gap.
+	Got int64
+}
+
+// Missing returns the number of chunks missing.
+func (e *DiscontinuityError) Missing() int64 { return e.Got - e.Expected }
+
+// ConnectionLostError is raised when a live session's connection closes
+// abnormally (close code 1006) before the server finished the session.
+// Audio delivered before the loss is still valid, so callers can keep it
+// and retry only the remainder.
+type ConnectionLostError struct {
+	*WebSocketError
+	// PartialResult reports whether any audio was delivered before the
+	// connection was lost.
+	PartialResult bool
+	// Chunks is the number of audio chunks delivered.
+	Chunks int
+	// Bytes is the number of audio bytes delivered.
+	Bytes int64
+	// Err is the underlying close error.
+	Err error
+}
+
+func (e *ConnectionLostError) Unwrap() error { return e.Err }
+
+// TrainingFailedError is returned by VoicesService.WaitForTrained when a
+// voice's training ends in the failed state.
+type TrainingFailedError struct {
+	// Voice is the voice as last fetched.
+	Voice *Voice
+}
+
+func (e *TrainingFailedError) Error() string {
+	return fmt.Sprintf("training failed for voice %s", e.Voice.ID)
+}
+
+func (e *TrainingFailedError) IsFishAudioError() {}
+
+// PanicError is returned when code run on a goroutine the SDK started
+// panics, such as a WebSocketOptions.OnEvent callback, a text iterator, or
+// a Storage. The panic fails the stream, request, or job it belongs to
+// instead of crashing the process; WithRethrowPanics restores the crash.
+type PanicError struct {
+	// Goroutine names the SDK goroutine that panicked.
+	Goroutine string
+	// Value is the value passed to panic.
+	Value interface{}
+	// Stack is the stack trace of the panic.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic in %s: %v", e.Goroutine, e.Value)
+}
+
+// Unwrap returns Value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+func (e *PanicError) IsFishAudioError() {}
+
 // newAPIError creates the appropriate error type based on status code.
 func newAPIError(statusCode int, message, body string) error {
 	base := &APIError{
@@ -78,7 +344,7 @@ func newAPIError(statusCode int, message, body string) error {
 	case 404:
 		return &NotFoundError{APIError: base}
 	case 422:
-		return &ValidationError{APIError: base}
+		return &ValidationError{APIError: base, Fields: parseFieldErrors(body)}
 	case 429:
 		return &RateLimitError{APIError: base}
 	default:
@@ -1,8 +1,14 @@
 package fishaudio
 
 import (
+	"context"
 	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestAPIError_Error(t *testing.T) {
@@ -47,6 +53,18 @@ func TestWebSocketError_Error(t *testing.T) {
 	}
 }
 
+func TestWebSocketError_ErrorWithDetails(t *testing.T) {
+	err := &WebSocketError{
+		Message: "stream finished with error",
+		Code:    "402",
+		Detail:  "insufficient credits",
+	}
+	want := "stream finished with error (code 402): insufficient credits"
+	if got := err.Error(); got != want {
+		t.Errorf("WebSocketError.Error() = %q, want %q", got, want)
+	}
+}
+
 func TestNewAPIError(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -128,3 +146,148 @@ func getTypeName(err error) string {
 		return "unknown"
 	}
 }
+
+func TestTimeoutError(t *testing.T) {
+	var err error = &TimeoutError{
+		WebSocketError: &WebSocketError{Message: "no pong received"},
+		Op:             "pong",
+	}
+
+	if got := err.Error(); got != "no pong received" {
+		t.Errorf("TimeoutError.Error() = %q, want %q", got, "no pong received")
+	}
+
+	var netErr interface{ Timeout() bool }
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Error("TimeoutError should report Timeout() = true")
+	}
+
+	if _, ok := err.(FishAudioError); !ok {
+		t.Error("TimeoutError should implement FishAudioError")
+	}
+}
+
+func TestConnectionLostError(t *testing.T) {
+	cause := errors.New("connection reset by peer")
+	var err error = &ConnectionLostError{
+		WebSocketError: &WebSocketError{Message: "connection closed abnormally after 2 audio chunks"},
+		PartialResult:  true,
+		Chunks:         2,
+		Bytes:          10,
+		Err:            cause,
+	}
+
+	if got := err.Error(); got != "connection closed abnormally after 2 audio chunks" {
+		t.Errorf("ConnectionLostError.Error() = %q", got)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("ConnectionLostError should unwrap to its cause")
+	}
+	if _, ok := err.(FishAudioError); !ok {
+		t.Error("ConnectionLostError should implement FishAudioError")
+	}
+}
+
+func TestTrainingFailedError(t *testing.T) {
+	err := &TrainingFailedError{Voice: &Voice{ID: "voice-123"}}
+	if got, want := err.Error(), "training failed for voice voice-123"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	var fishErr FishAudioError = err
+	_ = fishErr
+}
+
+func TestAPIError_ClientSide(t *testing.T) {
+	err := newValidationError("cover image must be %s", "PNG")
+	if got, want := err.Error(), "cover image must be PNG"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestAPIError_RequestContext(t *testing.T) {
+	err := &APIError{StatusCode: 503, Message: "Service Unavailable", Method: "GET", Path: "/model/abc", Attempts: 2, Duration: 1250 * time.Millisecond}
+	if got, want := err.Error(), "HTTP 503: Service Unavailable (GET /model/abc, 2 attempts, 1.25s)"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithRetryPolicy(BackoffPolicy{MaxRetries: 1, Delay: time.Millisecond}))
+	_, getErr := client.Voices.Get(context.Background(), "abc")
+	var serverErr *ServerError
+	if !errors.As(getErr, &serverErr) {
+		t.Fatalf("Get() error = %v, want *ServerError", getErr)
+	}
+	if serverErr.Method != http.MethodGet || serverErr.Path != "/model/abc" || serverErr.Attempts != 2 || serverErr.Duration < time.Millisecond {
+		t.Errorf("ServerError = %+v, want the request context", serverErr.APIError)
+	}
+	if !strings.Contains(getErr.Error(), "(GET /model/abc, 2 attempts, ") {
+		t.Errorf("Error() = %q, want the request context", getErr.Error())
+	}
+}
+
+func TestRequestError(t *testing.T) {
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL("http://127.0.0.1:1"))
+	_, err := client.TTS.Convert(context.Background(), &ConvertParams{Text: "Hello"})
+
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) || reqErr.Method != http.MethodPost || reqErr.Path != "/v1/tts" || reqErr.Attempts != 1 {
+		t.Fatalf("Convert() error = %v, want *RequestError for POST /v1/tts", err)
+	}
+	var netErr net.Error
+	if !errors.As(err, &netErr) {
+		t.Errorf("RequestError does not unwrap to the network error: %v", err)
+	}
+	if !strings.HasPrefix(err.Error(), "request failed (POST /v1/tts, 1 attempt, ") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	if _, ok := err.(FishAudioError); !ok {
+		t.Error("RequestError should implement FishAudioError")
+	}
+}
+
+func TestValidationError_Fields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"detail": [
+			{"loc": ["body", "prosody", "speed"], "msg": "Input should be less than or equal to 2", "type": "less_than_equal"},
+			{"loc": ["body", "references", 0, "audio"], "msg": "Field required", "type": "missing"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	_, err := client.TTS.Convert(context.Background(), &ConvertParams{Text: "Hello"})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Convert() error = %v, want *ValidationError", err)
+	}
+	if len(validationErr.Fields) != 2 {
+		t.Fatalf("Fields = %+v, want 2", validationErr.Fields)
+	}
+	if f := validationErr.Fields[0]; f.Field() != "prosody.speed" || f.Type != "less_than_equal" || f.Message != "Input should be less than or equal to 2" {
+		t.Errorf("Fields[0] = %+v, Field() = %q", f, f.Field())
+	}
+	if f := validationErr.Fields[1]; f.Field() != "references.0.audio" || len(f.Location) != 4 || f.Location[0] != "body" {
+		t.Errorf("Fields[1] = %+v, Field() = %q", f, f.Field())
+	}
+}
+
+func TestParseFieldErrors(t *testing.T) {
+	for _, body := range []string{``, `not json`, `{"detail": "Invalid voice"}`, `{"message": "bad"}`} {
+		if fields := parseFieldErrors(body); fields != nil {
+			t.Errorf("parseFieldErrors(%q) = %+v, want nil", body, fields)
+		}
+	}
+	fields := parseFieldErrors(`{"detail": [{"loc": ["query", "page_size"], "msg": "too big", "type": "less_than"}]}`)
+	if len(fields) != 1 || fields[0].Field() != "page_size" {
+		t.Errorf("parseFieldErrors() = %+v, want page_size", fields)
+	}
+	if got := (FieldError{Location: []string{"body"}}).Field(); got != "body" {
+		t.Errorf("Field() = %q for the whole body, want body", got)
+	}
+}
@@ -0,0 +1,69 @@
+package fishaudio
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestVoice_Extra(t *testing.T) {
+	data := `{"_id":"voice-1","title":"Narrator","Like_Count":3,"default_text":"Hello","cover_metadata":{"w":512}}`
+
+	var voice Voice
+	if err := json.Unmarshal([]byte(data), &voice); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if voice.ID != "voice-1" || voice.Title != "Narrator" || voice.LikeCount != 3 {
+		t.Errorf("known fields not decoded: %+v", voice)
+	}
+	if len(voice.Extra) != 2 {
+		t.Fatalf("Extra = %v, want 2 unknown fields", voice.Extra)
+	}
+	if string(voice.Extra["default_text"]) != `"Hello"` {
+		t.Errorf("Extra[default_text] = %s, want %q", voice.Extra["default_text"], `"Hello"`)
+	}
+	if string(voice.Extra["cover_metadata"]) != `{"w":512}` {
+		t.Errorf("Extra[cover_metadata] = %s, want %s", voice.Extra["cover_metadata"], `{"w":512}`)
+	}
+}
+
+func TestExtra_NoUnknownFields(t *testing.T) {
+	var credits Credits
+	if err := json.Unmarshal([]byte(`{"_id":"c","credit":"10.5"}`), &credits); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if credits.Extra != nil {
+		t.Errorf("Extra = %v, want nil", credits.Extra)
+	}
+}
+
+func TestExtra_OtherModels(t *testing.T) {
+	var pkg Package
+	if err := json.Unmarshal([]byte(`{"_id":"p","balance":5,"renews_at":"2026-01-01"}`), &pkg); err != nil {
+		t.Fatalf("Unmarshal(Package) error = %v", err)
+	}
+	if pkg.Balance != 5 || string(pkg.Extra["renews_at"]) != `"2026-01-01"` {
+		t.Errorf("Package = %+v", pkg)
+	}
+
+	var resp ASRResponse
+	if err := json.Unmarshal([]byte(`{"text":"hi","segments":[],"language":"en","formatted":"x"}`), &resp); err != nil {
+		t.Fatalf("Unmarshal(ASRResponse) error = %v", err)
+	}
+	if resp.Text != "hi" || string(resp.Extra["language"]) != `"en"` {
+		t.Errorf("ASRResponse = %+v", resp)
+	}
+	if resp.Formatted != "" || resp.Extra["formatted"] == nil {
+		t.Error("a field excluded from JSON should be reported in Extra, not decoded")
+	}
+}
+
+func TestVoice_ExtraInList(t *testing.T) {
+	data := `{"total":1,"items":[{"_id":"v","new_field":true}]}`
+	var page PaginatedResponse[Voice]
+	if err := json.Unmarshal([]byte(data), &page); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if string(page.Items[0].Extra["new_field"]) != "true" {
+		t.Errorf("Items[0].Extra = %v", page.Items[0].Extra)
+	}
+}
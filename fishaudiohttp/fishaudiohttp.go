@@ -0,0 +1,62 @@
+// Package fishaudiohttp provides net/http handlers that proxy Fish Audio
+// to browsers and other HTTP clients, keeping the API key on the server.
+//
+// Example:
+//
+//	http.Handle("/speak", fishaudiohttp.NewTTSHandler(client, &fishaudiohttp.TTSHandlerOptions{
+//	    Params: &fishaudio.StreamParams{ReferenceID: voiceID},
+//	}))
+//
+// In the browser:
+//
+//	<audio src="/speak?text=Hello%20there" autoplay></audio>
+package fishaudiohttp
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+)
+
+// errInvalidText is returned for text that is not valid UTF-8.
+var errInvalidText = errors.New("text is not valid UTF-8")
+
+// sanitizeText removes control characters other than newlines and tabs,
+// which can't be spoken and may confuse the server, and trims surrounding
+// whitespace.
+func sanitizeText(text string) (string, error) {
+	if !utf8.ValidString(text) {
+		return "", errInvalidText
+	}
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\n' && r != '\t' {
+			return -1
+		}
+		return r
+	}, text)
+	return strings.TrimSpace(text), nil
+}
+
+// upstreamStatus returns the status code to answer with when a Fish Audio
+// request fails. Details of upstream errors are not passed on, since they
+// may describe the server's account.
+func upstreamStatus(err error) (int, string) {
+	var (
+		rateLimitErr  *fishaudio.RateLimitError
+		validationErr *fishaudio.ValidationError
+		notFoundErr   *fishaudio.NotFoundError
+	)
+	switch {
+	case errors.As(err, &rateLimitErr), errors.Is(err, fishaudio.ErrBudgetExceeded):
+		return http.StatusTooManyRequests, "too many requests"
+	case errors.As(err, &validationErr):
+		return http.StatusBadRequest, "invalid request"
+	case errors.As(err, &notFoundErr):
+		return http.StatusBadRequest, "unknown voice"
+	}
+	return http.StatusBadGateway, "speech service unavailable"
+}
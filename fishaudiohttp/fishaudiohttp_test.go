@@ -0,0 +1,47 @@
+package fishaudiohttp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+)
+
+func TestSanitizeText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"  Hello\x00 world\x1b[31m \n", "Hello world[31m"},
+		{"line one\nline\ttwo", "line one\nline\ttwo"},
+		{"\u200bzero\u0085", "\u200bzero"},
+	}
+	for _, tt := range tests {
+		if got, err := sanitizeText(tt.in); err != nil || got != tt.want {
+			t.Errorf("sanitizeText(%q) = %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := sanitizeText("bad \xff"); !errors.Is(err, errInvalidText) {
+		t.Errorf("sanitizeText(invalid UTF-8) err = %v, want %v", err, errInvalidText)
+	}
+}
+
+func TestUpstreamStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{&fishaudio.RateLimitError{APIError: &fishaudio.APIError{StatusCode: 429}}, http.StatusTooManyRequests},
+		{&fishaudio.BudgetExceededError{Resource: "characters"}, http.StatusTooManyRequests},
+		{&fishaudio.ValidationError{APIError: &fishaudio.APIError{StatusCode: 422}}, http.StatusBadRequest},
+		{&fishaudio.NotFoundError{APIError: &fishaudio.APIError{StatusCode: 404}}, http.StatusBadRequest},
+		{&fishaudio.AuthenticationError{APIError: &fishaudio.APIError{StatusCode: 401, Message: "bad key"}}, http.StatusBadGateway},
+		{context.DeadlineExceeded, http.StatusBadGateway},
+	}
+	for _, tt := range tests {
+		if got, _ := upstreamStatus(tt.err); got != tt.want {
+			t.Errorf("upstreamStatus(%T) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
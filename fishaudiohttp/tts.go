@@ -0,0 +1,211 @@
+package fishaudiohttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"unicode/utf8"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+)
+
+// TTSHandlerOptions configures NewTTSHandler.
+type TTSHandlerOptions struct {
+	// Params is the template for every request; its Text is replaced by
+	// the request's text. Default: the client's defaults, MP3 output.
+	Params *fishaudio.StreamParams
+
+	// Voices lists the voice model IDs a request may select with the
+	// "voice" parameter. If empty, requests can't select a voice and
+	// Params.ReferenceID is used.
+	Voices []string
+
+	// Formats lists the output formats a request may select with the
+	// "format" parameter. If empty, requests can't select a format and
+	// Params.Format is used.
+	Formats []fishaudio.AudioFormat
+
+	// MaxTextLength is the longest accepted text, in characters.
+	// Default: 1000.
+	MaxTextLength int
+
+	// MaxBodyBytes is the largest accepted POST body. Default: 64 KiB.
+	MaxBodyBytes int64
+}
+
+// ttsHandler implements the handler returned by NewTTSHandler.
+type ttsHandler struct {
+	client *fishaudio.Client
+	opts   TTSHandlerOptions
+}
+
+// ttsInput is the input of a TTS request.
+type ttsInput struct {
+	Text   string `json:"text"`
+	Voice  string `json:"voice"`
+	Format string `json:"format"`
+}
+
+// NewTTSHandler returns a handler that synthesizes speech and streams the
+// audio to the client as it is generated.
+//
+// It accepts GET requests with query parameters, and POST requests with a
+// form or JSON body. The parameters are "text", and "voice" and "format"
+// when allowed by opts. Text is stripped of control characters and limited
+// in length. Invalid input is answered with 400, 405, 413, or 415, and
+// upstream failures with 429 or 502.
+//
+// Example:
+//
+//	http.Handle("/speak", fishaudiohttp.NewTTSHandler(client, &fishaudiohttp.TTSHandlerOptions{
+//	    Voices:        []string{narratorID, assistantID},
+//	    MaxTextLength: 500,
+//	}))
+func NewTTSHandler(client *fishaudio.Client, opts *TTSHandlerOptions) http.Handler {
+	h := &ttsHandler{client: client}
+	if opts != nil {
+		h.opts = *opts
+	}
+	if h.opts.MaxTextLength <= 0 {
+		h.opts.MaxTextLength = 1000
+	}
+	if h.opts.MaxBodyBytes <= 0 {
+		h.opts.MaxBodyBytes = 64 << 10
+	}
+	return h
+}
+
+func (h *ttsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	input, status, err := h.readInput(w, r)
+	if err != nil {
+		if status == http.StatusMethodNotAllowed {
+			w.Header().Set("Allow", "GET, POST")
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	params, status, err := h.params(input)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	stream, err := h.client.TTS.Stream(r.Context(), params)
+	if err != nil {
+		status, msg := upstreamStatus(err)
+		http.Error(w, msg, status)
+		return
+	}
+	defer func() { _ = stream.Close() }()
+
+	header := w.Header()
+	header.Set("Content-Type", params.Format.ContentType())
+	header.Set("Cache-Control", "no-store")
+	header.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	// Flush each chunk so playback can start before synthesis finishes.
+	// Without a Content-Length the response is sent chunked. Errors after
+	// the header is written can only end the response early.
+	flusher, _ := w.(http.Flusher)
+	buf := make([]byte, 16<<10)
+	for {
+		n, err := stream.Read(buf)
+		if n > 0 {
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// readInput reads the request's parameters from its query or body.
+func (h *ttsHandler) readInput(w http.ResponseWriter, r *http.Request) (ttsInput, int, error) {
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		return ttsInput{Text: q.Get("text"), Voice: q.Get("voice"), Format: q.Get("format")}, 0, nil
+	case http.MethodPost:
+	default:
+		return ttsInput{}, http.StatusMethodNotAllowed, errors.New("method not allowed")
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.opts.MaxBodyBytes)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	var input ttsInput
+	var err error
+	switch mediaType {
+	case "application/json":
+		err = json.NewDecoder(r.Body).Decode(&input)
+		if err == nil {
+			_, err = io.Copy(io.Discard, r.Body)
+		}
+	case "application/x-www-form-urlencoded", "multipart/form-data":
+		err = r.ParseMultipartForm(h.opts.MaxBodyBytes)
+		if errors.Is(err, http.ErrNotMultipart) {
+			err = nil
+		}
+		input = ttsInput{Text: r.PostFormValue("text"), Voice: r.PostFormValue("voice"), Format: r.PostFormValue("format")}
+	default:
+		return ttsInput{}, http.StatusUnsupportedMediaType, errors.New("unsupported content type")
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return ttsInput{}, http.StatusRequestEntityTooLarge, errors.New("request body too large")
+	}
+	if err != nil {
+		return ttsInput{}, http.StatusBadRequest, errors.New("malformed request body")
+	}
+	return input, 0, nil
+}
+
+// params validates input and builds the TTS request for it.
+func (h *ttsHandler) params(input ttsInput) (*fishaudio.StreamParams, int, error) {
+	text, err := sanitizeText(input.Text)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if text == "" {
+		return nil, http.StatusBadRequest, errors.New("text is required")
+	}
+	if n := utf8.RuneCountInString(text); n > h.opts.MaxTextLength {
+		return nil, http.StatusRequestEntityTooLarge, fmt.Errorf("text is %d characters, limit is %d", n, h.opts.MaxTextLength)
+	}
+
+	var params fishaudio.StreamParams
+	if h.opts.Params != nil {
+		params = *h.opts.Params
+	}
+	params.Text = text
+
+	if input.Voice != "" {
+		if !slices.Contains(h.opts.Voices, input.Voice) {
+			return nil, http.StatusBadRequest, errors.New("voice not allowed")
+		}
+		params.ReferenceID = input.Voice
+	}
+	if input.Format != "" {
+		format := fishaudio.AudioFormat(input.Format)
+		if !slices.Contains(h.opts.Formats, format) {
+			return nil, http.StatusBadRequest, errors.New("format not allowed")
+		}
+		params.Format = format
+	}
+	if params.Format == "" {
+		params.Format = fishaudio.AudioFormatMP3
+	}
+	return &params, 0, nil
+}
@@ -0,0 +1,145 @@
+package fishaudiohttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+)
+
+// newTTSTestHandler returns a TTS handler backed by a fake API that
+// records the request bodies it receives.
+func newTTSTestHandler(t *testing.T, status int, opts *TTSHandlerOptions) (http.Handler, *[]map[string]interface{}) {
+	t.Helper()
+	var bodies []map[string]interface{}
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		if status != http.StatusOK {
+			http.Error(w, `{"message": "account detail"}`, status)
+			return
+		}
+		_, _ = w.Write([]byte("audio-data"))
+	}))
+	t.Cleanup(backend.Close)
+
+	client := fishaudio.NewClient(fishaudio.WithAPIKey("test-key"), fishaudio.WithBaseURL(backend.URL))
+	return NewTTSHandler(client, opts), &bodies
+}
+
+func TestTTSHandler_Get(t *testing.T) {
+	handler, bodies := newTTSTestHandler(t, http.StatusOK, &TTSHandlerOptions{
+		Params: &fishaudio.StreamParams{ReferenceID: "default-voice"},
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/speak?text="+url.QueryEscape(" Hello\x00 "), nil))
+
+	if rec.Code != http.StatusOK || rec.Body.String() != "audio-data" {
+		t.Fatalf("response = %d %q", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Content-Type"); got != "audio/mpeg" {
+		t.Errorf("Content-Type = %q, want audio/mpeg", got)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "no-store" {
+		t.Errorf("Cache-Control = %q, want no-store", got)
+	}
+	if !rec.Flushed {
+		t.Error("response was not flushed")
+	}
+	body := (*bodies)[0]
+	if body["text"] != "Hello" || body["reference_id"] != "default-voice" || body["format"] != "mp3" {
+		t.Errorf("upstream body = %v", body)
+	}
+}
+
+func TestTTSHandler_Post(t *testing.T) {
+	opts := &TTSHandlerOptions{
+		Voices:  []string{"voice-a"},
+		Formats: []fishaudio.AudioFormat{fishaudio.AudioFormatWAV, fishaudio.AudioFormatOpus},
+	}
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{"json", "application/json", `{"text": "Hi", "voice": "voice-a", "format": "wav"}`},
+		{"form", "application/x-www-form-urlencoded", "text=Hi&voice=voice-a&format=wav"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, bodies := newTTSTestHandler(t, http.StatusOK, opts)
+			req := httptest.NewRequest(http.MethodPost, "/speak", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "audio/wav" {
+				t.Fatalf("response = %d %q", rec.Code, rec.Header().Get("Content-Type"))
+			}
+			body := (*bodies)[0]
+			if body["text"] != "Hi" || body["reference_id"] != "voice-a" || body["format"] != "wav" {
+				t.Errorf("upstream body = %v", body)
+			}
+		})
+	}
+}
+
+func TestTTSHandler_Rejects(t *testing.T) {
+	opts := &TTSHandlerOptions{Voices: []string{"voice-a"}, MaxTextLength: 5, MaxBodyBytes: 64}
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		contentType string
+		body        string
+		want        int
+	}{
+		{"empty text", http.MethodGet, "/?text=+%00+", "", "", http.StatusBadRequest},
+		{"invalid utf-8", http.MethodGet, "/?text=%FF", "", "", http.StatusBadRequest},
+		{"too long", http.MethodGet, "/?text=abcdef", "", "", http.StatusRequestEntityTooLarge},
+		{"voice not allowed", http.MethodGet, "/?text=hi&voice=other", "", "", http.StatusBadRequest},
+		{"format not allowed", http.MethodGet, "/?text=hi&format=wav", "", "", http.StatusBadRequest},
+		{"method", http.MethodPut, "/", "", "", http.StatusMethodNotAllowed},
+		{"media type", http.MethodPost, "/", "text/plain", "hi", http.StatusUnsupportedMediaType},
+		{"malformed json", http.MethodPost, "/", "application/json", "{", http.StatusBadRequest},
+		{"body too large", http.MethodPost, "/", "application/json", `{"text": "` + strings.Repeat("a", 100) + `"}`, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, bodies := newTTSTestHandler(t, http.StatusOK, opts)
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d (%s)", rec.Code, tt.want, rec.Body.String())
+			}
+			if len(*bodies) != 0 {
+				t.Error("rejected request reached the API")
+			}
+		})
+	}
+}
+
+func TestTTSHandler_UpstreamError(t *testing.T) {
+	handler, _ := newTTSTestHandler(t, http.StatusUnauthorized, nil)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?text=hi", nil))
+
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadGateway)
+	}
+	if strings.Contains(rec.Body.String(), "account detail") {
+		t.Errorf("upstream error details leaked: %q", rec.Body.String())
+	}
+}
@@ -0,0 +1,163 @@
+package fishaudiotest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// GoldenOptions configures CompareAudio and AssertGolden.
+type GoldenOptions struct {
+	// SizeTolerance is the allowed difference in audio size, relative to
+	// the golden audio, e.g. 0.05 for 5%. Default: 0, sizes must match.
+	SizeTolerance float64
+	// WindowSize is the number of bytes hashed together when comparing
+	// content. Default: 4096.
+	WindowSize int
+	// MaxWindowMismatch is the allowed fraction of windows that differ,
+	// e.g. 0.1 for 10%. Default: 0, the content must match.
+	MaxWindowMismatch float64
+	// Update writes the audio to the golden file instead of comparing,
+	// for regenerating fixtures. Set it from a test flag, e.g. -update.
+	Update bool
+}
+
+// CompareAudio compares audio against golden audio and returns an error
+// describing how they differ beyond the tolerances of opts, which may be
+// nil.
+//
+// Both are compared as raw bytes, except that two WAV files are compared
+// by their sample format and sample data only, so differences in size
+// fields and metadata chunks, such as those of streamed WAV headers, are
+// ignored.
+func CompareAudio(got, want []byte, opts *GoldenOptions) error {
+	var o GoldenOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.WindowSize <= 0 {
+		o.WindowSize = 4096
+	}
+
+	gotFormat, gotData, gotErr := wavData(got)
+	wantFormat, wantData, wantErr := wavData(want)
+	if gotErr == nil && wantErr == nil {
+		if !bytes.Equal(gotFormat, wantFormat) {
+			return errors.New("WAV sample formats differ")
+		}
+		got, want = gotData, wantData
+	}
+
+	if len(got) != len(want) {
+		diff := math.Abs(float64(len(got)-len(want))) / math.Max(float64(len(want)), 1)
+		if diff > o.SizeTolerance {
+			return fmt.Errorf("size is %d bytes, want %d (%.1f%% off, tolerance %.1f%%)", len(got), len(want), diff*100, o.SizeTolerance*100)
+		}
+	}
+
+	// Compare the content both have; the size check covers the rest
+	n := min(len(got), len(want))
+	got, want = got[:n], want[:n]
+	windows := (n + o.WindowSize - 1) / o.WindowSize
+	mismatched, first := 0, -1
+	for i := 0; i < windows; i++ {
+		start := i * o.WindowSize
+		if windowHash(got, start, o.WindowSize) != windowHash(want, start, o.WindowSize) {
+			mismatched++
+			if first < 0 {
+				first = start
+			}
+		}
+	}
+	if windows > 0 {
+		if frac := float64(mismatched) / float64(windows); frac > o.MaxWindowMismatch {
+			return fmt.Errorf("%d of %d windows differ, first at byte %d (%.1f%%, tolerance %.1f%%)", mismatched, windows, first, frac*100, o.MaxWindowMismatch*100)
+		}
+	}
+	return nil
+}
+
+// AssertGolden compares audio against the golden file at path with
+// CompareAudio and fails t if they differ. If the golden file doesn't exist
+// or opts.Update is set, the audio is written to path instead.
+//
+// Example:
+//
+//	var update = flag.Bool("update", false, "update golden files")
+//
+//	func TestGreeting(t *testing.T) {
+//	    audio := synthesizeGreeting(t)
+//	    fishaudiotest.AssertGolden(t, "testdata/greeting.wav", audio, &fishaudiotest.GoldenOptions{
+//	        SizeTolerance: 0.05,
+//	        Update:        *update,
+//	    })
+//	}
+func AssertGolden(t testing.TB, path string, audio []byte, opts *GoldenOptions) {
+	t.Helper()
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) || opts != nil && opts.Update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create golden directory: %v", err)
+		}
+		if err := os.WriteFile(path, audio, 0o644); err != nil {
+			t.Fatalf("failed to write golden file: %v", err)
+		}
+		t.Logf("wrote golden file %s", path)
+		return
+	}
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	if err := CompareAudio(audio, want, opts); err != nil {
+		t.Errorf("audio differs from %s: %v", path, err)
+	}
+}
+
+// windowHash hashes the size bytes of data at start, or fewer at the end.
+func windowHash(data []byte, start, size int) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(data[start:min(start+size, len(data))])
+	return h.Sum64()
+}
+
+// wavData returns the essential fields of a WAV file's fmt chunk (format,
+// channels, sample rate, and bit depth) and its sample data. A data chunk
+// whose size is unknown or too large, as in streamed WAV, runs to the end.
+func wavData(wav []byte) (format, data []byte, err error) {
+	if len(wav) < 12 || string(wav[0:4]) != "RIFF" || string(wav[8:12]) != "WAVE" {
+		return nil, nil, errors.New("not a WAV file")
+	}
+	for off := 12; off+8 <= len(wav); {
+		id := string(wav[off : off+4])
+		size := int(binary.LittleEndian.Uint32(wav[off+4 : off+8]))
+		body := wav[off+8:]
+		if size >= 0 && size < len(body) {
+			body = body[:size]
+		}
+		switch id {
+		case "fmt ":
+			if len(body) < 16 {
+				return nil, nil, errors.New("fmt chunk too short")
+			}
+			// Skip the byte rate and block align, which follow from the rest
+			format = append(append([]byte{}, body[0:8]...), body[14:16]...)
+		case "data":
+			if format == nil {
+				return nil, nil, errors.New("data chunk before fmt chunk")
+			}
+			return format, body, nil
+		}
+		off += 8 + size + size%2
+		if off < 0 {
+			break
+		}
+	}
+	return nil, nil, errors.New("no data chunk")
+}
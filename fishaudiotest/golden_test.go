@@ -0,0 +1,90 @@
+package fishaudiotest_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fishaudio/fish-audio-go/fishaudiotest"
+)
+
+// wav builds a 16-bit mono WAV file. dataSize overrides the data chunk
+// size if non-zero, and extra chunks are inserted before the data.
+func wav(sampleRate uint32, pcm []byte, dataSize uint32, extra ...[]byte) []byte {
+	var b bytes.Buffer
+	b.WriteString("RIFF")
+	_ = binary.Write(&b, binary.LittleEndian, uint32(0xFFFFFFFF))
+	b.WriteString("WAVEfmt ")
+	for _, v := range []interface{}{uint32(16), uint16(1), uint16(1), sampleRate, sampleRate * 2, uint16(2), uint16(16)} {
+		_ = binary.Write(&b, binary.LittleEndian, v)
+	}
+	for _, chunk := range extra {
+		b.Write(chunk)
+	}
+	if dataSize == 0 {
+		dataSize = uint32(len(pcm))
+	}
+	b.WriteString("data")
+	_ = binary.Write(&b, binary.LittleEndian, dataSize)
+	b.Write(pcm)
+	return b.Bytes()
+}
+
+func TestCompareAudio(t *testing.T) {
+	pcm := bytes.Repeat([]byte{1, 2, 3, 4}, 1000)
+	changed := bytes.Clone(pcm)
+	changed[100] = 9
+	list := []byte("LIST\x04\x00\x00\x00INFO")
+
+	tests := []struct {
+		name    string
+		got     []byte
+		want    []byte
+		opts    *fishaudiotest.GoldenOptions
+		wantErr string
+	}{
+		{"equal", pcm, pcm, nil, ""},
+		{"content differs", changed, pcm, nil, "1 of 1 windows differ, first at byte 0"},
+		{"window tolerance", changed, pcm, &fishaudiotest.GoldenOptions{WindowSize: 100, MaxWindowMismatch: 0.1}, ""},
+		{"size differs", pcm[:3800], pcm, nil, "size is 3800 bytes, want 4000"},
+		{"size tolerance", pcm[:3800], pcm, &fishaudiotest.GoldenOptions{SizeTolerance: 0.05}, ""},
+		{"wav header ignored", wav(44100, pcm, 0xFFFFFFFF), wav(44100, pcm, 0, list), nil, ""},
+		{"wav format differs", wav(22050, pcm, 0), wav(44100, pcm, 0), nil, "WAV sample formats differ"},
+		{"wav data differs", wav(44100, changed, 0), wav(44100, pcm, 0), nil, "windows differ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fishaudiotest.CompareAudio(tt.got, tt.want, tt.opts)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("CompareAudio() error = %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("CompareAudio() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "hello.pcm")
+	audio := bytes.Repeat([]byte("hello"), 100)
+
+	// A missing golden file is written
+	fishaudiotest.AssertGolden(t, path, audio, nil)
+	if written, err := os.ReadFile(path); err != nil || !bytes.Equal(written, audio) {
+		t.Fatalf("golden file = %d bytes, %v, want the audio", len(written), err)
+	}
+
+	// Matching audio passes
+	fishaudiotest.AssertGolden(t, path, audio, nil)
+
+	// Update rewrites it
+	fishaudiotest.AssertGolden(t, path, []byte("changed"), &fishaudiotest.GoldenOptions{Update: true})
+	if written, _ := os.ReadFile(path); string(written) != "changed" {
+		t.Errorf("golden file = %q after Update, want changed", written)
+	}
+}
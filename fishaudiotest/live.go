@@ -0,0 +1,278 @@
+// Package fishaudiotest provides utilities for testing applications built on
+// the Fish Audio SDK without calling the real service.
+package fishaudiotest
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// FaultKind specifies how a LiveServer session fails.
+type FaultKind int
+
+const (
+	// FaultError finishes the session with reason "error" and the fault's
+	// Code and Message.
+	FaultError FaultKind = iota
+	// FaultDrop closes the TCP connection without a close frame, which
+	// clients observe as an abnormal closure (1006).
+	FaultDrop
+	// FaultClose sends a close frame with the fault's CloseCode.
+	FaultClose
+	// FaultStall stops responding without closing the connection, including
+	// ignoring pings.
+	FaultStall
+)
+
+// Fault injects a failure into each live session.
+type Fault struct {
+	// Kind is the type of failure.
+	Kind FaultKind
+	// AfterChunks is the number of audio chunks sent before the fault.
+	AfterChunks int
+	// Code is the error code sent with FaultError.
+	Code string
+	// Message is the error message sent with FaultError, or the close
+	// reason sent with FaultClose.
+	Message string
+	// CloseCode is the WebSocket close code sent with FaultClose.
+	CloseCode int
+}
+
+// LiveScript controls how a LiveServer responds.
+type LiveScript struct {
+	// Audio returns the audio for a text event. Default: the text's bytes.
+	Audio func(text string) []byte
+	// ChunkSize splits each text's audio into chunks of at most this many
+	// bytes. Zero sends the audio as a single chunk.
+	ChunkSize int
+	// ChunkDelay is the delay before each audio chunk.
+	ChunkDelay time.Duration
+	// HandshakeStatus, if non-zero, rejects the upgrade with this HTTP status.
+	HandshakeStatus int
+	// Fault, if set, injects a failure into every session.
+	Fault *Fault
+}
+
+// LiveSession records what a client sent during one live session.
+type LiveSession struct {
+	// Header is the upgrade request header.
+	Header http.Header
+	// Start is the decoded "request" field of the start event.
+	Start map[string]interface{}
+	// Texts are the text events received, in order.
+	Texts []string
+	// Stopped reports whether the client sent the stop event.
+	Stopped bool
+}
+
+// LiveServer is a scriptable live TTS WebSocket server.
+//
+// Point a client at it with fishaudio.WithBaseURL(server.URL).
+//
+// Example:
+//
+//	server := fishaudiotest.NewLiveServer(fishaudiotest.LiveScript{
+//	    ChunkSize: 1024,
+//	    Fault:     &fishaudiotest.Fault{Kind: fishaudiotest.FaultDrop, AfterChunks: 3},
+//	})
+//	defer server.Close()
+//
+//	client := fishaudio.NewClient(fishaudio.WithAPIKey("test"), fishaudio.WithBaseURL(server.URL))
+type LiveServer struct {
+	*httptest.Server
+
+	script   LiveScript
+	upgrader websocket.Upgrader
+
+	mu       sync.Mutex
+	sessions []*LiveSession
+}
+
+// NewLiveServer starts a LiveServer that follows script.
+func NewLiveServer(script LiveScript) *LiveServer {
+	s := &LiveServer{
+		script: script,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Sessions returns a snapshot of the sessions handled so far.
+func (s *LiveServer) Sessions() []LiveSession {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]LiveSession, len(s.sessions))
+	for i, session := range s.sessions {
+		out[i] = *session
+		out[i].Texts = append([]string(nil), session.Texts...)
+	}
+	return out
+}
+
+// record applies fn to a session under the server lock.
+func (s *LiveServer) record(session *LiveSession, fn func(*LiveSession)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(session)
+}
+
+// liveEvent is the union of client and server live events.
+type liveEvent struct {
+	Event   string                 `json:"event" msgpack:"event"`
+	Text    string                 `json:"text,omitempty" msgpack:"text,omitempty"`
+	Request map[string]interface{} `json:"request,omitempty" msgpack:"request,omitempty"`
+	Audio   []byte                 `json:"audio,omitempty" msgpack:"audio,omitempty"`
+	Reason  string                 `json:"reason,omitempty" msgpack:"reason,omitempty"`
+	Code    string                 `json:"code,omitempty" msgpack:"code,omitempty"`
+	Message string                 `json:"message,omitempty" msgpack:"message,omitempty"`
+}
+
+// liveConn tracks the frame type and chunk count for one session.
+type liveConn struct {
+	conn        *websocket.Conn
+	messageType int
+	chunks      int
+}
+
+// write encodes evt in the same frame type the client uses.
+func (c *liveConn) write(evt liveEvent) error {
+	var data []byte
+	var err error
+	if c.messageType == websocket.TextMessage {
+		data, err = json.Marshal(evt)
+	} else {
+		data, err = msgpack.Marshal(evt)
+	}
+	if err != nil {
+		return err
+	}
+	return c.conn.WriteMessage(c.messageType, data)
+}
+
+func (s *LiveServer) handle(w http.ResponseWriter, r *http.Request) {
+	if s.script.HandshakeStatus != 0 {
+		http.Error(w, http.StatusText(s.script.HandshakeStatus), s.script.HandshakeStatus)
+		return
+	}
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer func() { _ = conn.Close() }()
+
+	session := &LiveSession{Header: r.Header.Clone()}
+	s.mu.Lock()
+	s.sessions = append(s.sessions, session)
+	s.mu.Unlock()
+
+	c := &liveConn{conn: conn, messageType: websocket.BinaryMessage}
+
+	// A fault after zero chunks fires as soon as the session starts
+	if fault := s.script.Fault; fault != nil && fault.AfterChunks == 0 {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+		s.inject(c, fault)
+		return
+	}
+
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		c.messageType = messageType
+
+		var evt liveEvent
+		if messageType == websocket.TextMessage {
+			err = json.Unmarshal(data, &evt)
+		} else {
+			err = msgpack.Unmarshal(data, &evt)
+		}
+		if err != nil {
+			return
+		}
+
+		switch evt.Event {
+		case "start":
+			s.record(session, func(ls *LiveSession) { ls.Start = evt.Request })
+		case "text":
+			s.record(session, func(ls *LiveSession) { ls.Texts = append(ls.Texts, evt.Text) })
+			if !s.sendAudio(c, evt.Text) {
+				return
+			}
+		case "stop":
+			s.record(session, func(ls *LiveSession) { ls.Stopped = true })
+			_ = c.write(liveEvent{Event: "finish", Reason: "stop"})
+			_ = conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+			return
+		}
+	}
+}
+
+// sendAudio sends the audio for text and reports whether the session continues.
+func (s *LiveServer) sendAudio(c *liveConn, text string) bool {
+	audio := []byte(text)
+	if s.script.Audio != nil {
+		audio = s.script.Audio(text)
+	}
+
+	for len(audio) > 0 {
+		n := len(audio)
+		if s.script.ChunkSize > 0 && n > s.script.ChunkSize {
+			n = s.script.ChunkSize
+		}
+
+		if s.script.ChunkDelay > 0 {
+			time.Sleep(s.script.ChunkDelay)
+		}
+		if err := c.write(liveEvent{Event: "audio", Audio: audio[:n]}); err != nil {
+			return false
+		}
+		audio = audio[n:]
+		c.chunks++
+
+		if fault := s.script.Fault; fault != nil && c.chunks == fault.AfterChunks {
+			s.inject(c, fault)
+			return false
+		}
+	}
+	return true
+}
+
+// inject performs the fault on the connection.
+func (s *LiveServer) inject(c *liveConn, fault *Fault) {
+	switch fault.Kind {
+	case FaultError:
+		_ = c.write(liveEvent{Event: "finish", Reason: "error", Code: fault.Code, Message: fault.Message})
+	case FaultDrop:
+		if tcp, ok := c.conn.UnderlyingConn().(*net.TCPConn); ok {
+			_ = tcp.SetLinger(0)
+		}
+		_ = c.conn.UnderlyingConn().Close()
+	case FaultClose:
+		_ = c.conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(fault.CloseCode, fault.Message), time.Now().Add(time.Second))
+	case FaultStall:
+		c.conn.SetPingHandler(func(string) error { return nil })
+		for {
+			if _, _, err := c.conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}
+}
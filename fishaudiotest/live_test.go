@@ -0,0 +1,145 @@
+package fishaudiotest_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+	"github.com/fishaudio/fish-audio-go/fishaudiotest"
+)
+
+func stream(t *testing.T, server *fishaudiotest.LiveServer, texts ...string) ([]byte, error) {
+	t.Helper()
+	client := fishaudio.NewClient(fishaudio.WithAPIKey("test-key"), fishaudio.WithBaseURL(server.URL))
+
+	textChan := make(chan string, len(texts))
+	for _, text := range texts {
+		textChan <- text
+	}
+	close(textChan)
+
+	opts := fishaudio.DefaultWebSocketOptions()
+	opts.PingInterval = 50 * time.Millisecond
+	opts.PingTimeout = 50 * time.Millisecond
+
+	s, err := client.TTS.StreamWebSocket(context.Background(), textChan, &fishaudio.StreamParams{ReferenceID: "voice-123"}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return s.Collect()
+}
+
+func TestLiveServer_Default(t *testing.T) {
+	server := fishaudiotest.NewLiveServer(fishaudiotest.LiveScript{ChunkSize: 2})
+	defer server.Close()
+
+	audio, err := stream(t, server, "hello", "world")
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if string(audio) != "helloworld" {
+		t.Errorf("audio = %q, want %q", audio, "helloworld")
+	}
+
+	sessions := server.Sessions()
+	if len(sessions) != 1 {
+		t.Fatalf("sessions = %d, want 1", len(sessions))
+	}
+	session := sessions[0]
+	if session.Start["reference_id"] != "voice-123" {
+		t.Errorf("Start[reference_id] = %v, want %q", session.Start["reference_id"], "voice-123")
+	}
+	if len(session.Texts) != 2 || !session.Stopped {
+		t.Errorf("session = %+v, want two texts and stop", session)
+	}
+	if session.Header.Get("Authorization") != "Bearer test-key" {
+		t.Errorf("Authorization = %q", session.Header.Get("Authorization"))
+	}
+}
+
+func TestLiveServer_Faults(t *testing.T) {
+	tests := []struct {
+		name  string
+		fault fishaudiotest.Fault
+		check func(t *testing.T, err error)
+	}{
+		{
+			name:  "error finish",
+			fault: fishaudiotest.Fault{Kind: fishaudiotest.FaultError, AfterChunks: 1, Code: "quota_exceeded", Message: "no credits"},
+			check: func(t *testing.T, err error) {
+				var wsErr *fishaudio.WebSocketError
+				if !errors.As(err, &wsErr) || wsErr.Code != "quota_exceeded" {
+					t.Errorf("err = %v, want WebSocketError with code quota_exceeded", err)
+				}
+			},
+		},
+		{
+			name:  "abrupt drop",
+			fault: fishaudiotest.Fault{Kind: fishaudiotest.FaultDrop, AfterChunks: 2},
+			check: func(t *testing.T, err error) {
+				if err == nil {
+					t.Error("expected error after dropped connection")
+				}
+			},
+		},
+		{
+			name:  "close frame",
+			fault: fishaudiotest.Fault{Kind: fishaudiotest.FaultClose, CloseCode: 1013, Message: "try again"},
+			check: func(t *testing.T, err error) {
+				if err == nil {
+					t.Error("expected close error")
+				}
+			},
+		},
+		{
+			name:  "stall",
+			fault: fishaudiotest.Fault{Kind: fishaudiotest.FaultStall, AfterChunks: 1},
+			check: func(t *testing.T, err error) {
+				var timeoutErr *fishaudio.TimeoutError
+				if !errors.As(err, &timeoutErr) {
+					t.Errorf("err = %v, want *TimeoutError", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fault := tt.fault
+			server := fishaudiotest.NewLiveServer(fishaudiotest.LiveScript{ChunkSize: 1, Fault: &fault})
+			defer server.Close()
+
+			_, err := stream(t, server, "abc")
+			tt.check(t, err)
+		})
+	}
+}
+
+func TestLiveServer_HandshakeStatus(t *testing.T) {
+	server := fishaudiotest.NewLiveServer(fishaudiotest.LiveScript{HandshakeStatus: 401})
+	defer server.Close()
+
+	if _, err := stream(t, server, "hi"); err == nil {
+		t.Fatal("expected dial error")
+	}
+}
+
+func TestLiveServer_CustomAudio(t *testing.T) {
+	server := fishaudiotest.NewLiveServer(fishaudiotest.LiveScript{
+		Audio:      func(text string) []byte { return bytes.Repeat([]byte{0}, 10) },
+		ChunkSize:  4,
+		ChunkDelay: time.Millisecond,
+	})
+	defer server.Close()
+
+	audio, err := stream(t, server, "x")
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if len(audio) != 10 {
+		t.Errorf("audio length = %d, want 10", len(audio))
+	}
+}
@@ -0,0 +1,146 @@
+package fishaudio
+
+import "encoding/binary"
+
+const (
+	// muLawBias is added to magnitudes before μ-law encoding so every
+	// segment starts at a power of two.
+	muLawBias = 0x84
+	// muLawClip is the largest magnitude that encodes without overflow.
+	muLawClip = 32635
+)
+
+// muLawTable and aLawTable decode each G.711 byte to a 16-bit sample.
+var muLawTable, aLawTable = g711Tables()
+
+func g711Tables() (mu, a [256]int16) {
+	for i := range mu {
+		mu[i] = decodeMuLawSample(byte(i))
+		a[i] = decodeALawSample(byte(i))
+	}
+	return mu, a
+}
+
+// EncodeMuLaw encodes 16-bit little-endian PCM as G.711 μ-law, one byte
+// per sample. μ-law is the telephone codec of North America and Japan,
+// carried by SIP and Twilio Media Streams. The sample rate is unchanged;
+// telephony expects 8 kHz. A trailing odd byte is dropped.
+//
+// Example:
+//
+//	pcm, err := client.TTS.Convert(ctx, &fishaudio.ConvertParams{
+//	    Text:   "Thanks for calling.",
+//	    Format: fishaudio.AudioFormatPCM,
+//	    Config: &fishaudio.TTSConfig{SampleRate: 16000},
+//	})
+//	ulaw := fishaudio.EncodeMuLaw(fishaudio.ResamplePCM(pcm, 1, 16000, 8000))
+func EncodeMuLaw(pcm []byte) []byte {
+	dst := make([]byte, len(pcm)/2)
+	for i := range dst {
+		dst[i] = encodeMuLawSample(int16(binary.LittleEndian.Uint16(pcm[2*i:])))
+	}
+	return dst
+}
+
+// DecodeMuLaw decodes G.711 μ-law to 16-bit little-endian PCM.
+func DecodeMuLaw(ulaw []byte) []byte {
+	dst := make([]byte, len(ulaw)*2)
+	for i, b := range ulaw {
+		binary.LittleEndian.PutUint16(dst[2*i:], uint16(muLawTable[b]))
+	}
+	return dst
+}
+
+// EncodeALaw encodes 16-bit little-endian PCM as G.711 A-law, one byte per
+// sample. A-law is the telephone codec outside North America and Japan.
+// The sample rate is unchanged; telephony expects 8 kHz. A trailing odd
+// byte is dropped.
+func EncodeALaw(pcm []byte) []byte {
+	dst := make([]byte, len(pcm)/2)
+	for i := range dst {
+		dst[i] = encodeALawSample(int16(binary.LittleEndian.Uint16(pcm[2*i:])))
+	}
+	return dst
+}
+
+// DecodeALaw decodes G.711 A-law to 16-bit little-endian PCM.
+func DecodeALaw(alaw []byte) []byte {
+	dst := make([]byte, len(alaw)*2)
+	for i, b := range alaw {
+		binary.LittleEndian.PutUint16(dst[2*i:], uint16(aLawTable[b]))
+	}
+	return dst
+}
+
+// encodeMuLawSample encodes one sample: a sign bit, a 3-bit segment, and a
+// 4-bit step within the segment, all inverted.
+func encodeMuLawSample(s int16) byte {
+	v := int(s)
+	var sign byte
+	if v < 0 {
+		v = -v
+		sign = 0x80
+	}
+	if v > muLawClip {
+		v = muLawClip
+	}
+	v += muLawBias
+
+	exp := 7
+	for mask := 0x4000; v&mask == 0 && exp > 0; mask >>= 1 {
+		exp--
+	}
+	mantissa := byte(v>>(exp+3)) & 0x0f
+	return ^(sign | byte(exp)<<4 | mantissa)
+}
+
+func decodeMuLawSample(b byte) int16 {
+	b = ^b
+	exp := (b >> 4) & 0x07
+	v := (int(b&0x0f)<<3 + muLawBias) << exp
+	v -= muLawBias
+	if b&0x80 != 0 {
+		return int16(-v)
+	}
+	return int16(v)
+}
+
+// encodeALawSample encodes one sample of the 13-bit A-law range: a sign
+// bit, a 3-bit segment, and a 4-bit step, with even bits inverted.
+func encodeALawSample(s int16) byte {
+	v := int(s) >> 3
+	mask := byte(0xd5)
+	if v < 0 {
+		mask = 0x55
+		v = -v - 1
+	}
+
+	seg := 0
+	for end := 0x1f; seg < 8 && v > end; end = end<<1 | 1 {
+		seg++
+	}
+	if seg == 8 {
+		return 0x7f ^ mask
+	}
+	shift := seg
+	if seg < 2 {
+		shift = 1
+	}
+	return (byte(seg)<<4 | byte(v>>shift)&0x0f) ^ mask
+}
+
+func decodeALawSample(b byte) int16 {
+	b ^= 0x55
+	v := int(b&0x0f)<<4 + 8
+	switch seg := (b >> 4) & 0x07; seg {
+	case 0:
+	case 1:
+		v += 0x100
+	default:
+		v = (v + 0x100) << (seg - 1)
+	}
+	if b&0x80 != 0 {
+		return int16(v)
+	}
+	return int16(-v)
+}
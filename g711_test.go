@@ -0,0 +1,103 @@
+package fishaudio
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMuLaw_KnownValues(t *testing.T) {
+	tests := []struct {
+		sample int16
+		code   byte
+	}{
+		{0, 0xff},
+		{-1, 0x7f},
+		{32767, 0x80},
+		{-32768, 0x00},
+		{1000, 0xce},
+		{-1000, 0x4e},
+	}
+	for _, tt := range tests {
+		if got := EncodeMuLaw(samples16(tt.sample))[0]; got != tt.code {
+			t.Errorf("EncodeMuLaw(%d) = 0x%02x, want 0x%02x", tt.sample, got, tt.code)
+		}
+	}
+	if got := decode16(DecodeMuLaw([]byte{0x00, 0x80, 0xff})); got[0] != -32124 || got[1] != 32124 || got[2] != 0 {
+		t.Errorf("DecodeMuLaw() = %v, want [-32124 32124 0]", got)
+	}
+}
+
+func TestALaw_KnownValues(t *testing.T) {
+	tests := []struct {
+		sample int16
+		code   byte
+	}{
+		{0, 0xd5},
+		{-1, 0x55},
+		{32767, 0xaa},
+		{-32768, 0x2a},
+		{1000, 0xfa},
+		{-1000, 0x7a},
+	}
+	for _, tt := range tests {
+		if got := EncodeALaw(samples16(tt.sample))[0]; got != tt.code {
+			t.Errorf("EncodeALaw(%d) = 0x%02x, want 0x%02x", tt.sample, got, tt.code)
+		}
+	}
+	if got := decode16(DecodeALaw([]byte{0x2a, 0xaa, 0xd5})); got[0] != -32256 || got[1] != 32256 || got[2] != 8 {
+		t.Errorf("DecodeALaw() = %v, want [-32256 32256 8]", got)
+	}
+}
+
+func TestG711_RoundTrip(t *testing.T) {
+	// Every code decodes to a sample that encodes back to it, except
+	// μ-law's negative zero
+	for i := 0; i < 256; i++ {
+		b := byte(i)
+		if got := EncodeALaw(DecodeALaw([]byte{b}))[0]; got != b {
+			t.Errorf("A-law 0x%02x round-trips to 0x%02x", b, got)
+		}
+		if b == 0x7f {
+			continue
+		}
+		if got := EncodeMuLaw(DecodeMuLaw([]byte{b}))[0]; got != b {
+			t.Errorf("μ-law 0x%02x round-trips to 0x%02x", b, got)
+		}
+	}
+}
+
+func TestG711_QuantizationError(t *testing.T) {
+	// Logarithmic coding keeps the error a small fraction of the sample
+	var v []int16
+	for s := -32768; s <= 32767; s += 7 {
+		v = append(v, int16(s))
+	}
+	pcm := samples16(v...)
+
+	codecs := map[string]func([]byte) []byte{
+		"μ-law": func(p []byte) []byte { return DecodeMuLaw(EncodeMuLaw(p)) },
+		"A-law": func(p []byte) []byte { return DecodeALaw(EncodeALaw(p)) },
+	}
+	for name, roundTrip := range codecs {
+		got := decode16(roundTrip(pcm))
+		if len(got) != len(v) {
+			t.Fatalf("%s: %d samples, want %d", name, len(got), len(v))
+		}
+		for i, s := range v {
+			diff := math.Abs(float64(got[i]) - float64(s))
+			if diff > 1100 || (math.Abs(float64(s)) > 256 && diff > math.Abs(float64(s))/16+16) {
+				t.Fatalf("%s: %d decodes to %d", name, s, got[i])
+			}
+		}
+	}
+}
+
+func TestG711_OddLength(t *testing.T) {
+	pcm := append(samples16(100, -100), 0x01)
+	if got := len(EncodeMuLaw(pcm)); got != 2 {
+		t.Errorf("len(EncodeMuLaw()) = %d, want 2", got)
+	}
+	if got := len(EncodeALaw(pcm)); got != 2 {
+		t.Errorf("len(EncodeALaw()) = %d, want 2", got)
+	}
+}
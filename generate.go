@@ -0,0 +1,5 @@
+package fishaudio
+
+// Types described by the API spec are generated into types_gen.go. To add
+// a field or enum value, edit api/openapi.json and run go generate.
+//go:generate go run ./internal/gentypes -spec api/openapi.json -out types_gen.go
@@ -5,6 +5,8 @@ go 1.22
 require (
 	github.com/gorilla/websocket v1.5.3
 	github.com/vmihailenco/msgpack/v5 v5.4.1
+	golang.org/x/sync v0.10.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
@@ -0,0 +1,46 @@
+package grpcgateway
+
+import (
+	"context"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+	"github.com/fishaudio/fish-audio-go/grpcgateway/gatewaypb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Transcribe implements gatewaypb.GatewayServer.
+func (s *Server) Transcribe(ctx context.Context, req *gatewaypb.TranscribeRequest) (*gatewaypb.TranscribeResponse, error) {
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	params := &fishaudio.TranscribeParams{Language: fishaudio.Language(req.GetLanguage())}
+	if req.GetIgnoreTimestamps() {
+		params.IncludeTimestamps = new(bool)
+	}
+
+	var result *fishaudio.ASRResponse
+	var err error
+	switch source := req.GetSource().(type) {
+	case *gatewaypb.TranscribeRequest_Audio:
+		result, err = s.client.ASR.Transcribe(ctx, source.Audio, params)
+	case *gatewaypb.TranscribeRequest_AudioUrl:
+		result, err = s.client.ASR.TranscribeURL(ctx, source.AudioUrl, params)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "audio or audio_url is required")
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &gatewaypb.TranscribeResponse{
+		Text:       result.Text,
+		DurationMs: result.Duration,
+		Usage:      toUsage(result.Usage),
+	}
+	for _, seg := range result.Segments {
+		resp.Segments = append(resp.Segments, &gatewaypb.Segment{Text: seg.Text, Start: seg.Start, End: seg.End})
+	}
+	return resp, nil
+}
@@ -0,0 +1,75 @@
+package grpcgateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+	"github.com/fishaudio/fish-audio-go/grpcgateway/gatewaypb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_Transcribe(t *testing.T) {
+	var got map[string]string
+	gateway := newTestGateway(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Errorf("ParseMultipartForm error = %v", err)
+			return
+		}
+		got = map[string]string{
+			"audio_url":         r.FormValue("audio_url"),
+			"language":          r.FormValue("language"),
+			"ignore_timestamps": r.FormValue("ignore_timestamps"),
+		}
+		if file, _, err := r.FormFile("audio"); err == nil {
+			data, _ := io.ReadAll(file)
+			got["audio"] = string(data)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(fishaudio.ASRResponse{
+			Text:     "hello",
+			Duration: 1500,
+			Segments: []fishaudio.ASRSegment{{Text: "hello", Start: 0.5, End: 1}},
+		})
+	}, nil)
+
+	resp, err := gateway.Transcribe(context.Background(), &gatewaypb.TranscribeRequest{
+		Source:   &gatewaypb.TranscribeRequest_Audio{Audio: []byte("audio data")},
+		Language: "en",
+	})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if got["audio"] != "audio data" || got["language"] != "en" {
+		t.Errorf("form = %v", got)
+	}
+	if resp.GetText() != "hello" || resp.GetDurationMs() != 1500 || len(resp.GetSegments()) != 1 || resp.GetSegments()[0].GetStart() != 0.5 {
+		t.Errorf("resp = %v", resp)
+	}
+
+	_, err = gateway.Transcribe(context.Background(), &gatewaypb.TranscribeRequest{
+		Source:           &gatewaypb.TranscribeRequest_AudioUrl{AudioUrl: "https://example.com/a.mp3"},
+		IgnoreTimestamps: true,
+	})
+	if err != nil {
+		t.Fatalf("Transcribe(url) error = %v", err)
+	}
+	if got["audio_url"] != "https://example.com/a.mp3" || got["ignore_timestamps"] != "true" {
+		t.Errorf("form = %v", got)
+	}
+}
+
+func TestServer_Transcribe_NoSource(t *testing.T) {
+	gateway := newTestGateway(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected API request")
+	}, nil)
+
+	_, err := gateway.Transcribe(context.Background(), &gatewaypb.TranscribeRequest{Language: "en"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Transcribe() err = %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,5 @@
+// Package gatewaypb contains the generated protobuf and gRPC code for the
+// Fish Audio gateway service defined in proto/fishaudio/gateway/v1.
+package gatewaypb
+
+//go:generate protoc -I ../proto --go_out=. --go_opt=module=github.com/fishaudio/fish-audio-go/grpcgateway/gatewaypb --go-grpc_out=. --go-grpc_opt=module=github.com/fishaudio/fish-audio-go/grpcgateway/gatewaypb fishaudio/gateway/v1/gateway.proto
@@ -0,0 +1,136 @@
+// Package grpcgateway serves the Fish Audio SDK over gRPC, so services in
+// any language can synthesize speech, transcribe audio, and list voices
+// through one gateway process that holds the Fish Audio API key.
+//
+// The service is defined in proto/fishaudio/gateway/v1/gateway.proto;
+// clients in other languages generate their stubs from it. The package
+// lives in its own module so the core SDK doesn't depend on gRPC.
+//
+// Example:
+//
+//	gateway := grpcgateway.NewServer(fishaudio.NewClient(), &grpcgateway.Options{
+//	    Authenticate: grpcgateway.BearerTokens(os.Getenv("GATEWAY_TOKEN")),
+//	})
+//	server := grpc.NewServer()
+//	gatewaypb.RegisterGatewayServer(server, gateway)
+//	lis, _ := net.Listen("tcp", ":50051")
+//	err := server.Serve(lis)
+package grpcgateway
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+	"github.com/fishaudio/fish-audio-go/grpcgateway/gatewaypb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Options configures a Server.
+type Options struct {
+	// Authenticate is called before every RPC; a non-nil error rejects the
+	// call and is returned to the caller. Return a status error, e.g. with
+	// codes.Unauthenticated, to control the code. Default: every call is
+	// allowed, so only use the default on a trusted network.
+	Authenticate func(ctx context.Context) error
+}
+
+// Server implements gatewaypb.GatewayServer on top of a fishaudio.Client.
+type Server struct {
+	gatewaypb.UnimplementedGatewayServer
+
+	client *fishaudio.Client
+	opts   Options
+}
+
+// NewServer returns a gateway server that makes requests with client.
+func NewServer(client *fishaudio.Client, opts *Options) *Server {
+	s := &Server{client: client}
+	if opts != nil {
+		s.opts = *opts
+	}
+	return s
+}
+
+// BearerTokens returns an Authenticate function that accepts calls whose
+// "authorization" metadata is "Bearer <token>" for one of tokens.
+func BearerTokens(tokens ...string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, value := range md.Get("authorization") {
+			token, ok := strings.CutPrefix(value, "Bearer ")
+			if !ok {
+				continue
+			}
+			for _, want := range tokens {
+				if want != "" && subtle.ConstantTimeCompare([]byte(token), []byte(want)) == 1 {
+					return nil
+				}
+			}
+		}
+		return status.Error(codes.Unauthenticated, "missing or invalid bearer token")
+	}
+}
+
+// authenticate runs the configured Authenticate function, if any.
+func (s *Server) authenticate(ctx context.Context) error {
+	if s.opts.Authenticate == nil {
+		return nil
+	}
+	return s.opts.Authenticate(ctx)
+}
+
+// toStatus converts an SDK error to a gRPC status error. Errors caused by
+// the gateway's own credentials are reported as internal, since the caller
+// can't fix them.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var (
+		authErr       *fishaudio.AuthenticationError
+		permissionErr *fishaudio.PermissionError
+		notFoundErr   *fishaudio.NotFoundError
+		rateLimitErr  *fishaudio.RateLimitError
+		validationErr *fishaudio.ValidationError
+		serverErr     *fishaudio.ServerError
+		timeoutErr    *fishaudio.TimeoutError
+	)
+	code := codes.Unknown
+	switch {
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &timeoutErr):
+		code = codes.DeadlineExceeded
+	case errors.As(err, &authErr):
+		code = codes.Internal
+	case errors.As(err, &permissionErr):
+		code = codes.PermissionDenied
+	case errors.As(err, &notFoundErr):
+		code = codes.NotFound
+	case errors.As(err, &rateLimitErr), errors.Is(err, fishaudio.ErrBudgetExceeded):
+		code = codes.ResourceExhausted
+	case errors.As(err, &validationErr):
+		code = codes.InvalidArgument
+	case errors.As(err, &serverErr):
+		code = codes.Unavailable
+	}
+	return status.Error(code, err.Error())
+}
+
+// toUsage converts request usage to its message form.
+func toUsage(u *fishaudio.RequestUsage) *gatewaypb.Usage {
+	if u == nil {
+		return nil
+	}
+	return &gatewaypb.Usage{
+		Characters:   int64(u.Characters),
+		MicroCredits: int64(u.Credits),
+		Model:        u.Model,
+	}
+}
@@ -0,0 +1,98 @@
+package grpcgateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+	"github.com/fishaudio/fish-audio-go/grpcgateway/gatewaypb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestGateway serves a gateway backed by a fake Fish Audio API and
+// returns a client connected to it.
+func newTestGateway(t *testing.T, api http.HandlerFunc, opts *Options) gatewaypb.GatewayClient {
+	t.Helper()
+	backend := httptest.NewServer(api)
+	t.Cleanup(backend.Close)
+
+	client := fishaudio.NewClient(fishaudio.WithAPIKey("test-key"), fishaudio.WithBaseURL(backend.URL))
+	server := grpc.NewServer()
+	gatewaypb.RegisterGatewayServer(server, NewServer(client, opts))
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return gatewaypb.NewGatewayClient(conn)
+}
+
+func TestBearerTokens(t *testing.T) {
+	api := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"total": 0, "items": []}`))
+	}
+	gateway := newTestGateway(t, api, &Options{Authenticate: BearerTokens("secret", "")})
+
+	tests := []struct {
+		auth string
+		want codes.Code
+	}{
+		{"Bearer secret", codes.OK},
+		{"Bearer wrong", codes.Unauthenticated},
+		{"secret", codes.Unauthenticated},
+		{"Bearer ", codes.Unauthenticated},
+		{"", codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		ctx := context.Background()
+		if tt.auth != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", tt.auth)
+		}
+		_, err := gateway.ListVoices(ctx, &gatewaypb.ListVoicesRequest{})
+		if got := status.Code(err); got != tt.want {
+			t.Errorf("authorization %q: code = %v, want %v", tt.auth, got, tt.want)
+		}
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{&fishaudio.AuthenticationError{APIError: &fishaudio.APIError{StatusCode: 401}}, codes.Internal},
+		{&fishaudio.PermissionError{APIError: &fishaudio.APIError{StatusCode: 403}}, codes.PermissionDenied},
+		{&fishaudio.NotFoundError{APIError: &fishaudio.APIError{StatusCode: 404}}, codes.NotFound},
+		{&fishaudio.ValidationError{APIError: &fishaudio.APIError{StatusCode: 422}}, codes.InvalidArgument},
+		{&fishaudio.RateLimitError{APIError: &fishaudio.APIError{StatusCode: 429}}, codes.ResourceExhausted},
+		{&fishaudio.ServerError{APIError: &fishaudio.APIError{StatusCode: 503}}, codes.Unavailable},
+		{&fishaudio.BudgetExceededError{Resource: "credits"}, codes.ResourceExhausted},
+		{fmt.Errorf("read: %w", context.DeadlineExceeded), codes.DeadlineExceeded},
+		{context.Canceled, codes.Canceled},
+		{status.Error(codes.Aborted, "aborted"), codes.Aborted},
+		{errors.New("boom"), codes.Unknown},
+	}
+	for _, tt := range tests {
+		if got := status.Code(toStatus(tt.err)); got != tt.want {
+			t.Errorf("toStatus(%T) code = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package grpcgateway
+
+import (
+	"context"
+	"io"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+	"github.com/fishaudio/fish-audio-go/grpcgateway/gatewaypb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// chunkSize is the most audio sent in one AudioChunk.
+const chunkSize = 16 << 10
+
+// Synthesize implements gatewaypb.GatewayServer.
+func (s *Server) Synthesize(ctx context.Context, req *gatewaypb.SynthesizeRequest) (*gatewaypb.SynthesizeResponse, error) {
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	stream, err := s.stream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	audio, err := stream.Collect()
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &gatewaypb.SynthesizeResponse{Audio: audio, Usage: toUsage(stream.Usage())}, nil
+}
+
+// SynthesizeStream implements gatewaypb.GatewayServer.
+func (s *Server) SynthesizeStream(req *gatewaypb.SynthesizeRequest, out gatewaypb.Gateway_SynthesizeStreamServer) error {
+	ctx := out.Context()
+	if err := s.authenticate(ctx); err != nil {
+		return err
+	}
+	stream, err := s.stream(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stream.Close() }()
+
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := stream.Read(buf)
+		if n > 0 {
+			if err := out.Send(&gatewaypb.AudioChunk{Data: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return toStatus(err)
+		}
+	}
+}
+
+// stream starts the TTS request described by req.
+func (s *Server) stream(ctx context.Context, req *gatewaypb.SynthesizeRequest) (*fishaudio.AudioStream, error) {
+	if req.GetText() == "" {
+		return nil, status.Error(codes.InvalidArgument, "text is required")
+	}
+	stream, err := s.client.TTS.Stream(ctx, &fishaudio.StreamParams{
+		Text:        req.GetText(),
+		ReferenceID: req.GetReferenceId(),
+		Format:      fishaudio.AudioFormat(req.GetFormat()),
+		Model:       fishaudio.Model(req.GetModel()),
+		Latency:     fishaudio.LatencyMode(req.GetLatency()),
+		Speed:       req.GetSpeed(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return stream, nil
+}
@@ -0,0 +1,93 @@
+package grpcgateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/fishaudio/fish-audio-go/grpcgateway/gatewaypb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func ttsAPI(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["text"] != "Hello" || body["reference_id"] != "voice-1" || body["format"] != "wav" {
+			t.Errorf("body = %v", body)
+		}
+		if got := r.Header.Get("model"); got != "s1" {
+			t.Errorf("model = %q, want s1", got)
+		}
+		w.Header().Set("X-Usage-Characters", "5")
+		w.Header().Set("X-Usage-Credits", "0.5")
+		_, _ = w.Write([]byte("chunk1"))
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte("chunk2"))
+	}
+}
+
+var synthesizeRequest = &gatewaypb.SynthesizeRequest{Text: "Hello", ReferenceId: "voice-1", Format: "wav", Model: "s1"}
+
+func TestServer_Synthesize(t *testing.T) {
+	gateway := newTestGateway(t, ttsAPI(t), nil)
+
+	resp, err := gateway.Synthesize(context.Background(), synthesizeRequest)
+	if err != nil {
+		t.Fatalf("Synthesize() error = %v", err)
+	}
+	if string(resp.GetAudio()) != "chunk1chunk2" {
+		t.Errorf("audio = %q", resp.GetAudio())
+	}
+	if u := resp.GetUsage(); u.GetCharacters() != 5 || u.GetMicroCredits() != 500_000 {
+		t.Errorf("usage = %v", u)
+	}
+}
+
+func TestServer_SynthesizeStream(t *testing.T) {
+	gateway := newTestGateway(t, ttsAPI(t), nil)
+
+	stream, err := gateway.SynthesizeStream(context.Background(), synthesizeRequest)
+	if err != nil {
+		t.Fatalf("SynthesizeStream() error = %v", err)
+	}
+	var audio bytes.Buffer
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		audio.Write(chunk.GetData())
+	}
+	if audio.String() != "chunk1chunk2" {
+		t.Errorf("audio = %q", audio.String())
+	}
+}
+
+func TestServer_Synthesize_Errors(t *testing.T) {
+	gateway := newTestGateway(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}, nil)
+
+	if _, err := gateway.Synthesize(context.Background(), &gatewaypb.SynthesizeRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("empty text: err = %v, want InvalidArgument", err)
+	}
+	if _, err := gateway.Synthesize(context.Background(), &gatewaypb.SynthesizeRequest{Text: "Hi"}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("rate limited: err = %v, want ResourceExhausted", err)
+	}
+
+	stream, err := gateway.SynthesizeStream(context.Background(), &gatewaypb.SynthesizeRequest{Text: "Hi"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("stream rate limited: err = %v, want ResourceExhausted", err)
+	}
+}
@@ -0,0 +1,46 @@
+package grpcgateway
+
+import (
+	"context"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+	"github.com/fishaudio/fish-audio-go/grpcgateway/gatewaypb"
+)
+
+// ListVoices implements gatewaypb.GatewayServer.
+func (s *Server) ListVoices(ctx context.Context, req *gatewaypb.ListVoicesRequest) (*gatewaypb.ListVoicesResponse, error) {
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	page, err := s.client.Voices.List(ctx, &fishaudio.ListVoicesParams{
+		PageSize:   int(req.GetPageSize()),
+		PageNumber: int(req.GetPageNumber()),
+		Title:      req.GetTitle(),
+		Tags:       req.GetTags(),
+		SelfOnly:   req.GetSelfOnly(),
+		Language:   req.GetLanguages(),
+		Query:      req.GetQuery(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &gatewaypb.ListVoicesResponse{Total: int64(page.Total)}
+	for _, v := range page.Items {
+		voice := &gatewaypb.Voice{
+			Id:          v.ID,
+			Title:       v.Title,
+			Description: v.Description,
+			Languages:   v.Languages,
+			Tags:        v.Tags,
+			State:       string(v.State),
+			Visibility:  string(v.Visibility),
+		}
+		if !v.CreatedAt.IsZero() || v.CreatedAt.Raw != "" {
+			voice.CreatedAt = v.CreatedAt.String()
+		}
+		resp.Voices = append(resp.Voices, voice)
+	}
+	return resp, nil
+}
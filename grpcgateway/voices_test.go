@@ -0,0 +1,49 @@
+package grpcgateway
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/fishaudio/fish-audio-go/grpcgateway/gatewaypb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_ListVoices(t *testing.T) {
+	gateway := newTestGateway(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("page_size") != "5" || q.Get("self") != "true" || q.Get("title") != "Narrator" {
+			t.Errorf("query = %v", q)
+		}
+		_, _ = w.Write([]byte(`{"total": 7, "items": [
+			{"_id": "v1", "title": "Narrator", "languages": ["en"], "state": "trained", "created_at": "2024-01-02T03:04:05Z"},
+			{"_id": "v2", "title": "Narrator 2"}
+		]}`))
+	}, nil)
+
+	resp, err := gateway.ListVoices(context.Background(), &gatewaypb.ListVoicesRequest{PageSize: 5, SelfOnly: true, Title: "Narrator"})
+	if err != nil {
+		t.Fatalf("ListVoices() error = %v", err)
+	}
+	if resp.GetTotal() != 7 || len(resp.GetVoices()) != 2 {
+		t.Fatalf("resp = %v", resp)
+	}
+	v := resp.GetVoices()[0]
+	if v.GetId() != "v1" || v.GetState() != "trained" || v.GetLanguages()[0] != "en" || v.GetCreatedAt() != "2024-01-02T03:04:05Z" {
+		t.Errorf("voice = %v", v)
+	}
+	if got := resp.GetVoices()[1].GetCreatedAt(); got != "" {
+		t.Errorf("missing created_at = %q, want empty", got)
+	}
+}
+
+func TestServer_ListVoices_NotFound(t *testing.T) {
+	gateway := newTestGateway(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}, nil)
+
+	if _, err := gateway.ListVoices(context.Background(), &gatewaypb.ListVoicesRequest{}); status.Code(err) != codes.NotFound {
+		t.Errorf("ListVoices() err = %v, want NotFound", err)
+	}
+}
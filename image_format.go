@@ -0,0 +1,100 @@
+package fishaudio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	_ "image/jpeg" // register JPEG for image.DecodeConfig
+	_ "image/png"  // register PNG for image.DecodeConfig
+)
+
+// Cover image limits checked before upload.
+const (
+	// MaxCoverImageSize is the largest cover image accepted, in bytes.
+	MaxCoverImageSize = 5 << 20
+	// MaxCoverImageDimension is the largest accepted width or height, in pixels.
+	MaxCoverImageDimension = 4096
+)
+
+// detectImageFormat identifies a JPEG, PNG, or WebP image from its leading
+// bytes and returns its file extension and MIME type, or empty strings if
+// unknown.
+func detectImageFormat(data []byte) (ext, contentType string) {
+	switch {
+	case bytes.HasPrefix(data, []byte("\xFF\xD8\xFF")):
+		return "jpg", "image/jpeg"
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return "png", "image/png"
+	case len(data) >= 12 && bytes.Equal(data[0:4], []byte("RIFF")) && bytes.Equal(data[8:12], []byte("WEBP")):
+		return "webp", "image/webp"
+	}
+	return "", ""
+}
+
+// coverImageFile checks a cover image against the upload limits and returns
+// the file description to upload it with.
+func coverImageFile(data []byte) (formFile, error) {
+	if len(data) > MaxCoverImageSize {
+		return formFile{}, newValidationError("cover image is %d bytes, exceeding the %d byte limit", len(data), MaxCoverImageSize)
+	}
+
+	ext, contentType := detectImageFormat(data)
+	if ext == "" {
+		return formFile{}, newValidationError("cover image must be JPEG, PNG, or WebP")
+	}
+
+	width, height, ok := imageSize(ext, data)
+	if !ok {
+		return formFile{}, newValidationError("cover image is not a valid %s file", ext)
+	}
+	if width > MaxCoverImageDimension || height > MaxCoverImageDimension {
+		return formFile{}, newValidationError("cover image is %dx%d, exceeding the %dx%d limit",
+			width, height, MaxCoverImageDimension, MaxCoverImageDimension)
+	}
+
+	return formFile{name: "cover." + ext, contentType: contentType}, nil
+}
+
+// imageSize returns the pixel dimensions of an image of the detected format.
+func imageSize(ext string, data []byte) (width, height int, ok bool) {
+	if ext == "webp" {
+		return webpSize(data)
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, false
+	}
+	return cfg.Width, cfg.Height, true
+}
+
+// webpSize reads the dimensions from the first chunk of a WebP file.
+func webpSize(data []byte) (width, height int, ok bool) {
+	if len(data) < 30 {
+		return 0, 0, false
+	}
+	chunk := data[12:]
+	switch string(chunk[0:4]) {
+	case "VP8 ":
+		// Lossy: 3-byte frame tag, start code 9D 01 2A, then 14-bit sizes
+		if !bytes.Equal(chunk[11:14], []byte{0x9D, 0x01, 0x2A}) {
+			return 0, 0, false
+		}
+		width = int(binary.LittleEndian.Uint16(chunk[14:16]) & 0x3FFF)
+		height = int(binary.LittleEndian.Uint16(chunk[16:18]) & 0x3FFF)
+	case "VP8L":
+		// Lossless: signature 2F, then 14-bit width-1 and height-1
+		if chunk[8] != 0x2F {
+			return 0, 0, false
+		}
+		bits := binary.LittleEndian.Uint32(chunk[9:13])
+		width = int(bits&0x3FFF) + 1
+		height = int(bits>>14&0x3FFF) + 1
+	case "VP8X":
+		// Extended: 24-bit canvas width-1 and height-1 after 4 flag bytes
+		width = int(uint32(chunk[12])|uint32(chunk[13])<<8|uint32(chunk[14])<<16) + 1
+		height = int(uint32(chunk[15])|uint32(chunk[16])<<8|uint32(chunk[17])<<16) + 1
+	default:
+		return 0, 0, false
+	}
+	return width, height, true
+}
@@ -0,0 +1,130 @@
+package fishaudio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// testPNG returns a PNG image of the given size.
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// testJPEG returns a JPEG image of the given size.
+func testJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)), nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// testWebP returns the header of a WebP file with the given first chunk.
+func testWebP(chunk string, body []byte) []byte {
+	data := []byte("RIFF\x00\x00\x00\x00WEBP" + chunk + "\x00\x00\x00\x00")
+	data = append(data, body...)
+	for len(data) < 30 {
+		data = append(data, 0)
+	}
+	return data
+}
+
+func TestDetectImageFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		wantExt  string
+		wantType string
+	}{
+		{"png", testPNG(t, 1, 1), "png", "image/png"},
+		{"jpeg", testJPEG(t, 1, 1), "jpg", "image/jpeg"},
+		{"webp", testWebP("VP8X", nil), "webp", "image/webp"},
+		{"wav is not an image", []byte("RIFF\x00\x00\x00\x00WAVEfmt "), "", ""},
+		{"unknown", []byte("GIF89a"), "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ext, contentType := detectImageFormat(tt.data)
+			if ext != tt.wantExt || contentType != tt.wantType {
+				t.Errorf("detectImageFormat() = %q, %q, want %q, %q", ext, contentType, tt.wantExt, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestWebPSize(t *testing.T) {
+	lossy := []byte{0, 0, 0, 0x9D, 0x01, 0x2A}
+	lossy = binary.LittleEndian.AppendUint16(lossy, 640)
+	lossy = binary.LittleEndian.AppendUint16(lossy, 480)
+
+	lossless := []byte{0x2F}
+	lossless = binary.LittleEndian.AppendUint32(lossless, uint32(799)|uint32(599)<<14)
+
+	extended := []byte{0, 0, 0, 0, 0x3F, 0x1F, 0x00, 0x0F, 0x00, 0x00} // 8000x16
+
+	tests := []struct {
+		name          string
+		data          []byte
+		width, height int
+		ok            bool
+	}{
+		{"lossy", testWebP("VP8 ", lossy), 640, 480, true},
+		{"lossless", testWebP("VP8L", lossless), 800, 600, true},
+		{"extended", testWebP("VP8X", extended), 8000, 16, true},
+		{"bad lossy start code", testWebP("VP8 ", []byte{0, 0, 0, 1, 2, 3}), 0, 0, false},
+		{"truncated", []byte("RIFF\x00\x00\x00\x00WEBPVP8X"), 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height, ok := webpSize(tt.data)
+			if width != tt.width || height != tt.height || ok != tt.ok {
+				t.Errorf("webpSize() = %d, %d, %v, want %d, %d, %v", width, height, ok, tt.width, tt.height, tt.ok)
+			}
+		})
+	}
+}
+
+func TestCoverImageFile(t *testing.T) {
+	file, err := coverImageFile(testJPEG(t, 32, 32))
+	if err != nil {
+		t.Fatalf("coverImageFile() error = %v", err)
+	}
+	if file.name != "cover.jpg" || file.contentType != "image/jpeg" {
+		t.Errorf("coverImageFile() = %+v, want cover.jpg as image/jpeg", file)
+	}
+}
+
+func TestCoverImageFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"unsupported format", []byte("GIF89a....")},
+		{"too many pixels", testPNG(t, MaxCoverImageDimension+1, 1)},
+		{"corrupt png", []byte("\x89PNG\r\n\x1a\ngarbage")},
+		{"too large", append(testPNG(t, 1, 1), make([]byte, MaxCoverImageSize)...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := coverImageFile(tt.data)
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("coverImageFile() error = %v, want *ValidationError", err)
+			}
+			if validationErr.StatusCode != 0 {
+				t.Errorf("StatusCode = %d, want 0 for a client-side error", validationErr.StatusCode)
+			}
+		})
+	}
+}
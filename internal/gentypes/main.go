@@ -0,0 +1,308 @@
+// Command gentypes generates the SDK's request and response types from the
+// schemas of an OpenAPI document, so new API fields land by editing the
+// spec instead of by hand. Service methods stay hand-written on top of the
+// generated types.
+//
+// It supports the subset of OpenAPI the API uses: string enums, and
+// objects whose properties are scalars, date-times, arrays, or references
+// to other schemas. Properties not listed as required are omitempty, and
+// nullable properties are pointers. These extensions are recognized:
+//
+//   - x-go-name: the Go name of a schema or property
+//   - x-enum-varnames: the constant names of an enum's values
+//   - x-enum-descriptions: the doc comments of an enum's values
+//   - x-go-extra: collect unknown JSON fields of an object in Extra
+//
+// Usage:
+//
+//	go run ./internal/gentypes -spec api/openapi.json -out types_gen.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+	"unicode"
+)
+
+func main() {
+	specPath := flag.String("spec", "api/openapi.json", "OpenAPI document to read")
+	out := flag.String("out", "types_gen.go", "Go file to write")
+	pkg := flag.String("package", "fishaudio", "package of the generated file")
+	flag.Parse()
+
+	data, err := os.ReadFile(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(data, *pkg, *specPath)
+	if err != nil {
+		log.Fatalf("%s: %v", *specPath, err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// document is the part of an OpenAPI document gentypes reads.
+type document struct {
+	Components struct {
+		Schemas schemaList `json:"schemas"`
+	} `json:"components"`
+}
+
+// schema is an OpenAPI schema object.
+type schema struct {
+	Type        string     `json:"type"`
+	Format      string     `json:"format"`
+	Description string     `json:"description"`
+	Ref         string     `json:"$ref"`
+	Nullable    bool       `json:"nullable"`
+	Enum        []string   `json:"enum"`
+	Items       *schema    `json:"items"`
+	Properties  schemaList `json:"properties"`
+	Required    []string   `json:"required"`
+
+	GoName           string   `json:"x-go-name"`
+	EnumVarNames     []string `json:"x-enum-varnames"`
+	EnumDescriptions []string `json:"x-enum-descriptions"`
+	Extra            bool     `json:"x-go-extra"`
+}
+
+// namedSchema is an entry of a schemaList.
+type namedSchema struct {
+	Name   string
+	Schema *schema
+}
+
+// schemaList is a JSON object of schemas that keeps the order of its keys,
+// so generated declarations and fields follow the spec.
+type schemaList []namedSchema
+
+func (l *schemaList) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return errors.New("schemas must be an object")
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var s schema
+		if err := dec.Decode(&s); err != nil {
+			return fmt.Errorf("%s: %w", tok, err)
+		}
+		*l = append(*l, namedSchema{Name: tok.(string), Schema: &s})
+	}
+	_, err := dec.Token()
+	return err
+}
+
+// generate returns the formatted Go source for the schemas in spec.
+func generate(spec []byte, pkg, source string) ([]byte, error) {
+	var doc document
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gentypes from %s. DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	if needsJSON(doc.Components.Schemas) {
+		buf.WriteString("import \"encoding/json\"\n\n")
+	}
+
+	for _, named := range doc.Components.Schemas {
+		var err error
+		switch {
+		case len(named.Schema.Enum) > 0:
+			err = writeEnum(&buf, named)
+		case named.Schema.Type == "object":
+			err = writeObject(&buf, named)
+		default:
+			err = fmt.Errorf("unsupported schema type %q", named.Schema.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", named.Name, err)
+		}
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
+
+// needsJSON reports whether the generated code uses encoding/json.
+func needsJSON(schemas schemaList) bool {
+	for _, named := range schemas {
+		if named.Schema.Extra {
+			return true
+		}
+	}
+	return false
+}
+
+// writeEnum writes a string type and a constant for each enum value.
+func writeEnum(buf *bytes.Buffer, named namedSchema) error {
+	s := named.Schema
+	if s.Type != "string" {
+		return fmt.Errorf("enum of type %q, want string", s.Type)
+	}
+	if len(s.EnumVarNames) != len(s.Enum) {
+		return fmt.Errorf("x-enum-varnames has %d names for %d values", len(s.EnumVarNames), len(s.Enum))
+	}
+	if len(s.EnumDescriptions) > 0 && len(s.EnumDescriptions) != len(s.Enum) {
+		return fmt.Errorf("x-enum-descriptions has %d entries for %d values", len(s.EnumDescriptions), len(s.Enum))
+	}
+
+	name := typeName(named)
+	writeComment(buf, "", s.Description)
+	fmt.Fprintf(buf, "type %s string\n\nconst (\n", name)
+	for i, value := range s.Enum {
+		if len(s.EnumDescriptions) > 0 {
+			writeComment(buf, "\t", s.EnumDescriptions[i])
+		}
+		fmt.Fprintf(buf, "\t%s %s = %q\n", s.EnumVarNames[i], name, value)
+	}
+	buf.WriteString(")\n\n")
+	return nil
+}
+
+// writeObject writes a struct with a field for each property.
+func writeObject(buf *bytes.Buffer, named namedSchema) error {
+	s := named.Schema
+	name := typeName(named)
+	required := map[string]bool{}
+	for _, key := range s.Required {
+		required[key] = true
+	}
+
+	writeComment(buf, "", s.Description)
+	fmt.Fprintf(buf, "type %s struct {\n", name)
+	for _, prop := range s.Properties {
+		typ, err := goType(prop.Schema)
+		if err != nil {
+			return fmt.Errorf("%s: %w", prop.Name, err)
+		}
+		tag := prop.Name
+		if !required[prop.Name] {
+			tag += ",omitempty"
+		}
+		writeComment(buf, "\t", prop.Schema.Description)
+		fmt.Fprintf(buf, "\t%s %s `json:%q`\n", fieldName(prop), typ, tag)
+	}
+	if s.Extra {
+		buf.WriteString("\n\t// Extra holds response fields not known to this SDK version.\n")
+		buf.WriteString("\tExtra map[string]json.RawMessage `json:\"-\"`\n")
+	}
+	buf.WriteString("}\n\n")
+
+	if s.Extra {
+		recv := strings.ToLower(name[:1])
+		fmt.Fprintf(buf, "// UnmarshalJSON decodes %s, collecting unknown fields in Extra.\n", name)
+		fmt.Fprintf(buf, "func (%s *%s) UnmarshalJSON(data []byte) error {\n", recv, name)
+		fmt.Fprintf(buf, "\ttype plain %s\n", name)
+		fmt.Fprintf(buf, "\tif err := json.Unmarshal(data, (*plain)(%s)); err != nil {\n\t\treturn err\n\t}\n", recv)
+		fmt.Fprintf(buf, "\textra, err := unknownFields(data, %s)\n\t%s.Extra = extra\n\treturn err\n}\n\n", recv, recv)
+	}
+	return nil
+}
+
+// goType returns the Go type of a property schema.
+func goType(s *schema) (string, error) {
+	var typ string
+	switch {
+	case s.Ref != "":
+		ref, ok := strings.CutPrefix(s.Ref, "#/components/schemas/")
+		if !ok {
+			return "", fmt.Errorf("unsupported $ref %q", s.Ref)
+		}
+		typ = ref
+	case s.Type == "array":
+		if s.Items == nil {
+			return "", errors.New("array without items")
+		}
+		item, err := goType(s.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	case s.Type == "string" && s.Format == "date-time":
+		typ = "Timestamp"
+	case s.Type == "string":
+		typ = "string"
+	case s.Type == "integer":
+		typ = "int"
+	case s.Type == "number":
+		typ = "float64"
+	case s.Type == "boolean":
+		typ = "bool"
+	default:
+		return "", fmt.Errorf("unsupported property type %q", s.Type)
+	}
+	if s.Nullable {
+		typ = "*" + typ
+	}
+	return typ, nil
+}
+
+// typeName returns the Go name of a schema.
+func typeName(named namedSchema) string {
+	if named.Schema.GoName != "" {
+		return named.Schema.GoName
+	}
+	return named.Name
+}
+
+// initialisms are name parts written in upper case, following Go style.
+var initialisms = map[string]bool{
+	"api": true, "asr": true, "id": true, "sha256": true, "tts": true, "url": true,
+}
+
+// fieldName returns the Go name of a property: its x-go-name, or its JSON
+// name in camel case, such as "user_id" to UserID.
+func fieldName(prop namedSchema) string {
+	if prop.Schema.GoName != "" {
+		return prop.Schema.GoName
+	}
+	parts := strings.FieldsFunc(prop.Name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, part := range parts {
+		if initialisms[strings.ToLower(part)] {
+			b.WriteString(strings.ToUpper(part))
+			continue
+		}
+		runes := []rune(part)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	return b.String()
+}
+
+// writeComment writes text as a doc comment wrapped at 76 columns.
+func writeComment(buf *bytes.Buffer, indent, text string) {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return
+	}
+	line := indent + "//"
+	for _, word := range words {
+		if len(line)+1+len(word) > 76 && line != indent+"//" {
+			buf.WriteString(line + "\n")
+			line = indent + "//"
+		}
+		line += " " + word
+	}
+	buf.WriteString(line + "\n")
+}
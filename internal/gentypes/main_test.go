@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGenerate_UpToDate(t *testing.T) {
+	spec, err := os.ReadFile("../../api/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := generate(spec, "fishaudio", "api/openapi.json")
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	got, err := os.ReadFile("../../types_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("types_gen.go is out of date with api/openapi.json; run go generate")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	spec := `{"components": {"schemas": {
+		"Zeta": {
+			"type": "object",
+			"description": "Zeta is declared first.",
+			"x-go-extra": true,
+			"required": ["id"],
+			"properties": {
+				"id": {"type": "string"},
+				"kind": {"$ref": "#/components/schemas/Kind"},
+				"seen_at": {"type": "string", "format": "date-time", "nullable": true, "description": "SeenAt is when it was seen."},
+				"scores": {"type": "array", "items": {"type": "number"}},
+				"raw": {"type": "integer", "x-go-name": "RawCount"}
+			}
+		},
+		"Kind": {
+			"type": "string",
+			"enum": ["a", "b"],
+			"x-enum-varnames": ["KindA", "KindB"],
+			"x-enum-descriptions": ["KindA is the first kind.", ""]
+		}
+	}}}`
+
+	src, err := generate([]byte(spec), "example", "spec.json")
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	// Compare with whitespace collapsed so gofmt alignment doesn't matter
+	out := strings.Join(strings.Fields(string(src)), " ")
+
+	for _, want := range []string{
+		"// Code generated by gentypes from spec.json. DO NOT EDIT.",
+		"package example",
+		`import "encoding/json"`,
+		"ID string `json:\"id\"`",
+		"Kind Kind `json:\"kind,omitempty\"`",
+		"// SeenAt is when it was seen. SeenAt *Timestamp `json:\"seen_at,omitempty\"`",
+		"Scores []float64 `json:\"scores,omitempty\"`",
+		"RawCount int `json:\"raw,omitempty\"`",
+		"Extra map[string]json.RawMessage `json:\"-\"`",
+		"func (z *Zeta) UnmarshalJSON(data []byte) error {",
+		"// KindA is the first kind. KindA Kind = \"a\" KindB Kind = \"b\"",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "type Zeta") > strings.Index(out, "type Kind") {
+		t.Error("declarations are not in spec order")
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{"unsupported schema", `{"components": {"schemas": {"X": {"type": "integer"}}}}`},
+		{"enum names", `{"components": {"schemas": {"X": {"type": "string", "enum": ["a"]}}}}`},
+		{"enum descriptions", `{"components": {"schemas": {"X": {"type": "string", "enum": ["a"], "x-enum-varnames": ["XA"], "x-enum-descriptions": ["", ""]}}}}`},
+		{"property type", `{"components": {"schemas": {"X": {"type": "object", "properties": {"a": {"type": "tuple"}}}}}}`},
+		{"array items", `{"components": {"schemas": {"X": {"type": "object", "properties": {"a": {"type": "array"}}}}}}`},
+		{"external ref", `{"components": {"schemas": {"X": {"type": "object", "properties": {"a": {"$ref": "other.json#/X"}}}}}}`},
+		{"schemas not an object", `{"components": {"schemas": []}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := generate([]byte(tt.spec), "example", "spec.json"); err == nil {
+				t.Error("generate() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestFieldName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"_id", "ID"},
+		{"user_id", "UserID"},
+		{"cover_image", "CoverImage"},
+		{"has_phone_sha256", "HasPhoneSHA256"},
+		{"audio-url", "AudioURL"},
+		{"like_count", "LikeCount"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldName(namedSchema{Name: tt.name, Schema: &schema{}}); got != tt.want {
+				t.Errorf("fieldName(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+//go:build go1.23
+
+package fishaudio
+
+import (
+	"context"
+	"iter"
+)
+
+// Chunks returns an iterator over the remaining audio chunks.
+// A non-nil error is yielded once, as the final element, if streaming fails.
+// With WithChunkReuse, each chunk is only valid until the next iteration.
+//
+// Example:
+//
+//	for chunk, err := range stream.Chunks() {
+//	    if err != nil {
+//	        // handle error
+//	    }
+//	    // process chunk
+//	}
+func (s *AudioStream) Chunks() iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		for s.Next() {
+			if !yield(s.Bytes(), nil) {
+				return
+			}
+		}
+		if err := s.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// Chunks returns an iterator over the remaining audio chunks.
+// A non-nil error is yielded once, as the final element, if streaming fails.
+// With WithChunkReuse, each chunk is only valid until the next iteration.
+func (s *WebSocketAudioStream) Chunks() iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		for s.Next() {
+			if !yield(s.Bytes(), nil) {
+				return
+			}
+		}
+		if err := s.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// StreamWebSocketSeq is like StreamWebSocket but consumes text from an iterator
+// and returns the audio as an iterator.
+//
+// The session is dialed when iteration starts and closed when it ends,
+// including when the caller breaks out of the loop early.
+//
+// Example:
+//
+//	text := slices.Values([]string{"Hello, ", "world!"})
+//	for chunk, err := range client.TTS.StreamWebSocketSeq(ctx, text, params, nil) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    // process chunk
+//	}
+func (s *TTSService) StreamWebSocketSeq(ctx context.Context, textSeq iter.Seq[string], params *StreamParams, opts *WebSocketOptions) iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		textChan := make(chan string)
+		stream, err := s.StreamWebSocket(ctx, textChan, params, opts)
+		if err != nil {
+			close(textChan)
+			yield(nil, err)
+			return
+		}
+		defer func() { _ = stream.Close() }()
+
+		// Feed text from the iterator until it is exhausted or iteration
+		// stops. A panic in the iterator ends the text and is reported after
+		// the audio of the text before it
+		panicked := make(chan error, 1)
+		go func() {
+			defer close(textChan)
+			panicked <- s.client.guard("text iterator", func() error {
+				for text := range textSeq {
+					select {
+					case textChan <- text:
+					case <-ctx.Done():
+						return nil
+					}
+				}
+				return nil
+			})()
+		}()
+
+		for chunk, err := range stream.Chunks() {
+			if !yield(chunk, err) {
+				return
+			}
+		}
+		select {
+		case err := <-panicked:
+			if err != nil {
+				yield(nil, err)
+			}
+		default:
+		}
+	}
+}
+
+// Items returns an iterator over the items of the remaining pages.
+// A non-nil error is yielded once, as the final element, if fetching fails.
+//
+// Example:
+//
+//	for voice, err := range client.Voices.Pager(nil).Items(ctx) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(voice.Title)
+//	}
+func (p *Pager[T]) Items(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for p.Next(ctx) {
+			for _, item := range p.page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+		if err := p.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
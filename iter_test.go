@@ -0,0 +1,176 @@
+//go:build go1.23
+
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestAudioStream_Chunks(t *testing.T) {
+	stream := newAudioStream(&http.Response{
+		Body: io.NopCloser(strings.NewReader("audio data")),
+	})
+	defer func() { _ = stream.Close() }()
+
+	var collected []byte
+	for chunk, err := range stream.Chunks() {
+		if err != nil {
+			t.Fatalf("Chunks() error = %v", err)
+		}
+		collected = append(collected, chunk...)
+	}
+	if string(collected) != "audio data" {
+		t.Errorf("collected = %q, want %q", collected, "audio data")
+	}
+}
+
+func TestWebSocketAudioStream_ChunksError(t *testing.T) {
+	audioChan := make(chan []byte, 1)
+	errChan := make(chan error, 1)
+	audioChan <- []byte("chunk")
+	close(audioChan)
+	errChan <- io.ErrUnexpectedEOF
+
+	stream := &WebSocketAudioStream{
+		audioChan: audioChan,
+		errChan:   errChan,
+	}
+
+	var chunks int
+	var lastErr error
+	for chunk, err := range stream.Chunks() {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if string(chunk) != "chunk" {
+			t.Errorf("chunk = %q, want %q", chunk, "chunk")
+		}
+		chunks++
+	}
+	if chunks != 1 {
+		t.Errorf("chunks = %d, want 1", chunks)
+	}
+	if lastErr != io.ErrUnexpectedEOF {
+		t.Errorf("final error = %v, want %v", lastErr, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestTTSService_StreamWebSocketSeq(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		_, _, _ = conn.ReadMessage()
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var msg map[string]interface{}
+			_ = msgpack.Unmarshal(data, &msg)
+			if msg["event"] == "stop" {
+				break
+			}
+			text, _ := msg["text"].(string)
+			resp, _ := msgpack.Marshal(wsResponse{Event: "audio", Audio: []byte(strings.ToUpper(text))})
+			_ = conn.WriteMessage(websocket.BinaryMessage, resp)
+		}
+		resp, _ := msgpack.Marshal(wsResponse{Event: "finish", Reason: "stop"})
+		_ = conn.WriteMessage(websocket.BinaryMessage, resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	var got []string
+	text := slices.Values([]string{"hello", "world"})
+	for chunk, err := range client.TTS.StreamWebSocketSeq(context.Background(), text, nil, nil) {
+		if err != nil {
+			t.Fatalf("StreamWebSocketSeq() error = %v", err)
+		}
+		got = append(got, string(chunk))
+	}
+
+	if !slices.Equal(got, []string{"HELLO", "WORLD"}) {
+		t.Errorf("chunks = %v, want %v", got, []string{"HELLO", "WORLD"})
+	}
+}
+
+func TestTTSService_StreamWebSocketSeq_DialError(t *testing.T) {
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL("http://127.0.0.1:1"))
+
+	var errs int
+	for _, err := range client.TTS.StreamWebSocketSeq(context.Background(), slices.Values([]string{"hi"}), nil, nil) {
+		if err == nil {
+			t.Fatal("expected dial error")
+		}
+		errs++
+	}
+	if errs != 1 {
+		t.Errorf("errors yielded = %d, want 1", errs)
+	}
+}
+
+func TestPager_Items(t *testing.T) {
+	pager := NewPager(1, func(ctx context.Context, pageNumber int) (*PaginatedResponse[int], error) {
+		items := []int{pageNumber*10 + 1, pageNumber*10 + 2}
+		return &PaginatedResponse[int]{Total: 6, Items: items, PageSize: 2, PageNumber: pageNumber}, nil
+	})
+
+	var got []int
+	for item, err := range pager.Items(context.Background()) {
+		if err != nil {
+			t.Fatalf("Items() error = %v", err)
+		}
+		got = append(got, item)
+		if len(got) == 5 {
+			break
+		}
+	}
+	if want := []int{11, 12, 21, 22, 31}; !slices.Equal(got, want) {
+		t.Errorf("Items() = %v, want %v", got, want)
+	}
+}
+
+func TestPanicError_TextIterator(t *testing.T) {
+	server := newEchoLiveServer(t)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	text := func(yield func(string) bool) {
+		if yield("first") {
+			panic(errors.New("iterator bug"))
+		}
+	}
+
+	var audio []byte
+	var lastErr error
+	for chunk, err := range client.TTS.StreamWebSocketSeq(context.Background(), text, nil, nil) {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		audio = append(audio, chunk...)
+	}
+	if string(audio) != "first" {
+		t.Errorf("audio = %q, want the text before the panic", audio)
+	}
+	var panicErr *PanicError
+	if !errors.As(lastErr, &panicErr) || panicErr.Goroutine != "text iterator" || !strings.Contains(lastErr.Error(), "iterator bug") {
+		t.Errorf("error = %v, want *PanicError from the text iterator", lastErr)
+	}
+}
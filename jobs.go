@@ -0,0 +1,535 @@
+package fishaudio
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// JobKind is the kind of work a Job performs.
+type JobKind string
+
+const (
+	// JobKindTTS synthesizes speech to a file.
+	JobKindTTS JobKind = "tts"
+	// JobKindASR transcribes an audio file or URL.
+	JobKindASR JobKind = "asr"
+)
+
+// JobState is the lifecycle state of a Job.
+type JobState string
+
+const (
+	// JobPending jobs are waiting to run.
+	JobPending JobState = "pending"
+	// JobRunning jobs are being executed.
+	JobRunning JobState = "running"
+	// JobSucceeded jobs finished successfully.
+	JobSucceeded JobState = "succeeded"
+	// JobFailed jobs failed after all retries.
+	JobFailed JobState = "failed"
+)
+
+// Done reports whether the state is final.
+func (s JobState) Done() bool {
+	return s == JobSucceeded || s == JobFailed
+}
+
+// TTSJob describes speech synthesis performed by a job.
+type TTSJob struct {
+	// Text is the text to synthesize (required).
+	Text string `json:"text"`
+	// ReferenceID is the voice model ID.
+	ReferenceID string `json:"reference_id,omitempty"`
+	// Model is the TTS model. Default: the server default.
+	Model Model `json:"model,omitempty"`
+	// Format is the output format. Default: AudioFormatMP3.
+	Format AudioFormat `json:"format,omitempty"`
+	// Latency is the latency mode.
+	Latency LatencyMode `json:"latency,omitempty"`
+	// Speed is the speech speed multiplier.
+	Speed float64 `json:"speed,omitempty"`
+	// OutputPath is the file the audio is written to with the client's
+	// Storage (required).
+	OutputPath string `json:"output_path"`
+}
+
+// ASRJob describes a transcription performed by a job.
+type ASRJob struct {
+	// AudioPath is the audio file to transcribe, opened with the client's
+	// Storage.
+	AudioPath string `json:"audio_path,omitempty"`
+	// AudioURL is an http or https URL of the audio, used instead of
+	// AudioPath.
+	AudioURL string `json:"audio_url,omitempty"`
+	// Language is the spoken language. Auto-detected if empty.
+	Language Language `json:"language,omitempty"`
+}
+
+// Job is a unit of work in a JobQueue. Jobs are stored as JSON, so a
+// queue backed by a persistent JobStore resumes them after a restart.
+type Job struct {
+	ID    string   `json:"id"`
+	Kind  JobKind  `json:"kind"`
+	State JobState `json:"state"`
+
+	// TTS is set for JobKindTTS jobs.
+	TTS *TTSJob `json:"tts,omitempty"`
+	// ASR is set for JobKindASR jobs.
+	ASR *ASRJob `json:"asr,omitempty"`
+
+	// Transcript is the result of a succeeded ASR job.
+	Transcript *ASRResponse `json:"transcript,omitempty"`
+	// Error describes why the job failed.
+	Error string `json:"error,omitempty"`
+	// Attempts is the number of times the job was tried.
+	Attempts int `json:"attempts"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// JobQueueOptions configures a JobQueue.
+type JobQueueOptions struct {
+	// Store persists jobs. Default: a new MemoryJobStore, which doesn't
+	// survive restarts.
+	Store JobStore
+
+	// Concurrency is the number of jobs run at once. Default: 4.
+	Concurrency int
+
+	// RequestsPerSecond limits how often jobs are started. Default: no limit.
+	RequestsPerSecond float64
+
+	// MaxRetries is the number of times a job is retried when the error is
+	// transient (rate limits, server errors, and network failures).
+	// Default: 2. Set to a negative value to disable retries.
+	MaxRetries int
+
+	// RetryDelay is the wait before the first retry, doubled for each
+	// subsequent one. Default: 1 second.
+	RetryDelay time.Duration
+
+	// OnUpdate, if set, is called with a copy of a job each time its state
+	// changes. Calls may be concurrent.
+	OnUpdate func(Job)
+}
+
+// JobQueue runs TTS and ASR jobs in the background with concurrency and
+// rate limits, persisting them in a JobStore so their status can be
+// queried and unfinished jobs resume when the queue is run again.
+//
+// Only one process may run a queue over a given store at a time.
+//
+// Example:
+//
+//	queue := fishaudio.NewJobQueue(client, &fishaudio.JobQueueOptions{
+//	    Store:       fishaudio.NewRedisJobStore(redisAdapter, ""),
+//	    Concurrency: 8,
+//	})
+//	go queue.Run(ctx)
+//
+//	job, err := queue.SubmitTTS(ctx, &fishaudio.TTSJob{Text: chapter, OutputPath: "out/ch1.mp3"})
+//	if err != nil {
+//	    return err
+//	}
+//	job, err = queue.Wait(ctx, job.ID)
+type JobQueue struct {
+	client *Client
+	opts   JobQueueOptions
+
+	mu      sync.Mutex
+	pending []string
+	running bool
+	wake    chan struct{}
+	changed chan struct{}
+	nextRun time.Time
+}
+
+// NewJobQueue creates a JobQueue. Jobs can be submitted before Run is
+// called.
+func NewJobQueue(client *Client, opts *JobQueueOptions) *JobQueue {
+	q := &JobQueue{
+		client:  client,
+		wake:    make(chan struct{}, 1),
+		changed: make(chan struct{}),
+	}
+	if opts != nil {
+		q.opts = *opts
+	}
+	if q.opts.Store == nil {
+		q.opts.Store = NewMemoryJobStore()
+	}
+	if q.opts.Concurrency <= 0 {
+		q.opts.Concurrency = 4
+	}
+	if q.opts.MaxRetries == 0 {
+		q.opts.MaxRetries = 2
+	}
+	if q.opts.MaxRetries < 0 {
+		q.opts.MaxRetries = 0
+	}
+	if q.opts.RetryDelay <= 0 {
+		q.opts.RetryDelay = time.Second
+	}
+	return q
+}
+
+// SubmitTTS adds a speech synthesis job to the queue.
+func (q *JobQueue) SubmitTTS(ctx context.Context, task *TTSJob) (*Job, error) {
+	if task == nil || task.Text == "" {
+		return nil, newValidationError("TTS job requires Text")
+	}
+	if task.OutputPath == "" {
+		return nil, newValidationError("TTS job requires OutputPath")
+	}
+	t := *task
+	return q.submit(ctx, &Job{Kind: JobKindTTS, TTS: &t})
+}
+
+// SubmitASR adds a transcription job to the queue.
+func (q *JobQueue) SubmitASR(ctx context.Context, task *ASRJob) (*Job, error) {
+	if task == nil || (task.AudioPath == "") == (task.AudioURL == "") {
+		return nil, newValidationError("ASR job requires exactly one of AudioPath and AudioURL")
+	}
+	t := *task
+	return q.submit(ctx, &Job{Kind: JobKindASR, ASR: &t})
+}
+
+// submit stores a new pending job and schedules it.
+func (q *JobQueue) submit(ctx context.Context, job *Job) (*Job, error) {
+	id, err := newJobID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	job.ID = id
+	job.State = JobPending
+	job.CreatedAt = now
+	job.UpdatedAt = now
+	if err := q.opts.Store.SaveJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	q.mu.Lock()
+	q.pending = append(q.pending, job.ID)
+	q.mu.Unlock()
+	q.signal()
+	q.notify(job)
+	return job, nil
+}
+
+// Get returns the job with the given ID, or ErrJobNotFound.
+func (q *JobQueue) Get(ctx context.Context, id string) (*Job, error) {
+	return q.opts.Store.GetJob(ctx, id)
+}
+
+// List returns all jobs in the store, oldest first.
+func (q *JobQueue) List(ctx context.Context) ([]*Job, error) {
+	jobs, err := q.opts.Store.ListJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sortJobs(jobs)
+	return jobs, nil
+}
+
+// Wait blocks until the job with the given ID has finished and returns it.
+// It returns ctx's error if ctx is cancelled first.
+func (q *JobQueue) Wait(ctx context.Context, id string) (*Job, error) {
+	for {
+		q.mu.Lock()
+		changed := q.changed
+		q.mu.Unlock()
+
+		job, err := q.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if job.State.Done() {
+			return job, nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Run executes jobs until ctx is cancelled, then waits for running jobs
+// to stop and returns ctx's error. Jobs left pending or running by a
+// previous Run, including one in another process that exited, are resumed
+// first. Jobs interrupted by cancellation are returned to pending.
+func (q *JobQueue) Run(ctx context.Context) error {
+	if err := q.resume(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		q.mu.Lock()
+		q.running = false
+		q.mu.Unlock()
+	}()
+
+	sem := make(chan struct{}, q.opts.Concurrency)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		id, ok := q.next()
+		if !ok {
+			select {
+			case <-q.wake:
+				continue
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if err := q.throttle(ctx); err != nil {
+			<-sem
+			return err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			q.execute(ctx, id)
+		}()
+	}
+}
+
+// resume marks the queue as running and schedules every unfinished job in
+// the store.
+func (q *JobQueue) resume(ctx context.Context) error {
+	q.mu.Lock()
+	if q.running {
+		q.mu.Unlock()
+		return errors.New("job queue is already running")
+	}
+	q.running = true
+	q.mu.Unlock()
+
+	jobs, err := q.List(ctx)
+	if err != nil {
+		q.mu.Lock()
+		q.running = false
+		q.mu.Unlock()
+		return err
+	}
+
+	var pending []string
+	for _, job := range jobs {
+		if !job.State.Done() {
+			pending = append(pending, job.ID)
+		}
+	}
+
+	q.mu.Lock()
+	// Jobs submitted while listing are already in the store, and so in
+	// pending, unless they were saved after the list was read
+	seen := make(map[string]bool, len(pending))
+	for _, id := range pending {
+		seen[id] = true
+	}
+	for _, id := range q.pending {
+		if !seen[id] {
+			pending = append(pending, id)
+		}
+	}
+	q.pending = pending
+	q.mu.Unlock()
+	return nil
+}
+
+// next pops the next pending job ID.
+func (q *JobQueue) next() (string, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.pending) == 0 {
+		return "", false
+	}
+	id := q.pending[0]
+	q.pending = q.pending[1:]
+	return id, true
+}
+
+// throttle waits until the rate limit allows another job to start.
+func (q *JobQueue) throttle(ctx context.Context) error {
+	if q.opts.RequestsPerSecond <= 0 {
+		return nil
+	}
+	interval := time.Duration(float64(time.Second) / q.opts.RequestsPerSecond)
+
+	q.mu.Lock()
+	now := time.Now()
+	start := q.nextRun
+	if start.Before(now) {
+		start = now
+	}
+	q.nextRun = start.Add(interval)
+	q.mu.Unlock()
+
+	if wait := time.Until(start); wait > 0 {
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// execute runs one job, retrying transient failures, and records its
+// outcome.
+func (q *JobQueue) execute(ctx context.Context, id string) {
+	// Store writes outlive cancellation so interrupted jobs are recorded
+	storeCtx := context.WithoutCancel(ctx)
+
+	job, err := q.opts.Store.GetJob(storeCtx, id)
+	if err != nil || job.State.Done() {
+		return
+	}
+
+	delay := q.opts.RetryDelay
+	for {
+		job.State = JobRunning
+		job.Attempts++
+		q.save(storeCtx, job)
+
+		err = q.perform(ctx, job)
+		if err == nil {
+			job.State = JobSucceeded
+			job.Error = ""
+			q.save(storeCtx, job)
+			return
+		}
+
+		if ctx.Err() != nil {
+			// Interrupted rather than failed; the attempt doesn't count
+			job.State = JobPending
+			job.Attempts--
+			q.save(storeCtx, job)
+			return
+		}
+		if job.Attempts > q.opts.MaxRetries || !isTransient(err) {
+			job.State = JobFailed
+			job.Error = err.Error()
+			q.save(storeCtx, job)
+			return
+		}
+
+		job.Error = err.Error()
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			job.State = JobPending
+			q.save(storeCtx, job)
+			return
+		}
+		delay *= 2
+	}
+}
+
+// perform does the work of one attempt at a job.
+func (q *JobQueue) perform(ctx context.Context, job *Job) (err error) {
+	defer q.client.catchPanic("job "+job.ID, &err)
+
+	switch job.Kind {
+	case JobKindTTS:
+		t := job.TTS
+		format := t.Format
+		if format == "" {
+			format = AudioFormatMP3
+		}
+		return q.client.TTS.ConvertToFile(ctx, &ConvertParams{
+			Text:        t.Text,
+			ReferenceID: t.ReferenceID,
+			Model:       t.Model,
+			Format:      format,
+			Latency:     t.Latency,
+			Speed:       t.Speed,
+		}, t.OutputPath)
+
+	case JobKindASR:
+		a := job.ASR
+		params := &TranscribeParams{Language: a.Language}
+		var resp *ASRResponse
+		var err error
+		if a.AudioURL != "" {
+			resp, err = q.client.ASR.TranscribeURL(ctx, a.AudioURL, params)
+		} else {
+			resp, err = q.client.ASR.TranscribeFile(ctx, a.AudioPath, params)
+		}
+		if err != nil {
+			return err
+		}
+		job.Transcript = resp
+		return nil
+	}
+	return fmt.Errorf("unknown job kind %q", job.Kind)
+}
+
+// save stores job and notifies waiters. Store errors can't be reported to
+// anyone, so the job is simply retried from its last stored state when
+// the queue next runs.
+func (q *JobQueue) save(ctx context.Context, job *Job) {
+	job.UpdatedAt = time.Now().UTC()
+	if err := q.opts.Store.SaveJob(ctx, job); err != nil {
+		return
+	}
+	q.notify(job)
+}
+
+// notify wakes Wait callers and calls OnUpdate.
+func (q *JobQueue) notify(job *Job) {
+	q.mu.Lock()
+	close(q.changed)
+	q.changed = make(chan struct{})
+	q.mu.Unlock()
+
+	if q.opts.OnUpdate != nil {
+		q.opts.OnUpdate(*job)
+	}
+}
+
+// signal wakes Run if it is waiting for jobs.
+func (q *JobQueue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// sortJobs orders jobs by creation time, then ID.
+func sortJobs(jobs []*Job) {
+	sort.Slice(jobs, func(i, j int) bool {
+		if !jobs[i].CreatedAt.Equal(jobs[j].CreatedAt) {
+			return jobs[i].CreatedAt.Before(jobs[j].CreatedAt)
+		}
+		return jobs[i].ID < jobs[j].ID
+	})
+}
+
+// newJobID returns a random job ID.
+func newJobID() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
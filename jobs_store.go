@@ -0,0 +1,177 @@
+package fishaudio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+)
+
+// ErrJobNotFound is returned when a job ID is not in the store.
+var ErrJobNotFound = errors.New("job not found")
+
+// JobStore persists the jobs of a JobQueue. Implementations must be safe
+// for concurrent use and must not retain the *Job passed to SaveJob.
+type JobStore interface {
+	// SaveJob creates or replaces a job.
+	SaveJob(ctx context.Context, job *Job) error
+	// GetJob returns the job with the given ID, or ErrJobNotFound.
+	GetJob(ctx context.Context, id string) (*Job, error)
+	// ListJobs returns all jobs in any order.
+	ListJobs(ctx context.Context) ([]*Job, error)
+}
+
+// MemoryJobStore is a JobStore that keeps jobs in memory.
+type MemoryJobStore struct {
+	mu   sync.Mutex
+	jobs map[string][]byte
+}
+
+// NewMemoryJobStore creates an empty MemoryJobStore.
+func NewMemoryJobStore() *MemoryJobStore {
+	return &MemoryJobStore{jobs: map[string][]byte{}}
+}
+
+// SaveJob implements JobStore.
+func (s *MemoryJobStore) SaveJob(ctx context.Context, job *Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.ID] = data
+	return nil
+}
+
+// GetJob implements JobStore.
+func (s *MemoryJobStore) GetJob(ctx context.Context, id string) (*Job, error) {
+	s.mu.Lock()
+	data, ok := s.jobs[id]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	return decodeJob(data)
+}
+
+// ListJobs implements JobStore.
+func (s *MemoryJobStore) ListJobs(ctx context.Context) ([]*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jobs := make([]*Job, 0, len(s.jobs))
+	for _, data := range s.jobs {
+		job, err := decodeJob(data)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// RedisClient is the subset of Redis commands RedisJobStore uses. Adapt
+// your Redis library to it; with go-redis:
+//
+//	type redisAdapter struct{ rdb *redis.Client }
+//
+//	func (a redisAdapter) Get(ctx context.Context, key string) (string, bool, error) {
+//	    v, err := a.rdb.Get(ctx, key).Result()
+//	    if errors.Is(err, redis.Nil) {
+//	        return "", false, nil
+//	    }
+//	    return v, err == nil, err
+//	}
+//
+//	func (a redisAdapter) Set(ctx context.Context, key, value string) error {
+//	    return a.rdb.Set(ctx, key, value, 0).Err()
+//	}
+//
+//	func (a redisAdapter) SAdd(ctx context.Context, key, member string) error {
+//	    return a.rdb.SAdd(ctx, key, member).Err()
+//	}
+//
+//	func (a redisAdapter) SMembers(ctx context.Context, key string) ([]string, error) {
+//	    return a.rdb.SMembers(ctx, key).Result()
+//	}
+type RedisClient interface {
+	// Get returns the value of key and whether it exists.
+	Get(ctx context.Context, key string) (string, bool, error)
+	// Set sets key to value with no expiry.
+	Set(ctx context.Context, key, value string) error
+	// SAdd adds member to the set at key.
+	SAdd(ctx context.Context, key, member string) error
+	// SMembers returns the members of the set at key.
+	SMembers(ctx context.Context, key string) ([]string, error)
+}
+
+// RedisJobStore is a JobStore backed by Redis, so jobs survive restarts.
+// Each job is stored as JSON under prefix + "job:" + ID, and the set at
+// prefix + "ids" indexes them.
+type RedisJobStore struct {
+	client RedisClient
+	prefix string
+}
+
+// NewRedisJobStore creates a RedisJobStore whose keys start with prefix.
+// Default prefix: "fishaudio:jobs:".
+func NewRedisJobStore(client RedisClient, prefix string) *RedisJobStore {
+	if prefix == "" {
+		prefix = "fishaudio:jobs:"
+	}
+	return &RedisJobStore{client: client, prefix: prefix}
+}
+
+// SaveJob implements JobStore.
+func (s *RedisJobStore) SaveJob(ctx context.Context, job *Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	if err := s.client.Set(ctx, s.prefix+"job:"+job.ID, string(data)); err != nil {
+		return err
+	}
+	return s.client.SAdd(ctx, s.prefix+"ids", job.ID)
+}
+
+// GetJob implements JobStore.
+func (s *RedisJobStore) GetJob(ctx context.Context, id string) (*Job, error) {
+	data, ok, err := s.client.Get(ctx, s.prefix+"job:"+id)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	return decodeJob([]byte(data))
+}
+
+// ListJobs implements JobStore.
+func (s *RedisJobStore) ListJobs(ctx context.Context) ([]*Job, error) {
+	ids, err := s.client.SMembers(ctx, s.prefix+"ids")
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]*Job, 0, len(ids))
+	for _, id := range ids {
+		job, err := s.GetJob(ctx, id)
+		if errors.Is(err, ErrJobNotFound) {
+			// Expired or deleted outside the store
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// decodeJob decodes a job stored as JSON.
+func decodeJob(data []byte) (*Job, error) {
+	var job Job
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
@@ -0,0 +1,111 @@
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// fakeRedis is an in-memory RedisClient.
+type fakeRedis struct {
+	mu     sync.Mutex
+	values map[string]string
+	sets   map[string]map[string]bool
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{values: map[string]string{}, sets: map[string]map[string]bool{}}
+}
+
+func (r *fakeRedis) Get(ctx context.Context, key string) (string, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	v, ok := r.values[key]
+	return v, ok, nil
+}
+
+func (r *fakeRedis) Set(ctx context.Context, key, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.values[key] = value
+	return nil
+}
+
+func (r *fakeRedis) SAdd(ctx context.Context, key, member string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.sets[key] == nil {
+		r.sets[key] = map[string]bool{}
+	}
+	r.sets[key][member] = true
+	return nil
+}
+
+func (r *fakeRedis) SMembers(ctx context.Context, key string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var members []string
+	for m := range r.sets[key] {
+		members = append(members, m)
+	}
+	return members, nil
+}
+
+func TestJobStores(t *testing.T) {
+	redis := newFakeRedis()
+	stores := map[string]JobStore{
+		"memory": NewMemoryJobStore(),
+		"redis":  NewRedisJobStore(redis, ""),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			job := &Job{ID: "a", Kind: JobKindTTS, State: JobPending, TTS: &TTSJob{Text: "Hi", OutputPath: "a.mp3"}}
+			if err := store.SaveJob(ctx, job); err != nil {
+				t.Fatalf("SaveJob() error = %v", err)
+			}
+			job.State = JobRunning // the store must not retain the job
+			if err := store.SaveJob(ctx, &Job{ID: "b", Kind: JobKindASR, State: JobFailed}); err != nil {
+				t.Fatalf("SaveJob() error = %v", err)
+			}
+
+			got, err := store.GetJob(ctx, "a")
+			if err != nil || got.State != JobPending || got.TTS.Text != "Hi" {
+				t.Errorf("GetJob(a) = %+v, %v", got, err)
+			}
+			if _, err := store.GetJob(ctx, "missing"); !errors.Is(err, ErrJobNotFound) {
+				t.Errorf("GetJob(missing) err = %v, want %v", err, ErrJobNotFound)
+			}
+
+			jobs, err := store.ListJobs(ctx)
+			if err != nil {
+				t.Fatalf("ListJobs() error = %v", err)
+			}
+			var ids []string
+			for _, j := range jobs {
+				ids = append(ids, j.ID)
+			}
+			sort.Strings(ids)
+			if len(ids) != 2 || ids[0] != "a" || ids[1] != "b" {
+				t.Errorf("ListJobs() ids = %v, want [a b]", ids)
+			}
+		})
+	}
+
+	if _, ok := redis.values["fishaudio:jobs:job:a"]; !ok {
+		t.Errorf("redis keys = %v, want default prefix", redis.values)
+	}
+}
+
+func TestRedisJobStore_MissingJob(t *testing.T) {
+	redis := newFakeRedis()
+	store := NewRedisJobStore(redis, "test:")
+	_ = redis.SAdd(context.Background(), "test:ids", "expired")
+
+	jobs, err := store.ListJobs(context.Background())
+	if err != nil || len(jobs) != 0 {
+		t.Errorf("ListJobs() = %v, %v, want indexed but missing jobs skipped", jobs, err)
+	}
+}
@@ -0,0 +1,255 @@
+package fishaudio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newJobServer serves TTS requests with "audio:<text>" and transcription
+// requests with a fixed transcript.
+func newJobServer(t *testing.T, fail func(n int32) int) *httptest.Server {
+	var requests atomic.Int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status := fail(requests.Add(1)); status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		if r.URL.Path == "/v1/asr" {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(ASRResponse{Text: "transcribed " + r.FormValue("audio_url")})
+			return
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte("audio:" + body["text"].(string)))
+	}))
+}
+
+func noFailures(int32) int { return 0 }
+
+func runQueue(t *testing.T, q *JobQueue) context.CancelFunc {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := q.Run(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("Run() error = %v, want context.Canceled", err)
+		}
+	}()
+	stop := func() {
+		cancel()
+		<-done
+	}
+	t.Cleanup(stop)
+	return stop
+}
+
+func TestJobQueue(t *testing.T) {
+	server := newJobServer(t, noFailures)
+	defer server.Close()
+
+	var mu sync.Mutex
+	var states []JobState
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	q := NewJobQueue(client, &JobQueueOptions{OnUpdate: func(job Job) {
+		mu.Lock()
+		defer mu.Unlock()
+		if job.Kind == JobKindTTS {
+			states = append(states, job.State)
+		}
+	}})
+	runQueue(t, q)
+
+	ctx := context.Background()
+	out := filepath.Join(t.TempDir(), "hello.mp3")
+	ttsJob, err := q.SubmitTTS(ctx, &TTSJob{Text: "Hello", OutputPath: out})
+	if err != nil {
+		t.Fatalf("SubmitTTS() error = %v", err)
+	}
+	asrJob, err := q.SubmitASR(ctx, &ASRJob{AudioURL: "https://example.com/a.mp3"})
+	if err != nil {
+		t.Fatalf("SubmitASR() error = %v", err)
+	}
+
+	job, err := q.Wait(ctx, ttsJob.ID)
+	if err != nil || job.State != JobSucceeded || job.Attempts != 1 {
+		t.Fatalf("Wait(tts) = %+v, %v", job, err)
+	}
+	if audio, _ := os.ReadFile(out); string(audio) != "audio:Hello" {
+		t.Errorf("output = %q, want %q", audio, "audio:Hello")
+	}
+
+	job, err = q.Wait(ctx, asrJob.ID)
+	if err != nil || job.State != JobSucceeded {
+		t.Fatalf("Wait(asr) = %+v, %v", job, err)
+	}
+	if job.Transcript == nil || job.Transcript.Text != "transcribed https://example.com/a.mp3" {
+		t.Errorf("Transcript = %+v", job.Transcript)
+	}
+
+	jobs, err := q.List(ctx)
+	if err != nil || len(jobs) != 2 || jobs[0].ID != ttsJob.ID {
+		t.Errorf("List() = %v, %v, want both jobs oldest first", jobs, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []JobState{JobPending, JobRunning, JobSucceeded}
+	if len(states) != len(want) || states[0] != want[0] || states[1] != want[1] || states[2] != want[2] {
+		t.Errorf("updates = %v, want %v", states, want)
+	}
+}
+
+func TestJobQueue_Retry(t *testing.T) {
+	server := newJobServer(t, func(n int32) int {
+		if n == 1 {
+			return http.StatusServiceUnavailable
+		}
+		return 0
+	})
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	q := NewJobQueue(client, &JobQueueOptions{RetryDelay: time.Millisecond})
+	runQueue(t, q)
+
+	job, _ := q.SubmitTTS(context.Background(), &TTSJob{Text: "Hi", OutputPath: filepath.Join(t.TempDir(), "hi.mp3")})
+	job, err := q.Wait(context.Background(), job.ID)
+	if err != nil || job.State != JobSucceeded || job.Attempts != 2 {
+		t.Errorf("Wait() = %+v, %v, want success on attempt 2", job, err)
+	}
+}
+
+func TestJobQueue_Failure(t *testing.T) {
+	server := newJobServer(t, func(int32) int { return http.StatusUnprocessableEntity })
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	q := NewJobQueue(client, &JobQueueOptions{RetryDelay: time.Millisecond})
+	runQueue(t, q)
+
+	out := filepath.Join(t.TempDir(), "hi.mp3")
+	job, _ := q.SubmitTTS(context.Background(), &TTSJob{Text: "Hi", OutputPath: out})
+	job, err := q.Wait(context.Background(), job.ID)
+	if err != nil || job.State != JobFailed || job.Attempts != 1 || job.Error == "" {
+		t.Errorf("Wait() = %+v, %v, want failure without retry", job, err)
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Errorf("output exists after failure: %v", err)
+	}
+}
+
+func TestJobQueue_Resume(t *testing.T) {
+	server := newJobServer(t, noFailures)
+	defer server.Close()
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	store := NewMemoryJobStore()
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	// A previous process submitted one job and crashed while running another
+	first := NewJobQueue(client, &JobQueueOptions{Store: store})
+	pending, _ := first.SubmitTTS(ctx, &TTSJob{Text: "one", OutputPath: filepath.Join(dir, "1.mp3")})
+	crashed, _ := first.SubmitTTS(ctx, &TTSJob{Text: "two", OutputPath: filepath.Join(dir, "2.mp3")})
+	crashed.State = JobRunning
+	crashed.Attempts = 1
+	_ = store.SaveJob(ctx, crashed)
+
+	second := NewJobQueue(client, &JobQueueOptions{Store: store})
+	runQueue(t, second)
+	for _, id := range []string{pending.ID, crashed.ID} {
+		job, err := second.Wait(ctx, id)
+		if err != nil || job.State != JobSucceeded {
+			t.Errorf("Wait(%s) = %+v, %v", id, job, err)
+		}
+	}
+	if job, _ := second.Get(ctx, crashed.ID); job.Attempts != 2 {
+		t.Errorf("Attempts = %d, want 2", job.Attempts)
+	}
+}
+
+func TestJobQueue_Interrupted(t *testing.T) {
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		close(started)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	q := NewJobQueue(client, nil)
+	stop := runQueue(t, q)
+
+	job, _ := q.SubmitTTS(context.Background(), &TTSJob{Text: "Hi", OutputPath: filepath.Join(t.TempDir(), "hi.mp3")})
+	<-started
+	stop()
+
+	job, err := q.Get(context.Background(), job.ID)
+	if err != nil || job.State != JobPending || job.Attempts != 0 {
+		t.Errorf("Get() = %+v, %v, want pending with no attempts", job, err)
+	}
+}
+
+func TestJobQueue_RateLimit(t *testing.T) {
+	server := newJobServer(t, noFailures)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	q := NewJobQueue(client, &JobQueueOptions{RequestsPerSecond: 20})
+	dir := t.TempDir()
+	var ids []string
+	for _, name := range []string{"a", "b", "c", "d"} {
+		job, _ := q.SubmitTTS(context.Background(), &TTSJob{Text: name, OutputPath: filepath.Join(dir, name)})
+		ids = append(ids, job.ID)
+	}
+
+	start := time.Now()
+	runQueue(t, q)
+	for _, id := range ids {
+		if _, err := q.Wait(context.Background(), id); err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("4 jobs at 20/s took %v, want at least 150ms", elapsed)
+	}
+}
+
+func TestJobQueue_Submit_Validation(t *testing.T) {
+	q := NewJobQueue(NewClient(WithAPIKey("test-key")), nil)
+	ctx := context.Background()
+
+	var validationErr *ValidationError
+	if _, err := q.SubmitTTS(ctx, &TTSJob{Text: "Hi"}); !errors.As(err, &validationErr) {
+		t.Errorf("SubmitTTS(no output) err = %v, want *ValidationError", err)
+	}
+	if _, err := q.SubmitTTS(ctx, &TTSJob{OutputPath: "x.mp3"}); !errors.As(err, &validationErr) {
+		t.Errorf("SubmitTTS(no text) err = %v, want *ValidationError", err)
+	}
+	if _, err := q.SubmitASR(ctx, &ASRJob{AudioPath: "a.wav", AudioURL: "https://example.com/a.wav"}); !errors.As(err, &validationErr) {
+		t.Errorf("SubmitASR(both) err = %v, want *ValidationError", err)
+	}
+	if _, err := q.Get(ctx, "missing"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Get(missing) err = %v, want %v", err, ErrJobNotFound)
+	}
+}
+
+func TestJobQueue_RunTwice(t *testing.T) {
+	q := NewJobQueue(NewClient(WithAPIKey("test-key")), nil)
+	runQueue(t, q)
+	time.Sleep(10 * time.Millisecond)
+	if err := q.Run(context.Background()); err == nil {
+		t.Error("second Run() error = nil, want already running")
+	}
+}
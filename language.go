@@ -0,0 +1,92 @@
+package fishaudio
+
+import (
+	"slices"
+	"strings"
+)
+
+// supportedLanguages are the Language constants, in declaration order.
+var supportedLanguages = []Language{
+	LanguageEnglish, LanguageChinese, LanguageJapanese, LanguageKorean, LanguageFrench,
+	LanguageGerman, LanguageSpanish, LanguageArabic, LanguageRussian, LanguageDutch,
+	LanguageItalian, LanguagePolish, LanguagePortuguese,
+}
+
+// SupportedLanguages returns the languages Fish Audio supports.
+func SupportedLanguages() []Language {
+	return slices.Clone(supportedLanguages)
+}
+
+// Known reports whether l is one of the Language constants. Other
+// well-formed codes are still sent to the API, so newly supported
+// languages and regional variants work before the SDK lists them.
+func (l Language) Known() bool {
+	return slices.Contains(supportedLanguages, l)
+}
+
+// ParseLanguage parses a language code such as "en", "EN", or "pt_BR",
+// normalizing the case of the language and the separator of its subtags.
+// It returns a ValidationError if s is not a well-formed BCP 47 tag.
+//
+// Example:
+//
+//	lang, err := fishaudio.ParseLanguage(r.URL.Query().Get("lang"))
+//	if err != nil {
+//	    return err
+//	}
+func ParseLanguage(s string) (Language, error) {
+	primary, rest, hasRest := strings.Cut(strings.ReplaceAll(strings.TrimSpace(s), "_", "-"), "-")
+	lang := Language(strings.ToLower(primary))
+	if hasRest {
+		lang += Language("-" + rest)
+	}
+	if lang == "" {
+		return "", newValidationError("empty language code")
+	}
+	if err := lang.validate(); err != nil {
+		return "", err
+	}
+	return lang, nil
+}
+
+// validate returns a ValidationError if l is set but not a well-formed
+// tag: a two or three letter language followed by subtags of one to eight
+// letters and digits, separated by hyphens.
+func (l Language) validate() error {
+	if l == "" {
+		return nil
+	}
+	subtags := strings.Split(string(l), "-")
+	ok := len(subtags[0]) >= 2 && len(subtags[0]) <= 3 && isASCII(subtags[0], false)
+	for _, subtag := range subtags[1:] {
+		ok = ok && len(subtag) >= 1 && len(subtag) <= 8 && isASCII(subtag, true)
+	}
+	if !ok {
+		return newValidationError("invalid language code %q", string(l))
+	}
+	return nil
+}
+
+// validateLanguages validates each language code of a list.
+func validateLanguages(codes []string) error {
+	for _, code := range codes {
+		if err := Language(code).validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isASCII reports whether s is made only of ASCII letters, and digits if
+// digits is set.
+func isASCII(s string, digits bool) bool {
+	for _, c := range s {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case digits && '0' <= c && c <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
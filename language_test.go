@@ -0,0 +1,125 @@
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseLanguage(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Language
+		wantErr bool
+	}{
+		{"en", LanguageEnglish, false},
+		{" ZH ", LanguageChinese, false},
+		{"pt_BR", "pt-BR", false},
+		{"yue", "yue", false},
+		{"zh-Hant-TW", "zh-Hant-TW", false},
+		{"", "", true},
+		{"english", "", true},
+		{"e", "", true},
+		{"en-", "", true},
+		{"en-toolongsubtag", "", true},
+		{"en US", "", true},
+		{"日本", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseLanguage(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLanguage(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			var validationErr *ValidationError
+			if err != nil && !errors.As(err, &validationErr) {
+				t.Errorf("error = %v, want *ValidationError", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLanguage(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLanguage_Known(t *testing.T) {
+	for _, lang := range SupportedLanguages() {
+		if !lang.Known() {
+			t.Errorf("%q.Known() = false, want true", lang)
+		}
+		if err := lang.validate(); err != nil {
+			t.Errorf("%q.validate() error = %v", lang, err)
+		}
+	}
+	if Language("yue").Known() {
+		t.Error(`"yue".Known() = true, want false`)
+	}
+
+	langs := SupportedLanguages()
+	langs[0] = "xx"
+	if SupportedLanguages()[0] != LanguageEnglish {
+		t.Error("SupportedLanguages() shares its backing array")
+	}
+}
+
+func TestInvalidLanguage_NoRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	ctx := context.Background()
+	bad := Language("english")
+
+	calls := map[string]func() error{
+		"Transcribe": func() error {
+			_, err := client.ASR.Transcribe(ctx, []byte("audio"), &TranscribeParams{Language: bad})
+			return err
+		},
+		"TranscribeURL": func() error {
+			_, err := client.ASR.TranscribeURL(ctx, "https://example.com/a.mp3", &TranscribeParams{Language: bad})
+			return err
+		},
+		"List": func() error {
+			_, err := client.Voices.List(ctx, &ListVoicesParams{TitleLanguage: []string{"en", string(bad)}})
+			return err
+		},
+		"Stream": func() error {
+			_, err := client.TTS.Stream(ctx, &StreamParams{Text: "hi", Config: &TTSConfig{Language: bad}})
+			return err
+		},
+		"StreamWebSocket": func() error {
+			_, err := client.TTS.StreamWebSocket(ctx, make(chan string), &StreamParams{Config: &TTSConfig{Language: bad}}, nil)
+			return err
+		},
+		"SessionPool.Stream": func() error {
+			// The pool dials in the background, so point it nowhere
+			offline := NewClient(WithAPIKey("test-key"), WithBaseURL("http://127.0.0.1:1"))
+			pool := offline.TTS.NewSessionPool(ctx, nil)
+			defer func() { _ = pool.Close() }()
+			_, err := pool.Stream(ctx, make(chan string), &StreamParams{Config: &TTSConfig{Language: bad}})
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			var validationErr *ValidationError
+			if err := call(); !errors.As(err, &validationErr) {
+				t.Errorf("error = %v, want *ValidationError", err)
+			}
+		})
+	}
+}
+
+func TestTTSService_BuildRequest_Language(t *testing.T) {
+	client := NewClient(WithAPIKey("test-key"))
+	req := client.TTS.buildRequest(&StreamParams{Text: "こんにちは", Config: &TTSConfig{Language: LanguageJapanese}})
+	if req.Language != LanguageJapanese {
+		t.Errorf("Language = %q, want %q", req.Language, LanguageJapanese)
+	}
+}
@@ -0,0 +1,261 @@
+package fishaudio
+
+import (
+	"fmt"
+	"math"
+)
+
+// NormalizeOptions configures NormalizePCM and NormalizeWAV.
+type NormalizeOptions struct {
+	// TargetLoudness is the integrated loudness to reach in LUFS.
+	// Default: -23, the EBU R128 broadcast target. Streaming video and
+	// podcasts commonly use -16, telephony prompts around -20.
+	TargetLoudness float64
+	// PeakLimit is the highest sample peak allowed in dBFS. If reaching
+	// the target loudness would push a peak above it, less gain is
+	// applied, so the audio never clips. Default: -1.
+	PeakLimit float64
+	// PeakOnly scales the audio so its sample peak is at PeakLimit,
+	// without measuring loudness.
+	PeakOnly bool
+}
+
+// MeasureLoudness returns the integrated loudness of interleaved 16-bit
+// little-endian PCM in LUFS, measured as specified by ITU-R BS.1770 and
+// EBU R128: K-weighted, in 400 ms blocks, gated at -70 LUFS and 10 LU
+// below the ungated loudness. Audio shorter than one block is measured as
+// a single block. Silence is -Inf.
+//
+// Example:
+//
+//	lufs, err := fishaudio.MeasureLoudness(pcm, 1, 44100)
+func MeasureLoudness(pcm []byte, channels, sampleRate int) (float64, error) {
+	if channels <= 0 || sampleRate <= 0 {
+		return 0, fmt.Errorf("invalid PCM format: %d channels at %d Hz", channels, sampleRate)
+	}
+	return integratedLoudness(decodeFloat(pcm, 16), channels, sampleRate), nil
+}
+
+// NormalizePCM scales interleaved 16-bit little-endian PCM to the target
+// loudness of opts, so prompts synthesized with different voices play back
+// at the same level. Silent audio is returned unchanged.
+//
+// Example:
+//
+//	audio, err := client.TTS.Convert(ctx, &fishaudio.ConvertParams{
+//	    Text:   "Press one for sales.",
+//	    Format: fishaudio.AudioFormatPCM,
+//	})
+//	audio, err = fishaudio.NormalizePCM(audio, 1, fishaudio.DefaultPCMSampleRate, &fishaudio.NormalizeOptions{
+//	    TargetLoudness: -20,
+//	})
+func NormalizePCM(pcm []byte, channels, sampleRate int, opts *NormalizeOptions) ([]byte, error) {
+	if channels <= 0 || sampleRate <= 0 {
+		return nil, fmt.Errorf("invalid PCM format: %d channels at %d Hz", channels, sampleRate)
+	}
+	return normalize(pcmFormat{sampleRate: sampleRate, channels: channels, bitsPerSample: 16}, pcm, opts), nil
+}
+
+// NormalizeWAV is NormalizePCM for a PCM WAV file of any supported bit
+// depth. The result is a WAV file in the same format.
+//
+// Example:
+//
+//	wav, _ := os.ReadFile("greeting.wav")
+//	wav, err := fishaudio.NormalizeWAV(wav, nil)
+func NormalizeWAV(wav []byte, opts *NormalizeOptions) ([]byte, error) {
+	f, pcm, err := parseWAV(wav)
+	if err != nil {
+		return nil, err
+	}
+	if !validBitDepth(f.bitsPerSample) {
+		return nil, fmt.Errorf("unsupported bit depth %d", f.bitsPerSample)
+	}
+	return encodeWAV(f, normalize(f, pcm, opts)), nil
+}
+
+// normalize returns pcm scaled as opts asks.
+func normalize(f pcmFormat, pcm []byte, opts *NormalizeOptions) []byte {
+	target, limit := -23.0, -1.0
+	peakOnly := false
+	if opts != nil {
+		if opts.TargetLoudness != 0 {
+			target = opts.TargetLoudness
+		}
+		if opts.PeakLimit != 0 {
+			limit = opts.PeakLimit
+		}
+		peakOnly = opts.PeakOnly
+	}
+
+	samples := decodeFloat(pcm, f.bitsPerSample)
+	var peak float64
+	for _, v := range samples {
+		peak = math.Max(peak, math.Abs(v))
+	}
+	if peak == 0 {
+		return pcm
+	}
+
+	// Gains are in dB until applied
+	peakGain := limit - 20*math.Log10(peak)
+	gain := peakGain
+	if !peakOnly {
+		loudness := integratedLoudness(samples, f.channels, f.sampleRate)
+		if math.IsInf(loudness, -1) {
+			return pcm
+		}
+		gain = math.Min(target-loudness, peakGain)
+	}
+
+	scale := math.Pow(10, gain/20)
+	for i := range samples {
+		samples[i] *= scale
+	}
+	return encodeFloat(samples, f.bitsPerSample)
+}
+
+// integratedLoudness returns the gated loudness of interleaved samples in
+// LUFS. All channels are weighted equally, as for mono and stereo.
+func integratedLoudness(samples []float64, channels, sampleRate int) float64 {
+	frames := len(samples) / channels
+	if frames == 0 {
+		return math.Inf(-1)
+	}
+
+	// Sum the squared K-weighted samples of each 100 ms segment; a block
+	// is four consecutive segments, so blocks overlap by 75%
+	segment := sampleRate / 10
+	if segment == 0 {
+		segment = 1
+	}
+	if frames < 4*segment {
+		// Too short for a full block; measure the whole audio as one
+		segment = frames
+	}
+	sums := make([]float64, frames/segment)
+	for c := 0; c < channels; c++ {
+		filter := newKWeighting(sampleRate)
+		for i := 0; i < len(sums)*segment; i++ {
+			v := filter.process(samples[i*channels+c])
+			sums[i/segment] += v * v
+		}
+	}
+
+	var blocks []float64
+	if len(sums) == 1 {
+		blocks = append(blocks, sums[0]/float64(segment))
+	}
+	for i := 0; i+4 <= len(sums); i++ {
+		blocks = append(blocks, (sums[i]+sums[i+1]+sums[i+2]+sums[i+3])/float64(4*segment))
+	}
+
+	// Absolute gate, then a relative gate 10 LU below the remaining blocks
+	gated := gateBlocks(blocks, loudnessPower(-70))
+	if len(gated) == 0 {
+		return math.Inf(-1)
+	}
+	gated = gateBlocks(gated, meanPower(gated)/10)
+	return -0.691 + 10*math.Log10(meanPower(gated))
+}
+
+// gateBlocks returns the blocks with mean square power above threshold.
+func gateBlocks(blocks []float64, threshold float64) []float64 {
+	var kept []float64
+	for _, b := range blocks {
+		if b > threshold {
+			kept = append(kept, b)
+		}
+	}
+	return kept
+}
+
+// meanPower returns the mean of block powers.
+func meanPower(blocks []float64) float64 {
+	var sum float64
+	for _, b := range blocks {
+		sum += b
+	}
+	return sum / float64(len(blocks))
+}
+
+// loudnessPower returns the mean square power of a block with the given
+// loudness in LUFS.
+func loudnessPower(lufs float64) float64 {
+	return math.Pow(10, (lufs+0.691)/10)
+}
+
+// kWeighting is the BS.1770 K-weighting filter for one channel: a high
+// shelf modelling the head, then a high pass. Coefficients are derived for
+// the sample rate, as in libebur128, so any rate is measured correctly.
+type kWeighting struct {
+	shelf, highPass biquad
+}
+
+func newKWeighting(sampleRate int) *kWeighting {
+	fs := float64(sampleRate)
+
+	k := math.Tan(math.Pi * 1681.974450955533 / fs)
+	q := 0.7071752369554196
+	vh := math.Pow(10, 3.999843853973347/20)
+	vb := math.Pow(vh, 0.4996667741545416)
+	a0 := 1 + k/q + k*k
+	shelf := biquad{
+		b0: (vh + vb*k/q + k*k) / a0,
+		b1: 2 * (k*k - vh) / a0,
+		b2: (vh - vb*k/q + k*k) / a0,
+		a1: 2 * (k*k - 1) / a0,
+		a2: (1 - k/q + k*k) / a0,
+	}
+
+	k = math.Tan(math.Pi * 38.13547087602444 / fs)
+	q = 0.5003270373238773
+	a0 = 1 + k/q + k*k
+	highPass := biquad{
+		b0: 1, b1: -2, b2: 1,
+		a1: 2 * (k*k - 1) / a0,
+		a2: (1 - k/q + k*k) / a0,
+	}
+	return &kWeighting{shelf: shelf, highPass: highPass}
+}
+
+func (k *kWeighting) process(v float64) float64 {
+	return k.highPass.process(k.shelf.process(v))
+}
+
+// biquad is a second-order IIR filter in direct form I.
+type biquad struct {
+	b0, b1, b2, a1, a2 float64
+	x1, x2, y1, y2     float64
+}
+
+func (f *biquad) process(x float64) float64 {
+	y := f.b0*x + f.b1*f.x1 + f.b2*f.x2 - f.a1*f.y1 - f.a2*f.y2
+	f.x2, f.x1 = f.x1, x
+	f.y2, f.y1 = f.y1, y
+	return y
+}
+
+// decodeFloat decodes little-endian PCM at a supported bit depth into
+// samples in [-1, 1).
+func decodeFloat(pcm []byte, bits int) []float64 {
+	size := bits / 8
+	samples := make([]float64, len(pcm)/size)
+	for i := range samples {
+		samples[i] = float64(sample32(pcm[i*size:], bits)) / (1 << 31)
+	}
+	return samples
+}
+
+// encodeFloat encodes samples in [-1, 1) as little-endian PCM, rounding to
+// the bit depth and clipping values out of range.
+func encodeFloat(samples []float64, bits int) []byte {
+	size := bits / 8
+	pcm := make([]byte, len(samples)*size)
+	step := math.Ldexp(1, bits-1)
+	for i, v := range samples {
+		q := math.Max(-step, math.Min(step-1, math.Round(v*step)))
+		putSample32(pcm[i*size:], bits, int32(q)<<(32-bits))
+	}
+	return pcm
+}
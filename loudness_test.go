@@ -0,0 +1,143 @@
+package fishaudio
+
+import (
+	"math"
+	"testing"
+)
+
+// sinePCM returns seconds of a sine tone as 16-bit PCM with the same
+// signal on every channel. amp is relative to full scale.
+func sinePCM(freq, amp float64, sampleRate, channels int, seconds float64) []byte {
+	frames := int(seconds * float64(sampleRate))
+	v := make([]int16, frames*channels)
+	for i := 0; i < frames; i++ {
+		s := clamp16(amp * 32767 * math.Sin(2*math.Pi*freq*float64(i)/float64(sampleRate)))
+		for c := 0; c < channels; c++ {
+			v[i*channels+c] = s
+		}
+	}
+	return samples16(v...)
+}
+
+// peakDBFS returns the sample peak of 16-bit PCM in dBFS.
+func peakDBFS(pcm []byte) float64 {
+	var peak float64
+	for _, v := range decode16(pcm) {
+		peak = math.Max(peak, math.Abs(float64(v)))
+	}
+	return 20 * math.Log10(peak/32768)
+}
+
+func TestMeasureLoudness(t *testing.T) {
+	// A 1 kHz tone on one channel measures 3.01 dB below its level in dBFS
+	tests := []struct {
+		name       string
+		pcm        []byte
+		channels   int
+		sampleRate int
+		want       float64
+	}{
+		{"mono 48k", sinePCM(1000, 0.5, 48000, 1, 2), 1, 48000, -9.03},
+		{"mono 44.1k", sinePCM(1000, 0.5, 44100, 1, 2), 1, 44100, -9.03},
+		{"mono 16k", sinePCM(1000, 0.1, 16000, 1, 2), 1, 16000, -23.01},
+		{"stereo", sinePCM(1000, 0.5, 48000, 2, 2), 2, 48000, -6.02},
+		{"shorter than a block", sinePCM(1000, 0.5, 48000, 1, 0.2), 1, 48000, -9.03},
+		{
+			// Ungated, the silence would halve the power to -12.04; only
+			// the three blocks overlapping the tone's end count against it
+			"silence is gated",
+			append(sinePCM(1000, 0.5, 48000, 1, 2), make([]byte, 48000*2*2)...),
+			1, 48000, -9.37,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MeasureLoudness(tt.pcm, tt.channels, tt.sampleRate)
+			if err != nil {
+				t.Fatalf("MeasureLoudness() error = %v", err)
+			}
+			if math.Abs(got-tt.want) > 0.1 {
+				t.Errorf("MeasureLoudness() = %.2f LUFS, want %.2f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMeasureLoudness_Silence(t *testing.T) {
+	for _, pcm := range [][]byte{nil, make([]byte, 48000)} {
+		got, err := MeasureLoudness(pcm, 1, 24000)
+		if err != nil {
+			t.Fatalf("MeasureLoudness() error = %v", err)
+		}
+		if !math.IsInf(got, -1) {
+			t.Errorf("MeasureLoudness(%d bytes of silence) = %v, want -Inf", len(pcm), got)
+		}
+	}
+
+	if _, err := MeasureLoudness(nil, 0, 24000); err == nil {
+		t.Error("MeasureLoudness() with 0 channels error = nil, want error")
+	}
+}
+
+func TestNormalizePCM(t *testing.T) {
+	quiet := sinePCM(440, 0.05, 24000, 1, 1)
+
+	got, err := NormalizePCM(quiet, 1, 24000, nil)
+	if err != nil {
+		t.Fatalf("NormalizePCM() error = %v", err)
+	}
+	if lufs, _ := MeasureLoudness(got, 1, 24000); math.Abs(lufs+23) > 0.1 {
+		t.Errorf("loudness = %.2f LUFS, want -23", lufs)
+	}
+
+	// Reaching -5 LUFS would clip, so the peak limit wins
+	got, _ = NormalizePCM(quiet, 1, 24000, &NormalizeOptions{TargetLoudness: -5, PeakLimit: -2})
+	if peak := peakDBFS(got); math.Abs(peak+2) > 0.05 {
+		t.Errorf("peak = %.2f dBFS, want -2", peak)
+	}
+
+	got, _ = NormalizePCM(quiet, 1, 24000, &NormalizeOptions{PeakOnly: true})
+	if peak := peakDBFS(got); math.Abs(peak+1) > 0.05 {
+		t.Errorf("peak = %.2f dBFS, want -1", peak)
+	}
+
+	silence := make([]byte, 4800)
+	if got, _ := NormalizePCM(silence, 1, 24000, nil); &got[0] != &silence[0] {
+		t.Error("NormalizePCM() copied silent audio, want it unchanged")
+	}
+
+	if _, err := NormalizePCM(quiet, 1, 0, nil); err == nil {
+		t.Error("NormalizePCM() with 0 Hz error = nil, want error")
+	}
+}
+
+func TestNormalizeWAV(t *testing.T) {
+	pcm16 := sinePCM(440, 0.05, 24000, 2, 1)
+	pcm24, err := ConvertBitDepth(pcm16, 16, 24)
+	if err != nil {
+		t.Fatal(err)
+	}
+	format := pcmFormat{sampleRate: 24000, channels: 2, bitsPerSample: 24}
+
+	wav, err := NormalizeWAV(encodeWAV(format, pcm24), &NormalizeOptions{TargetLoudness: -16})
+	if err != nil {
+		t.Fatalf("NormalizeWAV() error = %v", err)
+	}
+	f, pcm, err := parseWAV(wav)
+	if err != nil {
+		t.Fatalf("parseWAV() error = %v", err)
+	}
+	if f != format || len(pcm) != len(pcm24) {
+		t.Fatalf("format = %+v with %d bytes, want %+v with %d", f, len(pcm), format, len(pcm24))
+	}
+
+	back, _ := ConvertBitDepth(pcm, 24, 16)
+	if lufs, _ := MeasureLoudness(back, 2, 24000); math.Abs(lufs+16) > 0.1 {
+		t.Errorf("loudness = %.2f LUFS, want -16", lufs)
+	}
+
+	if _, err := NormalizeWAV([]byte("not a wav"), nil); err == nil {
+		t.Error("NormalizeWAV() error = nil, want error")
+	}
+}
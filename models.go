@@ -0,0 +1,76 @@
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+)
+
+// ModelKind is what a model does.
+type ModelKind string
+
+const (
+	ModelKindTTS ModelKind = "tts"
+	ModelKindASR ModelKind = "asr"
+)
+
+// ModelInfo describes a model available to the API key.
+type ModelInfo struct {
+	// ID is the model identifier, e.g. ModelS2Pro for a TTS model.
+	ID string `json:"id"`
+	// Kind is what the model does.
+	Kind ModelKind `json:"type"`
+	// Title is the display name of the model.
+	Title string `json:"title,omitempty"`
+	// Description describes the model.
+	Description string `json:"description,omitempty"`
+	// Languages are the languages the model supports, if listed.
+	Languages []Language `json:"languages,omitempty"`
+	// Capabilities lists features of the model, such as "streaming" or
+	// "emotion".
+	Capabilities []string `json:"capabilities,omitempty"`
+	// Deprecated reports whether the model is deprecated.
+	Deprecated bool `json:"deprecated,omitempty"`
+}
+
+// knownModels are the Model constants, newest first, used by ListModels
+// when the API doesn't list models.
+var knownModels = []ModelInfo{
+	{ID: string(ModelS2Pro), Kind: ModelKindTTS, Title: "S2 Pro"},
+	{ID: string(ModelS1), Kind: ModelKindTTS, Title: "S1"},
+	{ID: string(ModelSpeech16), Kind: ModelKindTTS, Title: "Speech 1.6", Deprecated: true},
+	{ID: string(ModelSpeech15), Kind: ModelKindTTS, Title: "Speech 1.5", Deprecated: true},
+}
+
+// Known reports whether m is one of the Model constants. Other models are
+// still sent to the API, so new releases work before the SDK lists them.
+func (m Model) Known() bool {
+	return slices.ContainsFunc(knownModels, func(info ModelInfo) bool { return info.ID == string(m) })
+}
+
+// ListModels returns the TTS and ASR models available to the API key, for
+// model pickers and for noticing new releases with Model.Known. If the API
+// doesn't provide the list, it returns the TTS models of the Model
+// constants instead; other errors are returned.
+//
+// Example:
+//
+//	models, err := client.ListModels(ctx)
+//	for _, m := range models {
+//	    if m.Kind == fishaudio.ModelKindTTS && !m.Deprecated {
+//	        fmt.Println(m.ID, m.Title)
+//	    }
+//	}
+func (c *Client) ListModels(ctx context.Context) ([]ModelInfo, error) {
+	var result PaginatedResponse[ModelInfo]
+	err := c.doJSONRequest(ctx, http.MethodGet, "/v1/models", nil, &result, nil)
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		return slices.Clone(knownModels), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
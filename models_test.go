@@ -0,0 +1,64 @@
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_ListModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/models" {
+			t.Errorf("request = %s %s, want GET /v1/models", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"total": 2, "items": [
+			{"id": "s3", "type": "tts", "title": "S3", "languages": ["en", "ja"], "capabilities": ["streaming", "emotion"]},
+			{"id": "asr-1", "type": "asr"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	models, err := client.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	if len(models) != 2 || models[0].ID != "s3" || models[0].Kind != ModelKindTTS || len(models[0].Languages) != 2 ||
+		len(models[0].Capabilities) != 2 || models[1].Kind != ModelKindASR {
+		t.Errorf("ListModels() = %+v", models)
+	}
+	if Model(models[0].ID).Known() {
+		t.Errorf("Model(%q).Known() = true, want false", models[0].ID)
+	}
+}
+
+func TestClient_ListModels_Fallback(t *testing.T) {
+	status := http.StatusNotFound
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", status)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	models, err := client.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	if len(models) != 4 || models[0].ID != string(ModelS2Pro) || !models[3].Deprecated {
+		t.Errorf("ListModels() = %+v, want the Model constants", models)
+	}
+	for _, m := range models {
+		if !Model(m.ID).Known() {
+			t.Errorf("Model(%q).Known() = false, want true", m.ID)
+		}
+	}
+
+	status = http.StatusUnauthorized
+	var authErr *AuthenticationError
+	if _, err := client.ListModels(context.Background()); !errors.As(err, &authErr) {
+		t.Errorf("ListModels() error = %v, want *AuthenticationError", err)
+	}
+}
@@ -0,0 +1,79 @@
+package fishaudio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// acceptMsgpack prefers msgpack responses but accepts JSON from endpoints
+// that don't support it.
+const acceptMsgpack = "application/msgpack, application/json;q=0.9"
+
+// WithMsgpackResponses asks the API for msgpack instead of JSON responses,
+// which are smaller for large listings such as voices with many samples.
+// Responses are decoded by their Content-Type, so endpoints that only
+// return JSON keep working.
+func WithMsgpackResponses() ClientOption {
+	return func(c *Client) {
+		c.msgpackResponses = true
+	}
+}
+
+// isMsgpack reports whether contentType is a msgpack media type.
+func isMsgpack(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/msgpack", "application/x-msgpack", "application/vnd.msgpack":
+		return true
+	}
+	return false
+}
+
+// decodeResponse decodes a JSON or msgpack response body into result,
+// based on the response's Content-Type.
+func decodeResponse(resp *http.Response, result interface{}) error {
+	if !isMsgpack(resp.Header.Get("Content-Type")) {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	data, err = msgpackToJSON(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+// errorBody reads and closes the body of a failed response, converting a
+// msgpack body to JSON so errors parse the same way from either.
+func errorBody(resp *http.Response) string {
+	data, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if isMsgpack(resp.Header.Get("Content-Type")) {
+		if converted, err := msgpackToJSON(data); err == nil {
+			data = converted
+		}
+	}
+	return string(data)
+}
+
+// msgpackToJSON converts a msgpack document to JSON, so that response
+// types decode the same way from either, including their Extra fields.
+// Binary values become base64 strings, as []byte fields expect in JSON.
+func msgpackToJSON(data []byte) ([]byte, error) {
+	if err := checkMsgpack(data); err != nil {
+		return nil, fmt.Errorf("invalid msgpack: %w", err)
+	}
+	var v interface{}
+	if err := msgpack.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("invalid msgpack: %w", err)
+	}
+	return json.Marshal(v)
+}
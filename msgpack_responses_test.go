@@ -0,0 +1,110 @@
+package fishaudio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestWithMsgpackResponses(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	var accepts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accepts = append(accepts, r.Header.Get("Accept"))
+		switch r.URL.Path {
+		case "/model/msgpack":
+			w.Header().Set("Content-Type", "application/msgpack")
+			_ = msgpack.NewEncoder(w).Encode(map[string]interface{}{
+				"_id":        "msgpack",
+				"title":      "Narrator",
+				"created_at": created,
+				"samples":    []map[string]interface{}{{"title": "intro", "audio": "https://cdn/intro.mp3"}},
+				"rating":     4.5,
+			})
+		case "/model/json":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"_id": "json", "title": "Fallback"}`))
+		default:
+			w.Header().Set("Content-Type", "application/msgpack")
+			w.WriteHeader(http.StatusNotFound)
+			_ = msgpack.NewEncoder(w).Encode(map[string]interface{}{"detail": "not found"})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithMsgpackResponses())
+	voice, err := client.Voices.Get(context.Background(), "msgpack")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if voice.ID != "msgpack" || voice.Title != "Narrator" || !voice.CreatedAt.Equal(created) || len(voice.Samples) != 1 {
+		t.Errorf("Get() = %+v", voice)
+	}
+	if string(voice.Extra["rating"]) != "4.5" {
+		t.Errorf("Extra = %v, want the unknown rating field", voice.Extra)
+	}
+	if accepts[0] != acceptMsgpack {
+		t.Errorf("Accept = %q, want %q", accepts[0], acceptMsgpack)
+	}
+
+	// JSON responses decode as before
+	if voice, err := client.Voices.Get(context.Background(), "json"); err != nil || voice.Title != "Fallback" {
+		t.Errorf("Get() = %+v, %v, want the JSON voice", voice, err)
+	}
+
+	// Error bodies are converted to JSON
+	_, err = client.Voices.Get(context.Background(), "missing")
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) || !json.Valid([]byte(notFound.Body)) || !strings.Contains(notFound.Body, "not found") {
+		t.Errorf("Get() error = %v, want *NotFoundError with a JSON body", err)
+	}
+
+	// Without the option, no preference is sent
+	client = NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	_, _ = client.Voices.Get(context.Background(), "json")
+	if got := accepts[len(accepts)-1]; got != "" {
+		t.Errorf("Accept = %q without WithMsgpackResponses, want none", got)
+	}
+}
+
+func TestMsgpackToJSON_Invalid(t *testing.T) {
+	data, _ := msgpack.Marshal(map[string]interface{}{"audio": []byte("abc")})
+	if _, err := msgpackToJSON(data[:len(data)-1]); err == nil {
+		t.Error("msgpackToJSON(truncated) error = nil, want an error")
+	}
+	if got, err := msgpackToJSON(data); err != nil || string(got) != `{"audio":"YWJj"}` {
+		t.Errorf("msgpackToJSON() = %s, %v, want base64 audio", got, err)
+	}
+}
+
+func TestMsgpackResponses_MultipartError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/msgpack")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = msgpack.NewEncoder(w).Encode(map[string]interface{}{
+			"detail": []interface{}{map[string]interface{}{
+				"loc":  []interface{}{"body", "language"},
+				"msg":  "Unsupported language",
+				"type": "value_error",
+			}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithMsgpackResponses())
+	_, err := client.ASR.Transcribe(context.Background(), []byte("audio"), nil)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Transcribe() error = %v, want *ValidationError", err)
+	}
+	if len(validationErr.Fields) != 1 || validationErr.Fields[0].Field() != "language" || validationErr.Fields[0].Type != "value_error" {
+		t.Errorf("Fields = %+v, want the language field", validationErr.Fields)
+	}
+}
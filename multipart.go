@@ -0,0 +1,203 @@
+package fishaudio
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"strings"
+	"time"
+)
+
+// doMultipartRequest performs an authenticated request whose multipart body
+// is produced by write. The body is streamed through a pipe with chunked
+// transfer encoding, so large uploads are never buffered in memory. write
+// must not close the multipart writer.
+//
+// Failed attempts are retried as the client's RetryPolicy decides. write is
+// called again for each attempt, so readers it uploads must be passed as
+// uploads, which are rewound before each retry.
+func (c *Client) doMultipartRequest(ctx context.Context, method, path string, write func(*multipart.Writer) error, uploads ...*replayReader) (*http.Response, error) {
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			for _, upload := range uploads {
+				if _, err := upload.rewind(); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		resp, req, err := c.sendMultipart(ctx, method, path, write)
+		if err == nil {
+			return resp, nil
+		}
+		if req != nil {
+			err = withRequest(err, req, attempt, start)
+		}
+		if req == nil || !replayable(uploads) {
+			return nil, err
+		}
+		if retry, err := c.retryWait(ctx, resp, err, attempt); !retry {
+			return nil, err
+		}
+	}
+}
+
+// sendMultipart makes one attempt of a multipart request. On an error
+// status, the response is returned along with an error holding its body.
+// The request is nil if it could not be created. sendMultipart returns
+// once write has stopped, so the uploads can be rewound.
+func (c *Client) sendMultipart(ctx context.Context, method, path string, write func(*multipart.Writer) error) (*http.Response, *http.Request, error) {
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		err := c.guard("multipart writer", func() error {
+			return write(writer)
+		})()
+		if err == nil {
+			err = writer.Close()
+		}
+		// A write error means the request ended early or the form could not
+		// be built; closing the pipe with it reports it to the transport
+		pw.CloseWithError(err)
+	}()
+	// Unblock the writer if the request returns before reading the whole body
+	defer func() {
+		_ = pr.Close()
+		<-written
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, pr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.authorize(req.Header)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("User-Agent", "fish-audio/go/"+Version)
+
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, req, err
+	}
+
+	if resp.StatusCode >= 400 {
+		return resp, req, newAPIError(resp.StatusCode, resp.Status, errorBody(resp))
+	}
+
+	return resp, req, nil
+}
+
+// replayReader replays an upload from its starting position on each
+// attempt.
+type replayReader struct {
+	r       io.Reader
+	seeker  io.Seeker
+	start   int64
+	cleanup func()
+}
+
+// newReplayReader prepares r for replay. A reader that can't seek is
+// spooled to a temporary file when retry is set, and read once otherwise.
+func newReplayReader(r io.Reader, retry bool) (*replayReader, error) {
+	if rs, ok := r.(io.ReadSeeker); ok {
+		// Pipes and terminals implement io.Seeker but fail to seek
+		if start, err := rs.Seek(0, io.SeekCurrent); err == nil {
+			return &replayReader{r: rs, seeker: rs, start: start}, nil
+		}
+	}
+	if !retry {
+		return &replayReader{r: r}, nil
+	}
+
+	f, err := os.CreateTemp("", "fishaudio-upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to buffer audio: %w", err)
+	}
+	cleanup := func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		cleanup()
+		return nil, fmt.Errorf("failed to buffer audio: %w", err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		cleanup()
+		return nil, fmt.Errorf("failed to buffer audio: %w", err)
+	}
+	return &replayReader{r: f, seeker: f, cleanup: cleanup}, nil
+}
+
+// upload prepares r for an upload, spooling it if the client retries
+// requests and r can't seek.
+func (c *Client) upload(r io.Reader) (*replayReader, error) {
+	return newReplayReader(r, c.retryPolicy != nil)
+}
+
+// Read reads from the current attempt's position.
+func (r *replayReader) Read(p []byte) (int, error) {
+	return r.r.Read(p)
+}
+
+// rewind returns the reader positioned at the start of the audio.
+func (r *replayReader) rewind() (io.Reader, error) {
+	if r.seeker != nil {
+		if _, err := r.seeker.Seek(r.start, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("failed to rewind audio: %w", err)
+		}
+	}
+	return r.r, nil
+}
+
+// close removes any temporary file.
+func (r *replayReader) close() {
+	if r.cleanup != nil {
+		r.cleanup()
+	}
+}
+
+// replayable reports whether all uploads can be rewound.
+func replayable(uploads []*replayReader) bool {
+	for _, upload := range uploads {
+		if upload.seeker == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// formFile describes a file part of a multipart upload.
+type formFile struct {
+	name        string
+	contentType string
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// createFilePart creates a multipart file part with an explicit content type.
+// multipart.Writer.CreateFormFile always uses application/octet-stream.
+func createFilePart(writer *multipart.Writer, field string, file formFile) (io.Writer, error) {
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+		quoteEscaper.Replace(field), quoteEscaper.Replace(file.name)))
+	h.Set("Content-Type", file.contentType)
+	return writer.CreatePart(h)
+}
+
+// writeFilePart writes data as a file part.
+func writeFilePart(writer *multipart.Writer, field string, file formFile, data io.Reader) error {
+	part, err := createFilePart(writer, field, file)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, data)
+	return err
+}
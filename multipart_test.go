@@ -0,0 +1,133 @@
+package fishaudio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCreateFilePart(t *testing.T) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, err := createFilePart(writer, "audio", formFile{name: `my "clip".wav`, contentType: "audio/wav"})
+	if err != nil {
+		t.Fatalf("createFilePart() error = %v", err)
+	}
+	_, _ = part.Write([]byte("data"))
+	_ = writer.Close()
+
+	reader := multipart.NewReader(&buf, writer.Boundary())
+	p, err := reader.NextPart()
+	if err != nil {
+		t.Fatalf("NextPart() error = %v", err)
+	}
+	if p.FormName() != "audio" {
+		t.Errorf("FormName() = %q, want %q", p.FormName(), "audio")
+	}
+	if p.FileName() != `my "clip".wav` {
+		t.Errorf("FileName() = %q, want %q", p.FileName(), `my "clip".wav`)
+	}
+	if ct := p.Header.Get("Content-Type"); ct != "audio/wav" {
+		t.Errorf("Content-Type = %q, want %q", ct, "audio/wav")
+	}
+}
+
+func TestDoMultipartRequest_Streams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength != -1 {
+			t.Errorf("ContentLength = %d, want -1 (chunked)", r.ContentLength)
+		}
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm error = %v", err)
+		}
+		if r.FormValue("title") != "hello" {
+			t.Errorf("title = %q, want %q", r.FormValue("title"), "hello")
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("FormFile error = %v", err)
+		}
+		data, _ := io.ReadAll(file)
+		if len(data) != 1<<20 {
+			t.Errorf("file size = %d, want %d", len(data), 1<<20)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	resp, err := client.doMultipartRequest(context.Background(), http.MethodPost, "/upload", func(w *multipart.Writer) error {
+		if err := w.WriteField("title", "hello"); err != nil {
+			return err
+		}
+		return writeFilePart(w, "file", formFile{name: "a.bin", contentType: "application/octet-stream"},
+			bytes.NewReader(make([]byte, 1<<20)))
+	})
+	if err != nil {
+		t.Fatalf("doMultipartRequest() error = %v", err)
+	}
+	_ = resp.Body.Close()
+}
+
+func TestDoMultipartRequest_WriteError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	writeErr := errors.New("disk failure")
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	_, err := client.doMultipartRequest(context.Background(), http.MethodPost, "/upload", func(w *multipart.Writer) error {
+		return writeErr
+	})
+	if !errors.Is(err, writeErr) {
+		t.Errorf("error = %v, want %v", err, writeErr)
+	}
+}
+
+func TestDoMultipartRequest_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Respond without reading the body, as a server rejecting the
+		// request up front would
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("invalid key"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	_, err := client.doMultipartRequest(context.Background(), http.MethodPost, "/upload", func(w *multipart.Writer) error {
+		return writeFilePart(w, "file", formFile{name: "a.bin", contentType: "application/octet-stream"},
+			strings.NewReader(strings.Repeat("x", 4<<20)))
+	})
+	var authErr *AuthenticationError
+	if !errors.As(err, &authErr) {
+		t.Errorf("error = %v, want *AuthenticationError", err)
+	}
+}
+
+func TestNewReplayReader_NoRetry(t *testing.T) {
+	src := io.MultiReader(bytes.NewReader([]byte("audio")))
+	replay, err := newReplayReader(src, false)
+	if err != nil {
+		t.Fatalf("newReplayReader() error = %v", err)
+	}
+	defer replay.close()
+
+	if replay.cleanup != nil {
+		t.Error("unseekable reader should not be spooled when retries are disabled")
+	}
+	r, err := replay.rewind()
+	if err != nil {
+		t.Fatalf("rewind() error = %v", err)
+	}
+	if r != src {
+		t.Error("rewind() should return the original reader")
+	}
+}
@@ -0,0 +1,194 @@
+// Package oggopus reads Opus packets from an Ogg Opus stream, such as the
+// SDK's TTS output with the opus format. The packets can be sent over
+// WebRTC or to voice chat services as-is, without decoding and re-encoding.
+package oggopus
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// SampleRate is the rate Opus packet durations are measured at.
+const SampleRate = 48000
+
+// Head is the Opus identification header of a stream.
+type Head struct {
+	// Channels is the channel count of the encoded audio.
+	Channels int
+	// PreSkip is the number of 48 kHz samples to discard from the start of
+	// the decoded audio.
+	PreSkip int
+	// InputSampleRate is the sample rate of the audio before encoding. It
+	// is informational; Opus always decodes at up to 48 kHz.
+	InputSampleRate int
+}
+
+// Reader reads Opus packets from an Ogg Opus stream. Only the first
+// logical stream is read; chained streams are not supported.
+//
+// Example:
+//
+//	stream, _ := client.TTS.Stream(ctx, &fishaudio.StreamParams{Text: "Hi", Format: fishaudio.AudioFormatOpus})
+//	packets := oggopus.NewReader(stream)
+//	for {
+//	    packet, err := packets.ReadPacket()
+//	    if err == io.EOF {
+//	        break
+//	    }
+//	    if err != nil {
+//	        return err
+//	    }
+//	    send(packet, oggopus.PacketDuration(packet))
+//	}
+type Reader struct {
+	r       *bufio.Reader
+	head    *Head
+	serial  uint32
+	pending [][]byte
+	partial []byte
+	started bool
+	eos     bool
+}
+
+// NewReader creates a Reader reading from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// Head returns the stream's identification header, or nil before the first
+// call to ReadPacket.
+func (r *Reader) Head() *Head {
+	return r.head
+}
+
+// ReadPacket returns the next audio packet. The identification and comment
+// headers are consumed, not returned. It returns io.EOF at the end of the
+// stream.
+func (r *Reader) ReadPacket() ([]byte, error) {
+	for {
+		if len(r.pending) > 0 {
+			packet := r.pending[0]
+			r.pending = r.pending[1:]
+
+			switch {
+			case r.head == nil:
+				head, err := parseHead(packet)
+				if err != nil {
+					return nil, err
+				}
+				r.head = head
+				continue
+			case bytes.HasPrefix(packet, []byte("OpusTags")):
+				continue
+			}
+			return packet, nil
+		}
+
+		if r.eos {
+			return nil, io.EOF
+		}
+		if err := r.readPage(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// readPage reads one Ogg page, queueing its complete packets.
+func (r *Reader) readPage() error {
+	var header [27]byte
+	if _, err := io.ReadFull(r.r, header[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			r.eos = true
+			return nil
+		}
+		return fmt.Errorf("oggopus: %w", err)
+	}
+	if !bytes.Equal(header[0:4], []byte("OggS")) {
+		return errors.New("oggopus: not an Ogg stream")
+	}
+	headerType := header[5]
+	serial := binary.LittleEndian.Uint32(header[14:18])
+
+	segments := make([]byte, header[26])
+	if _, err := io.ReadFull(r.r, segments); err != nil {
+		return fmt.Errorf("oggopus: %w", err)
+	}
+	size := 0
+	for _, s := range segments {
+		size += int(s)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		return fmt.Errorf("oggopus: %w", err)
+	}
+
+	if !r.started {
+		r.serial = serial
+		r.started = true
+	}
+	if serial != r.serial {
+		return nil
+	}
+	if headerType&0x01 == 0 {
+		// A page that doesn't continue a packet drops any unfinished one
+		r.partial = nil
+	}
+
+	for _, s := range segments {
+		r.partial = append(r.partial, data[:s]...)
+		data = data[s:]
+		if s < 255 {
+			r.pending = append(r.pending, r.partial)
+			r.partial = nil
+		}
+	}
+	if headerType&0x04 != 0 {
+		r.eos = true
+	}
+	return nil
+}
+
+// parseHead parses an OpusHead packet.
+func parseHead(packet []byte) (*Head, error) {
+	if len(packet) < 19 || !bytes.Equal(packet[0:8], []byte("OpusHead")) {
+		return nil, errors.New("oggopus: missing OpusHead; not an Opus stream")
+	}
+	return &Head{
+		Channels:        int(packet[9]),
+		PreSkip:         int(binary.LittleEndian.Uint16(packet[10:12])),
+		InputSampleRate: int(binary.LittleEndian.Uint32(packet[12:16])),
+	}, nil
+}
+
+// frameSizes maps the configuration number of an Opus TOC byte to the
+// frame duration in units of 2.5 ms.
+var frameSizes = [32]int{
+	4, 8, 16, 24, 4, 8, 16, 24, 4, 8, 16, 24, // SILK
+	4, 8, 4, 8, // Hybrid
+	1, 2, 4, 8, 1, 2, 4, 8, 1, 2, 4, 8, 1, 2, 4, 8, // CELT
+}
+
+// PacketDuration returns the audio duration of an Opus packet, read from
+// its table-of-contents byte, or 0 if the packet is malformed.
+func PacketDuration(packet []byte) time.Duration {
+	if len(packet) == 0 {
+		return 0
+	}
+	toc := packet[0]
+	frames := 1
+	switch toc & 0x03 {
+	case 1, 2:
+		frames = 2
+	case 3:
+		if len(packet) < 2 {
+			return 0
+		}
+		frames = int(packet[1] & 0x3F)
+	}
+	return time.Duration(frameSizes[toc>>3]*frames) * 2500 * time.Microsecond
+}
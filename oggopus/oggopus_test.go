@@ -0,0 +1,116 @@
+package oggopus
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// page builds an Ogg page holding the given segments of data.
+func page(headerType byte, serial uint32, lacing []byte, data []byte) []byte {
+	var b bytes.Buffer
+	b.WriteString("OggS")
+	b.WriteByte(0)
+	b.WriteByte(headerType)
+	b.Write(make([]byte, 8)) // granule position
+	_ = binary.Write(&b, binary.LittleEndian, serial)
+	b.Write(make([]byte, 8)) // sequence number and CRC
+	b.WriteByte(byte(len(lacing)))
+	b.Write(lacing)
+	b.Write(data)
+	return b.Bytes()
+}
+
+// lace returns the lacing values of a packet of size n.
+func lace(n int) []byte {
+	var l []byte
+	for ; n >= 255; n -= 255 {
+		l = append(l, 255)
+	}
+	return append(l, byte(n))
+}
+
+func opusHead(channels byte) []byte {
+	head := []byte("OpusHead\x01")
+	head = append(head, channels, 0x38, 0x01) // pre-skip 312
+	head = binary.LittleEndian.AppendUint32(head, 24000)
+	return append(head, 0, 0, 0)
+}
+
+func TestReader(t *testing.T) {
+	head := opusHead(1)
+	tags := []byte("OpusTags\x00\x00\x00\x00\x00\x00\x00\x00")
+	p1 := bytes.Repeat([]byte{0xFC}, 10)
+	p2 := bytes.Repeat([]byte{0xFD}, 300) // spans two pages
+
+	var stream []byte
+	stream = append(stream, page(0x02, 7, lace(len(head)), head)...)
+	stream = append(stream, page(0x02, 9, lace(3), []byte("xyz"))...) // another logical stream
+	stream = append(stream, page(0, 7, lace(len(tags)), tags)...)
+	stream = append(stream, page(0, 7, append(lace(len(p1)), 255), append(append([]byte{}, p1...), p2[:255]...))...)
+	stream = append(stream, page(0x01|0x04, 7, lace(45), p2[255:])...)
+
+	r := NewReader(bytes.NewReader(stream))
+	var got [][]byte
+	for {
+		packet, err := r.ReadPacket()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("ReadPacket() error = %v", err)
+		}
+		got = append(got, packet)
+	}
+
+	if len(got) != 2 || !bytes.Equal(got[0], p1) || !bytes.Equal(got[1], p2) {
+		t.Errorf("packets = %d, want p1 and p2", len(got))
+	}
+	want := Head{Channels: 1, PreSkip: 312, InputSampleRate: 24000}
+	if h := r.Head(); h == nil || *h != want {
+		t.Errorf("Head() = %+v, want %+v", h, want)
+	}
+}
+
+func TestReader_NotOpus(t *testing.T) {
+	stream := page(0x02, 1, lace(8), []byte("OggVorbi"))
+	if _, err := NewReader(bytes.NewReader(stream)).ReadPacket(); err == nil {
+		t.Error("ReadPacket() error = nil for a non-Opus stream")
+	}
+	if _, err := NewReader(bytes.NewReader([]byte("ID3 not ogg at all........."))).ReadPacket(); err == nil {
+		t.Error("ReadPacket() error = nil for a non-Ogg stream")
+	}
+}
+
+func TestReader_Truncated(t *testing.T) {
+	head := opusHead(2)
+	stream := page(0x02, 1, lace(len(head)), head)
+	stream = append(stream, page(0, 1, lace(20), make([]byte, 20))[:30]...)
+	_, err := NewReader(bytes.NewReader(stream)).ReadPacket()
+	if err == nil || errors.Is(err, io.EOF) {
+		t.Errorf("ReadPacket() err = %v, want unexpected EOF", err)
+	}
+}
+
+func TestPacketDuration(t *testing.T) {
+	tests := []struct {
+		packet []byte
+		want   time.Duration
+	}{
+		{[]byte{0xFC}, 20 * time.Millisecond},        // CELT FB 20 ms, 1 frame
+		{[]byte{0xFD}, 40 * time.Millisecond},        // 2 frames
+		{[]byte{0x1B, 0x03}, 180 * time.Millisecond}, // SILK 60 ms, 3 frames
+		{[]byte{0x60}, 10 * time.Millisecond},        // Hybrid 10 ms
+		{[]byte{0x80}, 2500 * time.Microsecond},      // CELT 2.5 ms
+		{[]byte{0x0B}, 0},                            // code 3 without frame count
+		{nil, 0},
+	}
+	for _, tt := range tests {
+		if got := PacketDuration(tt.packet); got != tt.want {
+			t.Errorf("PacketDuration(%x) = %v, want %v", tt.packet, got, tt.want)
+		}
+	}
+}
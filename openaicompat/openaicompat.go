@@ -0,0 +1,120 @@
+// Package openaicompat adapts the Fish Audio client to the method shapes of
+// the go-openai client's speech and transcription calls, easing migration
+// for code written against them. Only the request fields that map to Fish
+// Audio are honored; the rest are ignored.
+//
+// Example:
+//
+//	// Before: client := openai.NewClient(key)
+//	client := openaicompat.NewClient(fishaudio.NewClient())
+//	client.Voices["alloy"] = "your-fish-voice-id"
+//	speech, err := client.CreateSpeech(ctx, openaicompat.CreateSpeechRequest{
+//	    Input: "Hello!",
+//	    Voice: "alloy",
+//	})
+package openaicompat
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+)
+
+// Client serves OpenAI-shaped requests with Fish Audio.
+type Client struct {
+	fish *fishaudio.Client
+
+	// Voices maps OpenAI voice names such as "alloy" to Fish Audio voice
+	// model IDs. Voices not in the map are used as model IDs directly.
+	Voices map[string]string
+}
+
+// NewClient creates a Client backed by fish.
+func NewClient(fish *fishaudio.Client) *Client {
+	return &Client{fish: fish, Voices: map[string]string{}}
+}
+
+// SpeechResponseFormat is the audio format of a speech response.
+type SpeechResponseFormat string
+
+const (
+	SpeechResponseFormatMp3  SpeechResponseFormat = "mp3"
+	SpeechResponseFormatOpus SpeechResponseFormat = "opus"
+	SpeechResponseFormatWav  SpeechResponseFormat = "wav"
+	SpeechResponseFormatPcm  SpeechResponseFormat = "pcm"
+)
+
+// CreateSpeechRequest mirrors go-openai's CreateSpeechRequest.
+type CreateSpeechRequest struct {
+	// Model is a Fish Audio model such as "s1". OpenAI model names such as
+	// "tts-1" use the default model.
+	Model string `json:"model"`
+	// Input is the text to speak.
+	Input string `json:"input"`
+	// Voice is an OpenAI voice name mapped through Client.Voices, or a Fish
+	// Audio voice model ID.
+	Voice string `json:"voice"`
+	// Instructions is ignored.
+	Instructions string `json:"instructions,omitempty"`
+	// ResponseFormat is the audio format. AAC and FLAC are not supported.
+	// Default: mp3.
+	ResponseFormat SpeechResponseFormat `json:"response_format,omitempty"`
+	// Speed is the speech speed multiplier, from 0.5 to 2.0. Default: 1.0.
+	Speed float64 `json:"speed,omitempty"`
+}
+
+// RawResponse mirrors go-openai's RawResponse: the audio body, which the
+// caller must close.
+type RawResponse struct {
+	io.ReadCloser
+
+	header http.Header
+}
+
+// Header returns the response headers.
+func (r RawResponse) Header() http.Header {
+	return r.header
+}
+
+// CreateSpeech synthesizes request.Input and returns the audio as it
+// streams.
+func (c *Client) CreateSpeech(ctx context.Context, request CreateSpeechRequest) (RawResponse, error) {
+	format := fishaudio.AudioFormat(request.ResponseFormat)
+	switch request.ResponseFormat {
+	case "":
+		format = fishaudio.AudioFormatMP3
+	case SpeechResponseFormatMp3, SpeechResponseFormatOpus, SpeechResponseFormatWav, SpeechResponseFormatPcm:
+	default:
+		return RawResponse{}, fmt.Errorf("openaicompat: unsupported response format %q", request.ResponseFormat)
+	}
+
+	voice := request.Voice
+	if id, ok := c.Voices[voice]; ok {
+		voice = id
+	}
+
+	stream, err := c.fish.TTS.Stream(ctx, &fishaudio.StreamParams{
+		Text:        request.Input,
+		Model:       fishModel(request.Model),
+		ReferenceID: voice,
+		Format:      format,
+		Speed:       request.Speed,
+	})
+	if err != nil {
+		return RawResponse{}, err
+	}
+	return RawResponse{ReadCloser: stream, header: http.Header{}}, nil
+}
+
+// fishModel returns model if it names a Fish Audio model, or "" for the
+// default.
+func fishModel(model string) fishaudio.Model {
+	switch m := fishaudio.Model(model); m {
+	case fishaudio.ModelS1, fishaudio.ModelS2Pro, fishaudio.ModelSpeech15, fishaudio.ModelSpeech16:
+		return m
+	}
+	return ""
+}
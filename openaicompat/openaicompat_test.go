@@ -0,0 +1,76 @@
+package openaicompat
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+)
+
+func TestClient_CreateSpeech(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/tts" {
+			t.Errorf("Path = %q, want /v1/tts", r.URL.Path)
+		}
+		if got := r.Header.Get("model"); got != "s1" {
+			t.Errorf("model = %q, want s1", got)
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["text"] != "Hello" || body["reference_id"] != "fish-voice" || body["format"] != "wav" {
+			t.Errorf("body = %v", body)
+		}
+		if prosody, _ := body["prosody"].(map[string]interface{}); prosody["speed"] != 1.5 {
+			t.Errorf("prosody = %v, want speed 1.5", body["prosody"])
+		}
+		_, _ = w.Write([]byte("audio"))
+	}))
+	defer server.Close()
+
+	client := NewClient(fishaudio.NewClient(fishaudio.WithAPIKey("test-key"), fishaudio.WithBaseURL(server.URL)))
+	client.Voices["alloy"] = "fish-voice"
+
+	resp, err := client.CreateSpeech(context.Background(), CreateSpeechRequest{
+		Model:          "s1",
+		Input:          "Hello",
+		Voice:          "alloy",
+		ResponseFormat: SpeechResponseFormatWav,
+		Speed:          1.5,
+	})
+	if err != nil {
+		t.Fatalf("CreateSpeech() error = %v", err)
+	}
+	defer resp.Close()
+	if audio, _ := io.ReadAll(resp); string(audio) != "audio" {
+		t.Errorf("audio = %q, want %q", audio, "audio")
+	}
+}
+
+func TestClient_CreateSpeech_Defaults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("model"); got != string(fishaudio.ModelS2Pro) {
+			t.Errorf("model = %q, want default for tts-1", got)
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["reference_id"] != "raw-id" || body["format"] != "mp3" {
+			t.Errorf("body = %v", body)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(fishaudio.NewClient(fishaudio.WithAPIKey("test-key"), fishaudio.WithBaseURL(server.URL)))
+	resp, err := client.CreateSpeech(context.Background(), CreateSpeechRequest{Model: "tts-1", Input: "Hi", Voice: "raw-id"})
+	if err != nil {
+		t.Fatalf("CreateSpeech() error = %v", err)
+	}
+	_ = resp.Close()
+
+	if _, err := client.CreateSpeech(context.Background(), CreateSpeechRequest{Input: "Hi", ResponseFormat: "aac"}); err == nil {
+		t.Error("CreateSpeech() error = nil for aac")
+	}
+}
@@ -0,0 +1,101 @@
+package openaicompat
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+)
+
+// AudioResponseFormat is the format of a transcription response.
+type AudioResponseFormat string
+
+const (
+	AudioResponseFormatJSON        AudioResponseFormat = "json"
+	AudioResponseFormatText        AudioResponseFormat = "text"
+	AudioResponseFormatSRT         AudioResponseFormat = "srt"
+	AudioResponseFormatVerboseJSON AudioResponseFormat = "verbose_json"
+	AudioResponseFormatVTT         AudioResponseFormat = "vtt"
+)
+
+// AudioRequest mirrors go-openai's AudioRequest.
+type AudioRequest struct {
+	// Model is ignored.
+	Model string
+	// FilePath is the audio file to read, or the filename sent with Reader.
+	FilePath string
+	// Reader, if set, is read instead of opening FilePath.
+	Reader io.Reader
+	// Prompt is ignored.
+	Prompt string
+	// Temperature is ignored.
+	Temperature float32
+	// Language is the language code, e.g. "en". Detected if empty.
+	Language string
+	// Format is the response format. Default: json.
+	Format AudioResponseFormat
+}
+
+// Segment mirrors a go-openai AudioResponse segment.
+type Segment struct {
+	ID    int     `json:"id"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Text  string  `json:"text"`
+}
+
+// AudioResponse mirrors go-openai's AudioResponse. For the text, SRT, and
+// VTT formats, Text holds the formatted transcript.
+type AudioResponse struct {
+	Task     string    `json:"task"`
+	Language string    `json:"language"`
+	Duration float64   `json:"duration"`
+	Segments []Segment `json:"segments"`
+	Text     string    `json:"text"`
+}
+
+// CreateTranscription transcribes the audio in request.
+func (c *Client) CreateTranscription(ctx context.Context, request AudioRequest) (AudioResponse, error) {
+	params := &fishaudio.TranscribeParams{Language: fishaudio.Language(request.Language)}
+	switch request.Format {
+	case "", AudioResponseFormatJSON, AudioResponseFormatVerboseJSON:
+	case AudioResponseFormatText:
+		params.ResponseFormat = fishaudio.ASRResponseText
+	case AudioResponseFormatSRT:
+		params.ResponseFormat = fishaudio.ASRResponseSRT
+	case AudioResponseFormatVTT:
+		params.ResponseFormat = fishaudio.ASRResponseVTT
+	default:
+		return AudioResponse{}, fmt.Errorf("openaicompat: unsupported response format %q", request.Format)
+	}
+
+	var result *fishaudio.ASRResponse
+	var err error
+	if request.Reader != nil {
+		if request.FilePath != "" {
+			params.Filename = filepath.Base(request.FilePath)
+		}
+		result, err = c.fish.ASR.TranscribeReader(ctx, request.Reader, params)
+	} else {
+		result, err = c.fish.ASR.TranscribeFile(ctx, request.FilePath, params)
+	}
+	if err != nil {
+		return AudioResponse{}, err
+	}
+
+	resp := AudioResponse{
+		Task:     "transcribe",
+		Language: request.Language,
+		Duration: result.Duration / 1000,
+		Text:     result.Text,
+	}
+	if params.ResponseFormat == fishaudio.ASRResponseSRT || params.ResponseFormat == fishaudio.ASRResponseVTT {
+		resp.Text = result.Formatted
+	}
+	for i, seg := range result.Segments {
+		resp.Segments = append(resp.Segments, Segment{ID: i, Start: seg.Start, End: seg.End, Text: seg.Text})
+	}
+	return resp, nil
+}
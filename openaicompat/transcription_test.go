@@ -0,0 +1,81 @@
+package openaicompat
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+)
+
+func TestClient_CreateTranscription(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm error = %v", err)
+		}
+		if got := r.FormValue("language"); got != "en" {
+			t.Errorf("language = %q, want en", got)
+		}
+		if _, header, _ := r.FormFile("audio"); header.Filename != "call.mp3" {
+			t.Errorf("filename = %q, want call.mp3", header.Filename)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"text":"hello world","duration":2500,"segments":[{"text":"hello","start":0,"end":1},{"text":"world","start":1,"end":2.5}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(fishaudio.NewClient(fishaudio.WithAPIKey("test-key"), fishaudio.WithBaseURL(server.URL)))
+	resp, err := client.CreateTranscription(context.Background(), AudioRequest{
+		Model:    "whisper-1",
+		FilePath: "/recordings/call.mp3",
+		Reader:   strings.NewReader("audio bytes"),
+		Language: "en",
+		Format:   AudioResponseFormatVerboseJSON,
+	})
+	if err != nil {
+		t.Fatalf("CreateTranscription() error = %v", err)
+	}
+
+	if resp.Text != "hello world" || resp.Duration != 2.5 || resp.Language != "en" {
+		t.Errorf("resp = %+v", resp)
+	}
+	if len(resp.Segments) != 2 || resp.Segments[1].ID != 1 || resp.Segments[1].End != 2.5 {
+		t.Errorf("Segments = %+v", resp.Segments)
+	}
+}
+
+func TestClient_CreateTranscription_SRTFile(t *testing.T) {
+	const srt = "1\n00:00:00,000 --> 00:00:01,000\nhello\n\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm error = %v", err)
+		}
+		if got := r.FormValue("response_format"); got != "srt" {
+			t.Errorf("response_format = %q, want srt", got)
+		}
+		_, _ = w.Write([]byte(srt))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "clip.wav")
+	if err := os.WriteFile(path, []byte("audio"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient(fishaudio.NewClient(fishaudio.WithAPIKey("test-key"), fishaudio.WithBaseURL(server.URL)))
+	resp, err := client.CreateTranscription(context.Background(), AudioRequest{FilePath: path, Format: AudioResponseFormatSRT})
+	if err != nil {
+		t.Fatalf("CreateTranscription() error = %v", err)
+	}
+	if resp.Text != srt {
+		t.Errorf("Text = %q, want the SRT body", resp.Text)
+	}
+
+	if _, err := client.CreateTranscription(context.Background(), AudioRequest{FilePath: path, Format: "diarized"}); err == nil {
+		t.Error("CreateTranscription() error = nil for an unknown format")
+	}
+}
@@ -1,8 +1,12 @@
 package fishaudio
 
 import (
+	"context"
+	"net"
 	"net/http"
 	"time"
+
+	"github.com/gorilla/websocket"
 )
 
 // ClientOption is a function that configures the Client.
@@ -22,6 +26,60 @@ func WithBaseURL(url string) ClientOption {
 	}
 }
 
+// WithWorkspace scopes all requests to the workspace with the given ID.
+// Without it, requests use the API key's default workspace.
+func WithWorkspace(id string) ClientOption {
+	return func(c *Client) {
+		c.workspace = id
+	}
+}
+
+// WithBudgetGuard rejects TTS and ASR requests that would exceed the
+// guard's daily limits. See BudgetGuard.
+func WithBudgetGuard(guard *BudgetGuard) ClientOption {
+	return func(c *Client) {
+		c.budget = guard
+	}
+}
+
+// WithTTSCache serves repeated TTS.Convert requests from cache instead of
+// synthesizing them again. See TTSCache and DiskCache.
+func WithTTSCache(cache TTSCache) ClientOption {
+	return func(c *Client) {
+		c.ttsCache = cache
+	}
+}
+
+// WithConfigFile loads TTS defaults from a JSON or YAML file with
+// LoadTTSConfig. They apply to every TTS request under the request's own
+// fields, its Config, and its preset. If the file can't be loaded, TTS
+// requests fail with the error, so a bad deploy is caught on first use.
+//
+// Example:
+//
+//	client := fishaudio.NewClient(fishaudio.WithConfigFile("/etc/myapp/tts.yaml"))
+func WithConfigFile(path string) ClientOption {
+	return func(c *Client) {
+		c.ttsDefaults, c.ttsDefaultsErr = LoadTTSConfig(path)
+	}
+}
+
+// WithPresets sets the presets that StreamParams.PresetName and
+// ConvertParams.PresetName refer to. See Presets.
+func WithPresets(presets *Presets) ClientOption {
+	return func(c *Client) {
+		c.presets = presets
+	}
+}
+
+// WithStorage sets the filesystem file inputs are read from and outputs
+// are written to. See Storage.
+func WithStorage(storage Storage) ClientOption {
+	return func(c *Client) {
+		c.storage = storage
+	}
+}
+
 // WithHTTPClient sets a custom HTTP client.
 func WithHTTPClient(httpClient *http.Client) ClientOption {
 	return func(c *Client) {
@@ -48,17 +106,41 @@ type RequestOptions struct {
 	AdditionalQueryParams map[string]string
 }
 
+// WireFormat specifies how live WebSocket events are encoded.
+type WireFormat string
+
+const (
+	// WireFormatMsgpack sends events as msgpack binary frames (default).
+	WireFormatMsgpack WireFormat = "msgpack"
+	// WireFormatJSON sends events as JSON text frames with base64-encoded
+	// audio, for gateways that do not pass binary frames.
+	WireFormatJSON WireFormat = "json"
+)
+
 // WebSocketOptions configures WebSocket connections.
 type WebSocketOptions struct {
 	// PingTimeout is the maximum delay to wait for a pong response.
+	// If no pong arrives in time, the stream fails with a *TimeoutError.
 	// Default: 20 seconds.
 	PingTimeout time.Duration
 
-	// PingInterval is the interval for sending ping messages.
+	// PingInterval is the interval for sending ping messages. Zero disables keepalive.
 	// Default: 20 seconds.
 	PingInterval time.Duration
 
-	// MaxMessageSize is the maximum message size in bytes.
+	// ReadTimeout is the maximum time to wait for the next frame (including
+	// pongs) from the server. If exceeded, the stream fails with a *TimeoutError.
+	// Zero means no limit beyond the keepalive.
+	ReadTimeout time.Duration
+
+	// WriteTimeout is the maximum time to wait for each frame to be written.
+	// If exceeded, the stream fails with a *TimeoutError. Zero means no limit.
+	// Default: 10 seconds.
+	WriteTimeout time.Duration
+
+	// MaxMessageSize is the maximum size in bytes of a single server frame.
+	// Large WAV or PCM chunks need a higher limit; frames over the limit fail
+	// the stream with a *MessageTooLargeError. Zero means no limit.
 	// Default: 10 MiB.
 	MaxMessageSize int64
 
@@ -67,6 +149,66 @@ type WebSocketOptions struct {
 
 	// WriteBufferSize is the size of the write buffer.
 	WriteBufferSize int
+
+	// Dialer is the WebSocket dialer to use, e.g. to configure a proxy or TLS.
+	// Buffer sizes left at zero are taken from ReadBufferSize and WriteBufferSize.
+	Dialer *websocket.Dialer
+
+	// NetDialContext overrides how the underlying network connection is made,
+	// e.g. for SOCKS proxies or custom DNS resolution.
+	NetDialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+
+	// Header contains additional headers sent with the upgrade request.
+	// The Authorization, workspace, and model headers are always set by the
+	// SDK.
+	Header http.Header
+
+	// WireFormat is the encoding used for live events. Server events are
+	// decoded based on the frame type, so either format is accepted.
+	// Default: WireFormatMsgpack.
+	WireFormat WireFormat
+
+	// MaxCharsPerSecond limits how fast text is sent on a live session so a
+	// large burst isn't buffered by the server at the cost of latency. Text
+	// events are never split; each one delays the next by its length divided
+	// by this rate. Watch WebSocketStats.QueuedTexts to adapt how fast text
+	// is produced. Zero disables pacing.
+	MaxCharsPerSecond float64
+
+	// ReorderWindow is the number of audio chunks held back waiting for a
+	// missing one when the server numbers its audio frames. Chunks arriving
+	// out of order within the window are delivered in order; once more are
+	// held, the missing chunks are reported as a gap. Holding chunks adds
+	// latency only while one is missing. Zero reports gaps at once.
+	ReorderWindow int
+
+	// FailOnGap ends the stream with a *DiscontinuityError when audio
+	// chunks are missing. By default the stream continues and each gap is
+	// reported to OnEvent as a WebSocketEventDiscontinuity event and counted
+	// in WebSocketStats.Gaps.
+	FailOnGap bool
+
+	// OnEvent, if set, is called from the read loop for every event received
+	// from the server, before audio is delivered to the stream. It must not block.
+	OnEvent func(WebSocketEvent)
+
+	// OnRawMessage, if set, is called from the read loop with every frame
+	// before it is decoded, including event types the SDK does not know.
+	// It must not block.
+	OnRawMessage func(RawMessage)
+
+	// OnConnect, if set, is called after each successful dial with the
+	// dial duration.
+	OnConnect func(ConnectionEvent)
+
+	// OnDisconnect, if set, is called when a session's connection ends, with
+	// the session duration, close code, and terminal error, if any.
+	OnDisconnect func(ConnectionEvent)
+
+	// OnReconnect, if set, is called when a stream transparently restarts
+	// its session on a new connection (see WebSocketAudioStream.SetVoice),
+	// with the time taken to restart.
+	OnReconnect func(ConnectionEvent)
 }
 
 // DefaultWebSocketOptions returns WebSocketOptions with default values.
@@ -74,6 +216,7 @@ func DefaultWebSocketOptions() *WebSocketOptions {
 	return &WebSocketOptions{
 		PingTimeout:     20 * time.Second,
 		PingInterval:    20 * time.Second,
+		WriteTimeout:    10 * time.Second,
 		MaxMessageSize:  10 * 1024 * 1024, // 10 MiB - audio chunks can be large
 		ReadBufferSize:  32 * 1024,        // 32 KiB
 		WriteBufferSize: 32 * 1024,        // 32 KiB
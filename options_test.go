@@ -48,6 +48,9 @@ func TestDefaultWebSocketOptions(t *testing.T) {
 	if opts.PingInterval != 20*time.Second {
 		t.Errorf("PingInterval = %v, want %v", opts.PingInterval, 20*time.Second)
 	}
+	if opts.WriteTimeout != 10*time.Second {
+		t.Errorf("WriteTimeout = %v, want %v", opts.WriteTimeout, 10*time.Second)
+	}
 	if opts.MaxMessageSize != 10*1024*1024 {
 		t.Errorf("MaxMessageSize = %d, want %d", opts.MaxMessageSize, 10*1024*1024)
 	}
@@ -0,0 +1,81 @@
+package fishaudio
+
+import "context"
+
+// PageFetcher fetches one page of a paginated endpoint. pageNumber is
+// 1-indexed.
+type PageFetcher[T any] func(ctx context.Context, pageNumber int) (*PaginatedResponse[T], error)
+
+// Pager walks the pages of a paginated endpoint.
+//
+// Example:
+//
+//	pager := client.Voices.Pager(&fishaudio.ListVoicesParams{SelfOnly: true})
+//	for pager.Next(ctx) {
+//	    for _, voice := range pager.Page().Items {
+//	        fmt.Println(voice.Title)
+//	    }
+//	}
+//	if err := pager.Err(); err != nil {
+//	    return err
+//	}
+type Pager[T any] struct {
+	fetch PageFetcher[T]
+	next  int
+	page  *PaginatedResponse[T]
+	err   error
+	done  bool
+}
+
+// NewPager creates a Pager that starts at firstPage. A firstPage below 1
+// starts at the first page.
+func NewPager[T any](firstPage int, fetch PageFetcher[T]) *Pager[T] {
+	if firstPage < 1 {
+		firstPage = 1
+	}
+	return &Pager[T]{fetch: fetch, next: firstPage}
+}
+
+// Next fetches the next page. It returns false when the previous page was
+// the last one or an error occurred.
+func (p *Pager[T]) Next(ctx context.Context) bool {
+	if p.done {
+		return false
+	}
+
+	page, err := p.fetch(ctx, p.next)
+	if err != nil {
+		p.err = err
+		p.done = true
+		return false
+	}
+	if len(page.Items) == 0 {
+		p.done = true
+		return false
+	}
+
+	p.page = page
+	p.next++
+	p.done = !page.HasNext()
+	return true
+}
+
+// Page returns the current page.
+// Only valid after a successful call to Next().
+func (p *Pager[T]) Page() *PaginatedResponse[T] {
+	return p.page
+}
+
+// Err returns any error that occurred while fetching pages.
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// All fetches the remaining pages and returns their items.
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	var items []T
+	for p.Next(ctx) {
+		items = append(items, p.page.Items...)
+	}
+	return items, p.err
+}
@@ -0,0 +1,113 @@
+package fishaudio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// pagedVoices serves total voices at /model, honoring page_size and page_number.
+func pagedVoices(t *testing.T, total int, requested *[]int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		size, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+		number, _ := strconv.Atoi(r.URL.Query().Get("page_number"))
+		*requested = append(*requested, number)
+
+		resp := PaginatedResponse[Voice]{Total: total, Items: []Voice{}}
+		for i := (number - 1) * size; i < number*size && i < total; i++ {
+			resp.Items = append(resp.Items, Voice{ID: strconv.Itoa(i)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestVoicesService_Pager(t *testing.T) {
+	var requested []int
+	server := pagedVoices(t, 5, &requested)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	pager := client.Voices.Pager(&ListVoicesParams{PageSize: 2})
+
+	var pages [][]string
+	for pager.Next(context.Background()) {
+		page := pager.Page()
+		if page.PageSize != 2 || page.TotalPages() != 3 {
+			t.Errorf("PageSize = %d, TotalPages() = %d, want 2 and 3", page.PageSize, page.TotalPages())
+		}
+		var ids []string
+		for _, v := range page.Items {
+			ids = append(ids, v.ID)
+		}
+		pages = append(pages, ids)
+	}
+	if err := pager.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	want := [][]string{{"0", "1"}, {"2", "3"}, {"4"}}
+	if !reflect.DeepEqual(pages, want) {
+		t.Errorf("pages = %v, want %v", pages, want)
+	}
+	if !reflect.DeepEqual(requested, []int{1, 2, 3}) {
+		t.Errorf("requested pages %v, want [1 2 3]", requested)
+	}
+	if pager.Next(context.Background()) {
+		t.Error("Next() after the last page should return false")
+	}
+}
+
+func TestVoicesService_Pager_StartPage(t *testing.T) {
+	var requested []int
+	server := pagedVoices(t, 6, &requested)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	voices, err := client.Voices.Pager(&ListVoicesParams{PageSize: 2, PageNumber: 2}).All(context.Background())
+	if err != nil {
+		t.Fatalf("All() error = %v", err)
+	}
+	if len(voices) != 4 || voices[0].ID != "2" {
+		t.Errorf("got %d voices starting at %q, want 4 starting at %q", len(voices), voices[0].ID, "2")
+	}
+}
+
+func TestPager_EmptyPageStops(t *testing.T) {
+	calls := 0
+	pager := NewPager(1, func(ctx context.Context, pageNumber int) (*PaginatedResponse[int], error) {
+		calls++
+		// Total overstates the items, as when items are deleted mid-walk
+		return &PaginatedResponse[int]{Total: 100, PageSize: 10, PageNumber: pageNumber}, nil
+	})
+	items, err := pager.All(context.Background())
+	if err != nil || len(items) != 0 {
+		t.Errorf("All() = %v, %v, want no items", items, err)
+	}
+	if calls != 1 {
+		t.Errorf("fetched %d pages, want 1", calls)
+	}
+}
+
+func TestPager_Error(t *testing.T) {
+	fetchErr := errors.New("boom")
+	pager := NewPager(1, func(ctx context.Context, pageNumber int) (*PaginatedResponse[int], error) {
+		if pageNumber == 2 {
+			return nil, fetchErr
+		}
+		return &PaginatedResponse[int]{Total: 4, Items: []int{1, 2}, PageSize: 2, PageNumber: pageNumber}, nil
+	})
+	items, err := pager.All(context.Background())
+	if !errors.Is(err, fetchErr) {
+		t.Errorf("All() error = %v, want %v", err, fetchErr)
+	}
+	if !reflect.DeepEqual(items, []int{1, 2}) {
+		t.Errorf("All() items = %v, want [1 2]", items)
+	}
+}
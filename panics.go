@@ -0,0 +1,34 @@
+package fishaudio
+
+import "runtime/debug"
+
+// WithRethrowPanics lets panics on SDK goroutines crash the process with
+// their original value, as they would without the SDK's recovery. Use it
+// while debugging to get the panic's full trace; by default they are
+// returned as *PanicError.
+func WithRethrowPanics() ClientOption {
+	return func(c *Client) {
+		c.rethrowPanics = true
+	}
+}
+
+// catchPanic recovers a panic on the calling goroutine, named by goroutine,
+// and stores it in *errp as a *PanicError. It must be deferred directly.
+func (c *Client) catchPanic(goroutine string, errp *error) {
+	v := recover()
+	if v == nil {
+		return
+	}
+	if c != nil && c.rethrowPanics {
+		panic(v)
+	}
+	*errp = &PanicError{Goroutine: goroutine, Value: v, Stack: debug.Stack()}
+}
+
+// guard returns fn with its panics returned as errors by catchPanic.
+func (c *Client) guard(goroutine string, fn func() error) func() error {
+	return func() (err error) {
+		defer c.catchPanic(goroutine, &err)
+		return fn()
+	}
+}
@@ -0,0 +1,70 @@
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPanicError_WebSocketCallback(t *testing.T) {
+	server := newEchoLiveServer(t)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	opts := DefaultWebSocketOptions()
+	opts.OnEvent = func(evt WebSocketEvent) {
+		if evt.Type == WebSocketEventAudio {
+			panic("callback bug")
+		}
+	}
+
+	textChan := make(chan string, 1)
+	textChan <- "hello"
+	close(textChan)
+	stream, err := client.TTS.StreamWebSocket(context.Background(), textChan, nil, opts)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+	_, err = stream.Collect()
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) || panicErr.Value != "callback bug" || panicErr.Goroutine != "websocket receive" {
+		t.Fatalf("Collect() error = %v, want *PanicError from the receive goroutine", err)
+	}
+	if !strings.Contains(string(panicErr.Stack), "TestPanicError_WebSocketCallback") {
+		t.Errorf("Stack does not include the panicking callback:\n%s", panicErr.Stack)
+	}
+}
+
+func TestPanicError_BatchProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"text": "hi", "duration": 1000}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	results, err := client.ASR.TranscribeBatch(context.Background(), []AudioInput{{Audio: []byte("a")}, {Audio: []byte("b")}}, &BatchOptions{
+		OnProgress: func(p BatchProgress) { panic("progress bug") },
+	})
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) || panicErr.Goroutine != "batch worker" {
+		t.Errorf("TranscribeBatch() error = %v, want *PanicError", err)
+	}
+	if len(results) != 2 || results[0].Response == nil {
+		t.Errorf("results = %+v, want both transcribed", results)
+	}
+}
+
+func TestWithRethrowPanics(t *testing.T) {
+	client := NewClient(WithRethrowPanics())
+	defer func() {
+		if v := recover(); v != "bug" {
+			t.Errorf("recovered %v, want the original panic value", v)
+		}
+	}()
+	_ = client.guard("test", func() error { panic("bug") })()
+	t.Error("guard() returned, want the panic rethrown")
+}
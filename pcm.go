@@ -0,0 +1,334 @@
+package fishaudio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// PreprocessOptions configures PreprocessWAV.
+type PreprocessOptions struct {
+	// SampleRate is the output sample rate in Hz. Default: 16000, the rate
+	// speech recognition models are typically trained on.
+	SampleRate int
+}
+
+// PreprocessWAV converts a PCM WAV file to 16-bit mono at the configured
+// sample rate, the smallest form that keeps full recognition accuracy.
+// Capture audio such as 48kHz stereo 24-bit shrinks by more than 90%,
+// which shortens uploads for ASR.
+//
+// Example:
+//
+//	raw, _ := os.ReadFile("capture.wav")
+//	audio, err := fishaudio.PreprocessWAV(raw, nil)
+//	if err != nil {
+//	    return err
+//	}
+//	result, err := client.ASR.Transcribe(ctx, audio, nil)
+func PreprocessWAV(wav []byte, opts *PreprocessOptions) ([]byte, error) {
+	sampleRate := 16000
+	if opts != nil && opts.SampleRate > 0 {
+		sampleRate = opts.SampleRate
+	}
+
+	f, pcm, err := parseWAV(wav)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.bitsPerSample != 16 {
+		if pcm, err = ConvertBitDepth(pcm, f.bitsPerSample, 16); err != nil {
+			return nil, err
+		}
+	}
+	pcm = DownmixPCM(pcm, f.channels)
+	pcm = ResamplePCM(pcm, 1, f.sampleRate, sampleRate)
+
+	return encodeWAV(pcmFormat{sampleRate: sampleRate, channels: 1, bitsPerSample: 16}, pcm), nil
+}
+
+// ResamplePCM converts interleaved 16-bit little-endian PCM from one sample
+// rate to another. Downsampling averages the source samples covering each
+// output sample, which suppresses most aliasing; upsampling interpolates
+// linearly. The audio is returned unchanged if the rates are equal or any
+// argument is not positive.
+func ResamplePCM(pcm []byte, channels, fromRate, toRate int) []byte {
+	if channels <= 0 || fromRate <= 0 || toRate <= 0 || fromRate == toRate {
+		return pcm
+	}
+
+	in := len(pcm) / (2 * channels)
+	out := int(int64(in) * int64(toRate) / int64(fromRate))
+	sample := func(i, c int) float64 {
+		return float64(int16(binary.LittleEndian.Uint16(pcm[(i*channels+c)*2:])))
+	}
+
+	dst := make([]byte, out*2*channels)
+	step := float64(fromRate) / float64(toRate)
+	for i := 0; i < out; i++ {
+		pos := float64(i) * step
+		for c := 0; c < channels; c++ {
+			var v float64
+			if step > 1 {
+				lo, hi := int(pos), int(pos+step)
+				if hi > in {
+					hi = in
+				}
+				if hi <= lo {
+					hi = lo + 1
+				}
+				for j := lo; j < hi; j++ {
+					v += sample(j, c)
+				}
+				v /= float64(hi - lo)
+			} else {
+				j := int(pos)
+				a, b := sample(j, c), sample(j, c)
+				if j+1 < in {
+					b = sample(j+1, c)
+				}
+				v = a + (b-a)*(pos-float64(j))
+			}
+			binary.LittleEndian.PutUint16(dst[(i*channels+c)*2:], uint16(clamp16(v)))
+		}
+	}
+	return dst
+}
+
+// DownmixPCM mixes interleaved 16-bit little-endian PCM with the given
+// number of channels down to mono by averaging the channels. Mono audio is
+// returned unchanged.
+func DownmixPCM(pcm []byte, channels int) []byte {
+	if channels <= 1 {
+		return pcm
+	}
+
+	frames := len(pcm) / (2 * channels)
+	dst := make([]byte, frames*2)
+	for i := 0; i < frames; i++ {
+		var sum int
+		for c := 0; c < channels; c++ {
+			sum += int(int16(binary.LittleEndian.Uint16(pcm[(i*channels+c)*2:])))
+		}
+		binary.LittleEndian.PutUint16(dst[i*2:], uint16(int16(sum/channels)))
+	}
+	return dst
+}
+
+// UpmixPCM copies mono 16-bit little-endian PCM to every channel of
+// interleaved audio, such as stereo for a sink that only accepts two
+// channels. A channels value of one or less returns the audio unchanged.
+func UpmixPCM(pcm []byte, channels int) []byte {
+	if channels <= 1 {
+		return pcm
+	}
+
+	frames := len(pcm) / 2
+	dst := make([]byte, frames*2*channels)
+	for i := 0; i < frames; i++ {
+		for c := 0; c < channels; c++ {
+			copy(dst[(i*channels+c)*2:], pcm[i*2:i*2+2])
+		}
+	}
+	return dst
+}
+
+// ResamplePCMSinc converts interleaved 16-bit little-endian PCM from one
+// sample rate to another with a windowed-sinc filter. It is slower than
+// ResamplePCM but keeps the passband flat and attenuates aliasing by
+// 70 dB or more, so speech stays clear when, for example, 44.1 kHz TTS output
+// is brought down to 8 kHz for telephony. The rates may have any ratio;
+// the filter is evaluated at the exact rational position of each output
+// sample. The audio is returned unchanged if the rates are equal or any
+// argument is not positive.
+//
+// Example:
+//
+//	pcm16k := fishaudio.ResamplePCMSinc(pcm, 1, 44100, 16000)
+func ResamplePCMSinc(pcm []byte, channels, fromRate, toRate int) []byte {
+	if channels <= 0 || fromRate <= 0 || toRate <= 0 || fromRate == toRate {
+		return pcm
+	}
+
+	// Output sample n lies at input position n*down/up
+	g := gcd(fromRate, toRate)
+	up, down := toRate/g, fromRate/g
+
+	in := len(pcm) / (2 * channels)
+	out := int(int64(in) * int64(up) / int64(down))
+	k := newSincKernel(fromRate, toRate)
+
+	// Filters for each fractional position, cached when there are few
+	var phases [][]float64
+	if up <= maxSincPhases {
+		phases = make([][]float64, up)
+	}
+	taps := func(phase int) []float64 {
+		if phases == nil {
+			return k.taps(float64(phase) / float64(up))
+		}
+		if phases[phase] == nil {
+			phases[phase] = k.taps(float64(phase) / float64(up))
+		}
+		return phases[phase]
+	}
+
+	dst := make([]byte, out*2*channels)
+	for n := 0; n < out; n++ {
+		pos := int64(n) * int64(down)
+		base := int(pos / int64(up))
+		h := taps(int(pos % int64(up)))
+		first := base - k.half + 1
+		for c := 0; c < channels; c++ {
+			var v float64
+			for j, w := range h {
+				i := first + j
+				if i < 0 || i >= in {
+					continue
+				}
+				v += w * float64(int16(binary.LittleEndian.Uint16(pcm[(i*channels+c)*2:])))
+			}
+			binary.LittleEndian.PutUint16(dst[(n*channels+c)*2:], uint16(clamp16(v)))
+		}
+	}
+	return dst
+}
+
+// maxSincPhases is the most fractional positions whose filters
+// ResamplePCMSinc caches. Rates with a larger ratio numerator compute
+// each filter as needed.
+const maxSincPhases = 1024
+
+// sincKernel is a Kaiser-windowed sinc low-pass filter in units of input
+// samples.
+type sincKernel struct {
+	cutoff float64 // in cycles per input sample
+	half   int     // taps on each side of the output position
+	beta   float64
+}
+
+// newSincKernel returns the filter for resampling between the rates. Its
+// cutoff is just below the lower Nyquist frequency, and it widens when
+// downsampling so the transition band stays the same in output samples.
+func newSincKernel(fromRate, toRate int) sincKernel {
+	scale := math.Min(1, float64(toRate)/float64(fromRate))
+	return sincKernel{
+		cutoff: 0.5 * scale * 0.92,
+		half:   int(math.Ceil(24 / scale)),
+		beta:   8.6,
+	}
+}
+
+// taps returns the filter weights for an output sample frac input samples
+// after the input sample it follows, normalized to unity gain.
+func (k sincKernel) taps(frac float64) []float64 {
+	h := make([]float64, 2*k.half)
+	var sum float64
+	for j := range h {
+		x := float64(j-k.half+1) - frac
+		w := 2 * k.cutoff * sinc(2*k.cutoff*x) * kaiser(x/float64(k.half), k.beta)
+		h[j] = w
+		sum += w
+	}
+	for j := range h {
+		h[j] /= sum
+	}
+	return h
+}
+
+// sinc is the normalized sinc function sin(πx)/(πx).
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	return math.Sin(math.Pi*x) / (math.Pi * x)
+}
+
+// kaiser is the Kaiser window at x in [-1, 1]; it is zero outside.
+func kaiser(x, beta float64) float64 {
+	if x <= -1 || x >= 1 {
+		return 0
+	}
+	return besselI0(beta*math.Sqrt(1-x*x)) / besselI0(beta)
+}
+
+// besselI0 is the zeroth-order modified Bessel function of the first kind.
+func besselI0(x float64) float64 {
+	sum, term := 1.0, 1.0
+	for k := 1; term > sum*1e-12; k++ {
+		term *= (x / (2 * float64(k))) * (x / (2 * float64(k)))
+		sum += term
+	}
+	return sum
+}
+
+// gcd returns the greatest common divisor of positive a and b.
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// ConvertBitDepth converts little-endian PCM samples between 8-bit
+// (unsigned), 16-bit, 24-bit, and 32-bit integer depths. Reducing the depth
+// truncates the low bits; increasing it pads them with zeros. A trailing
+// partial sample is dropped.
+func ConvertBitDepth(pcm []byte, fromBits, toBits int) ([]byte, error) {
+	if !validBitDepth(fromBits) {
+		return nil, fmt.Errorf("unsupported bit depth %d", fromBits)
+	}
+	if !validBitDepth(toBits) {
+		return nil, fmt.Errorf("unsupported bit depth %d", toBits)
+	}
+	if fromBits == toBits {
+		return pcm, nil
+	}
+
+	inSize, outSize := fromBits/8, toBits/8
+	n := len(pcm) / inSize
+	dst := make([]byte, n*outSize)
+	for i := 0; i < n; i++ {
+		putSample32(dst[i*outSize:], toBits, sample32(pcm[i*inSize:], fromBits))
+	}
+	return dst, nil
+}
+
+// validBitDepth reports whether bits is a supported integer PCM depth.
+func validBitDepth(bits int) bool {
+	return bits == 8 || bits == 16 || bits == 24 || bits == 32
+}
+
+// sample32 decodes one sample scaled to the full 32-bit range.
+func sample32(b []byte, bits int) int32 {
+	switch bits {
+	case 8:
+		return int32(int(b[0])-128) << 24
+	case 16:
+		return int32(int16(binary.LittleEndian.Uint16(b))) << 16
+	case 24:
+		return int32(uint32(b[0])<<8 | uint32(b[1])<<16 | uint32(b[2])<<24)
+	default:
+		return int32(binary.LittleEndian.Uint32(b))
+	}
+}
+
+// putSample32 encodes a full-range 32-bit sample at the given depth.
+func putSample32(b []byte, bits int, v int32) {
+	switch bits {
+	case 8:
+		b[0] = byte(v>>24 + 128)
+	case 16:
+		binary.LittleEndian.PutUint16(b, uint16(v>>16))
+	case 24:
+		b[0], b[1], b[2] = byte(v>>8), byte(v>>16), byte(v>>24)
+	default:
+		binary.LittleEndian.PutUint32(b, uint32(v))
+	}
+}
+
+// clamp16 rounds v to the nearest 16-bit sample value.
+func clamp16(v float64) int16 {
+	return int16(math.Max(math.MinInt16, math.Min(math.MaxInt16, math.Round(v))))
+}
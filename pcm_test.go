@@ -0,0 +1,267 @@
+package fishaudio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// samples16 encodes 16-bit samples as little-endian PCM.
+func samples16(v ...int16) []byte {
+	pcm := make([]byte, len(v)*2)
+	for i, s := range v {
+		binary.LittleEndian.PutUint16(pcm[2*i:], uint16(s))
+	}
+	return pcm
+}
+
+// decode16 decodes little-endian 16-bit PCM.
+func decode16(pcm []byte) []int16 {
+	v := make([]int16, len(pcm)/2)
+	for i := range v {
+		v[i] = int16(binary.LittleEndian.Uint16(pcm[2*i:]))
+	}
+	return v
+}
+
+func TestResamplePCM_Downsample(t *testing.T) {
+	pcm := samples16(0, 100, 200, 300, 400, 500, 600, 700)
+	got := decode16(ResamplePCM(pcm, 1, 4000, 2000))
+	want := []int16{50, 250, 450, 650}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ResamplePCM() = %v, want %v", got, want)
+	}
+}
+
+func TestResamplePCM_Upsample(t *testing.T) {
+	pcm := samples16(0, 100, 200)
+	got := decode16(ResamplePCM(pcm, 1, 1000, 2000))
+	want := []int16{0, 50, 100, 150, 200, 200}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ResamplePCM() = %v, want %v", got, want)
+	}
+}
+
+func TestResamplePCM_Stereo(t *testing.T) {
+	// Left rises, right falls; channels must not bleed into each other
+	pcm := samples16(0, 1000, 100, 900, 200, 800, 300, 700)
+	got := decode16(ResamplePCM(pcm, 2, 2000, 1000))
+	want := []int16{50, 950, 250, 750}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ResamplePCM() = %v, want %v", got, want)
+	}
+}
+
+func TestResamplePCM_NonIntegerRatio(t *testing.T) {
+	pcm := tone(time.Second, 1000)
+	got := ResamplePCM(pcm, 1, 44100, 16000)
+	wantSamples := len(pcm) / 2 * 16000 / 44100
+	if len(got) != wantSamples*2 {
+		t.Errorf("len = %d, want %d", len(got), wantSamples*2)
+	}
+}
+
+func TestResamplePCM_Unchanged(t *testing.T) {
+	pcm := samples16(1, 2, 3)
+	if got := ResamplePCM(pcm, 1, 16000, 16000); !bytes.Equal(got, pcm) {
+		t.Errorf("ResamplePCM() = %v, want input unchanged", got)
+	}
+}
+
+func TestDownmixPCM(t *testing.T) {
+	pcm := samples16(100, 300, -200, 200, 32767, 32767)
+	got := decode16(DownmixPCM(pcm, 2))
+	want := []int16{200, 0, 32767}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DownmixPCM() = %v, want %v", got, want)
+	}
+
+	mono := samples16(1, 2)
+	if got := DownmixPCM(mono, 1); !bytes.Equal(got, mono) {
+		t.Errorf("DownmixPCM(mono) = %v, want input unchanged", got)
+	}
+}
+
+func TestConvertBitDepth(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       []byte
+		from, to int
+		want     []byte
+	}{
+		{"8 to 16", []byte{0, 128, 255}, 8, 16, samples16(-32768, 0, 32512)},
+		{"16 to 8", samples16(-32768, 0, 32767), 16, 8, []byte{0, 128, 255}},
+		{"24 to 16", []byte{0x00, 0x34, 0x12, 0xFF, 0xFF, 0xFF}, 24, 16, samples16(0x1234, -1)},
+		{"16 to 24", samples16(0x1234, -1), 16, 24, []byte{0x00, 0x34, 0x12, 0x00, 0xFF, 0xFF}},
+		{"32 to 16", []byte{0xFF, 0xFF, 0x34, 0x12}, 32, 16, samples16(0x1234)},
+		{"partial sample dropped", []byte{0x34, 0x12, 0x01}, 16, 24, []byte{0x00, 0x34, 0x12}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertBitDepth(tt.in, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("ConvertBitDepth() error = %v", err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("ConvertBitDepth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertBitDepth_Unsupported(t *testing.T) {
+	if _, err := ConvertBitDepth(nil, 12, 16); err == nil {
+		t.Error("expected error for 12-bit input")
+	}
+	if _, err := ConvertBitDepth(nil, 16, 64); err == nil {
+		t.Error("expected error for 64-bit output")
+	}
+}
+
+func TestPreprocessWAV(t *testing.T) {
+	// 4 frames of 32 kHz stereo 24-bit
+	pcm := []byte{
+		0x00, 0x00, 0x01, 0x00, 0x00, 0x03,
+		0x00, 0x00, 0x01, 0x00, 0x00, 0x03,
+		0x00, 0x00, 0x05, 0x00, 0x00, 0x07,
+		0x00, 0x00, 0x05, 0x00, 0x00, 0x07,
+	}
+	in := encodeWAV(pcmFormat{sampleRate: 32000, channels: 2, bitsPerSample: 24}, pcm)
+
+	out, err := PreprocessWAV(in, nil)
+	if err != nil {
+		t.Fatalf("PreprocessWAV() error = %v", err)
+	}
+	f, got, err := parseWAV(out)
+	if err != nil {
+		t.Fatalf("parseWAV() error = %v", err)
+	}
+	if want := (pcmFormat{sampleRate: 16000, channels: 1, bitsPerSample: 16}); f != want {
+		t.Errorf("format = %+v, want %+v", f, want)
+	}
+	if want := []int16{0x0200, 0x0600}; !reflect.DeepEqual(decode16(got), want) {
+		t.Errorf("samples = %v, want %v", decode16(got), want)
+	}
+}
+
+func TestPreprocessWAV_NotWAV(t *testing.T) {
+	if _, err := PreprocessWAV([]byte("not a wav"), nil); !errors.Is(err, errNotPCMWAV) {
+		t.Errorf("PreprocessWAV() err = %v, want %v", err, errNotPCMWAV)
+	}
+}
+
+func TestUpmixPCM(t *testing.T) {
+	mono := samples16(1, -2, 3)
+	got := decode16(UpmixPCM(mono, 2))
+	want := []int16{1, 1, -2, -2, 3, 3}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UpmixPCM() = %v, want %v", got, want)
+	}
+	if !bytes.Equal(DownmixPCM(UpmixPCM(mono, 3), 3), mono) {
+		t.Error("DownmixPCM(UpmixPCM()) did not restore the mono audio")
+	}
+	if got := UpmixPCM(mono, 1); !bytes.Equal(got, mono) {
+		t.Errorf("UpmixPCM(mono, 1) = %v, want unchanged", decode16(got))
+	}
+}
+
+// toneError returns the RMS difference between 16-bit PCM and an ideal
+// sine, relative to the sine's RMS, skipping the filter's edge effects.
+func toneError(pcm []byte, channels, channel int, freq, amp float64, sampleRate int) float64 {
+	v := decode16(pcm)
+	frames := len(v) / channels
+	skip := sampleRate / 100
+	var errSum, refSum float64
+	for i := skip; i < frames-skip; i++ {
+		ref := amp * 32767 * math.Sin(2*math.Pi*freq*float64(i)/float64(sampleRate))
+		d := float64(v[i*channels+channel]) - ref
+		errSum += d * d
+		refSum += ref * ref
+	}
+	return math.Sqrt(errSum / refSum)
+}
+
+func TestResamplePCMSinc_Tone(t *testing.T) {
+	tests := []struct {
+		name             string
+		freq             float64
+		fromRate, toRate int
+	}{
+		{"44.1k to 16k", 1000, 44100, 16000},
+		{"48k to 8k", 440, 48000, 8000},
+		{"24k to 8k", 3000, 24000, 8000},
+		{"8k to 24k", 1000, 8000, 24000},
+		{"22.05k to 48k", 2500, 22050, 48000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pcm := sinePCM(tt.freq, 0.5, tt.fromRate, 1, 0.5)
+			got := ResamplePCMSinc(pcm, 1, tt.fromRate, tt.toRate)
+			if want := len(ResamplePCM(pcm, 1, tt.fromRate, tt.toRate)); len(got) != want {
+				t.Fatalf("len = %d, want %d", len(got), want)
+			}
+			if e := toneError(got, 1, 0, tt.freq, 0.5, tt.toRate); e > 0.002 {
+				t.Errorf("relative error = %.5f, want <= 0.002", e)
+			}
+		})
+	}
+}
+
+func TestResamplePCMSinc_RejectsAliasing(t *testing.T) {
+	// 6 kHz is above the 4 kHz Nyquist frequency of 8 kHz audio, so it
+	// must be filtered out rather than folded down to 2 kHz
+	pcm := sinePCM(6000, 0.5, 48000, 1, 0.5)
+	v := decode16(ResamplePCMSinc(pcm, 1, 48000, 8000))
+
+	var sum float64
+	for _, s := range v[80 : len(v)-80] {
+		sum += float64(s) * float64(s)
+	}
+	rms := math.Sqrt(sum / float64(len(v)-160))
+	if db := 20 * math.Log10(rms/(0.5*32767/math.Sqrt2)); db > -70 {
+		t.Errorf("aliased tone at %.1f dB, want below -70 dB", db)
+	}
+
+	// The fast resampler lets most of it through
+	v = decode16(ResamplePCM(pcm, 1, 48000, 8000))
+	sum = 0
+	for _, s := range v[80 : len(v)-80] {
+		sum += float64(s) * float64(s)
+	}
+	if fast := math.Sqrt(sum / float64(len(v)-160)); fast < 100*rms {
+		t.Errorf("ResamplePCM aliasing %.1f is not far above ResamplePCMSinc's %.1f", fast, rms)
+	}
+}
+
+func TestResamplePCMSinc_Stereo(t *testing.T) {
+	// A tone on the left and silence on the right must stay separate
+	frames := decode16(sinePCM(1000, 0.5, 48000, 1, 0.5))
+	v := make([]int16, 2*len(frames))
+	for i, s := range frames {
+		v[2*i] = s
+	}
+
+	got := ResamplePCMSinc(samples16(v...), 2, 48000, 16000)
+	if e := toneError(got, 2, 0, 1000, 0.5, 16000); e > 0.002 {
+		t.Errorf("left relative error = %.5f, want <= 0.002", e)
+	}
+	for i, s := range decode16(got) {
+		if i%2 == 1 && s != 0 {
+			t.Fatalf("right sample %d = %d, want 0", i/2, s)
+		}
+	}
+}
+
+func TestResamplePCMSinc_Unchanged(t *testing.T) {
+	pcm := samples16(1, 2, 3)
+	for _, rates := range [][2]int{{16000, 16000}, {0, 16000}, {16000, -1}} {
+		if got := ResamplePCMSinc(pcm, 1, rates[0], rates[1]); !bytes.Equal(got, pcm) {
+			t.Errorf("ResamplePCMSinc(%d -> %d) = %v, want unchanged", rates[0], rates[1], decode16(got))
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package play
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ebitengine/oto/v3"
+	"github.com/hajimehoshi/go-mp3"
+)
+
+// source is decoded audio ready for an oto player.
+type source struct {
+	r          io.Reader
+	sampleRate int
+	channels   int
+	format     oto.Format
+}
+
+// decode detects the container of r from its first bytes and returns its
+// samples. Audio that is neither WAV nor MP3 is taken to be raw 16-bit PCM
+// as described by opts.
+func decode(r io.Reader, opts *Options) (*source, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(12)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	switch {
+	case len(header) >= 12 && bytes.Equal(header[0:4], []byte("RIFF")) && bytes.Equal(header[8:12], []byte("WAVE")):
+		return decodeWAV(br)
+	case len(header) >= 3 && (bytes.Equal(header[0:3], []byte("ID3")) || header[0] == 0xFF && header[1]&0xE0 == 0xE0):
+		d, err := mp3.NewDecoder(br)
+		if err != nil {
+			return nil, fmt.Errorf("play: invalid MP3: %w", err)
+		}
+		// go-mp3 always decodes to 16-bit stereo
+		return &source{r: d, sampleRate: d.SampleRate(), channels: 2, format: oto.FormatSignedInt16LE}, nil
+	case len(header) >= 4 && bytes.Equal(header[0:4], []byte("OggS")):
+		return nil, errors.New("play: Opus is not supported; request MP3, WAV, or PCM audio")
+	}
+
+	return &source{r: br, sampleRate: opts.SampleRate, channels: opts.Channels, format: oto.FormatSignedInt16LE}, nil
+}
+
+// decodeWAV reads a WAV header from r and returns its sample data. A data
+// chunk size of 0 or 0xFFFFFFFF, as written by streaming encoders, reads
+// until EOF.
+func decodeWAV(r io.Reader) (*source, error) {
+	var riff [12]byte
+	if _, err := io.ReadFull(r, riff[:]); err != nil {
+		return nil, fmt.Errorf("play: invalid WAV: %w", err)
+	}
+
+	var src *source
+	for {
+		var chunk [8]byte
+		if _, err := io.ReadFull(r, chunk[:]); err != nil {
+			return nil, fmt.Errorf("play: invalid WAV: %w", err)
+		}
+		id := string(chunk[0:4])
+		size := binary.LittleEndian.Uint32(chunk[4:8])
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return nil, errors.New("play: invalid WAV: short fmt chunk")
+			}
+			fmtChunk := make([]byte, size+size%2)
+			if _, err := io.ReadFull(r, fmtChunk); err != nil {
+				return nil, fmt.Errorf("play: invalid WAV: %w", err)
+			}
+			var err error
+			if src, err = wavSource(fmtChunk); err != nil {
+				return nil, err
+			}
+		case "data":
+			if src == nil {
+				return nil, errors.New("play: invalid WAV: data before fmt chunk")
+			}
+			src.r = r
+			if size != 0 && size != 0xFFFFFFFF {
+				src.r = io.LimitReader(r, int64(size))
+			}
+			return src, nil
+		default:
+			if _, err := io.CopyN(io.Discard, r, int64(size)+int64(size%2)); err != nil {
+				return nil, fmt.Errorf("play: invalid WAV: %w", err)
+			}
+		}
+	}
+}
+
+// wavSource describes the samples of a WAV fmt chunk.
+func wavSource(fmtChunk []byte) (*source, error) {
+	tag := binary.LittleEndian.Uint16(fmtChunk[0:2])
+	src := &source{
+		channels:   int(binary.LittleEndian.Uint16(fmtChunk[2:4])),
+		sampleRate: int(binary.LittleEndian.Uint32(fmtChunk[4:8])),
+	}
+	bits := binary.LittleEndian.Uint16(fmtChunk[14:16])
+	if tag == 0xFFFE && len(fmtChunk) >= 26 {
+		// WAVE_FORMAT_EXTENSIBLE keeps the real tag in the subformat GUID
+		tag = binary.LittleEndian.Uint16(fmtChunk[24:26])
+	}
+
+	switch {
+	case tag == 1 && bits == 8:
+		src.format = oto.FormatUnsignedInt8
+	case tag == 1 && bits == 16:
+		src.format = oto.FormatSignedInt16LE
+	case tag == 3 && bits == 32:
+		src.format = oto.FormatFloat32LE
+	default:
+		return nil, fmt.Errorf("play: unsupported WAV encoding (format %d, %d-bit)", tag, bits)
+	}
+	if src.channels < 1 || src.sampleRate < 1 {
+		return nil, errors.New("play: invalid WAV: bad channel count or sample rate")
+	}
+	return src, nil
+}
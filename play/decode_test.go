@@ -0,0 +1,99 @@
+package play
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ebitengine/oto/v3"
+)
+
+// testWAV builds a WAV file with the given fmt fields, an extra chunk
+// before the data, and the given data chunk size.
+func testWAV(tag, channels uint16, rate uint32, bits uint16, dataSize uint32, data []byte) []byte {
+	var b bytes.Buffer
+	b.WriteString("RIFF")
+	_ = binary.Write(&b, binary.LittleEndian, uint32(0))
+	b.WriteString("WAVE")
+
+	b.WriteString("fmt ")
+	_ = binary.Write(&b, binary.LittleEndian, uint32(16))
+	_ = binary.Write(&b, binary.LittleEndian, tag)
+	_ = binary.Write(&b, binary.LittleEndian, channels)
+	_ = binary.Write(&b, binary.LittleEndian, rate)
+	_ = binary.Write(&b, binary.LittleEndian, rate*uint32(channels*bits/8))
+	_ = binary.Write(&b, binary.LittleEndian, channels*bits/8)
+	_ = binary.Write(&b, binary.LittleEndian, bits)
+
+	b.WriteString("LIST")
+	_ = binary.Write(&b, binary.LittleEndian, uint32(3))
+	b.WriteString("abc\x00")
+
+	b.WriteString("data")
+	_ = binary.Write(&b, binary.LittleEndian, dataSize)
+	b.Write(data)
+	return b.Bytes()
+}
+
+func TestDecode_WAV(t *testing.T) {
+	wav := testWAV(1, 2, 22050, 16, 4, []byte("abcdTRAILER"))
+	src, err := decode(bytes.NewReader(wav), &Options{SampleRate: 44100, Channels: 1})
+	if err != nil {
+		t.Fatalf("decode() error = %v", err)
+	}
+	if src.sampleRate != 22050 || src.channels != 2 || src.format != oto.FormatSignedInt16LE {
+		t.Errorf("source = %+v, want 22050 Hz stereo 16-bit", src)
+	}
+	data, _ := io.ReadAll(src.r)
+	if string(data) != "abcd" {
+		t.Errorf("data = %q, want %q", data, "abcd")
+	}
+}
+
+func TestDecode_StreamingWAV(t *testing.T) {
+	wav := testWAV(3, 1, 48000, 32, 0xFFFFFFFF, []byte("12345678"))
+	src, err := decode(bytes.NewReader(wav), &Options{})
+	if err != nil {
+		t.Fatalf("decode() error = %v", err)
+	}
+	if src.format != oto.FormatFloat32LE {
+		t.Errorf("format = %v, want float32", src.format)
+	}
+	if data, _ := io.ReadAll(src.r); string(data) != "12345678" {
+		t.Errorf("data = %q, want all bytes until EOF", data)
+	}
+}
+
+func TestDecode_UnsupportedWAV(t *testing.T) {
+	wav := testWAV(1, 1, 44100, 24, 3, []byte("abc"))
+	if _, err := decode(bytes.NewReader(wav), &Options{}); err == nil || !strings.Contains(err.Error(), "24-bit") {
+		t.Errorf("decode() err = %v, want unsupported 24-bit", err)
+	}
+}
+
+func TestDecode_RawPCM(t *testing.T) {
+	src, err := decode(strings.NewReader("\x01\x00\x02\x00"), &Options{SampleRate: 16000, Channels: 1})
+	if err != nil {
+		t.Fatalf("decode() error = %v", err)
+	}
+	if src.sampleRate != 16000 || src.channels != 1 {
+		t.Errorf("source = %+v, want 16000 Hz mono", src)
+	}
+	if data, _ := io.ReadAll(src.r); string(data) != "\x01\x00\x02\x00" {
+		t.Errorf("data = %q, want input unchanged", data)
+	}
+}
+
+func TestDecode_Opus(t *testing.T) {
+	if _, err := decode(strings.NewReader("OggS\x00\x02rest of page"), &Options{}); err == nil {
+		t.Error("decode() error = nil for Opus")
+	}
+}
+
+func TestDecode_InvalidMP3(t *testing.T) {
+	if _, err := decode(strings.NewReader("ID3\x04\x00\x00\x00\x00\x00\x00"), &Options{}); err == nil {
+		t.Error("decode() error = nil for truncated MP3")
+	}
+}
@@ -0,0 +1,13 @@
+module github.com/fishaudio/fish-audio-go/play
+
+go 1.24.0
+
+require (
+	github.com/ebitengine/oto/v3 v3.4.0
+	github.com/hajimehoshi/go-mp3 v0.3.4
+)
+
+require (
+	github.com/ebitengine/purego v0.9.0 // indirect
+	golang.org/x/sys v0.36.0 // indirect
+)
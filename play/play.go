@@ -0,0 +1,119 @@
+// Package play plays Fish Audio streams through the default audio output
+// device. It is meant for demos, CLIs, and prototyping.
+//
+// It lives in its own module so the core SDK doesn't depend on audio
+// drivers. On Linux it needs cgo and the ALSA development headers
+// (libasound2-dev on Debian and Ubuntu).
+//
+// Example:
+//
+//	stream, err := client.TTS.Stream(ctx, &fishaudio.StreamParams{Text: "Hello!"})
+//	if err != nil {
+//	    return err
+//	}
+//	defer stream.Close()
+//	return play.Play(ctx, stream, nil)
+package play
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/ebitengine/oto/v3"
+)
+
+// Options configures Play.
+type Options struct {
+	// SampleRate is the sample rate of raw PCM audio. WAV and MP3 audio
+	// carry their own. Default: 44100.
+	SampleRate int
+
+	// Channels is the channel count of raw PCM audio. Default: 1.
+	Channels int
+}
+
+// The audio device can only be opened once per process, with one format.
+var (
+	deviceMu     sync.Mutex
+	device       *oto.Context
+	deviceFormat source
+)
+
+// Play decodes audio from r and plays it through the default output
+// device, returning when playback finishes or ctx is done. r may be a
+// fishaudio.AudioStream or fishaudio.WebSocketAudioStream, or any reader
+// of WAV, MP3, or raw 16-bit little-endian PCM audio. Opus is not
+// supported.
+//
+// Audio starts playing as soon as the first bytes arrive. The device is
+// opened on the first call with that audio's sample rate, channel count,
+// and sample format; later calls must use the same ones.
+func Play(ctx context.Context, r io.Reader, opts *Options) error {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	if o.SampleRate <= 0 {
+		o.SampleRate = 44100
+	}
+	if o.Channels <= 0 {
+		o.Channels = 1
+	}
+
+	src, err := decode(r, &o)
+	if err != nil {
+		return err
+	}
+	otoCtx, err := openDevice(src)
+	if err != nil {
+		return err
+	}
+
+	player := otoCtx.NewPlayer(src.r)
+	defer func() { _ = player.Close() }()
+	player.Play()
+
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for player.IsPlaying() {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			player.Pause()
+			return ctx.Err()
+		}
+	}
+	return player.Err()
+}
+
+// openDevice opens the output device for src's format, or returns the
+// device opened earlier if the formats match.
+func openDevice(src *source) (*oto.Context, error) {
+	deviceMu.Lock()
+	defer deviceMu.Unlock()
+
+	if device != nil {
+		if src.sampleRate != deviceFormat.sampleRate || src.channels != deviceFormat.channels || src.format != deviceFormat.format {
+			return nil, fmt.Errorf("play: device already open for %d Hz, %d channels; got %d Hz, %d channels",
+				deviceFormat.sampleRate, deviceFormat.channels, src.sampleRate, src.channels)
+		}
+		return device, nil
+	}
+
+	otoCtx, ready, err := oto.NewContext(&oto.NewContextOptions{
+		SampleRate:   src.sampleRate,
+		ChannelCount: src.channels,
+		Format:       src.format,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("play: failed to open audio device: %w", err)
+	}
+	<-ready
+
+	device = otoCtx
+	deviceFormat = source{sampleRate: src.sampleRate, channels: src.channels, format: src.format}
+	return device, nil
+}
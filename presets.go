@@ -0,0 +1,213 @@
+package fishaudio
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Preset is a named voice profile: TTS settings such as the voice, model,
+// and prosody, plus an emotion. Presets let product teams tune how an app
+// sounds in a config file rather than in code.
+type Preset struct {
+	// Name identifies the preset in StreamParams.PresetName (required).
+	Name string `json:"name"`
+	// Description describes the preset.
+	Description string `json:"description,omitempty"`
+	// Config holds the TTS settings of the preset, including its voice and
+	// Prosody. Fields set in the request or its Config take precedence.
+	Config TTSConfig `json:"config"`
+	// Emotion is an emotion marker such as "happy" or "whispering",
+	// prefixed to the text as "(happy) " unless the text already starts
+	// with a marker. Not applied to WebSocket streams, whose text arrives
+	// in chunks.
+	Emotion string `json:"emotion,omitempty"`
+}
+
+// Presets is a set of presets by name. It is safe for concurrent use, so it
+// can be reloaded from configuration while requests use it.
+//
+// A Presets marshals to and from a JSON array of presets.
+//
+// Example:
+//
+//	data, _ := os.ReadFile("presets.json")
+//	presets := fishaudio.NewPresets()
+//	if err := json.Unmarshal(data, presets); err != nil {
+//	    return err
+//	}
+//	client := fishaudio.NewClient(fishaudio.WithPresets(presets))
+//	audio, err := client.TTS.Convert(ctx, &fishaudio.ConvertParams{Text: "Welcome back!", PresetName: "greeter"})
+type Presets struct {
+	mu      sync.RWMutex
+	presets map[string]Preset
+}
+
+// NewPresets returns a set of the given presets. It panics if a preset is
+// invalid, as for a fixed set in code; use Register for presets from input.
+func NewPresets(presets ...Preset) *Presets {
+	p := &Presets{presets: make(map[string]Preset)}
+	for _, preset := range presets {
+		if err := p.Register(preset); err != nil {
+			panic(err)
+		}
+	}
+	return p
+}
+
+// Register adds preset, replacing any preset of the same name.
+func (p *Presets) Register(preset Preset) error {
+	if err := preset.validate(); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.presets == nil {
+		p.presets = make(map[string]Preset)
+	}
+	p.presets[preset.Name] = preset
+	return nil
+}
+
+// Lookup returns the named preset.
+func (p *Presets) Lookup(name string) (Preset, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	preset, ok := p.presets[name]
+	return preset, ok
+}
+
+// Names returns the names of the presets, sorted.
+func (p *Presets) Names() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	names := make([]string, 0, len(p.presets))
+	for name := range p.presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MarshalJSON encodes the presets as an array sorted by name.
+func (p *Presets) MarshalJSON() ([]byte, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	presets := make([]Preset, 0, len(p.presets))
+	for _, preset := range p.presets {
+		presets = append(presets, preset)
+	}
+	sort.Slice(presets, func(i, j int) bool { return presets[i].Name < presets[j].Name })
+	return json.Marshal(presets)
+}
+
+// UnmarshalJSON replaces the presets with those of a JSON array. If any
+// preset is invalid or a name repeats, the presets are left unchanged.
+func (p *Presets) UnmarshalJSON(data []byte) error {
+	var list []Preset
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	presets := make(map[string]Preset, len(list))
+	for _, preset := range list {
+		if err := preset.validate(); err != nil {
+			return err
+		}
+		if _, ok := presets[preset.Name]; ok {
+			return newValidationError("duplicate preset %q", preset.Name)
+		}
+		presets[preset.Name] = preset
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.presets = presets
+	return nil
+}
+
+func (preset *Preset) validate() error {
+	if strings.TrimSpace(preset.Name) == "" {
+		return newValidationError("preset name is required")
+	}
+	if strings.ContainsAny(preset.Emotion, "()") {
+		return newValidationError("preset %q: emotion %q must not contain parentheses", preset.Name, preset.Emotion)
+	}
+	return preset.Config.Language.validate()
+}
+
+// applyPreset returns params with the preset named by PresetName applied:
+// the preset's Config under params.Config and its emotion on the text.
+// Params without a preset are returned as is.
+func (s *TTSService) applyPreset(params *StreamParams) (*StreamParams, error) {
+	if params.PresetName == "" {
+		return params, nil
+	}
+	var preset Preset
+	ok := false
+	if s.client.presets != nil {
+		preset, ok = s.client.presets.Lookup(params.PresetName)
+	}
+	if !ok {
+		return nil, newValidationError("unknown preset %q", params.PresetName)
+	}
+
+	p := *params
+	p.PresetName = ""
+	cfg := preset.Config
+	if params.Config != nil {
+		cfg = mergeTTSConfig(cfg, *params.Config)
+	}
+	p.Config = &cfg
+	if preset.Emotion != "" && p.Text != "" && !strings.HasPrefix(strings.TrimSpace(p.Text), "(") {
+		p.Text = "(" + preset.Emotion + ") " + p.Text
+	}
+	return &p, nil
+}
+
+// mergeTTSConfig returns base with the fields set in over replacing its own.
+func mergeTTSConfig(base, over TTSConfig) TTSConfig {
+	if over.Model != "" {
+		base.Model = over.Model
+	}
+	if over.Format != "" {
+		base.Format = over.Format
+	}
+	if over.SampleRate != 0 {
+		base.SampleRate = over.SampleRate
+	}
+	if over.MP3Bitrate != 0 {
+		base.MP3Bitrate = over.MP3Bitrate
+	}
+	if over.OpusBitrate != 0 {
+		base.OpusBitrate = over.OpusBitrate
+	}
+	if over.Normalize != nil {
+		base.Normalize = over.Normalize
+	}
+	if over.ChunkLength != 0 {
+		base.ChunkLength = over.ChunkLength
+	}
+	if over.Latency != "" {
+		base.Latency = over.Latency
+	}
+	if over.ReferenceID != "" {
+		base.ReferenceID = over.ReferenceID
+	}
+	if len(over.References) > 0 {
+		base.References = over.References
+	}
+	if over.Prosody != nil {
+		base.Prosody = over.Prosody
+	}
+	if over.TopP != 0 {
+		base.TopP = over.TopP
+	}
+	if over.Temperature != 0 {
+		base.Temperature = over.Temperature
+	}
+	if over.Language != "" {
+		base.Language = over.Language
+	}
+	return base
+}
@@ -0,0 +1,109 @@
+package fishaudio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestPresets_JSON(t *testing.T) {
+	presets := NewPresets()
+	data := `[
+		{"name": "narrator", "config": {"reference_id": "voice-1", "prosody": {"speed": 0.9}}},
+		{"name": "greeter", "emotion": "happy", "config": {"model": "s1", "language": "en"}}
+	]`
+	if err := json.Unmarshal([]byte(data), presets); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got := presets.Names(); !reflect.DeepEqual(got, []string{"greeter", "narrator"}) {
+		t.Errorf("Names() = %v", got)
+	}
+	narrator, ok := presets.Lookup("narrator")
+	if !ok || narrator.Config.ReferenceID != "voice-1" || narrator.Config.Prosody.Speed != 0.9 {
+		t.Errorf("Lookup(narrator) = %+v, %v", narrator, ok)
+	}
+
+	out, err := json.Marshal(presets)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	again := NewPresets()
+	if err := json.Unmarshal(out, again); err != nil {
+		t.Fatalf("Unmarshal(Marshal()) error = %v", err)
+	}
+	if greeter, _ := again.Lookup("greeter"); greeter.Emotion != "happy" || greeter.Config.Model != ModelS1 {
+		t.Errorf("round trip greeter = %+v", greeter)
+	}
+
+	// A bad document leaves the presets unchanged
+	var validationErr *ValidationError
+	for _, bad := range []string{
+		`[{"name": "a"}, {"name": "a"}]`,
+		`[{"name": ""}]`,
+		`[{"name": "a", "emotion": "(sad)"}]`,
+		`[{"name": "a", "config": {"language": "english!"}}]`,
+	} {
+		if err := json.Unmarshal([]byte(bad), presets); !errors.As(err, &validationErr) {
+			t.Errorf("Unmarshal(%s) error = %v, want *ValidationError", bad, err)
+		}
+	}
+	if len(presets.Names()) != 2 {
+		t.Errorf("Names() after bad documents = %v, want the original presets", presets.Names())
+	}
+}
+
+func TestTTSService_Preset(t *testing.T) {
+	var got ttsRequest
+	var model string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = ttsRequest{}
+		model = r.Header.Get("model")
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte("audio"))
+	}))
+	defer server.Close()
+
+	presets := NewPresets(Preset{
+		Name:    "greeter",
+		Emotion: "happy",
+		Config: TTSConfig{
+			Model:       ModelS1,
+			ReferenceID: "voice-1",
+			Prosody:     &Prosody{Speed: 1.2, Volume: 3},
+			Temperature: 0.5,
+		},
+	})
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithPresets(presets))
+	ctx := context.Background()
+
+	if _, err := client.TTS.Convert(ctx, &ConvertParams{Text: "Welcome!", PresetName: "greeter"}); err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if got.Text != "(happy) Welcome!" || got.ReferenceID != "voice-1" || got.Prosody.Volume != 3 || got.Temperature != 0.5 || model != "s1" {
+		t.Errorf("request = %+v, model %q", got, model)
+	}
+
+	// Request fields and Config take precedence over the preset
+	stream, err := client.TTS.Stream(ctx, &StreamParams{
+		Text:        "(sad) Goodbye.",
+		ReferenceID: "voice-2",
+		Config:      &TTSConfig{Temperature: 0.9},
+		PresetName:  "greeter",
+	})
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	_, _ = stream.Collect()
+	if got.Text != "(sad) Goodbye." || got.ReferenceID != "voice-2" || got.Temperature != 0.9 || got.Prosody.Speed != 1.2 {
+		t.Errorf("request = %+v", got)
+	}
+
+	var validationErr *ValidationError
+	if _, err := client.TTS.Convert(ctx, &ConvertParams{Text: "Hi", PresetName: "missing"}); !errors.As(err, &validationErr) {
+		t.Errorf("Convert(missing preset) error = %v, want *ValidationError", err)
+	}
+}
@@ -0,0 +1,152 @@
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy decides whether a failed API request is sent again.
+//
+// ShouldRetry is called after each failed attempt. resp is the error
+// response, whose body has already been read into err, or nil if no
+// response arrived. err is the error the request would return, such as a
+// *RateLimitError. attempt is the number of attempts made so far, starting
+// at 1. ShouldRetry returns how long to wait before the next attempt and
+// whether to make it.
+type RetryPolicy interface {
+	ShouldRetry(resp *http.Response, err error, attempt int) (time.Duration, bool)
+}
+
+// RetryPolicyFunc adapts a function to a RetryPolicy.
+//
+// Example:
+//
+//	// Retry server errors once, but never validation errors
+//	policy := fishaudio.RetryPolicyFunc(func(resp *http.Response, err error, attempt int) (time.Duration, bool) {
+//	    var serverErr *fishaudio.ServerError
+//	    return time.Second, attempt == 1 && errors.As(err, &serverErr)
+//	})
+type RetryPolicyFunc func(resp *http.Response, err error, attempt int) (time.Duration, bool)
+
+// ShouldRetry calls f(resp, err, attempt).
+func (f RetryPolicyFunc) ShouldRetry(resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	return f(resp, err, attempt)
+}
+
+// BackoffPolicy is the built-in RetryPolicy. It retries rate limits,
+// server errors, and network errors with exponential backoff, waiting as
+// long as a 429 response's Retry-After header asks. Other errors, such as
+// a *ValidationError, are not retried.
+type BackoffPolicy struct {
+	// MaxRetries is the number of times a request is retried. Default: 2.
+	MaxRetries int
+	// Delay is the wait before the first retry, doubled for each retry
+	// after. Default: 1 second.
+	Delay time.Duration
+	// MaxDelay caps the wait between attempts. A Retry-After longer than
+	// MaxDelay is not waited for; the error is returned instead.
+	// Default: 30 seconds.
+	MaxDelay time.Duration
+	// MaxElapsed caps the total time spent waiting between attempts of one
+	// request. Zero means no limit beyond MaxRetries and the context.
+	MaxElapsed time.Duration
+}
+
+// ShouldRetry implements RetryPolicy.
+func (p BackoffPolicy) ShouldRetry(resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	maxRetries, delay, maxDelay := p.MaxRetries, p.Delay, p.MaxDelay
+	if maxRetries == 0 {
+		maxRetries = 2
+	}
+	if delay <= 0 {
+		delay = time.Second
+	}
+	if maxDelay <= 0 {
+		maxDelay = 30 * time.Second
+	}
+	if attempt > maxRetries || !isTransient(err) {
+		return 0, false
+	}
+
+	// Waits before earlier retries, for MaxElapsed
+	var waited time.Duration
+	for i := 1; i < attempt; i++ {
+		waited += min(delay<<(i-1), maxDelay)
+	}
+	wait := min(delay<<(attempt-1), maxDelay)
+
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) && resp != nil {
+		if after, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			if after > maxDelay {
+				return 0, false
+			}
+			wait = after
+		}
+	}
+	if p.MaxElapsed > 0 && waited+wait > p.MaxElapsed {
+		return 0, false
+	}
+	return wait, true
+}
+
+// WithRetryPolicy retries failed API requests as policy decides, such as
+// with BackoffPolicy{}. Requests are not retried by default. Uploaded audio
+// that can't seek is spooled to a temporary file so it can be sent again.
+//
+// A retry is skipped if its wait would outlast the request's context.
+//
+// Example:
+//
+//	client := fishaudio.NewClient(fishaudio.WithRetryPolicy(fishaudio.BackoffPolicy{
+//	    MaxRetries: 5,
+//	    MaxElapsed: time.Minute,
+//	}))
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// retryWait asks the retry policy whether to retry a failed attempt and
+// waits before it. It returns false if the request should fail with err,
+// or ctx's error if ctx ended while waiting.
+func (c *Client) retryWait(ctx context.Context, resp *http.Response, err error, attempt int) (bool, error) {
+	if c.retryPolicy == nil || ctx.Err() != nil {
+		return false, err
+	}
+	wait, retry := c.retryPolicy.ShouldRetry(resp, err, attempt)
+	if !retry {
+		return false, err
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+		return false, err
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// parseRetryAfter parses a Retry-After header, in seconds or as an HTTP
+// date, into a wait from now.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
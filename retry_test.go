@@ -0,0 +1,185 @@
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBackoffPolicy(t *testing.T) {
+	rateLimited := func(retryAfter string) *http.Response {
+		resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+		if retryAfter != "" {
+			resp.Header.Set("Retry-After", retryAfter)
+		}
+		return resp
+	}
+	rateLimitErr := newAPIError(429, "Too Many Requests", "")
+	serverErr := newAPIError(503, "Service Unavailable", "")
+	validationErr := newAPIError(422, "Unprocessable Entity", "")
+
+	tests := []struct {
+		name      string
+		policy    BackoffPolicy
+		resp      *http.Response
+		err       error
+		attempt   int
+		wantWait  time.Duration
+		wantRetry bool
+	}{
+		{"server error", BackoffPolicy{}, nil, serverErr, 1, time.Second, true},
+		{"doubles", BackoffPolicy{}, nil, serverErr, 2, 2 * time.Second, true},
+		{"max retries", BackoffPolicy{}, nil, serverErr, 3, 0, false},
+		{"max delay", BackoffPolicy{MaxRetries: 10, MaxDelay: 5 * time.Second}, nil, serverErr, 5, 5 * time.Second, true},
+		{"validation error", BackoffPolicy{}, nil, validationErr, 1, 0, false},
+		{"retry after", BackoffPolicy{}, rateLimited("7"), rateLimitErr, 1, 7 * time.Second, true},
+		{"retry after too long", BackoffPolicy{}, rateLimited("120"), rateLimitErr, 1, 0, false},
+		{"retry after missing", BackoffPolicy{}, rateLimited(""), rateLimitErr, 1, time.Second, true},
+		{"max elapsed", BackoffPolicy{MaxRetries: 5, MaxElapsed: 5 * time.Second}, nil, serverErr, 3, 0, false},
+		{"within max elapsed", BackoffPolicy{MaxRetries: 5, MaxElapsed: 5 * time.Second}, nil, serverErr, 2, 2 * time.Second, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, retry := tt.policy.ShouldRetry(tt.resp, tt.err, tt.attempt)
+			if wait != tt.wantWait || retry != tt.wantRetry {
+				t.Errorf("ShouldRetry() = %v, %v, want %v, %v", wait, retry, tt.wantWait, tt.wantRetry)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"30", 30 * time.Second, true},
+		{"Mon, 01 Jan 2024 12:00:10 GMT", 10 * time.Second, true},
+		{"Mon, 01 Jan 2024 11:00:00 GMT", 0, true},
+		{"soon", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) < 3 {
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte("audio"))
+	}))
+	defer server.Close()
+
+	var attempts []int
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithRetryPolicy(RetryPolicyFunc(
+		func(resp *http.Response, err error, attempt int) (time.Duration, bool) {
+			attempts = append(attempts, attempt)
+			var rateLimitErr *RateLimitError
+			return 0, resp != nil && resp.Header.Get("Retry-After") == "0" && errors.As(err, &rateLimitErr)
+		})))
+
+	audio, err := client.TTS.Convert(context.Background(), &ConvertParams{Text: "Hello"})
+	if err != nil || string(audio) != "audio" {
+		t.Fatalf("Convert() = %q, %v, want audio after retries", audio, err)
+	}
+	if len(bodies) != 3 || bodies[2] == "" || bodies[2] != bodies[0] {
+		t.Errorf("got %d requests with bodies %q, want the same body sent 3 times", len(bodies), bodies)
+	}
+	if len(attempts) != 2 || attempts[0] != 1 || attempts[1] != 2 {
+		t.Errorf("attempts = %v, want [1 2]", attempts)
+	}
+}
+
+func TestWithRetryPolicy_Limits(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	// Not retried without a policy
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	_, err := client.Voices.Get(context.Background(), "voice")
+	var serverErr *ServerError
+	if !errors.As(err, &serverErr) || requests != 1 {
+		t.Errorf("Get() error = %v after %d requests, want *ServerError after 1", err, requests)
+	}
+
+	// Not retried when the wait would outlast the context
+	requests = 0
+	client = NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithRetryPolicy(BackoffPolicy{Delay: time.Minute}))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	_, err = client.Voices.Get(ctx, "voice")
+	if !errors.As(err, &serverErr) || requests != 1 || time.Since(start) > time.Second {
+		t.Errorf("Get() error = %v after %d requests and %v, want *ServerError at once", err, requests, time.Since(start))
+	}
+
+	// Retried until MaxRetries
+	requests = 0
+	client = NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithRetryPolicy(BackoffPolicy{MaxRetries: 2, Delay: time.Millisecond}))
+	if _, err := client.Voices.Get(context.Background(), "voice"); !errors.As(err, &serverErr) || requests != 3 {
+		t.Errorf("Get() error = %v after %d requests, want *ServerError after 3", err, requests)
+	}
+}
+
+func TestWithRetryPolicy_Multipart(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("ParseMultipartForm() error = %v", err)
+		}
+		file, _, _ := r.FormFile("audio")
+		audio, _ := io.ReadAll(file)
+		bodies = append(bodies, string(audio))
+		if len(bodies) < 2 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("converted"))
+	}))
+	defer server.Close()
+
+	var attempts []int
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithRetryPolicy(RetryPolicyFunc(
+		func(resp *http.Response, err error, attempt int) (time.Duration, bool) {
+			attempts = append(attempts, attempt)
+			var serverErr *ServerError
+			return 0, errors.As(err, &serverErr)
+		})))
+
+	stream, err := client.TTS.ConvertVoice(context.Background(), io.MultiReader(strings.NewReader("speech")), &ConvertVoiceParams{ReferenceID: "voice"})
+	if err != nil {
+		t.Fatalf("ConvertVoice() error = %v", err)
+	}
+	if audio, err := stream.Collect(); err != nil || string(audio) != "converted" {
+		t.Errorf("Collect() = %q, %v", audio, err)
+	}
+	if len(bodies) != 2 || bodies[0] != "speech" || bodies[1] != "speech" {
+		t.Errorf("uploads = %q, want the audio sent twice", bodies)
+	}
+	if len(attempts) != 1 || attempts[0] != 1 {
+		t.Errorf("attempts = %v, want [1]", attempts)
+	}
+}
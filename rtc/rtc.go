@@ -0,0 +1,103 @@
+// Package rtc bridges Fish Audio TTS to WebRTC. It writes Opus audio from
+// a TTS stream to a Pion audio track in real time, so voice agents can
+// speak to browsers or LiveKit rooms without transcoding.
+//
+// It lives in its own module so the core SDK doesn't depend on Pion.
+//
+// Example with LiveKit:
+//
+//	track, _ := rtc.NewTrack("agent-voice", "agent")
+//	_, _ = room.LocalParticipant.PublishTrack(track, &lksdk.TrackPublicationOptions{Name: "voice"})
+//	err := rtc.Speak(ctx, client, track, textChan, &fishaudio.StreamParams{ReferenceID: voiceID}, nil)
+package rtc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+	"github.com/fishaudio/fish-audio-go/oggopus"
+	"github.com/pion/webrtc/v4"
+	"github.com/pion/webrtc/v4/pkg/media"
+)
+
+// maxLag is how far behind schedule writing may fall, e.g. while TTS
+// audio is still being generated, before the schedule restarts instead
+// of bursting the backlog.
+const maxLag = 100 * time.Millisecond
+
+// SampleWriter receives timed media samples. *webrtc.TrackLocalStaticSample
+// implements it.
+type SampleWriter interface {
+	WriteSample(sample media.Sample) error
+}
+
+// NewTrack creates an Opus audio track that can be added to a Pion
+// PeerConnection or published to a LiveKit room.
+func NewTrack(id, streamID string) (*webrtc.TrackLocalStaticSample, error) {
+	return webrtc.NewTrackLocalStaticSample(webrtc.RTPCodecCapability{
+		MimeType:  webrtc.MimeTypeOpus,
+		ClockRate: oggopus.SampleRate,
+		Channels:  2,
+	}, id, streamID)
+}
+
+// WriteOgg reads Ogg Opus audio from r and writes each packet to track at
+// the pace it plays, returning when r is exhausted or ctx is done.
+func WriteOgg(ctx context.Context, track SampleWriter, r io.Reader) error {
+	packets := oggopus.NewReader(r)
+	var next time.Time
+	for {
+		packet, err := packets.ReadPacket()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		duration := oggopus.PacketDuration(packet)
+		if duration == 0 {
+			continue
+		}
+
+		now := time.Now()
+		if next.IsZero() || now.Sub(next) > maxLag {
+			next = now
+		}
+		if wait := next.Sub(now); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
+
+		if err := track.WriteSample(media.Sample{Data: packet, Duration: duration}); err != nil {
+			return err
+		}
+		next = next.Add(duration)
+	}
+}
+
+// Speak streams text from textChan through a live TTS session and writes
+// the speech to track as it is generated. params.Format is overridden to
+// Opus. It returns when the session ends or ctx is done.
+func Speak(ctx context.Context, client *fishaudio.Client, track SampleWriter, textChan <-chan string, params *fishaudio.StreamParams, opts *fishaudio.WebSocketOptions) error {
+	var p fishaudio.StreamParams
+	if params != nil {
+		p = *params
+	}
+	p.Format = fishaudio.AudioFormatOpus
+
+	stream, err := client.TTS.StreamWebSocket(ctx, textChan, &p, opts)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stream.Close() }()
+
+	return WriteOgg(ctx, track, stream)
+}
@@ -0,0 +1,137 @@
+package rtc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	fishaudio "github.com/fishaudio/fish-audio-go"
+	"github.com/fishaudio/fish-audio-go/fishaudiotest"
+	"github.com/pion/webrtc/v4"
+	"github.com/pion/webrtc/v4/pkg/media"
+)
+
+// celt10ms is the TOC byte of a one-frame, 10 ms CELT packet.
+const celt10ms = 0x90
+
+type recorder struct {
+	mu      sync.Mutex
+	samples []media.Sample
+	times   []time.Time
+}
+
+func (r *recorder) WriteSample(s media.Sample) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.samples = append(r.samples, s)
+	r.times = append(r.times, time.Now())
+	return nil
+}
+
+// oggOpus builds an Ogg Opus stream with one page per packet.
+func oggOpus(packets ...[]byte) []byte {
+	head := append([]byte("OpusHead\x01\x02\x00\x00"), binary.LittleEndian.AppendUint32(nil, 48000)...)
+	head = append(head, 0, 0, 0)
+	all := append([][]byte{head, []byte("OpusTags\x00\x00\x00\x00\x00\x00\x00\x00")}, packets...)
+
+	var b bytes.Buffer
+	for i, p := range all {
+		headerType := byte(0)
+		if i == 0 {
+			headerType = 0x02
+		}
+		b.WriteString("OggS\x00")
+		b.WriteByte(headerType)
+		b.Write(make([]byte, 20)) // granule, serial, sequence, CRC
+		b.WriteByte(1)
+		b.WriteByte(byte(len(p)))
+		b.Write(p)
+	}
+	return b.Bytes()
+}
+
+func packets(n int) [][]byte {
+	var ps [][]byte
+	for i := 0; i < n; i++ {
+		ps = append(ps, []byte{celt10ms, byte(i)})
+	}
+	return ps
+}
+
+func TestWriteOgg(t *testing.T) {
+	var track recorder
+	start := time.Now()
+	if err := WriteOgg(context.Background(), &track, bytes.NewReader(oggOpus(packets(10)...))); err != nil {
+		t.Fatalf("WriteOgg() error = %v", err)
+	}
+
+	if len(track.samples) != 10 {
+		t.Fatalf("wrote %d samples, want 10", len(track.samples))
+	}
+	for i, s := range track.samples {
+		if s.Duration != 10*time.Millisecond || s.Data[1] != byte(i) {
+			t.Errorf("sample %d = %+v", i, s)
+		}
+	}
+	// The last packet is due 90 ms after the first
+	if elapsed := track.times[9].Sub(start); elapsed < 85*time.Millisecond {
+		t.Errorf("samples written in %v, want real-time pacing", elapsed)
+	}
+}
+
+func TestWriteOgg_Cancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	var track recorder
+	err := WriteOgg(ctx, &track, bytes.NewReader(oggOpus(packets(100)...)))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WriteOgg() err = %v, want context.DeadlineExceeded", err)
+	}
+	if n := len(track.samples); n == 0 || n > 10 {
+		t.Errorf("wrote %d samples before cancel, want a few", n)
+	}
+}
+
+func TestNewTrack(t *testing.T) {
+	track, err := NewTrack("voice", "agent")
+	if err != nil {
+		t.Fatalf("NewTrack() error = %v", err)
+	}
+	if track.Kind() != webrtc.RTPCodecTypeAudio || track.Codec().MimeType != webrtc.MimeTypeOpus {
+		t.Errorf("track = %v %s, want Opus audio", track.Kind(), track.Codec().MimeType)
+	}
+}
+
+func TestSpeak(t *testing.T) {
+	server := fishaudiotest.NewLiveServer(fishaudiotest.LiveScript{
+		Audio:     func(string) []byte { return oggOpus(packets(3)...) },
+		ChunkSize: 7,
+	})
+	defer server.Close()
+
+	client := fishaudio.NewClient(fishaudio.WithAPIKey("test-key"), fishaudio.WithBaseURL(server.URL))
+	textChan := make(chan string, 1)
+	textChan <- "Hello"
+	close(textChan)
+
+	var track recorder
+	err := Speak(context.Background(), client, &track, textChan, &fishaudio.StreamParams{
+		ReferenceID: "voice-1",
+		Format:      fishaudio.AudioFormatMP3,
+	}, nil)
+	if err != nil {
+		t.Fatalf("Speak() error = %v", err)
+	}
+	if len(track.samples) != 3 {
+		t.Errorf("wrote %d samples, want 3", len(track.samples))
+	}
+	sessions := server.Sessions()
+	if len(sessions) != 1 || sessions[0].Start["format"] != "opus" {
+		t.Errorf("start request = %v, want opus format", sessions[0].Start)
+	}
+}
@@ -0,0 +1,415 @@
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// ErrPoolClosed is returned when using a SessionPool after Close.
+var ErrPoolClosed = errors.New("session pool closed")
+
+// SessionPoolOptions configures a SessionPool.
+type SessionPoolOptions struct {
+	// Size is the number of idle connections to keep ready. Default: 2.
+	Size int
+
+	// Model is the TTS model for pooled connections. The model is fixed at
+	// dial time, so sessions requesting a different model dial a fresh
+	// connection instead. Default: "s2-pro".
+	Model Model
+
+	// MaxIdleTime is how long an idle connection is kept before it is
+	// replaced. Default: 5 minutes.
+	MaxIdleTime time.Duration
+
+	// RetryInterval is the delay between failed dial attempts. Default: 1 second.
+	RetryInterval time.Duration
+
+	// WebSocket configures the pooled connections. Default: DefaultWebSocketOptions().
+	WebSocket *WebSocketOptions
+}
+
+// SessionPool keeps pre-dialed TTS WebSocket connections ready so that new
+// live sessions skip the dial, TLS, and upgrade latency.
+//
+// Each connection serves a single session; the pool dials replacements in
+// the background as sessions are handed out.
+//
+// Example:
+//
+//	pool := client.TTS.NewSessionPool(ctx, &fishaudio.SessionPoolOptions{Size: 4})
+//	defer pool.Close()
+//
+//	stream, err := pool.Stream(ctx, textChan, &fishaudio.StreamParams{ReferenceID: "voice-id"})
+type SessionPool struct {
+	tts  *TTSService
+	opts SessionPoolOptions
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	idle   []*pooledConn
+	closed bool
+
+	refill chan struct{}
+}
+
+// pooledConn is an idle connection kept alive with pings until it is taken.
+// It is read while idle so that pongs, close frames, and broken sockets are
+// noticed.
+type pooledConn struct {
+	conn     *websocket.Conn
+	dialedAt time.Time
+	stop     chan struct{}
+	stopped  chan struct{}
+
+	// read receives the result of the idle read. A session started on the
+	// connection takes it as its first frame.
+	read chan wsFrame
+
+	mu     sync.Mutex
+	dead   bool
+	onPong func(string) error
+}
+
+// NewSessionPool creates a SessionPool and starts filling it in the background.
+// Cancelling ctx or calling Close releases all idle connections.
+func (s *TTSService) NewSessionPool(ctx context.Context, opts *SessionPoolOptions) *SessionPool {
+	p := &SessionPool{
+		tts:    s,
+		refill: make(chan struct{}, 1),
+	}
+	if opts != nil {
+		p.opts = *opts
+	}
+	if p.opts.Size <= 0 {
+		p.opts.Size = 2
+	}
+	if p.opts.Model == "" {
+		p.opts.Model = ModelS2Pro
+	}
+	if p.opts.MaxIdleTime <= 0 {
+		p.opts.MaxIdleTime = 5 * time.Minute
+	}
+	if p.opts.RetryInterval <= 0 {
+		p.opts.RetryInterval = time.Second
+	}
+	if p.opts.WebSocket == nil {
+		p.opts.WebSocket = DefaultWebSocketOptions()
+	}
+
+	p.ctx, p.cancel = context.WithCancel(ctx)
+
+	p.wg.Add(1)
+	go p.maintain()
+
+	return p
+}
+
+// Stream starts a live TTS session on a pooled connection, dialing a new one
+// if none is ready. It behaves like TTSService.StreamWebSocket with the
+// pool's WebSocket options.
+func (p *SessionPool) Stream(ctx context.Context, textChan <-chan string, params *StreamParams) (*WebSocketAudioStream, error) {
+	if params == nil {
+		params = &StreamParams{}
+	}
+	params, err := p.tts.resolveParams(params)
+	if err != nil {
+		return nil, err
+	}
+	if params.Config != nil {
+		if err := params.Config.Language.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if budget := p.tts.client.budget; budget != nil {
+		// Check the limits before taking a connection; text is counted as
+		// it is sent
+		if _, err := budget.reserve(""); err != nil {
+			return nil, err
+		}
+	}
+
+	model := p.tts.getModel(params)
+	var pc *pooledConn
+	if model == p.opts.Model {
+		if pc, err = p.take(); err != nil {
+			return nil, err
+		}
+	} else if p.isClosed() {
+		return nil, ErrPoolClosed
+	}
+
+	if pc != nil {
+		stream, err := p.tts.startWebSocket(ctx, pc.conn, pc, textChan, params, p.opts.WebSocket)
+		if err == nil || ctx.Err() != nil {
+			return stream, err
+		}
+		// The connection died since it was last checked; redial once
+	}
+
+	conn, err := p.tts.dialWebSocket(ctx, model, p.opts.WebSocket)
+	if err != nil {
+		return nil, err
+	}
+	return p.tts.startWebSocket(ctx, conn, nil, textChan, params, p.opts.WebSocket)
+}
+
+// Idle returns the number of connections currently ready.
+func (p *SessionPool) Idle() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.idle)
+}
+
+// Close stops refilling the pool and closes all idle connections.
+// Sessions already handed out are not affected.
+func (p *SessionPool) Close() error {
+	p.shutdown()
+	p.wg.Wait()
+	return nil
+}
+
+// shutdown marks the pool closed and releases idle connections.
+func (p *SessionPool) shutdown() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	idle := p.idle
+	p.idle = nil
+	p.mu.Unlock()
+
+	p.cancel()
+	for _, pc := range idle {
+		pc.release()
+		_ = pc.conn.Close()
+	}
+}
+
+// isClosed reports whether Close has been called.
+func (p *SessionPool) isClosed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.closed
+}
+
+// take removes a healthy idle connection from the pool, or returns nil if
+// none is available.
+func (p *SessionPool) take() (*pooledConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	defer p.signalRefill()
+
+	if p.closed {
+		return nil, ErrPoolClosed
+	}
+
+	for len(p.idle) > 0 {
+		pc := p.idle[0]
+		p.idle = p.idle[1:]
+		if pc.release() && time.Since(pc.dialedAt) < p.opts.MaxIdleTime {
+			return pc, nil
+		}
+		_ = pc.conn.Close()
+	}
+	return nil, nil
+}
+
+// signalRefill wakes the maintenance goroutine without blocking.
+func (p *SessionPool) signalRefill() {
+	select {
+	case p.refill <- struct{}{}:
+	default:
+	}
+}
+
+// maintain keeps the pool filled and evicts stale or dead connections.
+func (p *SessionPool) maintain() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.opts.RetryInterval)
+	defer ticker.Stop()
+
+	for {
+		p.evict()
+		for p.needsConn() {
+			conn, err := p.dial()
+			if err != nil {
+				// Retry on the next tick
+				break
+			}
+			p.add(conn)
+		}
+
+		select {
+		case <-p.ctx.Done():
+			p.shutdown()
+			return
+		case <-p.refill:
+		case <-ticker.C:
+		}
+	}
+}
+
+// dial opens a connection for the pool. A panic in a WebSocketOptions
+// callback such as OnConnect is returned as an error, so the pool retries
+// on the next tick.
+func (p *SessionPool) dial() (conn *websocket.Conn, err error) {
+	defer p.tts.client.catchPanic("session pool", &err)
+	return p.tts.dialWebSocket(p.ctx, p.opts.Model, p.opts.WebSocket)
+}
+
+// needsConn reports whether the pool is below its target size.
+func (p *SessionPool) needsConn() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return !p.closed && len(p.idle) < p.opts.Size
+}
+
+// add places a freshly dialed connection into the pool.
+func (p *SessionPool) add(conn *websocket.Conn) {
+	pc := &pooledConn{
+		conn:     conn,
+		dialedAt: time.Now(),
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+		read:     make(chan wsFrame, 1),
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		_ = conn.Close()
+		return
+	}
+
+	// Configure reads before the idle read starts; sessions can't change
+	// them without racing it
+	opts := p.opts.WebSocket
+	conn.SetReadLimit(opts.MaxMessageSize)
+	conn.SetPongHandler(pc.pong)
+	if opts.PingInterval > 0 {
+		wait := opts.PingInterval + opts.PingTimeout
+		_ = conn.SetReadDeadline(time.Now().Add(wait))
+		pc.setPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(wait))
+		})
+	}
+
+	p.idle = append(p.idle, pc)
+	go pc.watch(p.signalRefill)
+	go pc.keepAlive(opts)
+}
+
+// evict closes idle connections that are dead or past MaxIdleTime.
+func (p *SessionPool) evict() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	kept := p.idle[:0]
+	for _, pc := range p.idle {
+		if pc.isDead() || time.Since(pc.dialedAt) >= p.opts.MaxIdleTime {
+			pc.release()
+			_ = pc.conn.Close()
+			continue
+		}
+		kept = append(kept, pc)
+	}
+	p.idle = kept
+}
+
+// watch reads from the idle connection, which processes pongs and close
+// frames. The server sends nothing before the start event, so any frame or
+// error means the connection can't be handed out; it is marked dead and the
+// pool is signalled to replace it. A session on a connection taken before
+// then receives the result as its first frame.
+func (pc *pooledConn) watch(refill func()) {
+	messageType, r, err := pc.conn.NextReader()
+	var data []byte
+	if err == nil {
+		data, err = io.ReadAll(r)
+	}
+	pc.mu.Lock()
+	pc.dead = true
+	pc.mu.Unlock()
+	pc.read <- wsFrame{messageType: messageType, data: data, err: err}
+	refill()
+}
+
+// pong calls the current pong handler. It is installed before the idle read
+// starts and stays installed, so a session can replace the handler with
+// setPongHandler.
+func (pc *pooledConn) pong(appData string) error {
+	pc.mu.Lock()
+	h := pc.onPong
+	pc.mu.Unlock()
+	if h == nil {
+		return nil
+	}
+	return h(appData)
+}
+
+// setPongHandler replaces the handler called by pong.
+func (pc *pooledConn) setPongHandler(h func(string) error) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	pc.onPong = h
+}
+
+// keepAlive pings the idle connection until it is taken from the pool.
+func (pc *pooledConn) keepAlive(opts *WebSocketOptions) {
+	defer close(pc.stopped)
+
+	if opts.PingInterval <= 0 {
+		<-pc.stop
+		return
+	}
+
+	ticker := time.NewTicker(opts.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			writeWait := opts.PingTimeout
+			if writeWait <= 0 {
+				writeWait = opts.PingInterval
+			}
+			if err := pc.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				pc.mu.Lock()
+				pc.dead = true
+				pc.mu.Unlock()
+				<-pc.stop
+				return
+			}
+		case <-pc.stop:
+			return
+		}
+	}
+}
+
+// release stops the keepalive goroutine and reports whether the connection
+// is still usable.
+func (pc *pooledConn) release() bool {
+	close(pc.stop)
+	<-pc.stopped
+	return !pc.isDead()
+}
+
+// isDead reports whether a keepalive ping failed or the idle read ended.
+func (pc *pooledConn) isDead() bool {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	return pc.dead
+}
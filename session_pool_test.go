@@ -0,0 +1,227 @@
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// newPoolTestServer returns a live TTS server that answers every session
+// with one audio chunk and counts upgrades.
+func newPoolTestServer(t *testing.T, dials *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		atomic.AddInt32(dials, 1)
+		defer func() { _ = conn.Close() }()
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var msg map[string]interface{}
+			_ = msgpack.Unmarshal(data, &msg)
+			if msg["event"] == "stop" {
+				break
+			}
+		}
+
+		for _, resp := range []wsResponse{
+			{Event: "audio", Audio: []byte("pooled")},
+			{Event: "finish", Reason: "stop"},
+		} {
+			data, _ := msgpack.Marshal(resp)
+			_ = conn.WriteMessage(websocket.BinaryMessage, data)
+		}
+	}))
+}
+
+// waitFor polls cond until it returns true or the test times out.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before timeout")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSessionPool_PreDialsAndRefills(t *testing.T) {
+	var dials int32
+	server := newPoolTestServer(t, &dials)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	pool := client.TTS.NewSessionPool(context.Background(), &SessionPoolOptions{
+		Size:          2,
+		RetryInterval: 10 * time.Millisecond,
+	})
+	defer func() { _ = pool.Close() }()
+
+	waitFor(t, func() bool { return pool.Idle() == 2 })
+	if got := atomic.LoadInt32(&dials); got != 2 {
+		t.Fatalf("dials = %d, want 2", got)
+	}
+
+	textChan := make(chan string)
+	close(textChan)
+
+	stream, err := pool.Stream(context.Background(), textChan, &StreamParams{ReferenceID: "voice-123"})
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	data, err := stream.Collect()
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if string(data) != "pooled" {
+		t.Errorf("Collect() = %q, want %q", data, "pooled")
+	}
+
+	// The pool replaces the connection it handed out
+	waitFor(t, func() bool { return atomic.LoadInt32(&dials) == 3 && pool.Idle() == 2 })
+}
+
+func TestSessionPool_DifferentModelDialsFresh(t *testing.T) {
+	var dials int32
+	server := newPoolTestServer(t, &dials)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	pool := client.TTS.NewSessionPool(context.Background(), &SessionPoolOptions{Size: 1})
+	defer func() { _ = pool.Close() }()
+
+	waitFor(t, func() bool { return pool.Idle() == 1 })
+
+	textChan := make(chan string)
+	close(textChan)
+
+	stream, err := pool.Stream(context.Background(), textChan, &StreamParams{Model: ModelS1})
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	if _, err := stream.Collect(); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	if pool.Idle() != 1 {
+		t.Errorf("Idle() = %d, want pooled connection left untouched", pool.Idle())
+	}
+}
+
+func TestSessionPool_Close(t *testing.T) {
+	var dials int32
+	server := newPoolTestServer(t, &dials)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	pool := client.TTS.NewSessionPool(context.Background(), &SessionPoolOptions{Size: 1})
+
+	waitFor(t, func() bool { return pool.Idle() == 1 })
+
+	if err := pool.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if pool.Idle() != 0 {
+		t.Errorf("Idle() = %d, want 0 after Close()", pool.Idle())
+	}
+
+	_, err := pool.Stream(context.Background(), make(chan string), nil)
+	if !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Stream() err = %v, want %v", err, ErrPoolClosed)
+	}
+}
+
+func TestSessionPool_DropsConnectionsClosedWhileIdle(t *testing.T) {
+	var dials int32
+	live := newPoolTestServer(t, &dials)
+	defer live.Close()
+
+	// The first connection is closed by the server while idle
+	var upgrades int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&upgrades, 1) > 1 {
+			live.Config.Handler.ServeHTTP(w, r)
+			return
+		}
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, "idle"))
+		_ = conn.Close()
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	pool := client.TTS.NewSessionPool(context.Background(), &SessionPoolOptions{
+		Size:          1,
+		RetryInterval: 10 * time.Millisecond,
+	})
+	defer func() { _ = pool.Close() }()
+
+	// The closed connection is replaced without being handed out
+	waitFor(t, func() bool { return atomic.LoadInt32(&dials) == 1 && pool.Idle() == 1 })
+
+	textChan := make(chan string)
+	close(textChan)
+	stream, err := pool.Stream(context.Background(), textChan, nil)
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	if data, err := stream.Collect(); err != nil || string(data) != "pooled" {
+		t.Errorf("Collect() = %q, %v, want pooled audio", data, err)
+	}
+}
+
+func TestSessionPool_RedialsWhenStartFails(t *testing.T) {
+	var dials int32
+	server := newPoolTestServer(t, &dials)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	pool := client.TTS.NewSessionPool(context.Background(), &SessionPoolOptions{Size: 1})
+	defer func() { _ = pool.Close() }()
+	waitFor(t, func() bool { return pool.Idle() == 1 })
+
+	// Queue a connection that died without the pool noticing
+	conn, err := client.TTS.dialWebSocket(context.Background(), ModelS2Pro, DefaultWebSocketOptions())
+	if err != nil {
+		t.Fatalf("dialWebSocket() error = %v", err)
+	}
+	_ = conn.Close()
+	stale := &pooledConn{
+		conn:     conn,
+		dialedAt: time.Now(),
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+		read:     make(chan wsFrame, 1),
+	}
+	close(stale.stopped)
+	pool.mu.Lock()
+	pool.idle = append([]*pooledConn{stale}, pool.idle...)
+	pool.mu.Unlock()
+
+	textChan := make(chan string)
+	close(textChan)
+	stream, err := pool.Stream(context.Background(), textChan, nil)
+	if err != nil {
+		t.Fatalf("Stream() error = %v, want a redial after the failed start", err)
+	}
+	if data, err := stream.Collect(); err != nil || string(data) != "pooled" {
+		t.Errorf("Collect() = %q, %v, want pooled audio", data, err)
+	}
+}
@@ -1,63 +1,49 @@
 package fishaudio
 
-// AudioFormat specifies the output audio format.
-type AudioFormat string
-
-const (
-	AudioFormatMP3  AudioFormat = "mp3"
-	AudioFormatWAV  AudioFormat = "wav"
-	AudioFormatPCM  AudioFormat = "pcm"
-	AudioFormatOpus AudioFormat = "opus"
-)
-
-// LatencyMode specifies the generation latency mode.
-type LatencyMode string
-
-const (
-	LatencyNormal   LatencyMode = "normal"
-	LatencyBalanced LatencyMode = "balanced"
-)
+// ContentType returns the MIME type of audio in the format. Raw PCM and
+// unknown formats are "application/octet-stream"; an empty format is the
+// server default, MP3.
+func (f AudioFormat) ContentType() string {
+	switch f {
+	case AudioFormatMP3, "":
+		return "audio/mpeg"
+	case AudioFormatWAV:
+		return "audio/wav"
+	case AudioFormatOpus:
+		return "audio/ogg"
+	}
+	return "application/octet-stream"
+}
 
 // PaginatedResponse wraps paginated API responses.
 type PaginatedResponse[T any] struct {
 	Total int `json:"total"`
 	Items []T `json:"items"`
-}
-
-// Visibility specifies the visibility of a voice model.
-type Visibility string
-
-const (
-	VisibilityPublic  Visibility = "public"
-	VisibilityUnlist  Visibility = "unlist"
-	VisibilityPrivate Visibility = "private"
-)
 
-// TrainMode specifies the training mode for voice models.
-type TrainMode string
-
-const (
-	TrainModeFast TrainMode = "fast"
-)
-
-// ModelState specifies the state of a voice model.
-type ModelState string
+	// PageSize is the page size the page was requested with.
+	PageSize int `json:"-"`
+	// PageNumber is the 1-indexed number of the page.
+	PageNumber int `json:"-"`
+}
 
-const (
-	ModelStateCreated  ModelState = "created"
-	ModelStateTraining ModelState = "training"
-	ModelStateTrained  ModelState = "trained"
-	ModelStateFailed   ModelState = "failed"
-)
+// HasNext reports whether there are items after this page.
+func (r *PaginatedResponse[T]) HasNext() bool {
+	if r.PageSize <= 0 || r.PageNumber <= 0 {
+		return false
+	}
+	return r.PageNumber*r.PageSize < r.Total
+}
 
-// Model specifies the TTS model to use.
-type Model string
+// TotalPages returns the number of pages at the current page size.
+func (r *PaginatedResponse[T]) TotalPages() int {
+	if r.PageSize <= 0 {
+		return 0
+	}
+	return (r.Total + r.PageSize - 1) / r.PageSize
+}
 
-const (
-	// Deprecated: Use ModelS1 or ModelS2Pro instead.
-	ModelSpeech15 Model = "speech-1.5"
-	// Deprecated: Use ModelS1 or ModelS2Pro instead.
-	ModelSpeech16 Model = "speech-1.6"
-	ModelS1       Model = "s1"
-	ModelS2Pro    Model = "s2-pro"
-)
+// Known reports whether m is one of the TrainMode constants. Unknown modes
+// are still sent to the API, so newer modes work before the SDK lists them.
+func (m TrainMode) Known() bool {
+	return m == TrainModeFast || m == TrainModeFull
+}
@@ -22,6 +22,26 @@ func TestAudioFormat_Values(t *testing.T) {
 	}
 }
 
+func TestAudioFormat_ContentType(t *testing.T) {
+	tests := []struct {
+		format   AudioFormat
+		expected string
+	}{
+		{AudioFormatMP3, "audio/mpeg"},
+		{"", "audio/mpeg"},
+		{AudioFormatWAV, "audio/wav"},
+		{AudioFormatOpus, "audio/ogg"},
+		{AudioFormatPCM, "application/octet-stream"},
+		{"flac", "application/octet-stream"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.format.ContentType(); got != tt.expected {
+			t.Errorf("AudioFormat(%q).ContentType() = %q, want %q", tt.format, got, tt.expected)
+		}
+	}
+}
+
 func TestLatencyMode_Values(t *testing.T) {
 	tests := []struct {
 		mode     LatencyMode
@@ -63,6 +83,20 @@ func TestTrainMode_Values(t *testing.T) {
 	if string(TrainModeFast) != "fast" {
 		t.Errorf("TrainModeFast = %q, want %q", string(TrainModeFast), "fast")
 	}
+	if string(TrainModeFull) != "full" {
+		t.Errorf("TrainModeFull = %q, want %q", string(TrainModeFull), "full")
+	}
+}
+
+func TestTrainMode_Known(t *testing.T) {
+	for _, mode := range []TrainMode{TrainModeFast, TrainModeFull} {
+		if !mode.Known() {
+			t.Errorf("%q.Known() = false, want true", mode)
+		}
+	}
+	if TrainMode("studio").Known() {
+		t.Error(`"studio".Known() = true, want false`)
+	}
 }
 
 func TestModelState_Values(t *testing.T) {
@@ -130,3 +164,35 @@ func TestPaginatedResponse(t *testing.T) {
 		t.Errorf("Items[0].ID = %q, want %q", resp.Items[0].ID, "1")
 	}
 }
+
+func TestPaginatedResponse_HasNext(t *testing.T) {
+	tests := []struct {
+		name       string
+		resp       PaginatedResponse[int]
+		hasNext    bool
+		totalPages int
+	}{
+		{"first of three", PaginatedResponse[int]{Total: 25, PageSize: 10, PageNumber: 1}, true, 3},
+		{"last partial", PaginatedResponse[int]{Total: 25, PageSize: 10, PageNumber: 3}, false, 3},
+		{"exact fit", PaginatedResponse[int]{Total: 20, PageSize: 10, PageNumber: 2}, false, 2},
+		{"empty", PaginatedResponse[int]{Total: 0, PageSize: 10, PageNumber: 1}, false, 0},
+		{"unknown page size", PaginatedResponse[int]{Total: 25}, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.resp.HasNext(); got != tt.hasNext {
+				t.Errorf("HasNext() = %v, want %v", got, tt.hasNext)
+			}
+			if got := tt.resp.TotalPages(); got != tt.totalPages {
+				t.Errorf("TotalPages() = %d, want %d", got, tt.totalPages)
+			}
+		})
+	}
+}
+
+func TestSortOrder_Values(t *testing.T) {
+	if SortAscending != "asc" || SortDescending != "desc" {
+		t.Errorf("SortOrder values = %q, %q, want asc, desc", SortAscending, SortDescending)
+	}
+}
@@ -0,0 +1,223 @@
+package fishaudio
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Storage is the filesystem the client reads input files from and writes
+// output files to: TranscribeFile, ConvertToFile, JobQueue paths, and
+// VoiceLibrary directories.
+// Set it with WithStorage to use a virtual filesystem in tests or in
+// serverless environments without a writable disk. Default: OSStorage.
+type Storage interface {
+	// Open opens the named file for reading.
+	Open(name string) (fs.File, error)
+	// Create creates or truncates the named file. Its contents are only
+	// guaranteed to be complete once Close returns nil.
+	Create(name string) (io.WriteCloser, error)
+	// Stat returns information about the named file.
+	Stat(name string) (fs.FileInfo, error)
+}
+
+// OSStorage is a Storage backed by the operating system's filesystem.
+// Files are created atomically: they are written to a temporary file and
+// renamed into place by Close, so readers never see partial audio. New
+// files get mode 0644; a replaced file keeps its permissions.
+type OSStorage struct{}
+
+// Open implements Storage.
+func (OSStorage) Open(name string) (fs.File, error) {
+	return os.Open(name)
+}
+
+// Create implements Storage.
+func (OSStorage) Create(name string) (io.WriteCloser, error) {
+	f, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*")
+	if err != nil {
+		return nil, err
+	}
+	// CreateTemp uses 0600, which would make output files private
+	mode := fs.FileMode(0o644)
+	if info, err := os.Stat(name); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := f.Chmod(mode); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return nil, err
+	}
+	return &atomicFile{f: f, name: name}, nil
+}
+
+// Stat implements Storage.
+func (OSStorage) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+// atomicFile is a temporary file renamed to name when closed, unless a
+// write failed.
+type atomicFile struct {
+	f    *os.File
+	name string
+	err  error
+}
+
+func (a *atomicFile) Write(p []byte) (int, error) {
+	n, err := a.f.Write(p)
+	if err != nil && a.err == nil {
+		a.err = err
+	}
+	return n, err
+}
+
+func (a *atomicFile) Close() error {
+	err := a.f.Close()
+	if a.err != nil {
+		err = a.err
+	}
+	if err == nil {
+		err = os.Rename(a.f.Name(), a.name)
+	}
+	if err != nil {
+		_ = os.Remove(a.f.Name())
+	}
+	return err
+}
+
+// FSStorage is a read-only Storage backed by an fs.FS, such as an
+// embed.FS or fstest.MapFS. Names must be valid fs.FS paths.
+type FSStorage struct {
+	FS fs.FS
+}
+
+// Open implements Storage.
+func (s FSStorage) Open(name string) (fs.File, error) {
+	return s.FS.Open(name)
+}
+
+// Create implements Storage. It always fails with fs.ErrPermission.
+func (s FSStorage) Create(name string) (io.WriteCloser, error) {
+	return nil, &fs.PathError{Op: "create", Path: name, Err: fs.ErrPermission}
+}
+
+// Stat implements Storage.
+func (s FSStorage) Stat(name string) (fs.FileInfo, error) {
+	return fs.Stat(s.FS, name)
+}
+
+// MemStorage is a Storage that keeps files in memory. Names are cleaned
+// with path.Clean, so "a/b.wav" and "a//b.wav" are the same file.
+//
+// Example:
+//
+//	files := fishaudio.NewMemStorage()
+//	client := fishaudio.NewClient(fishaudio.WithStorage(files))
+//	err := client.TTS.ConvertToFile(ctx, &fishaudio.ConvertParams{Text: "Hi"}, "hi.mp3")
+type MemStorage struct {
+	mu    sync.Mutex
+	files map[string]memFileData
+}
+
+// memFileData is the stored content of a MemStorage file.
+type memFileData struct {
+	data    []byte
+	modTime time.Time
+}
+
+// NewMemStorage creates an empty MemStorage.
+func NewMemStorage() *MemStorage {
+	return &MemStorage{files: map[string]memFileData{}}
+}
+
+// Open implements Storage.
+func (s *MemStorage) Open(name string) (fs.File, error) {
+	name = path.Clean(name)
+	s.mu.Lock()
+	file, ok := s.files[name]
+	s.mu.Unlock()
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &memFile{Reader: bytes.NewReader(file.data), info: memFileInfo{name: name, file: file}}, nil
+}
+
+// Create implements Storage. The file appears when the writer is closed.
+func (s *MemStorage) Create(name string) (io.WriteCloser, error) {
+	return &memWriter{storage: s, name: path.Clean(name)}, nil
+}
+
+// Stat implements Storage.
+func (s *MemStorage) Stat(name string) (fs.FileInfo, error) {
+	name = path.Clean(name)
+	s.mu.Lock()
+	file, ok := s.files[name]
+	s.mu.Unlock()
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return memFileInfo{name: name, file: file}, nil
+}
+
+// WriteFile stores data as the named file.
+func (s *MemStorage) WriteFile(name string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[path.Clean(name)] = memFileData{data: bytes.Clone(data), modTime: time.Now()}
+}
+
+// ReadFile returns the contents of the named file.
+func (s *MemStorage) ReadFile(name string) ([]byte, error) {
+	name = path.Clean(name)
+	s.mu.Lock()
+	file, ok := s.files[name]
+	s.mu.Unlock()
+	if !ok {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+	return bytes.Clone(file.data), nil
+}
+
+// memWriter buffers a MemStorage file until it is closed.
+type memWriter struct {
+	storage *MemStorage
+	name    string
+	buf     bytes.Buffer
+}
+
+func (w *memWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+func (w *memWriter) Close() error {
+	w.storage.WriteFile(w.name, w.buf.Bytes())
+	return nil
+}
+
+// memFile is an open MemStorage file.
+type memFile struct {
+	*bytes.Reader
+	info memFileInfo
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *memFile) Close() error               { return nil }
+
+// memFileInfo describes a MemStorage file.
+type memFileInfo struct {
+	name string
+	file memFileData
+}
+
+func (i memFileInfo) Name() string       { return path.Base(i.name) }
+func (i memFileInfo) Size() int64        { return int64(len(i.file.data)) }
+func (i memFileInfo) Mode() fs.FileMode  { return 0o644 }
+func (i memFileInfo) ModTime() time.Time { return i.file.modTime }
+func (i memFileInfo) IsDir() bool        { return false }
+func (i memFileInfo) Sys() interface{}   { return nil }
@@ -0,0 +1,235 @@
+package fishaudio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"testing/fstest"
+)
+
+func TestOSStorage_Create(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "out.mp3")
+
+	w, err := OSStorage{}.Create(name)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := w.Write([]byte("audio")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if _, err := os.Stat(name); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("file visible before Close: err = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	info, err := OSStorage{}.Stat(name)
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if info.Size() != 5 {
+		t.Errorf("Size() = %d, want 5", info.Size())
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("dir has %d entries, want 1", len(entries))
+	}
+}
+
+func TestOSStorage_Create_Mode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not supported on Windows")
+	}
+	dir := t.TempDir()
+	create := func(name string) os.FileMode {
+		t.Helper()
+		w, err := OSStorage{}.Create(name)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+		info, err := os.Stat(name)
+		if err != nil {
+			t.Fatalf("Stat() error = %v", err)
+		}
+		return info.Mode().Perm()
+	}
+
+	name := filepath.Join(dir, "new.mp3")
+	if mode := create(name); mode != 0o644 {
+		t.Errorf("new file mode = %v, want 0644", mode)
+	}
+
+	name = filepath.Join(dir, "existing.mp3")
+	if err := os.WriteFile(name, []byte("old"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(name, 0o640); err != nil {
+		t.Fatal(err)
+	}
+	if mode := create(name); mode != 0o640 {
+		t.Errorf("replaced file mode = %v, want 0640", mode)
+	}
+}
+
+func TestOSStorage_Create_FailedWrite(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "out.mp3")
+
+	w, err := OSStorage{}.Create(name)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	a := w.(*atomicFile)
+	_ = a.f.Close() // make the next write fail
+	if _, err := w.Write([]byte("audio")); err == nil {
+		t.Fatal("Write() error = nil, want error")
+	}
+	if err := w.Close(); err == nil {
+		t.Error("Close() error = nil, want error")
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("dir has %d entries, want 0", len(entries))
+	}
+}
+
+func TestFSStorage(t *testing.T) {
+	s := FSStorage{FS: fstest.MapFS{"clips/a.wav": {Data: []byte("RIFF")}}}
+
+	f, err := s.Open("clips/a.wav")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	data, _ := io.ReadAll(f)
+	_ = f.Close()
+	if string(data) != "RIFF" {
+		t.Errorf("data = %q, want %q", data, "RIFF")
+	}
+
+	if info, err := s.Stat("clips/a.wav"); err != nil || info.Size() != 4 {
+		t.Errorf("Stat() = %v, %v", info, err)
+	}
+	if _, err := s.Create("out.mp3"); !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("Create() err = %v, want %v", err, fs.ErrPermission)
+	}
+}
+
+func TestMemStorage(t *testing.T) {
+	s := NewMemStorage()
+
+	w, err := s.Create("a//b.mp3")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	_, _ = w.Write([]byte("audio"))
+	if _, err := s.Stat("a/b.mp3"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat() before Close err = %v, want %v", err, fs.ErrNotExist)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	f, err := s.Open("a/b.mp3")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	data, _ := io.ReadAll(f)
+	if string(data) != "audio" {
+		t.Errorf("data = %q, want %q", data, "audio")
+	}
+	info, err := f.Stat()
+	if err != nil || info.Name() != "b.mp3" || info.Size() != 5 {
+		t.Errorf("Stat() = %v, %v", info, err)
+	}
+
+	if _, err := s.Open("missing"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Open(missing) err = %v, want %v", err, fs.ErrNotExist)
+	}
+	if _, err := s.ReadFile("missing"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadFile(missing) err = %v, want %v", err, fs.ErrNotExist)
+	}
+}
+
+func TestASRService_TranscribeFile_Storage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("audio")
+		if err != nil {
+			t.Fatalf("FormFile(audio) error = %v", err)
+		}
+		defer func() { _ = file.Close() }()
+		if header.Filename != "clip.wav" {
+			t.Errorf("filename = %q, want %q", header.Filename, "clip.wav")
+		}
+		if data, _ := io.ReadAll(file); string(data) != "RIFF\x24\x00\x00\x00WAVE" {
+			t.Errorf("audio = %q", data)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ASRResponse{Text: "ok"})
+	}))
+	defer server.Close()
+
+	files := NewMemStorage()
+	files.WriteFile("in/clip.wav", []byte("RIFF\x24\x00\x00\x00WAVE"))
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithStorage(files))
+	result, err := client.ASR.TranscribeFile(context.Background(), "in/clip.wav", nil)
+	if err != nil {
+		t.Fatalf("TranscribeFile() error = %v", err)
+	}
+	if result.Text != "ok" {
+		t.Errorf("Text = %q, want %q", result.Text, "ok")
+	}
+}
+
+func TestTTSService_ConvertToFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write([]byte("fake audio"))
+	}))
+	defer server.Close()
+
+	files := NewMemStorage()
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithStorage(files))
+	if err := client.TTS.ConvertToFile(context.Background(), &ConvertParams{Text: "Hello"}, "out/hello.mp3"); err != nil {
+		t.Fatalf("ConvertToFile() error = %v", err)
+	}
+
+	data, err := files.ReadFile("out/hello.mp3")
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(data) != "fake audio" {
+		t.Errorf("data = %q, want %q", data, "fake audio")
+	}
+}
+
+func TestTTSService_ConvertToFile_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"detail":"bad"}`))
+	}))
+	defer server.Close()
+
+	files := NewMemStorage()
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithStorage(files))
+	if err := client.TTS.ConvertToFile(context.Background(), &ConvertParams{Text: "Hello"}, "hello.mp3"); err == nil {
+		t.Fatal("ConvertToFile() error = nil, want error")
+	}
+	if _, err := files.Stat("hello.mp3"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat() err = %v, want %v", err, fs.ErrNotExist)
+	}
+}
@@ -0,0 +1,320 @@
+package fishaudio
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/websocket"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// maxFrameDepth is the deepest nesting of maps and arrays accepted in a
+// server frame. Real frames nest three levels at most; the limit keeps a
+// hostile frame from exhausting the stack.
+const maxFrameDepth = 32
+
+// errFrameDepth is returned for frames nested deeper than maxFrameDepth.
+var errFrameDepth = fmt.Errorf("nesting exceeds %d levels", maxFrameDepth)
+
+// decodeFrame decodes a server frame into s.resp based on its message
+// type. Msgpack audio is decoded into a recycled buffer when one is free.
+//
+// Decoding is tolerant so that one odd frame doesn't end a long session:
+// fields of an unexpected type are dropped and counted in the stats. A
+// frame that is not well-formed at all, or an audio frame whose audio
+// can't be read, is a *FrameError.
+func (s *wsSession) decodeFrame(messageType int, data []byte) error {
+	s.resp = wsResponse{}
+
+	var skipped []string
+	var err error
+	if messageType == websocket.TextMessage {
+		skipped, err = decodeJSONFrame(data, &s.resp)
+	} else {
+		skipped, err = s.decodeMsgpackFrame(data)
+	}
+	if err == nil && s.resp.Event == "audio" {
+		for _, field := range skipped {
+			if field == "audio" {
+				err = errors.New("audio has an unexpected type")
+			}
+		}
+	}
+	if err != nil {
+		return &FrameError{
+			WebSocketError: &WebSocketError{Message: fmt.Sprintf("failed to decode %d byte frame: %v", len(data), err)},
+			Event:          s.resp.Event,
+			Size:           len(data),
+			Err:            err,
+		}
+	}
+
+	if len(skipped) > 0 {
+		s.stats.fieldsSkipped(len(skipped))
+	}
+	return nil
+}
+
+// decodeMsgpackFrame decodes a binary frame into s.resp. The structure is
+// validated first so a corrupt length prefix can't make the decoder
+// allocate far more than the frame holds.
+func (s *wsSession) decodeMsgpackFrame(data []byte) ([]string, error) {
+	if err := checkMsgpack(data); err != nil {
+		return nil, err
+	}
+
+	select {
+	case buf := <-s.free:
+		s.resp.Audio = buf[:0]
+	default:
+	}
+	s.reader.Reset(data)
+	s.decoder.Reset(&s.reader)
+	s.decoder.UsePreallocateValues(true)
+
+	var skipped []string
+	if err := s.decoder.Decode(&s.resp); err != nil {
+		// Some field has an unexpected type; keep the others
+		s.resp = wsResponse{}
+		fields, err := msgpackFields(data)
+		if err != nil {
+			return nil, err
+		}
+		skipped = s.resp.decodeFields(fields, "msgpack", msgpack.Unmarshal)
+	}
+	if len(s.resp.Audio) == 0 {
+		// No audio in this frame; keep events free of the empty buffer
+		s.resp.Audio = nil
+	}
+	return skipped, nil
+}
+
+// decodeJSONFrame decodes a text frame into resp, dropping fields of an
+// unexpected type. It returns the keys of the dropped fields.
+func decodeJSONFrame(data []byte, resp *wsResponse) ([]string, error) {
+	if err := json.Unmarshal(data, resp); err == nil {
+		return nil, nil
+	}
+
+	*resp = wsResponse{}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	fields := make([]frameField, 0, len(values))
+	for key, value := range values {
+		fields = append(fields, frameField{key: key, value: value})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].key < fields[j].key })
+	return resp.decodeFields(fields, "json", json.Unmarshal), nil
+}
+
+// frameField is an undecoded key and value of a frame.
+type frameField struct {
+	key   string
+	value []byte
+}
+
+// decodeFields decodes each field whose key matches a field of r by the
+// struct tag named tag. Unknown keys are ignored. Fields whose values
+// don't decode into the field's type are left zero and their keys are
+// returned.
+func (r *wsResponse) decodeFields(fields []frameField, tag string, unmarshal func([]byte, interface{}) error) []string {
+	var skipped []string
+	rv := reflect.ValueOf(r).Elem()
+	for _, field := range fields {
+		for i := 0; i < rv.NumField(); i++ {
+			name, _, _ := strings.Cut(rv.Type().Field(i).Tag.Get(tag), ",")
+			if name != field.key {
+				continue
+			}
+			v := reflect.New(rv.Field(i).Type())
+			if err := unmarshal(field.value, v.Interface()); err != nil {
+				skipped = append(skipped, field.key)
+			} else {
+				rv.Field(i).Set(v.Elem())
+			}
+			break
+		}
+	}
+	return skipped
+}
+
+// msgpackFields splits a msgpack map into its string keys and encoded
+// values. data must have passed checkMsgpack. Entries with keys that are
+// not strings are ignored.
+func msgpackFields(data []byte) ([]frameField, error) {
+	kind, header, count, err := msgpackHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	if kind != msgpackMap {
+		return nil, errors.New("frame is not a map")
+	}
+
+	fields := make([]frameField, 0, count)
+	off := header
+	for i := uint64(0); i < count; i++ {
+		keySize, err := skipMsgpack(data[off:], 1)
+		if err != nil {
+			return nil, err
+		}
+		valueSize, err := skipMsgpack(data[off+keySize:], 1)
+		if err != nil {
+			return nil, err
+		}
+		var key string
+		if msgpack.Unmarshal(data[off:off+keySize], &key) == nil {
+			fields = append(fields, frameField{key: key, value: data[off+keySize : off+keySize+valueSize]})
+		}
+		off += keySize + valueSize
+	}
+	return fields, nil
+}
+
+// checkMsgpack validates the structure of the msgpack value at the start
+// of data without decoding it: every length must fit in data and nesting
+// is bounded. Trailing data is ignored, as the decoder does.
+func checkMsgpack(data []byte) error {
+	_, err := skipMsgpack(data, 0)
+	return err
+}
+
+// Kinds of msgpack values reported by msgpackHeader.
+const (
+	msgpackScalar = iota // a fixed-size value; the header is the whole value
+	msgpackBytes         // a string, binary, or extension of length bytes
+	msgpackArray         // an array of length values
+	msgpackMap           // a map of length key-value pairs
+)
+
+// msgpackHeader parses the header of the msgpack value at the start of
+// data. It returns the value's kind, the header size, and for non-scalar
+// values the length that follows.
+func msgpackHeader(data []byte) (kind, header int, length uint64, err error) {
+	if len(data) == 0 {
+		return 0, 0, 0, io.ErrUnexpectedEOF
+	}
+	c := data[0]
+	switch {
+	case c <= 0x7f || c >= 0xe0: // positive and negative fixint
+		return msgpackScalar, 1, 0, nil
+	case c <= 0x8f: // fixmap
+		return msgpackMap, 1, uint64(c & 0x0f), nil
+	case c <= 0x9f: // fixarray
+		return msgpackArray, 1, uint64(c & 0x0f), nil
+	case c <= 0xbf: // fixstr
+		return msgpackBytes, 1, uint64(c & 0x1f), nil
+	}
+
+	// sized returns a value whose length is in the width bytes after the
+	// code, followed by extra bytes before the payload.
+	sized := func(kind, width, extra int) (int, int, uint64, error) {
+		if len(data) < 1+width+extra {
+			return 0, 0, 0, io.ErrUnexpectedEOF
+		}
+		var n uint64
+		switch width {
+		case 1:
+			n = uint64(data[1])
+		case 2:
+			n = uint64(binary.BigEndian.Uint16(data[1:]))
+		case 4:
+			n = uint64(binary.BigEndian.Uint32(data[1:]))
+		}
+		return kind, 1 + width + extra, n, nil
+	}
+	scalar := func(size int) (int, int, uint64, error) {
+		return msgpackScalar, size, 0, nil
+	}
+
+	switch c {
+	case 0xc0, 0xc2, 0xc3: // nil, false, true
+		return scalar(1)
+	case 0xc4, 0xd9: // bin8, str8
+		return sized(msgpackBytes, 1, 0)
+	case 0xc5, 0xda: // bin16, str16
+		return sized(msgpackBytes, 2, 0)
+	case 0xc6, 0xdb: // bin32, str32
+		return sized(msgpackBytes, 4, 0)
+	case 0xc7: // ext8 has a type byte after the length
+		return sized(msgpackBytes, 1, 1)
+	case 0xc8: // ext16
+		return sized(msgpackBytes, 2, 1)
+	case 0xc9: // ext32
+		return sized(msgpackBytes, 4, 1)
+	case 0xcc, 0xd0: // uint8, int8
+		return scalar(2)
+	case 0xcd, 0xd1: // uint16, int16
+		return scalar(3)
+	case 0xca, 0xce, 0xd2: // float32, uint32, int32
+		return scalar(5)
+	case 0xcb, 0xcf, 0xd3: // float64, uint64, int64
+		return scalar(9)
+	case 0xd4: // fixext1 through fixext16: a type byte and the payload
+		return scalar(3)
+	case 0xd5:
+		return scalar(4)
+	case 0xd6:
+		return scalar(6)
+	case 0xd7:
+		return scalar(10)
+	case 0xd8:
+		return scalar(18)
+	case 0xdc: // array16
+		return sized(msgpackArray, 2, 0)
+	case 0xdd: // array32
+		return sized(msgpackArray, 4, 0)
+	case 0xde: // map16
+		return sized(msgpackMap, 2, 0)
+	case 0xdf: // map32
+		return sized(msgpackMap, 4, 0)
+	}
+	return 0, 0, 0, fmt.Errorf("invalid msgpack code 0x%02x", c)
+}
+
+// skipMsgpack returns the size of the msgpack value at the start of data,
+// checking that it fits. depth is the nesting level of the value.
+func skipMsgpack(data []byte, depth int) (int, error) {
+	kind, header, length, err := msgpackHeader(data)
+	if err != nil {
+		return 0, err
+	}
+
+	switch kind {
+	case msgpackBytes:
+		if length > uint64(len(data)-header) {
+			return 0, io.ErrUnexpectedEOF
+		}
+		return header + int(length), nil
+	case msgpackArray, msgpackMap:
+		if depth >= maxFrameDepth {
+			return 0, errFrameDepth
+		}
+		items := length
+		if kind == msgpackMap {
+			items *= 2
+		}
+		// Each item is at least one byte, so a count beyond the
+		// remaining data is caught when the data runs out
+		off := header
+		for i := uint64(0); i < items; i++ {
+			n, err := skipMsgpack(data[off:], depth+1)
+			if err != nil {
+				return 0, err
+			}
+			off += n
+		}
+		return off, nil
+	}
+	if header > len(data) {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return header, nil
+}
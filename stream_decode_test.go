@@ -0,0 +1,277 @@
+package fishaudio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// newDecodeSession returns a session with just enough state to decode frames.
+func newDecodeSession() *wsSession {
+	return &wsSession{
+		free:    make(chan []byte, 1),
+		decoder: msgpack.NewDecoder(nil),
+		stats:   newStreamStats(time.Now()),
+	}
+}
+
+func mustMarshal(t testing.TB, v interface{}) []byte {
+	t.Helper()
+	data, err := msgpack.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestCheckMsgpack(t *testing.T) {
+	deep := bytes.Repeat([]byte{0x91}, maxFrameDepth+1)
+	deep = append(deep, 0xc0)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{"frame", mustMarshal(t, wsResponse{Event: "audio", Audio: []byte("abc"), Timestamp: 1.5}), false},
+		{"nested", mustMarshal(t, map[string]interface{}{"words": []interface{}{map[string]interface{}{"word": "hi", "start": 0.1}}}), false},
+		{"scalars", mustMarshal(t, []interface{}{nil, true, int8(-1), uint16(300), int32(-70000), uint64(1 << 40), float32(1), 2.5, "s"}), false},
+		{"ext", mustMarshal(t, time.Unix(1, 0)), false},
+		{"trailing data", append(mustMarshal(t, "ok"), 0xc1), false},
+		{"empty", nil, true},
+		{"invalid code", []byte{0xc1}, true},
+		{"truncated string", []byte{0xa5, 'a'}, true},
+		{"truncated int", []byte{0xcf, 0, 0}, true},
+		{"bin32 beyond frame", []byte{0x81, 0xa5, 'a', 'u', 'd', 'i', 'o', 0xc6, 0x7f, 0xff, 0xff, 0xf0, 1, 2}, true},
+		{"array32 beyond frame", []byte{0xdd, 0xff, 0xff, 0xff, 0xff, 0xc0}, true},
+		{"map missing value", []byte{0x81, 0xa1, 'a'}, true},
+		{"too deep", deep, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMsgpack(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkMsgpack() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecodeFrame(t *testing.T) {
+	tests := []struct {
+		name        string
+		messageType int
+		data        []byte
+		want        wsResponse
+		wantSkipped int
+	}{
+		{
+			name:        "audio",
+			messageType: websocket.BinaryMessage,
+			data:        mustMarshal(t, wsResponse{Event: "audio", Audio: []byte("abc")}),
+			want:        wsResponse{Event: "audio", Audio: []byte("abc")},
+		},
+		{
+			name:        "unknown event and fields",
+			messageType: websocket.BinaryMessage,
+			data:        mustMarshal(t, map[string]interface{}{"event": "metrics", "latency": []int{1, 2}}),
+			want:        wsResponse{Event: "metrics"},
+		},
+		{
+			name:        "field of unexpected type",
+			messageType: websocket.BinaryMessage,
+			data:        mustMarshal(t, map[string]interface{}{"event": "finish", "reason": 3, "message": "done", "timestamp": "soon"}),
+			want:        wsResponse{Event: "finish", Message: "done"},
+			wantSkipped: 2,
+		},
+		{
+			name:        "non-string key",
+			messageType: websocket.BinaryMessage,
+			data:        mustMarshal(t, map[interface{}]interface{}{1: "x", "event": "audio", "audio": []byte("a")}),
+			want:        wsResponse{Event: "audio", Audio: []byte("a")},
+		},
+		{
+			name:        "json",
+			messageType: websocket.TextMessage,
+			data:        []byte(`{"event":"audio","audio":"YWJj"}`),
+			want:        wsResponse{Event: "audio", Audio: []byte("abc")},
+		},
+		{
+			name:        "json field of unexpected type",
+			messageType: websocket.TextMessage,
+			data:        []byte(`{"event":"finish","reason":{"a":1},"timestamp":[1]}`),
+			want:        wsResponse{Event: "finish"},
+			wantSkipped: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newDecodeSession()
+			if err := s.decodeFrame(tt.messageType, tt.data); err != nil {
+				t.Fatalf("decodeFrame() error = %v", err)
+			}
+			if s.resp.Event != tt.want.Event || !bytes.Equal(s.resp.Audio, tt.want.Audio) ||
+				s.resp.Reason != tt.want.Reason || s.resp.Message != tt.want.Message {
+				t.Errorf("resp = %+v, want %+v", s.resp, tt.want)
+			}
+			if got := s.stats.snapshot().SkippedFields; got != tt.wantSkipped {
+				t.Errorf("SkippedFields = %d, want %d", got, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+func TestDecodeFrame_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		messageType int
+		data        []byte
+		wantEvent   string
+	}{
+		{"truncated", websocket.BinaryMessage, mustMarshal(t, wsResponse{Event: "audio", Audio: []byte("abc")})[:10], ""},
+		{"not a map", websocket.BinaryMessage, mustMarshal(t, []string{"audio"}), ""},
+		{"audio of unexpected type", websocket.BinaryMessage, mustMarshal(t, map[string]interface{}{"event": "audio", "audio": 5}), "audio"},
+		{"json syntax", websocket.TextMessage, []byte(`{"event":`), ""},
+		{"json audio of unexpected type", websocket.TextMessage, []byte(`{"event":"audio","audio":5}`), "audio"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newDecodeSession().decodeFrame(tt.messageType, tt.data)
+			var frameErr *FrameError
+			if !errors.As(err, &frameErr) {
+				t.Fatalf("decodeFrame() error = %v, want *FrameError", err)
+			}
+			if frameErr.Event != tt.wantEvent {
+				t.Errorf("Event = %q, want %q", frameErr.Event, tt.wantEvent)
+			}
+			if frameErr.Size != len(tt.data) {
+				t.Errorf("Size = %d, want %d", frameErr.Size, len(tt.data))
+			}
+		})
+	}
+}
+
+func TestTTSService_StreamWebSocket_SkipsOddFrames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		_, _, _ = conn.ReadMessage()
+		for _, frame := range []interface{}{
+			wsResponse{Event: "audio", Audio: []byte("one")},
+			map[string]interface{}{"event": "billing", "credits": 0.5},
+			map[string]interface{}{"event": "audio", "audio": []byte("two"), "timestamp": "later"},
+			wsResponse{Event: "finish", Reason: "stop"},
+		} {
+			data, _ := msgpack.Marshal(frame)
+			_ = conn.WriteMessage(websocket.BinaryMessage, data)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	stream, err := client.TTS.StreamWebSocket(context.Background(), make(chan string), nil, nil)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	audio, err := stream.Collect()
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if string(audio) != "onetwo" {
+		t.Errorf("audio = %q, want %q", audio, "onetwo")
+	}
+	if got := stream.Stats().SkippedFields; got != 1 {
+		t.Errorf("SkippedFields = %d, want 1", got)
+	}
+}
+
+func TestTTSService_StreamWebSocket_MalformedFrame(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		_, _, _ = conn.ReadMessage()
+		data, _ := msgpack.Marshal(wsResponse{Event: "audio", Audio: []byte("one")})
+		_ = conn.WriteMessage(websocket.BinaryMessage, data)
+		// A bin32 audio field claiming 2 GiB
+		_ = conn.WriteMessage(websocket.BinaryMessage, []byte{0x81, 0xa5, 'a', 'u', 'd', 'i', 'o', 0xc6, 0x7f, 0xff, 0xff, 0xf0, 1, 2})
+		_, _, _ = conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	stream, err := client.TTS.StreamWebSocket(context.Background(), make(chan string), nil, nil)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	audio, err := io.ReadAll(stream)
+	var frameErr *FrameError
+	if !errors.As(err, &frameErr) {
+		t.Fatalf("ReadAll() error = %v, want *FrameError", err)
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("error = %v, want wrapped %v", err, io.ErrUnexpectedEOF)
+	}
+	if string(audio) != "one" {
+		t.Errorf("audio = %q, want %q", audio, "one")
+	}
+}
+
+func FuzzDecodeFrame(f *testing.F) {
+	f.Add(mustMarshal(f, wsResponse{Event: "audio", Audio: []byte("abc"), Timestamp: 1}))
+	f.Add(mustMarshal(f, wsResponse{Event: "finish", Reason: "error", Code: 402, Detail: map[string]interface{}{"field": "x"}}))
+	f.Add(mustMarshal(f, map[string]interface{}{"event": "audio", "words": []interface{}{map[string]interface{}{"word": "a", "start": 1}}}))
+	f.Add([]byte(`{"event":"audio","audio":"YWJj"}`))
+	f.Add([]byte{0xdf, 0xff, 0xff, 0xff, 0xff})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, messageType := range []int{websocket.BinaryMessage, websocket.TextMessage} {
+			s := newDecodeSession()
+			err := s.decodeFrame(messageType, data)
+			var frameErr *FrameError
+			if err != nil && !errors.As(err, &frameErr) {
+				t.Fatalf("decodeFrame() error = %T, want *FrameError", err)
+			}
+			if err == nil {
+				// Decoded frames must be usable downstream
+				_ = s.resp.wordBoundaries()
+				_ = s.resp.wsError()
+			}
+		}
+
+		// The validator must agree with the decoder on every value it accepts
+		n, err := skipMsgpack(data, 0)
+		if err != nil {
+			return
+		}
+		r := bytes.NewReader(data)
+		if err := msgpack.NewDecoder(r).Skip(); err != nil {
+			t.Fatalf("checkMsgpack accepted %x, but Skip() error = %v", data, err)
+		}
+		if consumed := len(data) - r.Len(); consumed != n {
+			t.Fatalf("skipMsgpack(%x) = %d, decoder consumed %d", data, n, consumed)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package fishaudio
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// pacer spaces text events so the characters sent stay under a fixed rate.
+// The first event is sent immediately; each event then delays the next by
+// its length divided by the rate.
+type pacer struct {
+	rate float64 // characters per second; zero disables pacing
+	next time.Time
+}
+
+// delay returns how long to wait before sending at now.
+func (p *pacer) delay(now time.Time) time.Duration {
+	if p.rate <= 0 || !p.next.After(now) {
+		return 0
+	}
+	return p.next.Sub(now)
+}
+
+// sent records that text was sent at now.
+func (p *pacer) sent(text string, now time.Time) {
+	if p.rate <= 0 {
+		return
+	}
+	if p.next.Before(now) {
+		p.next = now
+	}
+	chars := float64(utf8.RuneCountInString(text))
+	p.next = p.next.Add(time.Duration(chars / p.rate * float64(time.Second)))
+}
+
+// pace holds text until the pacer allows it to be sent and reports whether
+// the send loop should continue. If the caller finishes while text is held,
+// it is reported as unsent along with whatever is still queued.
+func (s *wsSession) pace(text string, textChan <-chan string) bool {
+	wait := s.pacer.delay(time.Now())
+	if wait <= 0 {
+		return true
+	}
+
+	s.stats.setHeld(1)
+	defer s.stats.setHeld(0)
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-s.finishing:
+		s.unsent = append([]string{text}, drainText(textChan)...)
+	case <-s.runCtx.Done():
+	}
+	return false
+}
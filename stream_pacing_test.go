@@ -0,0 +1,117 @@
+package fishaudio
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPacer(t *testing.T) {
+	start := time.Unix(1000, 0)
+	p := pacer{rate: 10}
+
+	if d := p.delay(start); d != 0 {
+		t.Errorf("first delay = %v, want 0", d)
+	}
+	p.sent("hello", start)
+	if d := p.delay(start); d != 500*time.Millisecond {
+		t.Errorf("delay = %v, want %v", d, 500*time.Millisecond)
+	}
+
+	// Multibyte characters count once
+	p.sent("wörld", start.Add(500*time.Millisecond))
+	if d := p.delay(start.Add(600 * time.Millisecond)); d != 400*time.Millisecond {
+		t.Errorf("delay = %v, want %v", d, 400*time.Millisecond)
+	}
+
+	// Idle time doesn't accumulate into a burst allowance
+	later := start.Add(10 * time.Second)
+	p.sent("ab", later)
+	if d := p.delay(later); d != 200*time.Millisecond {
+		t.Errorf("delay after idle = %v, want %v", d, 200*time.Millisecond)
+	}
+}
+
+func TestPacer_Disabled(t *testing.T) {
+	now := time.Now()
+	var p pacer
+	p.sent("a long burst of text", now)
+	if d := p.delay(now); d != 0 {
+		t.Errorf("delay = %v, want 0", d)
+	}
+}
+
+func TestTTSService_StreamWebSocket_Pacing(t *testing.T) {
+	server := newEchoLiveServer(t)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	opts := DefaultWebSocketOptions()
+	opts.MaxCharsPerSecond = 100
+
+	textChan := make(chan string, 3)
+	for _, text := range []string{"0123456789", "0123456789", "0123456789"} {
+		textChan <- text
+	}
+	close(textChan)
+
+	stream, err := client.TTS.StreamWebSocket(context.Background(), textChan, nil, opts)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+	if _, err := stream.Collect(); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	segments := stream.Stats().Segments
+	if len(segments) != 3 {
+		t.Fatalf("segments = %d, want 3", len(segments))
+	}
+	for i := 1; i < len(segments); i++ {
+		if gap := segments[i].SentAt.Sub(segments[i-1].SentAt); gap < 100*time.Millisecond {
+			t.Errorf("segment %d sent %v after the previous one, want at least %v", i, gap, 100*time.Millisecond)
+		}
+	}
+}
+
+func TestWebSocketAudioStream_FinishWhilePaced(t *testing.T) {
+	server := newEchoLiveServer(t)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	opts := DefaultWebSocketOptions()
+	opts.MaxCharsPerSecond = 1
+
+	textChan := make(chan string, 3)
+	textChan <- "first"
+	textChan <- "held"
+	textChan <- "queued"
+
+	stream, err := client.TTS.StreamWebSocket(context.Background(), textChan, nil, opts)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	// Wait until "held" is waiting on the pacer
+	deadline := time.Now().Add(2 * time.Second)
+	for stream.Stats().QueuedTexts != 2 || len(textChan) != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("QueuedTexts = %d, want 2", stream.Stats().QueuedTexts)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	result, err := stream.Finish(context.Background())
+	if err != nil {
+		t.Fatalf("Finish() error = %v", err)
+	}
+	if string(result.Audio) != "first" {
+		t.Errorf("Audio = %q, want %q", result.Audio, "first")
+	}
+	if len(result.UnsentTexts) != 2 || result.UnsentTexts[0] != "held" || result.UnsentTexts[1] != "queued" {
+		t.Errorf("UnsentTexts = %v, want [held queued]", result.UnsentTexts)
+	}
+}
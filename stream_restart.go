@@ -0,0 +1,161 @@
+package fishaudio
+
+import "time"
+
+// SetVoice switches the voice used for text sent after this call.
+//
+// The live protocol fixes the voice when a session starts, so the stream
+// finishes the current session and transparently starts a new one on a
+// fresh connection. Audio for text already sent is delivered first, and
+// the stream continues with the new session's audio.
+//
+// Example:
+//
+//	textChan <- "Hi, I'm Alice."
+//	_ = stream.SetVoice("bob-voice-id")
+//	textChan <- "And I'm Bob."
+func (s *WebSocketAudioStream) SetVoice(referenceID string) error {
+	return s.restart(func(params *StreamParams) {
+		params.ReferenceID = referenceID
+		params.References = nil
+		if params.Config != nil {
+			cfg := *params.Config
+			cfg.ReferenceID = ""
+			cfg.References = nil
+			params.Config = &cfg
+		}
+	})
+}
+
+// SetProsody switches the speed and volume used for text sent after this
+// call. Like SetVoice, it restarts the session transparently.
+func (s *WebSocketAudioStream) SetProsody(prosody *Prosody) error {
+	return s.restart(func(params *StreamParams) {
+		params.Speed = 0
+		cfg := TTSConfig{}
+		if params.Config != nil {
+			cfg = *params.Config
+		}
+		cfg.Prosody = prosody
+		params.Config = &cfg
+	})
+}
+
+// restart hands the text channel to a new session started with updated params.
+func (s *WebSocketAudioStream) restart(update func(*StreamParams)) error {
+	if s.tts == nil {
+		return &WebSocketError{Message: "stream does not support restarts"}
+	}
+
+	s.restartMu.Lock()
+	defer s.restartMu.Unlock()
+
+	s.chainMu.Lock()
+	if s.stopped {
+		s.chainMu.Unlock()
+		return ErrStreamClosed
+	}
+	tail := s.chain[len(s.chain)-1]
+	params := s.params
+	wait := make(chan struct{})
+	s.restarting = wait
+	s.chainMu.Unlock()
+
+	defer func() {
+		s.chainMu.Lock()
+		s.restarting = nil
+		s.chainMu.Unlock()
+		close(wait)
+	}()
+
+	update(&params)
+
+	// Stop the current session from reading text; it then sends stop and
+	// drains its remaining audio
+	tail.handoffOnce.Do(func() { close(tail.handoff) })
+	<-tail.sendDone
+
+	if tail.textClosed {
+		// No more text will arrive, so there is nothing to restart
+		s.chainMu.Lock()
+		s.params = params
+		s.chainMu.Unlock()
+		return nil
+	}
+	if !tail.handedOff {
+		select {
+		case <-tail.closing:
+			return ErrStreamClosed
+		default:
+		}
+		if err := s.ctx.Err(); err != nil {
+			return err
+		}
+		return &WebSocketError{Message: "session has already ended"}
+	}
+
+	started := time.Now()
+	next, err := s.startSuccessor(&params)
+
+	s.chainMu.Lock()
+	defer s.chainMu.Unlock()
+	if err != nil {
+		s.restartErr = err
+		return err
+	}
+	if s.stopped {
+		next.close()
+		return ErrStreamClosed
+	}
+	s.chain = append(s.chain, next)
+	s.params = params
+
+	if s.opts.OnReconnect != nil {
+		now := time.Now()
+		s.opts.OnReconnect(ConnectionEvent{
+			URL:      next.url,
+			Time:     now,
+			Duration: now.Sub(started),
+		})
+	}
+	return nil
+}
+
+// startSuccessor dials and starts a session that reads the stream's text channel.
+func (s *WebSocketAudioStream) startSuccessor(params *StreamParams) (*wsSession, error) {
+	conn, err := s.tts.dialWebSocket(s.ctx, s.tts.getModel(params), s.opts)
+	if err != nil {
+		return nil, err
+	}
+	return s.tts.startSession(s.ctx, conn, nil, s.textChan, params, s.opts)
+}
+
+// advance switches to the session after the current one once the current
+// session has ended, waiting for an in-progress restart. It reports whether
+// a successor was found, or the error that prevented starting one.
+// The caller must hold s.mu.
+func (s *WebSocketAudioStream) advance() (bool, error) {
+	s.chainMu.Lock()
+	for s.restarting != nil {
+		wait := s.restarting
+		s.chainMu.Unlock()
+		select {
+		case <-wait:
+		case <-s.closing():
+			return false, ErrStreamClosed
+		}
+		s.chainMu.Lock()
+	}
+	defer s.chainMu.Unlock()
+
+	for i, session := range s.chain {
+		if session == s.session && i+1 < len(s.chain) {
+			next := s.chain[i+1]
+			s.session = next
+			s.audioChan = next.audioChan
+			s.errChan = next.errChan
+			return true, nil
+		}
+	}
+	return false, s.restartErr
+}
@@ -0,0 +1,122 @@
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fishaudio/fish-audio-go/fishaudiotest"
+)
+
+func TestWebSocketAudioStream_SetVoice(t *testing.T) {
+	server := fishaudiotest.NewLiveServer(fishaudiotest.LiveScript{})
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	var reconnects int
+	opts := DefaultWebSocketOptions()
+	opts.OnReconnect = func(ConnectionEvent) { reconnects++ }
+
+	textChan := make(chan string, 1)
+	stream, err := client.TTS.StreamWebSocket(context.Background(), textChan, &StreamParams{ReferenceID: "alice"}, opts)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+
+	textChan <- "one"
+	if !stream.Next() || string(stream.Bytes()) != "one" {
+		t.Fatalf("first chunk = %q, err = %v", stream.Bytes(), stream.Err())
+	}
+
+	if err := stream.SetVoice("bob"); err != nil {
+		t.Fatalf("SetVoice() error = %v", err)
+	}
+	textChan <- "two"
+	close(textChan)
+
+	rest, err := stream.Collect()
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if string(rest) != "two" {
+		t.Errorf("audio after switch = %q, want %q", rest, "two")
+	}
+	if reconnects != 1 {
+		t.Errorf("OnReconnect calls = %d, want 1", reconnects)
+	}
+
+	sessions := server.Sessions()
+	if len(sessions) != 2 {
+		t.Fatalf("sessions = %d, want 2", len(sessions))
+	}
+	if sessions[0].Start["reference_id"] != "alice" || sessions[1].Start["reference_id"] != "bob" {
+		t.Errorf("reference IDs = %v, %v, want alice, bob", sessions[0].Start["reference_id"], sessions[1].Start["reference_id"])
+	}
+	if len(sessions[0].Texts) != 1 || sessions[0].Texts[0] != "one" || !sessions[0].Stopped {
+		t.Errorf("first session = %+v, want text one and stop", sessions[0])
+	}
+	if len(sessions[1].Texts) != 1 || sessions[1].Texts[0] != "two" {
+		t.Errorf("second session texts = %v, want [two]", sessions[1].Texts)
+	}
+}
+
+func TestWebSocketAudioStream_SetProsody(t *testing.T) {
+	server := fishaudiotest.NewLiveServer(fishaudiotest.LiveScript{})
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	config := &TTSConfig{Temperature: 0.5}
+	textChan := make(chan string, 1)
+	stream, err := client.TTS.StreamWebSocket(context.Background(), textChan, &StreamParams{Speed: 1.2, Config: config}, nil)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+
+	if err := stream.SetProsody(&Prosody{Speed: 0.8, Volume: -3}); err != nil {
+		t.Fatalf("SetProsody() error = %v", err)
+	}
+	textChan <- "slower"
+	close(textChan)
+
+	if _, err := stream.Collect(); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	sessions := server.Sessions()
+	if len(sessions) != 2 {
+		t.Fatalf("sessions = %d, want 2", len(sessions))
+	}
+	prosody, _ := sessions[1].Start["prosody"].(map[string]interface{})
+	if prosody == nil {
+		t.Fatal("second session has no prosody")
+	}
+	if config.Prosody != nil {
+		t.Error("SetProsody should not modify the caller's config")
+	}
+}
+
+func TestWebSocketAudioStream_SetVoiceAfterClose(t *testing.T) {
+	server := fishaudiotest.NewLiveServer(fishaudiotest.LiveScript{})
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	stream, err := client.TTS.StreamWebSocket(context.Background(), make(chan string), nil, nil)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+	_ = stream.Close()
+
+	if err := stream.SetVoice("bob"); !errors.Is(err, ErrStreamClosed) {
+		t.Errorf("SetVoice() err = %v, want %v", err, ErrStreamClosed)
+	}
+}
+
+func TestWebSocketAudioStream_SetVoiceUnsupported(t *testing.T) {
+	stream := &WebSocketAudioStream{}
+	if err := stream.SetVoice("bob"); err == nil {
+		t.Error("SetVoice() on a stream without a session should fail")
+	}
+}
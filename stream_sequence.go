@@ -0,0 +1,93 @@
+package fishaudio
+
+import "fmt"
+
+// audioSequencer restores the order of numbered audio chunks and finds
+// the gaps between them. Chunks after a missing one are held until it
+// arrives or more than window are held, when the missing chunks are given
+// up as a gap.
+type audioSequencer struct {
+	window  int
+	started bool
+	next    int64 // the sequence number of the next chunk to deliver
+	held    map[int64][]byte
+}
+
+// sequencedChunk is an audio chunk ready for delivery, or a gap before
+// the chunks that follow it when audio is nil.
+type sequencedChunk struct {
+	audio []byte
+	gap   *DiscontinuityError
+}
+
+// push adds chunk number seq and returns what is ready in order. The
+// numbering starts from the first chunk seen. late is set if the chunk
+// arrived after its gap was reported or repeats a delivered chunk; it is
+// dropped.
+func (q *audioSequencer) push(seq int64, audio []byte) (ready []sequencedChunk, late bool) {
+	if !q.started {
+		q.started = true
+		q.next = seq
+		q.held = make(map[int64][]byte)
+	}
+	if _, ok := q.held[seq]; ok || seq < q.next {
+		return nil, true
+	}
+	q.held[seq] = audio
+
+	ready = q.drain(nil)
+	for len(q.held) > q.window {
+		ready = q.skip(ready)
+	}
+	return ready, false
+}
+
+// flush gives up on the missing chunks and returns everything held, for
+// the end of the session.
+func (q *audioSequencer) flush() []sequencedChunk {
+	var ready []sequencedChunk
+	for len(q.held) > 0 {
+		ready = q.skip(ready)
+	}
+	return ready
+}
+
+// drain appends the held chunks that continue the sequence to ready.
+func (q *audioSequencer) drain(ready []sequencedChunk) []sequencedChunk {
+	for {
+		audio, ok := q.held[q.next]
+		if !ok {
+			return ready
+		}
+		delete(q.held, q.next)
+		q.next++
+		ready = append(ready, sequencedChunk{audio: audio})
+	}
+}
+
+// skip reports the gap before the earliest held chunk and delivers from
+// there.
+func (q *audioSequencer) skip(ready []sequencedChunk) []sequencedChunk {
+	first := int64(-1)
+	for seq := range q.held {
+		if first < 0 || seq < first {
+			first = seq
+		}
+	}
+	ready = append(ready, sequencedChunk{gap: newDiscontinuityError(q.next, first)})
+	q.next = first
+	return q.drain(ready)
+}
+
+// newDiscontinuityError describes the chunks from expected up to got.
+func newDiscontinuityError(expected, got int64) *DiscontinuityError {
+	msg := fmt.Sprintf("audio chunk %d missing", expected)
+	if got-expected > 1 {
+		msg = fmt.Sprintf("audio chunks %d-%d missing", expected, got-1)
+	}
+	return &DiscontinuityError{
+		WebSocketError: &WebSocketError{Message: msg},
+		Expected:       expected,
+		Got:            got,
+	}
+}
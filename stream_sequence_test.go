@@ -0,0 +1,139 @@
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestAudioSequencer(t *testing.T) {
+	tests := []struct {
+		name   string
+		window int
+		seqs   []int64
+		want   string // delivered chunks, with gaps as [expected-got)
+	}{
+		{"in order", 0, []int64{0, 1, 2}, "0 1 2"},
+		{"starts anywhere", 0, []int64{7, 8}, "7 8"},
+		{"gap", 0, []int64{0, 1, 3, 4}, "0 1 [2-3) 3 4"},
+		{"reordered in window", 2, []int64{0, 2, 1, 3}, "0 1 2 3"},
+		{"gap past window", 1, []int64{0, 2, 3, 4}, "0 [1-2) 2 3 4"},
+		{"late after gap", 0, []int64{0, 2, 1, 3}, "0 [1-2) 2 3"},
+		{"repeated", 0, []int64{0, 1, 1, 2}, "0 1 2"},
+		{"gaps at finish", 4, []int64{0, 2, 5}, "0 [1-2) 2 [3-5) 5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := audioSequencer{window: tt.window}
+			var got []string
+			record := func(ready []sequencedChunk) {
+				for _, c := range ready {
+					if c.gap != nil {
+						got = append(got, "["+strconv.FormatInt(c.gap.Expected, 10)+"-"+strconv.FormatInt(c.gap.Got, 10)+")")
+					} else {
+						got = append(got, string(c.audio))
+					}
+				}
+			}
+			for _, seq := range tt.seqs {
+				ready, _ := q.push(seq, []byte(strconv.FormatInt(seq, 10)))
+				record(ready)
+			}
+			record(q.flush())
+			if strings.Join(got, " ") != tt.want {
+				t.Errorf("delivered %q, want %q", strings.Join(got, " "), tt.want)
+			}
+		})
+	}
+}
+
+// newSequencedServer serves a live session that sends an audio frame
+// numbered by each of seqs, with the number as its audio.
+func newSequencedServer(t *testing.T, seqs ...int64) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		_, _, _ = conn.ReadMessage()
+		for _, seq := range seqs {
+			data, _ := msgpack.Marshal(wsResponse{Event: "audio", Audio: []byte(strconv.FormatInt(seq, 10)), Seq: &seq})
+			_ = conn.WriteMessage(websocket.BinaryMessage, data)
+		}
+		data, _ := msgpack.Marshal(wsResponse{Event: "finish", Reason: "stop"})
+		_ = conn.WriteMessage(websocket.BinaryMessage, data)
+	}))
+}
+
+func TestTTSService_StreamWebSocket_Discontinuity(t *testing.T) {
+	server := newSequencedServer(t, 0, 2, 1, 5, 6)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	opts := DefaultWebSocketOptions()
+	opts.ReorderWindow = 1
+	var gaps []*DiscontinuityError
+	opts.OnEvent = func(evt WebSocketEvent) {
+		if evt.Type == WebSocketEventDiscontinuity {
+			gaps = append(gaps, evt.Gap)
+		}
+	}
+
+	stream, err := client.TTS.StreamWebSocket(context.Background(), make(chan string), nil, opts)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	audio, err := stream.Collect()
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if string(audio) != "01256" {
+		t.Errorf("audio = %q, want the chunks in order", audio)
+	}
+	if len(gaps) != 1 || gaps[0].Expected != 3 || gaps[0].Missing() != 2 || gaps[0].Error() != "audio chunks 3-4 missing" {
+		t.Errorf("gaps = %v, want chunks 3-4", gaps)
+	}
+	if stats := stream.Stats(); stats.Gaps != 1 || stats.MissingChunks != 2 || stats.Chunks != 5 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+}
+
+func TestTTSService_StreamWebSocket_FailOnGap(t *testing.T) {
+	server := newSequencedServer(t, 0, 1, 3)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	opts := DefaultWebSocketOptions()
+	opts.FailOnGap = true
+
+	stream, err := client.TTS.StreamWebSocket(context.Background(), make(chan string), nil, opts)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	var audio []byte
+	for stream.Next() {
+		audio = append(audio, stream.Bytes()...)
+	}
+	var gap *DiscontinuityError
+	if err := stream.Err(); !errors.As(err, &gap) || gap.Expected != 2 || gap.Got != 3 {
+		t.Fatalf("Err() = %v, want *DiscontinuityError for chunk 2", err)
+	}
+	if string(audio) != "01" {
+		t.Errorf("audio = %q, want the chunks before the gap", audio)
+	}
+}
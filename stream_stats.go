@@ -0,0 +1,162 @@
+package fishaudio
+
+import (
+	"sync"
+	"time"
+)
+
+// SegmentLatency records timing for a single text event on a live session.
+type SegmentLatency struct {
+	// Index is the zero-based position of the text event.
+	Index int
+	// Chars is the number of characters in the text event.
+	Chars int
+	// SentAt is when the text event was written to the connection.
+	SentAt time.Time
+	// TTFB is the time from SentAt to the first audio chunk received after it.
+	// It is zero until that chunk arrives.
+	TTFB time.Duration
+}
+
+// WebSocketStats contains latency and throughput statistics for a live session.
+type WebSocketStats struct {
+	// Segments holds per-text-event latency, in send order.
+	Segments []SegmentLatency
+	// Chunks is the number of audio chunks received.
+	Chunks int
+	// Bytes is the number of audio bytes received.
+	Bytes int64
+	// FirstAudio is the time from session start to the first audio chunk.
+	FirstAudio time.Duration
+	// Elapsed is the time from session start to the last audio chunk.
+	Elapsed time.Duration
+	// QueuedTexts is the number of text events waiting to be sent, both
+	// buffered in the text channel and held back by pacing.
+	QueuedTexts int
+	// SkippedFields is the number of frame fields ignored because their
+	// values had an unexpected type.
+	SkippedFields int
+	// Gaps is the number of gaps in the audio sequence, and MissingChunks
+	// the number of chunks they span. See DiscontinuityError.
+	Gaps          int
+	MissingChunks int64
+	// LateChunks is the number of audio chunks dropped because they arrived
+	// after their gap was reported, or repeated a delivered chunk.
+	LateChunks int
+}
+
+// ChunksPerSecond returns the average audio chunk rate.
+func (s WebSocketStats) ChunksPerSecond() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Chunks) / s.Elapsed.Seconds()
+}
+
+// BytesPerSecond returns the average audio throughput.
+func (s WebSocketStats) BytesPerSecond() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Bytes) / s.Elapsed.Seconds()
+}
+
+// MeanTTFB returns the average time to first byte over answered segments.
+func (s WebSocketStats) MeanTTFB() time.Duration {
+	var total time.Duration
+	var n int
+	for _, seg := range s.Segments {
+		if seg.TTFB > 0 {
+			total += seg.TTFB
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return total / time.Duration(n)
+}
+
+// streamStats accumulates WebSocketStats from the send and read loops.
+type streamStats struct {
+	mu       sync.Mutex
+	started  time.Time
+	stats    WebSocketStats
+	answered int // segments before this index have a TTFB
+}
+
+// newStreamStats starts the session clock.
+func newStreamStats(started time.Time) *streamStats {
+	return &streamStats{started: started}
+}
+
+// textSent records a text event written at sentAt.
+func (s *streamStats) textSent(text string, sentAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.Segments = append(s.stats.Segments, SegmentLatency{
+		Index:  len(s.stats.Segments),
+		Chars:  len([]rune(text)),
+		SentAt: sentAt,
+	})
+}
+
+// audioReceived records an audio chunk and resolves TTFB for every segment
+// sent before it that has not yet seen audio.
+func (s *streamStats) audioReceived(size int, receivedAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stats.Chunks == 0 {
+		s.stats.FirstAudio = receivedAt.Sub(s.started)
+	}
+	s.stats.Chunks++
+	s.stats.Bytes += int64(size)
+	s.stats.Elapsed = receivedAt.Sub(s.started)
+
+	for ; s.answered < len(s.stats.Segments); s.answered++ {
+		seg := &s.stats.Segments[s.answered]
+		if seg.SentAt.After(receivedAt) {
+			break
+		}
+		seg.TTFB = receivedAt.Sub(seg.SentAt)
+	}
+}
+
+// fieldsSkipped records frame fields that could not be decoded.
+func (s *streamStats) fieldsSkipped(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.SkippedFields += n
+}
+
+// gap records a gap of missing chunks.
+func (s *streamStats) gap(missing int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.Gaps++
+	s.stats.MissingChunks += missing
+}
+
+// lateChunk records a dropped out-of-order or repeated chunk.
+func (s *streamStats) lateChunk() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.LateChunks++
+}
+
+// setHeld records the number of text events held back by pacing.
+func (s *streamStats) setHeld(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.QueuedTexts = n
+}
+
+// snapshot returns a copy of the current statistics.
+func (s *streamStats) snapshot() WebSocketStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := s.stats
+	out.Segments = append([]SegmentLatency(nil), s.stats.Segments...)
+	return out
+}
@@ -0,0 +1,120 @@
+package fishaudio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestStreamStats_AudioResolvesPendingSegments(t *testing.T) {
+	start := time.Unix(1000, 0)
+	stats := newStreamStats(start)
+
+	stats.textSent("hello", start.Add(10*time.Millisecond))
+	stats.textSent("wörld", start.Add(20*time.Millisecond))
+	stats.audioReceived(100, start.Add(110*time.Millisecond))
+	stats.textSent("again", start.Add(200*time.Millisecond))
+	stats.audioReceived(300, start.Add(250*time.Millisecond))
+	stats.audioReceived(600, start.Add(1000*time.Millisecond))
+
+	got := stats.snapshot()
+
+	wantTTFB := []time.Duration{100 * time.Millisecond, 90 * time.Millisecond, 50 * time.Millisecond}
+	if len(got.Segments) != len(wantTTFB) {
+		t.Fatalf("segments = %d, want %d", len(got.Segments), len(wantTTFB))
+	}
+	for i, want := range wantTTFB {
+		if got.Segments[i].TTFB != want {
+			t.Errorf("Segments[%d].TTFB = %v, want %v", i, got.Segments[i].TTFB, want)
+		}
+		if got.Segments[i].Index != i {
+			t.Errorf("Segments[%d].Index = %d, want %d", i, got.Segments[i].Index, i)
+		}
+	}
+	if got.Segments[1].Chars != 5 {
+		t.Errorf("Segments[1].Chars = %d, want 5", got.Segments[1].Chars)
+	}
+
+	if got.Chunks != 3 || got.Bytes != 1000 {
+		t.Errorf("Chunks, Bytes = %d, %d, want 3, 1000", got.Chunks, got.Bytes)
+	}
+	if got.FirstAudio != 110*time.Millisecond {
+		t.Errorf("FirstAudio = %v, want %v", got.FirstAudio, 110*time.Millisecond)
+	}
+	if got.ChunksPerSecond() != 3 {
+		t.Errorf("ChunksPerSecond() = %v, want 3", got.ChunksPerSecond())
+	}
+	if got.BytesPerSecond() != 1000 {
+		t.Errorf("BytesPerSecond() = %v, want 1000", got.BytesPerSecond())
+	}
+	if got.MeanTTFB() != 80*time.Millisecond {
+		t.Errorf("MeanTTFB() = %v, want %v", got.MeanTTFB(), 80*time.Millisecond)
+	}
+}
+
+func TestWebSocketStats_Empty(t *testing.T) {
+	var stats WebSocketStats
+	if stats.ChunksPerSecond() != 0 || stats.BytesPerSecond() != 0 || stats.MeanTTFB() != 0 {
+		t.Error("empty stats should report zero rates")
+	}
+}
+
+func TestWebSocketAudioStream_Stats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var msg map[string]interface{}
+			_ = msgpack.Unmarshal(data, &msg)
+			switch msg["event"] {
+			case "text":
+				resp, _ := msgpack.Marshal(wsResponse{Event: "audio", Audio: []byte("1234")})
+				_ = conn.WriteMessage(websocket.BinaryMessage, resp)
+			case "stop":
+				resp, _ := msgpack.Marshal(wsResponse{Event: "finish", Reason: "stop"})
+				_ = conn.WriteMessage(websocket.BinaryMessage, resp)
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	textChan := make(chan string, 2)
+	textChan <- "Hello"
+	textChan <- "World"
+	close(textChan)
+
+	stream, err := client.TTS.StreamWebSocket(context.Background(), textChan, nil, nil)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+	if _, err := stream.Collect(); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	stats := stream.Stats()
+	if len(stats.Segments) != 2 {
+		t.Fatalf("segments = %d, want 2", len(stats.Segments))
+	}
+	if stats.Chunks != 2 || stats.Bytes != 8 {
+		t.Errorf("Chunks, Bytes = %d, %d, want 2, 8", stats.Chunks, stats.Bytes)
+	}
+	if stats.Segments[0].TTFB <= 0 {
+		t.Error("first segment TTFB should be recorded")
+	}
+}
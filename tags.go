@@ -0,0 +1,128 @@
+package fishaudio
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// headerTags carries request tags when WithTagHeader is set, encoded as a
+// query string such as "tenant=acme&feature=audiobook".
+const headerTags = "X-Fish-Audio-Tags"
+
+type tagsKey struct{}
+
+// WithTag returns a copy of ctx carrying the tag key=value, which requests
+// made with the context report to the hooks of WithMetricsHook and
+// WithDeprecationHandler, and send upstream with WithTagHeader. A tag
+// replaces an earlier tag of the same key.
+//
+// Example:
+//
+//	ctx = fishaudio.WithTag(ctx, "tenant", tenantID)
+//	ctx = fishaudio.WithTag(ctx, "feature", "audiobook")
+//	audio, err := client.TTS.Convert(ctx, params)
+func WithTag(ctx context.Context, key, value string) context.Context {
+	tags := maps.Clone(Tags(ctx))
+	if tags == nil {
+		tags = make(map[string]string)
+	}
+	tags[key] = value
+	return context.WithValue(ctx, tagsKey{}, tags)
+}
+
+// Tags returns the tags of ctx set by WithTag, or nil if there are none.
+func Tags(ctx context.Context) map[string]string {
+	tags, _ := ctx.Value(tagsKey{}).(map[string]string)
+	return maps.Clone(tags)
+}
+
+// RequestMetrics describes a finished API request. For streaming
+// responses, it is reported when the response headers arrive.
+type RequestMetrics struct {
+	// Method and Path identify the request, e.g. "POST" and "/v1/tts".
+	Method string
+	Path   string
+	// StatusCode is the HTTP status, or zero if no response arrived.
+	StatusCode int
+	// Duration is the time until the response headers arrived.
+	Duration time.Duration
+	// Usage is what the request was billed, if the response says.
+	Usage *RequestUsage
+	// Err is the transport error, if no response arrived.
+	Err error
+	// Tags are the tags of the request's context. See WithTag.
+	Tags map[string]string
+}
+
+// WithMetricsHook calls fn after each API request, including WebSocket
+// handshakes, for latency, error, and usage metrics broken down by the
+// request's tags. fn is called synchronously and must not block.
+//
+// Example:
+//
+//	client := fishaudio.NewClient(fishaudio.WithMetricsHook(func(m fishaudio.RequestMetrics) {
+//	    requestLatency.WithLabelValues(m.Path, m.Tags["tenant"]).Observe(m.Duration.Seconds())
+//	}))
+func WithMetricsHook(fn func(RequestMetrics)) ClientOption {
+	return func(c *Client) {
+		c.metricsHook = fn
+	}
+}
+
+// WithTagHeader sends the tags of each request to the API in the
+// X-Fish-Audio-Tags header, for attribution by proxies and gateways in
+// between.
+func WithTagHeader() ClientOption {
+	return func(c *Client) {
+		c.tagHeader = true
+	}
+}
+
+// send performs req with the client's HTTP client, reporting it to the
+// wire log and the metrics and deprecation hooks.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	c.setTagHeader(req.Context(), req.Header)
+	c.wire().request("http", req)
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	c.wire().response("http", req.Method, req.URL, start, resp, err)
+	c.observe(req.Context(), req.Method, req.URL.Path, start, resp, err)
+	return resp, err
+}
+
+// setTagHeader sets the tags header if enabled and ctx has tags.
+func (c *Client) setTagHeader(ctx context.Context, header http.Header) {
+	if !c.tagHeader {
+		return
+	}
+	if tags := Tags(ctx); len(tags) > 0 {
+		values := url.Values{}
+		for k, v := range tags {
+			values.Set(k, v)
+		}
+		header.Set(headerTags, values.Encode())
+	}
+}
+
+// observe reports a request that started at start to the hooks.
+func (c *Client) observe(ctx context.Context, method, path string, start time.Time, resp *http.Response, err error) {
+	c.checkDeprecation(ctx, method, path, resp)
+	if c.metricsHook == nil {
+		return
+	}
+	m := RequestMetrics{
+		Method:   method,
+		Path:     path,
+		Duration: time.Since(start),
+		Err:      err,
+		Tags:     Tags(ctx),
+	}
+	if resp != nil {
+		m.StatusCode = resp.StatusCode
+		m.Usage = parseUsage(resp.Header)
+	}
+	c.metricsHook(m)
+}
@@ -0,0 +1,79 @@
+package fishaudio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestWithTag(t *testing.T) {
+	ctx := context.Background()
+	if tags := Tags(ctx); tags != nil {
+		t.Errorf("Tags(background) = %v, want nil", tags)
+	}
+
+	parent := WithTag(WithTag(ctx, "tenant", "acme"), "feature", "audiobook")
+	child := WithTag(parent, "tenant", "globex")
+	if got, want := Tags(parent), map[string]string{"tenant": "acme", "feature": "audiobook"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Tags(parent) = %v, want %v", got, want)
+	}
+	if got := Tags(child)["tenant"]; got != "globex" {
+		t.Errorf("Tags(child)[tenant] = %q, want globex", got)
+	}
+
+	// The returned map is a copy
+	Tags(parent)["tenant"] = "changed"
+	if got := Tags(parent)["tenant"]; got != "acme" {
+		t.Errorf("Tags(parent)[tenant] = %q after modifying a copy, want acme", got)
+	}
+}
+
+func TestWithMetricsHook(t *testing.T) {
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Fish-Audio-Tags")
+		if r.URL.Path == "/v1/tts" {
+			w.Header().Set("X-Usage-Characters", "5")
+			_, _ = w.Write([]byte("audio"))
+			return
+		}
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var metrics []RequestMetrics
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithTagHeader(), WithMetricsHook(func(m RequestMetrics) {
+		mu.Lock()
+		defer mu.Unlock()
+		metrics = append(metrics, m)
+	}))
+	ctx := WithTag(WithTag(context.Background(), "tenant", "acme"), "feature", "read aloud")
+
+	if _, err := client.TTS.Convert(ctx, &ConvertParams{Text: "Hello"}); err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if values, _ := url.ParseQuery(header); values.Get("tenant") != "acme" || values.Get("feature") != "read aloud" {
+		t.Errorf("X-Fish-Audio-Tags = %q", header)
+	}
+	_, _ = client.Voices.Get(context.Background(), "missing")
+	if header != "" {
+		t.Errorf("X-Fish-Audio-Tags = %q without tags, want none", header)
+	}
+
+	if len(metrics) != 2 {
+		t.Fatalf("got %d metrics, want 2", len(metrics))
+	}
+	m := metrics[0]
+	if m.Method != http.MethodPost || m.Path != "/v1/tts" || m.StatusCode != 200 || m.Tags["tenant"] != "acme" || m.Duration <= 0 ||
+		m.Usage == nil || m.Usage.Characters != 5 {
+		t.Errorf("metrics[0] = %+v", m)
+	}
+	if metrics[1].StatusCode != http.StatusNotFound || metrics[1].Tags != nil {
+		t.Errorf("metrics[1] = %+v", metrics[1])
+	}
+}
@@ -0,0 +1,104 @@
+package fishaudio
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// timestampLayouts are the formats the API has been seen to return, tried
+// in order. Layouts without a zone are read as UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// Timestamp is a time decoded from any of the API's timestamp formats:
+// RFC 3339 with or without a zone, space-separated date and time, a bare
+// date, or Unix seconds or milliseconds. It embeds time.Time, so it can be
+// used like one.
+type Timestamp struct {
+	time.Time
+	// Raw is the original value if it could not be parsed; Time is zero
+	// in that case.
+	Raw string
+}
+
+// UnmarshalJSON decodes a timestamp. Unparseable strings are kept in Raw
+// rather than failing the whole response.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	*t = Timestamp{}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if data[0] != '"' {
+		n, err := strconv.ParseFloat(string(data), 64)
+		if err != nil {
+			t.Raw = string(data)
+			return nil
+		}
+		t.Time = unixTime(n)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	t.Time, t.Raw = parseTimestamp(s)
+	return nil
+}
+
+// MarshalJSON encodes the time in RFC 3339, or the raw value if it could
+// not be parsed. A zero timestamp encodes as null.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	switch {
+	case !t.Time.IsZero():
+		return json.Marshal(t.Time.Format(time.RFC3339Nano))
+	case t.Raw != "":
+		return json.Marshal(t.Raw)
+	}
+	return []byte("null"), nil
+}
+
+// String returns the time in RFC 3339, or the raw value if it could not be
+// parsed.
+func (t Timestamp) String() string {
+	if t.Time.IsZero() && t.Raw != "" {
+		return t.Raw
+	}
+	return t.Time.Format(time.RFC3339Nano)
+}
+
+// parseTimestamp parses s in any known layout, returning s as raw if none
+// match.
+func parseTimestamp(s string) (time.Time, string) {
+	if s == "" {
+		return time.Time{}, ""
+	}
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			return parsed, ""
+		}
+	}
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		return unixTime(n), ""
+	}
+	return time.Time{}, s
+}
+
+// unixTime converts Unix seconds, or milliseconds for values too large to
+// be seconds, to a UTC time.
+func unixTime(n float64) time.Time {
+	if n > 1e11 {
+		return time.UnixMilli(int64(n)).UTC()
+	}
+	sec := int64(n)
+	return time.Unix(sec, int64((n-float64(sec))*1e9)).UTC()
+}
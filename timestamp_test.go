@@ -0,0 +1,97 @@
+package fishaudio
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestamp_UnmarshalJSON(t *testing.T) {
+	want := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		json string
+		want time.Time
+		raw  string
+	}{
+		{"rfc3339", `"2024-03-15T10:30:00Z"`, want, ""},
+		{"offset", `"2024-03-15T12:30:00+02:00"`, want, ""},
+		{"fractional", `"2024-03-15T10:30:00.000000Z"`, want, ""},
+		{"no zone", `"2024-03-15T10:30:00.000"`, want, ""},
+		{"space separated", `"2024-03-15 10:30:00"`, want, ""},
+		{"space separated with zone", `"2024-03-15 10:30:00+00:00"`, want, ""},
+		{"date", `"2024-03-15"`, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), ""},
+		{"unix seconds", `1710498600`, want, ""},
+		{"unix milliseconds", `1710498600000`, want, ""},
+		{"unix string", `"1710498600"`, want, ""},
+		{"null", `null`, time.Time{}, ""},
+		{"empty", `""`, time.Time{}, ""},
+		{"unparseable", `"last tuesday"`, time.Time{}, "last tuesday"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ts Timestamp
+			if err := json.Unmarshal([]byte(tt.json), &ts); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !ts.Time.Equal(tt.want) {
+				t.Errorf("Time = %v, want %v", ts.Time, tt.want)
+			}
+			if ts.Raw != tt.raw {
+				t.Errorf("Raw = %q, want %q", ts.Raw, tt.raw)
+			}
+		})
+	}
+}
+
+func TestTimestamp_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		ts   Timestamp
+		want string
+	}{
+		{"time", Timestamp{Time: time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)}, `"2024-03-15T10:30:00Z"`},
+		{"raw", Timestamp{Raw: "last tuesday"}, `"last tuesday"`},
+		{"zero", Timestamp{}, `null`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.ts)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Marshal() = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimestamp_InModels(t *testing.T) {
+	var pkg Package
+	data := `{"_id":"p1","created_at":"2024-03-15T10:30:00Z","updated_at":"not a time","finished_at":"2024-03-16"}`
+	if err := json.Unmarshal([]byte(data), &pkg); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if pkg.CreatedAt.Year() != 2024 {
+		t.Errorf("CreatedAt = %v, want 2024", pkg.CreatedAt)
+	}
+	if pkg.UpdatedAt.Raw != "not a time" || !pkg.UpdatedAt.IsZero() {
+		t.Errorf("UpdatedAt = %+v, want raw fallback", pkg.UpdatedAt)
+	}
+	if pkg.FinishedAt == nil || pkg.FinishedAt.Day() != 16 {
+		t.Errorf("FinishedAt = %v, want 2024-03-16", pkg.FinishedAt)
+	}
+	if len(pkg.Extra) != 0 {
+		t.Errorf("Extra = %v, want empty", pkg.Extra)
+	}
+	var voice Voice
+	data = `{"_id":"v1","created_at":"2024-03-15 10:30:00","updated_at":"2024-03-15T10:30:00"}`
+	if err := json.Unmarshal([]byte(data), &voice); err != nil {
+		t.Fatalf("Unmarshal() Voice error = %v", err)
+	}
+	if voice.CreatedAt.Hour() != 10 || !voice.UpdatedAt.Equal(voice.CreatedAt.Time) {
+		t.Errorf("Voice times = %v, %v, want zone-less times read as UTC", voice.CreatedAt, voice.UpdatedAt)
+	}
+}
@@ -3,14 +3,20 @@ package fishaudio
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"reflect"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/vmihailenco/msgpack/v5"
+	"golang.org/x/sync/errgroup"
 )
 
 // ReferenceAudio contains reference audio for voice cloning.
@@ -57,6 +63,9 @@ type TTSConfig struct {
 	TopP float64 `json:"top_p,omitempty"`
 	// Temperature is the randomness in generation. Range: 0.0-1.0. Default: 0.7.
 	Temperature float64 `json:"temperature,omitempty"`
+	// Language hints the language of the text, e.g. LanguageJapanese, for
+	// text whose script is shared by several languages. Detected if empty.
+	Language Language `json:"language,omitempty"`
 }
 
 // ConvertParams contains parameters for TTS conversion.
@@ -77,6 +86,9 @@ type ConvertParams struct {
 	Speed float64 `json:"-"`
 	// Config provides additional TTS configuration.
 	Config *TTSConfig `json:"-"`
+	// PresetName names a preset registered with WithPresets whose settings
+	// apply under Config. See Preset.
+	PresetName string `json:"-"`
 }
 
 // StreamParams contains parameters for TTS streaming.
@@ -97,6 +109,9 @@ type StreamParams struct {
 	Speed float64 `json:"-"`
 	// Config provides additional TTS configuration.
 	Config *TTSConfig `json:"-"`
+	// PresetName names a preset registered with WithPresets whose settings
+	// apply under Config. See Preset.
+	PresetName string `json:"-"`
 }
 
 // ttsRequest is the internal API request structure.
@@ -114,6 +129,7 @@ type ttsRequest struct {
 	Prosody     *Prosody         `json:"prosody,omitempty" msgpack:"prosody,omitempty"`
 	TopP        float64          `json:"top_p,omitempty" msgpack:"top_p,omitempty"`
 	Temperature float64          `json:"temperature,omitempty" msgpack:"temperature,omitempty"`
+	Language    Language         `json:"language,omitempty" msgpack:"language,omitempty"`
 }
 
 // TTSService provides text-to-speech operations.
@@ -122,26 +138,91 @@ type TTSService struct {
 }
 
 // Convert generates speech from text and returns the complete audio.
+// With a cache set by WithTTSCache, identical requests are served from the
+// cache.
 func (s *TTSService) Convert(ctx context.Context, params *ConvertParams) ([]byte, error) {
-	stream, err := s.Stream(ctx, &StreamParams{
-		Text:        params.Text,
-		Model:       params.Model,
-		ReferenceID: params.ReferenceID,
-		References:  params.References,
-		Format:      params.Format,
-		Latency:     params.Latency,
-		Speed:       params.Speed,
-		Config:      params.Config,
-	})
+	streamParams, err := s.resolveParams(params.streamParams())
+	if err != nil {
+		return nil, err
+	}
+
+	cache := s.client.ttsCache
+	var key string
+	if cache != nil {
+		key = ttsCacheKey(s.getModel(streamParams), s.buildRequest(streamParams))
+		if audio, ok, err := cache.Get(ctx, key); err == nil && ok {
+			return audio, nil
+		}
+	}
+
+	stream, err := s.Stream(ctx, streamParams)
+	if err != nil {
+		return nil, err
+	}
+	audio, err := stream.Collect()
 	if err != nil {
 		return nil, err
 	}
-	return stream.Collect()
+
+	if cache != nil {
+		_ = cache.Set(ctx, key, audio)
+	}
+	return audio, nil
+}
+
+// ConvertFunc generates speech from text and calls fn for each chunk of
+// audio as it arrives, for servers that forward audio without keeping it.
+// If fn returns an error, the request is closed and that error is
+// returned. With WithChunkReuse, the chunk is reused after fn returns.
+// Unlike Convert, it does
+// not use the cache of WithTTSCache.
+//
+// Example:
+//
+//	err := client.TTS.ConvertFunc(ctx, params, func(chunk []byte) error {
+//	    _, err := w.Write(chunk)
+//	    return err
+//	})
+func (s *TTSService) ConvertFunc(ctx context.Context, params *ConvertParams, fn func(chunk []byte) error) error {
+	stream, err := s.Stream(ctx, params.streamParams())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stream.Close() }()
+
+	for stream.Next() {
+		if err := fn(stream.Bytes()); err != nil {
+			return err
+		}
+	}
+	return stream.Err()
+}
+
+// streamParams returns the StreamParams of a Convert request.
+func (p *ConvertParams) streamParams() *StreamParams {
+	return &StreamParams{
+		Text:        p.Text,
+		Model:       p.Model,
+		ReferenceID: p.ReferenceID,
+		References:  p.References,
+		Format:      p.Format,
+		Latency:     p.Latency,
+		Speed:       p.Speed,
+		Config:      p.Config,
+		PresetName:  p.PresetName,
+	}
 }
 
 // Stream generates speech from text and returns an audio stream.
 func (s *TTSService) Stream(ctx context.Context, params *StreamParams) (*AudioStream, error) {
+	params, err := s.resolveParams(params)
+	if err != nil {
+		return nil, err
+	}
 	req := s.buildRequest(params)
+	if err := req.Language.validate(); err != nil {
+		return nil, err
+	}
 
 	// Build request options with model header
 	var opts *RequestOptions
@@ -152,12 +233,50 @@ func (s *TTSService) Stream(ctx context.Context, params *StreamParams) (*AudioSt
 		}
 	}
 
+	budget := s.client.budget
+	var day string
+	if budget != nil {
+		var err error
+		if day, err = budget.reserve(req.Text); err != nil {
+			return nil, err
+		}
+	}
+
 	resp, err := s.client.doRequest(ctx, http.MethodPost, "/v1/tts", req, opts)
 	if err != nil {
+		if budget != nil {
+			budget.release(day, req.Text)
+		}
 		return nil, err
 	}
 
-	return newAudioStream(resp), nil
+	stream := s.client.audioStream(resp)
+	if budget != nil {
+		budget.record(stream.Usage())
+	}
+	return stream, nil
+}
+
+// resolveParams returns params with the preset named by PresetName and
+// then the client's defaults from WithConfigFile applied under Config.
+func (s *TTSService) resolveParams(params *StreamParams) (*StreamParams, error) {
+	if err := s.client.ttsDefaultsErr; err != nil {
+		return nil, err
+	}
+	params, err := s.applyPreset(params)
+	if err != nil {
+		return nil, err
+	}
+	if defaults := s.client.ttsDefaults; defaults != nil {
+		p := *params
+		cfg := *defaults
+		if params.Config != nil {
+			cfg = mergeTTSConfig(cfg, *params.Config)
+		}
+		p.Config = &cfg
+		params = &p
+	}
+	return params, nil
 }
 
 // getModel returns the model to use, checking params then config, defaulting to s2-pro.
@@ -225,6 +344,9 @@ func (s *TTSService) buildRequest(params *StreamParams) *ttsRequest {
 		if cfg.Temperature != 0 {
 			req.Temperature = cfg.Temperature
 		}
+		if cfg.Language != "" {
+			req.Language = cfg.Language
+		}
 	}
 
 	return req
@@ -234,32 +356,234 @@ func (s *TTSService) buildRequest(params *StreamParams) *ttsRequest {
 
 // startEvent initiates a TTS WebSocket streaming session.
 type startEvent struct {
-	Event   string      `msgpack:"event"`
-	Request *ttsRequest `msgpack:"request"`
+	Event   string      `json:"event" msgpack:"event"`
+	Request *ttsRequest `json:"request" msgpack:"request"`
 }
 
 // textEvent sends a text chunk for synthesis.
 type textEvent struct {
-	Event string `msgpack:"event"`
-	Text  string `msgpack:"text"`
+	Event string `json:"event" msgpack:"event"`
+	Text  string `json:"text" msgpack:"text"`
 }
 
 // closeEvent ends the streaming session.
 type closeEvent struct {
-	Event string `msgpack:"event"`
+	Event string `json:"event" msgpack:"event"`
 }
 
 // wsResponse represents a WebSocket response message.
 type wsResponse struct {
-	Event  string `msgpack:"event"`
-	Audio  []byte `msgpack:"audio,omitempty"`
-	Reason string `msgpack:"reason,omitempty"`
+	Event     string  `json:"event" msgpack:"event"`
+	Audio     []byte  `json:"audio,omitempty" msgpack:"audio,omitempty"`
+	Reason    string  `json:"reason,omitempty" msgpack:"reason,omitempty"`
+	Timestamp float64 `json:"timestamp,omitempty" msgpack:"timestamp,omitempty"`
+	// Seq numbers audio frames, if the server provides it.
+	Seq *int64 `json:"seq,omitempty" msgpack:"seq,omitempty"`
+
+	// Error fields, sent either at the top level or nested under "error".
+	Code    interface{} `json:"code,omitempty" msgpack:"code,omitempty"`
+	Message string      `json:"message,omitempty" msgpack:"message,omitempty"`
+	Detail  interface{} `json:"detail,omitempty" msgpack:"detail,omitempty"`
+	Error   interface{} `json:"error,omitempty" msgpack:"error,omitempty"`
+
+	// Words carries word timings when the server provides them. It is
+	// decoded loosely so an unexpected shape can't fail the frame.
+	Words []interface{} `json:"words,omitempty" msgpack:"words,omitempty"`
+}
+
+// WordBoundary marks when a word is spoken in the synthesized audio.
+type WordBoundary struct {
+	// Text is the word or token.
+	Text string
+	// Start is the offset of the word from the start of the session's audio.
+	Start time.Duration
+	// End is the offset of the end of the word.
+	End time.Duration
+}
+
+// wordBoundaries converts loosely decoded word timings, skipping entries
+// that don't have the expected shape. Times are in seconds.
+func (r *wsResponse) wordBoundaries() []WordBoundary {
+	if len(r.Words) == 0 {
+		return nil
+	}
+	words := make([]WordBoundary, 0, len(r.Words))
+	for _, item := range r.Words {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		text, ok := fields["word"].(string)
+		if !ok {
+			if text, ok = fields["text"].(string); !ok {
+				continue
+			}
+		}
+		words = append(words, WordBoundary{
+			Text:  text,
+			Start: secondsToDuration(fields["start"]),
+			End:   secondsToDuration(fields["end"]),
+		})
+	}
+	return words
+}
+
+// secondsToDuration converts a decoded numeric value in seconds. msgpack
+// decodes small integers into sized types, so any numeric kind is accepted.
+func secondsToDuration(v interface{}) time.Duration {
+	var seconds float64
+	switch rv := reflect.ValueOf(v); {
+	case rv.CanFloat():
+		seconds = rv.Float()
+	case rv.CanInt():
+		seconds = float64(rv.Int())
+	case rv.CanUint():
+		seconds = float64(rv.Uint())
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// RawMessage is an undecoded frame received on a live TTS session.
+type RawMessage struct {
+	// Data is the frame payload. It must not be modified.
+	Data []byte
+	// Text reports whether this was a text (JSON) frame rather than a binary (msgpack) frame.
+	Text bool
+	// ReceivedAt is when the frame was read from the connection.
+	ReceivedAt time.Time
+}
+
+// Decode unmarshals the frame into v using the codec matching its frame type.
+// Use it to read event fields the SDK does not yet expose.
+//
+// Example:
+//
+//	opts.OnRawMessage = func(msg fishaudio.RawMessage) {
+//	    var evt struct {
+//	        Event string `json:"event" msgpack:"event"`
+//	        Words []any  `json:"words" msgpack:"words"`
+//	    }
+//	    if msg.Decode(&evt) == nil && evt.Event == "words" {
+//	        // handle word timings
+//	    }
+//	}
+func (m RawMessage) Decode(v interface{}) error {
+	if m.Text {
+		return json.Unmarshal(m.Data, v)
+	}
+	return msgpack.Unmarshal(m.Data, v)
+}
+
+// wsError builds a WebSocketError from the error fields of a finish event.
+func (r *wsResponse) wsError() *WebSocketError {
+	err := &WebSocketError{Message: "stream finished with error"}
+
+	code, message, detail := r.Code, r.Message, r.Detail
+	switch nested := r.Error.(type) {
+	case map[string]interface{}:
+		if v, ok := nested["code"]; ok && code == nil {
+			code = v
+		}
+		if v, ok := nested["message"].(string); ok && message == "" {
+			message = v
+		}
+		if v, ok := nested["detail"]; ok && detail == nil {
+			detail = v
+		}
+	case string:
+		if message == "" {
+			message = nested
+		}
+	}
+
+	if code != nil {
+		err.Code = fmt.Sprint(code)
+	}
+	if detail != nil {
+		err.Detail = formatErrorDetail(detail)
+	}
+	if message != "" {
+		if err.Detail == "" {
+			err.Detail = message
+		} else {
+			err.Detail = message + ": " + err.Detail
+		}
+	}
+	return err
+}
+
+// formatErrorDetail renders a decoded detail value as text.
+func formatErrorDetail(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	if data, err := json.Marshal(v); err == nil {
+		return string(data)
+	}
+	return fmt.Sprint(v)
+}
+
+// ConnectionEvent describes a connection state change on a live TTS session.
+type ConnectionEvent struct {
+	// URL is the endpoint of the connection.
+	URL string
+	// Time is when the state change happened.
+	Time time.Time
+	// Duration is the dial time for connects and the session lifetime for disconnects.
+	Duration time.Duration
+	// CloseCode is the WebSocket close code received from the server, or 0
+	// if the connection ended without a close frame.
+	CloseCode int
+	// Err is the error that ended the session, or nil for a clean finish.
+	Err error
+}
+
+// WebSocketEventType identifies the kind of event received on a live TTS session.
+type WebSocketEventType string
+
+const (
+	// WebSocketEventAudio carries a chunk of synthesized audio.
+	WebSocketEventAudio WebSocketEventType = "audio"
+	// WebSocketEventFinish marks the end of the session.
+	WebSocketEventFinish WebSocketEventType = "finish"
+	// WebSocketEventDiscontinuity reports audio chunks missing from the
+	// stream. It is raised by the SDK, before the audio that follows the gap.
+	WebSocketEventDiscontinuity WebSocketEventType = "discontinuity"
+)
+
+// WebSocketEvent is a typed event received on a live TTS session.
+//
+// Events other than audio and finish (such as checkpoints) are delivered
+// with Type set to the server's event name.
+type WebSocketEvent struct {
+	// Type is the event type.
+	Type WebSocketEventType
+	// Sequence is the zero-based position of the event within the session.
+	Sequence int
+	// Audio is the audio chunk for audio events. It must not be modified.
+	Audio []byte
+	// Reason is the finish reason for finish events ("stop" or "error").
+	Reason string
+	// Words holds word or speech-mark timings carried by the event, if the
+	// server provides them. Use them for captions or lip-sync.
+	Words []WordBoundary
+	// ServerTimestamp is the server-side timestamp in seconds, if provided.
+	ServerTimestamp float64
+	// Gap describes the missing chunks for discontinuity events.
+	Gap *DiscontinuityError
+	// ReceivedAt is when the frame was read from the connection.
+	ReceivedAt time.Time
+	// Elapsed is the time since the session started.
+	Elapsed time.Duration
 }
 
 // StreamWebSocket streams text to speech over WebSocket for real-time generation.
 //
 // The textChan receives text chunks to synthesize. Close the channel to end streaming.
 // Returns a WebSocketAudioStream that can be iterated for audio chunks.
+//
+// The ctx governs the whole session: cancelling it closes the connection and
+// causes Next, Read, and Collect to return ctx.Err().
 func (s *TTSService) StreamWebSocket(ctx context.Context, textChan <-chan string, params *StreamParams, opts *WebSocketOptions) (*WebSocketAudioStream, error) {
 	if opts == nil {
 		opts = DefaultWebSocketOptions()
@@ -268,180 +592,888 @@ func (s *TTSService) StreamWebSocket(ctx context.Context, textChan <-chan string
 	if params == nil {
 		params = &StreamParams{}
 	}
+	params, err := s.resolveParams(params)
+	if err != nil {
+		return nil, err
+	}
+	if params.Config != nil {
+		if err := params.Config.Language.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if budget := s.client.budget; budget != nil {
+		// Check the limits before dialing; text is counted as it is sent
+		if _, err := budget.reserve(""); err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := s.dialWebSocket(ctx, s.getModel(params), opts)
+	if err != nil {
+		return nil, err
+	}
 
-	// Build WebSocket URL from baseURL
-	wsURL := strings.Replace(strings.Replace(s.client.baseURL, "https://", "wss://", 1), "http://", "ws://", 1) + "/v1/tts/live"
+	return s.startWebSocket(ctx, conn, nil, textChan, params, opts)
+}
+
+// StreamWebSocketTo streams text to speech over WebSocket and writes each
+// audio chunk to w as it arrives. It returns when the session ends, the
+// context is cancelled, or a write fails.
+//
+// Example:
+//
+//	err := client.TTS.StreamWebSocketTo(ctx, textChan, conn, params, nil)
+func (s *TTSService) StreamWebSocketTo(ctx context.Context, textChan <-chan string, w io.Writer, params *StreamParams, opts *WebSocketOptions) error {
+	return s.StreamWebSocketFunc(ctx, textChan, func(chunk []byte) error {
+		_, err := w.Write(chunk)
+		return err
+	}, params, opts)
+}
 
-	// Set up dialer
-	dialer := websocket.Dialer{
-		ReadBufferSize:  opts.ReadBufferSize,
-		WriteBufferSize: opts.WriteBufferSize,
+// StreamWebSocketFunc streams text to speech over WebSocket and calls fn for
+// each audio chunk as it arrives. If fn returns an error, the session is
+// closed and that error is returned. With WithChunkReuse, the chunk is
+// reused after fn returns.
+func (s *TTSService) StreamWebSocketFunc(ctx context.Context, textChan <-chan string, fn func(chunk []byte) error, params *StreamParams, opts *WebSocketOptions) error {
+	stream, err := s.StreamWebSocket(ctx, textChan, params, opts)
+	if err != nil {
+		return err
 	}
+	defer func() { _ = stream.Close() }()
 
-	// Connect with auth and model headers
-	header := http.Header{}
-	header.Set("Authorization", "Bearer "+s.client.apiKey)
-	if model := s.getModel(params); model != "" {
+	for stream.Next() {
+		if err := fn(stream.Bytes()); err != nil {
+			return err
+		}
+	}
+	return stream.Err()
+}
+
+// liveURL builds the live TTS WebSocket URL from the client's base URL.
+func (s *TTSService) liveURL() string {
+	return strings.Replace(strings.Replace(s.client.baseURL, "https://", "wss://", 1), "http://", "ws://", 1) + "/v1/tts/live"
+}
+
+// dialWebSocket opens a live TTS connection for the given model.
+func (s *TTSService) dialWebSocket(ctx context.Context, model Model, opts *WebSocketOptions) (*websocket.Conn, error) {
+	wsURL := s.liveURL()
+
+	// Set up dialer, starting from a caller-provided one if set
+	dialer := websocket.Dialer{}
+	if opts.Dialer != nil {
+		dialer = *opts.Dialer
+	}
+	if dialer.ReadBufferSize == 0 {
+		dialer.ReadBufferSize = opts.ReadBufferSize
+	}
+	if dialer.WriteBufferSize == 0 {
+		dialer.WriteBufferSize = opts.WriteBufferSize
+	}
+	if opts.NetDialContext != nil {
+		dialer.NetDialContext = opts.NetDialContext
+	}
+
+	// Connect with caller headers plus auth and model headers
+	header := opts.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	s.client.authorize(header)
+	s.client.setTagHeader(ctx, header)
+	if model != "" {
 		header.Set("model", string(model))
 	}
 
-	conn, _, err := dialer.DialContext(ctx, wsURL, header)
+	var handshake *http.Request
+	if wire := s.client.wire(); wire != nil {
+		// Log the handshake as the request it is sent as
+		if handshake, _ = http.NewRequestWithContext(ctx, http.MethodGet, wsURL, nil); handshake != nil {
+			handshake.Header = header
+			wire.request("ws", handshake)
+		}
+	}
+	dialStart := time.Now()
+	conn, resp, err := dialer.DialContext(ctx, wsURL, header)
+	if handshake != nil {
+		s.client.wire().response("ws", http.MethodGet, handshake.URL, dialStart, resp, err)
+	}
+	s.client.observe(ctx, http.MethodGet, "/v1/tts/live", dialStart, resp, err)
 	if err != nil {
 		return nil, fmt.Errorf("websocket dial failed: %w", err)
 	}
 
-	conn.SetReadLimit(opts.MaxMessageSize)
+	if opts.OnConnect != nil {
+		now := time.Now()
+		opts.OnConnect(ConnectionEvent{
+			URL:      wsURL,
+			Time:     now,
+			Duration: now.Sub(dialStart),
+		})
+	}
 
-	// Send start event with msgpack
+	return conn, nil
+}
+
+// startWebSocket sends the start event on conn and runs the session goroutines.
+// pooled is set if conn was taken from a SessionPool. The connection is
+// closed if the session cannot be started.
+func (s *TTSService) startWebSocket(ctx context.Context, conn *websocket.Conn, pooled *pooledConn, textChan <-chan string, params *StreamParams, opts *WebSocketOptions) (*WebSocketAudioStream, error) {
+	session, err := s.startSession(ctx, conn, pooled, textChan, params, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebSocketAudioStream{
+		audioChan: session.audioChan,
+		errChan:   session.errChan,
+		session:   session,
+		chain:     []*wsSession{session},
+		tts:       s,
+		ctx:       ctx,
+		textChan:  textChan,
+		params:    *params,
+		opts:      opts,
+	}, nil
+}
+
+// startSession sends the start event on conn and runs the session goroutines.
+func (s *TTSService) startSession(ctx context.Context, conn *websocket.Conn, pooled *pooledConn, textChan <-chan string, params *StreamParams, opts *WebSocketOptions) (*wsSession, error) {
+	if pooled == nil {
+		// The pool sets the limit before its idle read
+		conn.SetReadLimit(opts.MaxMessageSize)
+	}
+
+	session := newWSSession(ctx, conn, pooled, opts)
+	session.client = s.client
+	session.url = s.liveURL()
+
+	// Send start event
 	req := s.buildRequest(params)
 	start := startEvent{
 		Event:   "start",
 		Request: req,
 	}
-	startData, err := msgpack.Marshal(start)
-	if err != nil {
-		_ = conn.Close()
-		return nil, fmt.Errorf("failed to marshal start event: %w", err)
-	}
-	if err := conn.WriteMessage(websocket.BinaryMessage, startData); err != nil {
+	if err := session.writeEvent(start); err != nil {
 		_ = conn.Close()
 		return nil, fmt.Errorf("failed to send start event: %w", err)
 	}
 
-	// Create channels for audio chunks and errors
-	audioChan := make(chan []byte, 100)
-	errChan := make(chan error, 1)
-	doneChan := make(chan struct{})
+	session.run(textChan)
 
-	// Goroutine to send text chunks
-	go func() {
-		defer func() {
-			// Send close event
-			close := closeEvent{Event: "stop"}
-			if data, err := msgpack.Marshal(close); err == nil {
-				_ = conn.WriteMessage(websocket.BinaryMessage, data)
+	return session, nil
+}
+
+// wsSession owns a live TTS WebSocket connection and its goroutines.
+type wsSession struct {
+	ctx    context.Context
+	client *Client
+	conn   *websocket.Conn
+	// pooled is the SessionPool connection the session runs on, until its
+	// idle read result has been taken as the first frame.
+	pooled    *pooledConn
+	url       string
+	opts      *WebSocketOptions
+	keepalive bool
+
+	// group runs the session goroutines. runCtx is cancelled when any of
+	// them fails, the read side ends, the caller closes the stream, or ctx
+	// is cancelled.
+	group  *errgroup.Group
+	runCtx context.Context
+	cancel context.CancelFunc
+
+	// writeMu serializes data frame writes; gorilla allows one concurrent writer.
+	writeMu  sync.Mutex
+	stopOnce sync.Once
+
+	started  time.Time
+	sequence int
+	stats    *streamStats
+	// audioSeq orders audio frames numbered by the server.
+	audioSeq audioSequencer
+	pacer    pacer
+
+	audioChan chan []byte
+	errChan   chan error
+	// free holds audio buffers the stream has consumed, for reuse by
+	// readFrames. It is as deep as audioChan so a burst of consumed chunks
+	// can all be reused.
+	free chan []byte
+	// done is closed when all session goroutines have exited.
+	done chan struct{}
+	// closing is closed when the stream is closed by the caller.
+	closing   chan struct{}
+	closeOnce sync.Once
+
+	// finishing is closed when the caller stops accepting text via Finish.
+	finishing  chan struct{}
+	finishOnce sync.Once
+	// handoff is closed to stop reading text so a successor session can
+	// take over the text channel.
+	handoff     chan struct{}
+	handoffOnce sync.Once
+	// sendDone is closed when the send loop exits; the fields below are
+	// valid after.
+	sendDone   chan struct{}
+	unsent     []string
+	handedOff  bool
+	textClosed bool
+	// finished is set by the read loop when the finish event arrives;
+	// it is valid once done is closed.
+	finished bool
+
+	// frame, reader, decoder, and resp are reused by readFrames for every
+	// frame to avoid per-frame allocations.
+	frame   bytes.Buffer
+	reader  bytes.Reader
+	decoder *msgpack.Decoder
+	resp    wsResponse
+}
+
+// newWSSession wraps conn and configures keepalive handling.
+// Cancelling ctx tears down the session.
+func newWSSession(ctx context.Context, conn *websocket.Conn, pooled *pooledConn, opts *WebSocketOptions) *wsSession {
+	s := &wsSession{
+		ctx:       ctx,
+		conn:      conn,
+		pooled:    pooled,
+		opts:      opts,
+		keepalive: opts.PingInterval > 0,
+		started:   time.Now(),
+		audioChan: make(chan []byte, 100),
+		errChan:   make(chan error, 1),
+		free:      make(chan []byte, 100),
+		done:      make(chan struct{}),
+		closing:   make(chan struct{}),
+		finishing: make(chan struct{}),
+		handoff:   make(chan struct{}),
+		sendDone:  make(chan struct{}),
+		pacer:     pacer{rate: opts.MaxCharsPerSecond},
+		decoder:   msgpack.NewDecoder(nil),
+		audioSeq:  audioSequencer{window: max(opts.ReorderWindow, 0)},
+	}
+
+	s.stats = newStreamStats(s.started)
+
+	runCtx, cancel := context.WithCancel(ctx)
+	s.group, s.runCtx = errgroup.WithContext(runCtx)
+	s.cancel = cancel
+
+	// Expect a pong (or any message) within the read wait
+	var onPong func(string) error
+	if wait, _ := s.readWait(); wait > 0 {
+		_ = conn.SetReadDeadline(time.Now().Add(wait))
+		onPong = func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(wait))
+		}
+	}
+	if pooled != nil {
+		// The pool's idle read may still be running
+		pooled.setPongHandler(onPong)
+	} else if onPong != nil {
+		conn.SetPongHandler(onPong)
+	}
+
+	return s
+}
+
+// writeEvent encodes v in the configured wire format and sends it.
+// Msgpack events are sent as binary frames and JSON events as text frames.
+func (s *wsSession) writeEvent(v interface{}) error {
+	messageType := websocket.BinaryMessage
+	var data []byte
+	var err error
+	if s.opts.WireFormat == WireFormatJSON {
+		messageType = websocket.TextMessage
+		data, err = json.Marshal(v)
+	} else {
+		data, err = msgpack.Marshal(v)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	s.client.wire().frame(">", messageType, data)
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if s.opts.WriteTimeout > 0 {
+		_ = s.conn.SetWriteDeadline(time.Now().Add(s.opts.WriteTimeout))
+	}
+	if err := s.conn.WriteMessage(messageType, data); err != nil {
+		if isTimeout(err) {
+			return &TimeoutError{
+				WebSocketError: &WebSocketError{Message: fmt.Sprintf("write did not complete within %s", s.opts.WriteTimeout)},
+				Op:             "write",
 			}
-		}()
+		}
+		return err
+	}
+	return nil
+}
+
+// isTimeout reports whether err is a network timeout.
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isConnectionLost reports whether err means the connection ended without
+// a close frame, which WebSocket reports as an abnormal closure (1006).
+func isConnectionLost(err error) bool {
+	var closeErr *websocket.CloseError
+	if errors.As(err, &closeErr) {
+		return closeErr.Code == websocket.CloseAbnormalClosure
+	}
+	if isTimeout(err) {
+		return false
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// sendStop sends the stop event at most once.
+func (s *wsSession) sendStop() {
+	s.stopOnce.Do(func() {
+		_ = s.writeEvent(closeEvent{Event: "stop"})
+	})
+}
+
+// close sends the stop event, closes the connection, and stops all goroutines.
+func (s *wsSession) close() {
+	s.closeOnce.Do(func() {
+		close(s.closing)
+		s.sendStop()
+		s.cancel()
+		_ = s.conn.Close()
+	})
+}
+
+// run starts the session goroutines. The first error cancels the others,
+// and the session ends once all of them have returned.
+func (s *wsSession) run(textChan <-chan string) {
+	if s.keepalive {
+		s.group.Go(s.client.guard("websocket keepalive", s.keepAlive))
+	}
+	s.group.Go(s.client.guard("websocket send", func() error {
+		return s.sendLoop(textChan)
+	}))
+	s.group.Go(s.client.guard("websocket receive", func() error {
+		// The session is over once the server stops sending, even without an error
+		defer s.cancel()
+		return s.readFrames()
+	}))
+	s.group.Go(func() error {
+		// Closing the connection unblocks pending reads and writes
+		<-s.runCtx.Done()
+		_ = s.conn.Close()
+		return nil
+	})
+
+	go func() {
+		s.end(s.group.Wait())
+	}()
+}
+
+// sendLoop forwards text chunks to the server until textChan is closed.
+func (s *wsSession) sendLoop(textChan <-chan string) error {
+	defer close(s.sendDone)
+	defer s.sendStop()
 
-		for {
-			select {
-			case text, ok := <-textChan:
-				if !ok {
-					return
+	for {
+		select {
+		case text, ok := <-textChan:
+			if !ok {
+				s.textClosed = true
+				return nil
+			}
+			if !s.pace(text, textChan) {
+				return nil
+			}
+			var day string
+			if budget := s.client.budgetGuard(); budget != nil {
+				var err error
+				if day, err = budget.reserve(text); err != nil {
+					return err
 				}
-				evt := textEvent{Event: "text", Text: text}
-				data, err := msgpack.Marshal(evt)
-				if err != nil {
-					select {
-					case errChan <- fmt.Errorf("failed to marshal text event: %w", err):
-					default:
-					}
-					return
+			}
+			// Record before writing so a fast reply can't precede the segment
+			now := time.Now()
+			s.stats.textSent(text, now)
+			s.pacer.sent(text, now)
+			if err := s.writeEvent(textEvent{Event: "text", Text: text}); err != nil {
+				if budget := s.client.budgetGuard(); budget != nil {
+					budget.release(day, text)
 				}
-				if err := conn.WriteMessage(websocket.BinaryMessage, data); err != nil {
-					select {
-					case errChan <- fmt.Errorf("failed to send text: %w", err):
-					default:
-					}
-					return
+				if s.runCtx.Err() != nil {
+					// The session ended while writing; the cause is reported elsewhere
+					return nil
 				}
-			case <-doneChan:
-				return
+				return fmt.Errorf("failed to send text: %w", err)
 			}
+		case <-s.finishing:
+			s.unsent = drainText(textChan)
+			return nil
+		case <-s.handoff:
+			s.handedOff = true
+			return nil
+		case <-s.runCtx.Done():
+			return nil
 		}
-	}()
+	}
+}
 
-	// Goroutine to receive audio chunks
-	go func() {
-		defer close(audioChan)
-		defer func() { _ = conn.Close() }()
-		defer close(doneChan)
-
-		for {
-			_, data, err := conn.ReadMessage()
-			if err != nil {
-				// Handle normal closure and no-status-received (1005) as expected closures
-				// Server often closes without a formal close frame after sending finish event
-				if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseNoStatusReceived) {
-					return
-				}
-				select {
-				case errChan <- err:
-				default:
-				}
-				return
+// drainText returns the text chunks already queued in textChan without blocking.
+func drainText(textChan <-chan string) []string {
+	var unsent []string
+	for {
+		select {
+		case text, ok := <-textChan:
+			if !ok {
+				return unsent
 			}
+			unsent = append(unsent, text)
+		default:
+			return unsent
+		}
+	}
+}
+
+// end reports how the session ended once all its goroutines have exited.
+// err is the first error returned by any of them.
+func (s *wsSession) end(err error) {
+	closeCode := 0
+	var closeErr *websocket.CloseError
+	if errors.As(err, &closeErr) {
+		closeCode = closeErr.Code
+	}
+
+	// Handle normal closure and no-status-received (1005) as expected closures
+	// Server often closes without a formal close frame after sending finish event
+	if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseNoStatusReceived) {
+		err = nil
+	}
+
+	select {
+	case <-s.closing:
+		// Errors caused by the caller closing the stream are not reported
+		err = nil
+	default:
+		if ctxErr := s.ctx.Err(); ctxErr != nil {
+			err = ctxErr
+		}
+	}
+
+	if isConnectionLost(err) && !s.finished {
+		closeCode = websocket.CloseAbnormalClosure
+		stats := s.stats.snapshot()
+		err = &ConnectionLostError{
+			WebSocketError: &WebSocketError{Message: fmt.Sprintf("connection closed abnormally after %d audio chunks", stats.Chunks)},
+			PartialResult:  stats.Chunks > 0,
+			Chunks:         stats.Chunks,
+			Bytes:          stats.Bytes,
+			Err:            err,
+		}
+	} else if errors.Is(err, websocket.ErrReadLimit) {
+		err = &MessageTooLargeError{
+			WebSocketError: &WebSocketError{Message: fmt.Sprintf("message exceeds limit of %d bytes, increase MaxMessageSize", s.opts.MaxMessageSize)},
+			Limit:          s.opts.MaxMessageSize,
+		}
+	} else if err != nil {
+		err = s.timeoutError(err)
+	}
+
+	// Notify before the stream observes the end so callbacks have run
+	// by the time Next, Read, or Collect return
+	if s.opts.OnDisconnect != nil {
+		now := time.Now()
+		s.opts.OnDisconnect(ConnectionEvent{
+			URL:       s.url,
+			Time:      now,
+			Duration:  now.Sub(s.started),
+			CloseCode: closeCode,
+			Err:       err,
+		})
+	}
+
+	// errChan is buffered and this is its only send, so it never blocks
+	if err != nil {
+		s.errChan <- err
+	}
+	close(s.done)
+	close(s.audioChan)
+}
+
+// readFrames reads until the session ends and returns the error that ended
+// it, or nil when the server sent the finish event or the caller stopped.
+func (s *wsSession) readFrames() error {
+	for {
+		// Start the deadline when we begin waiting, not after the last frame,
+		// so time spent blocked on a slow consumer doesn't count
+		if wait, _ := s.readWait(); wait > 0 {
+			_ = s.conn.SetReadDeadline(time.Now().Add(wait))
+		}
+
+		messageType, data, err := s.readFrame()
+		receivedAt := time.Now()
+		if err != nil {
+			return err
+		}
+		s.client.wire().frame("<", messageType, data)
+
+		if s.opts.OnRawMessage != nil {
+			s.opts.OnRawMessage(RawMessage{
+				// The frame buffer is reused; callbacks may keep Data
+				Data:       bytes.Clone(data),
+				Text:       messageType == websocket.TextMessage,
+				ReceivedAt: receivedAt,
+			})
+		}
+
+		if err := s.decodeFrame(messageType, data); err != nil {
+			return err
+		}
+		resp := &s.resp
 
-			// Decode msgpack response
-			var resp wsResponse
-			if err := msgpack.Unmarshal(data, &resp); err != nil {
-				select {
-				case errChan <- fmt.Errorf("failed to decode response: %w", err):
-				default:
+		s.emit(resp, receivedAt)
+
+		switch resp.Event {
+		case "audio":
+			if resp.Seq == nil {
+				if err := s.deliver(resp.Audio, receivedAt); err != nil {
+					return err
 				}
-				return
+				break
+			}
+			ready, late := s.audioSeq.push(*resp.Seq, resp.Audio)
+			if late {
+				s.stats.lateChunk()
+			}
+			if err := s.deliverSequenced(ready, receivedAt); err != nil {
+				return err
 			}
+		case "finish":
+			if err := s.deliverSequenced(s.audioSeq.flush(), receivedAt); err != nil {
+				return err
+			}
+			s.finished = true
+			// "stop" is normal - means we requested the stop
+			// Only treat "error" as an actual error
+			if resp.Reason == "error" {
+				return resp.wsError()
+			}
+			return nil
+		}
+	}
+}
 
-			switch resp.Event {
-			case "audio":
-				if len(resp.Audio) > 0 {
-					audioChan <- resp.Audio
-				}
-			case "finish":
-				// "stop" is normal - means we requested the stop
-				// Only treat "error" as an actual error
-				if resp.Reason == "error" {
-					select {
-					case errChan <- &WebSocketError{Message: "stream finished with error"}:
-					default:
-					}
-				}
-				return
+// deliver passes an audio chunk to the stream.
+func (s *wsSession) deliver(audio []byte, receivedAt time.Time) error {
+	if len(audio) == 0 {
+		return nil
+	}
+	s.stats.audioReceived(len(audio), receivedAt)
+	select {
+	case s.audioChan <- audio:
+		return nil
+	case <-s.runCtx.Done():
+		return s.runCtx.Err()
+	}
+}
+
+// deliverSequenced passes reordered audio chunks to the stream, reporting
+// the gaps between them.
+func (s *wsSession) deliverSequenced(ready []sequencedChunk, receivedAt time.Time) error {
+	for _, chunk := range ready {
+		if chunk.gap == nil {
+			if err := s.deliver(chunk.audio, receivedAt); err != nil {
+				return err
 			}
+			continue
 		}
-	}()
+		s.stats.gap(chunk.gap.Missing())
+		if s.opts.FailOnGap {
+			return chunk.gap
+		}
+		if s.opts.OnEvent != nil {
+			s.opts.OnEvent(WebSocketEvent{
+				Type:       WebSocketEventDiscontinuity,
+				Sequence:   s.sequence,
+				Gap:        chunk.gap,
+				ReceivedAt: receivedAt,
+				Elapsed:    receivedAt.Sub(s.started),
+			})
+			s.sequence++
+		}
+	}
+	return nil
+}
 
-	return &WebSocketAudioStream{
-		audioChan: audioChan,
-		errChan:   errChan,
-	}, nil
+// wsFrame is the result of reading a frame.
+type wsFrame struct {
+	messageType int
+	data        []byte
+	err         error
+}
+
+// readFrame reads the next frame into the reused frame buffer. The data
+// is only valid until the next call.
+func (s *wsSession) readFrame() (int, []byte, error) {
+	if s.pooled != nil {
+		// The pool's idle read is the first read on the connection
+		f := <-s.pooled.read
+		s.pooled = nil
+		if f.err != nil {
+			return 0, nil, f.err
+		}
+		s.frame.Reset()
+		s.frame.Write(f.data)
+		return f.messageType, s.frame.Bytes(), nil
+	}
+
+	messageType, r, err := s.conn.NextReader()
+	if err != nil {
+		return 0, nil, err
+	}
+	s.frame.Reset()
+	if _, err := s.frame.ReadFrom(r); err != nil {
+		return 0, nil, err
+	}
+	return messageType, s.frame.Bytes(), nil
+}
+
+// timeoutError converts a read deadline expiry into a *TimeoutError.
+func (s *wsSession) timeoutError(err error) error {
+	wait, op := s.readWait()
+	if wait <= 0 || !isTimeout(err) {
+		return err
+	}
+	msg := fmt.Sprintf("no message received within %s", wait)
+	if op == "pong" {
+		msg = fmt.Sprintf("no pong received within %s", wait)
+	}
+	return &TimeoutError{
+		WebSocketError: &WebSocketError{Message: msg},
+		Op:             op,
+	}
+}
+
+// emit delivers resp to the OnEvent callback, if one is configured.
+func (s *wsSession) emit(resp *wsResponse, receivedAt time.Time) {
+	if s.opts.OnEvent == nil {
+		return
+	}
+	evt := WebSocketEvent{
+		Type:            WebSocketEventType(resp.Event),
+		Sequence:        s.sequence,
+		Audio:           resp.Audio,
+		Reason:          resp.Reason,
+		Words:           resp.wordBoundaries(),
+		ServerTimestamp: resp.Timestamp,
+		ReceivedAt:      receivedAt,
+		Elapsed:         receivedAt.Sub(s.started),
+	}
+	s.sequence++
+	s.opts.OnEvent(evt)
+}
+
+// pongWait returns how long to wait for a pong after the last read.
+func (s *wsSession) pongWait() time.Duration {
+	return s.opts.PingInterval + s.opts.PingTimeout
+}
+
+// readWait returns the deadline applied to each read and the operation
+// reported when it expires: the shorter of ReadTimeout and the pong wait.
+// A zero duration means reads never time out.
+func (s *wsSession) readWait() (time.Duration, string) {
+	if s.keepalive && (s.opts.ReadTimeout <= 0 || s.pongWait() <= s.opts.ReadTimeout) {
+		return s.pongWait(), "pong"
+	}
+	return s.opts.ReadTimeout, "read"
+}
+
+// keepAlive sends pings every PingInterval until the session ends.
+func (s *wsSession) keepAlive() error {
+	ticker := time.NewTicker(s.opts.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			writeWait := s.opts.PingTimeout
+			if writeWait <= 0 {
+				writeWait = s.opts.PingInterval
+			}
+			// WriteControl is safe to call concurrently with the text writer
+			// A failed ping means the connection is broken, which the read
+			// side reports with more detail, so it isn't an error here
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				return nil
+			}
+		case <-s.runCtx.Done():
+			return nil
+		}
+	}
 }
 
 // WebSocketAudioStream wraps WebSocket audio chunks for iteration.
 type WebSocketAudioStream struct {
 	audioChan <-chan []byte
 	errChan   <-chan error
+	session   *wsSession
 	buf       []byte
+	chunk     []byte
 	err       error
+	eof       bool
 	closed    bool
 	mu        sync.Mutex
+
+	// Fields below support restarting with new parameters; see SetVoice.
+	tts      *TTSService
+	ctx      context.Context
+	textChan <-chan string
+	opts     *WebSocketOptions
+
+	// restartMu serializes restarts and Finish.
+	restartMu sync.Mutex
+
+	// chainMu guards the fields below and writes to session.
+	chainMu sync.Mutex
+	// chain holds every session started for this stream, in order.
+	chain      []*wsSession
+	params     StreamParams
+	restarting chan struct{}
+	restartErr error
+	stopped    bool
+}
+
+// closing returns a channel that is closed once Close is called.
+// It is nil (never ready) for streams without a live session.
+func (s *WebSocketAudioStream) closing() <-chan struct{} {
+	if s.session == nil {
+		return nil
+	}
+	return s.session.closing
 }
 
 // Next advances to the next chunk of audio data.
 // Returns false when there are no more chunks or an error occurred.
+//
+// With WithChunkReuse, chunk buffers are reused, so the slice returned by
+// Bytes is only valid until the next call to Next or Read. Copy it to keep
+// it longer.
 func (s *WebSocketAudioStream) Next() bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if s.closed || s.err != nil {
+	if s.closed || s.eof || s.err != nil {
 		return false
 	}
 
-	select {
-	case chunk, ok := <-s.audioChan:
-		if !ok {
-			s.closed = true
+	s.recycle()
+	chunk, ok, err := s.receiveChained()
+	if !ok {
+		if errors.Is(err, ErrStreamClosed) {
 			return false
 		}
-		s.buf = chunk
-		return true
-	case err := <-s.errChan:
+		if err == nil {
+			s.eof = true
+		}
 		s.err = err
 		return false
 	}
+	s.buf = chunk
+	s.chunk = chunk
+	return true
+}
+
+// recycle hands the consumed chunk back to the session so readFrames can
+// decode into it, if WithChunkReuse is set. Chunks are not reused when
+// OnEvent is set, since events share them and callbacks may keep them.
+// The caller must hold s.mu.
+func (s *WebSocketAudioStream) recycle() {
+	chunk := s.chunk
+	s.chunk, s.buf = nil, nil
+	if chunk == nil || s.session == nil || !s.session.client.chunkReuse() || s.session.opts.OnEvent != nil {
+		return
+	}
+	select {
+	case s.session.free <- chunk:
+	default:
+	}
+}
+
+// receiveChained receives from the current session and moves on to its
+// successor when it ends after a restart.
+func (s *WebSocketAudioStream) receiveChained() ([]byte, bool, error) {
+	for {
+		chunk, ok, err := s.receive()
+		if ok || err != nil {
+			return chunk, ok, err
+		}
+		advanced, err := s.advance()
+		if !advanced {
+			return nil, false, err
+		}
+	}
+}
+
+// receive waits for the next chunk. Audio queued before an error is always
+// delivered first. It returns ok=false with a nil error at end of stream.
+func (s *WebSocketAudioStream) receive() (chunk []byte, ok bool, err error) {
+	select {
+	case chunk, ok := <-s.audioChan:
+		return s.received(chunk, ok)
+	default:
+	}
+
+	select {
+	case chunk, ok := <-s.audioChan:
+		return s.received(chunk, ok)
+	case err := <-s.errChan:
+		return nil, false, err
+	case <-s.closing():
+		return nil, false, ErrStreamClosed
+	}
+}
+
+// received interprets a receive from audioChan. The session reports
+// errors before closing audioChan, so a closed channel checks for one.
+func (s *WebSocketAudioStream) received(chunk []byte, ok bool) ([]byte, bool, error) {
+	if !ok {
+		return nil, false, s.pendingErr()
+	}
+	return chunk, true, nil
+}
+
+// pendingErr returns a queued error without blocking, or nil.
+func (s *WebSocketAudioStream) pendingErr() error {
+	select {
+	case err := <-s.errChan:
+		return err
+	default:
+		return nil
+	}
+}
+
+// Stats returns latency and throughput statistics collected so far.
+// After a restart, the statistics describe the current session only.
+func (s *WebSocketAudioStream) Stats() WebSocketStats {
+	s.chainMu.Lock()
+	session := s.session
+	s.chainMu.Unlock()
+
+	if session == nil {
+		return WebSocketStats{}
+	}
+	stats := session.stats.snapshot()
+	stats.QueuedTexts += len(s.textChan)
+	return stats
 }
 
 // Bytes returns the current chunk of audio data.
+// With WithChunkReuse, it is only valid until the next call to Next or Read.
 func (s *WebSocketAudioStream) Bytes() []byte {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -461,17 +1493,22 @@ func (s *WebSocketAudioStream) Collect() ([]byte, error) {
 	for s.Next() {
 		buf.Write(s.Bytes())
 	}
-	if s.err != nil {
-		return nil, s.err
+	if err := s.Err(); err != nil {
+		return nil, err
 	}
 	return buf.Bytes(), nil
 }
 
 // Read implements io.Reader interface.
+// After Close, Read returns ErrStreamClosed.
 func (s *WebSocketAudioStream) Read(p []byte) (n int, err error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if s.closed {
+		return 0, ErrStreamClosed
+	}
+
 	// If we have buffered data, return it
 	if len(s.buf) > 0 {
 		n = copy(p, s.buf)
@@ -480,26 +1517,124 @@ func (s *WebSocketAudioStream) Read(p []byte) (n int, err error) {
 	}
 
 	// Try to get more data
-	select {
-	case chunk, ok := <-s.audioChan:
-		if !ok {
+	s.recycle()
+	chunk, ok, err := s.receiveChained()
+	if !ok {
+		if err == nil {
 			return 0, io.EOF
 		}
-		n = copy(p, chunk)
-		if n < len(chunk) {
-			s.buf = chunk[n:]
+		if !errors.Is(err, ErrStreamClosed) {
+			s.err = err
 		}
-		return n, nil
-	case err := <-s.errChan:
-		s.err = err
 		return 0, err
 	}
+	n = copy(p, chunk)
+	s.chunk = chunk
+	s.buf = chunk[n:]
+	return n, nil
+}
+
+// FinishResult reports the outcome of WebSocketAudioStream.Finish.
+type FinishResult struct {
+	// Audio is the audio received while draining the session.
+	Audio []byte
+	// Finished reports whether the server's finish event arrived in time.
+	Finished bool
+	// UnsentTexts are text chunks that were still queued in the text channel
+	// when Finish was called and were never sent for synthesis.
+	UnsentTexts []string
+	// UnsentChars is the total number of characters in UnsentTexts.
+	UnsentChars int
+}
+
+// Finish stops accepting text, sends the stop event, and drains the
+// remaining audio until the server's finish event arrives or ctx is done.
+//
+// Text still buffered in the text channel is not sent; it is reported in
+// the result instead. Producers should stop sending before calling Finish,
+// since the channel is no longer read afterwards.
+//
+// If ctx expires first, the stream is closed and the partial result is
+// returned along with ctx.Err().
+func (s *WebSocketAudioStream) Finish(ctx context.Context) (*FinishResult, error) {
+	result := &FinishResult{}
+
+	// Stop the session currently reading text; wait out any restart first
+	s.restartMu.Lock()
+	s.chainMu.Lock()
+	var session *wsSession
+	if len(s.chain) > 0 {
+		session = s.chain[len(s.chain)-1]
+	}
+	s.chainMu.Unlock()
+	if session != nil {
+		session.finishOnce.Do(func() { close(session.finishing) })
+	}
+	s.restartMu.Unlock()
+
+	if session == nil {
+		audio, err := s.Collect()
+		result.Audio = audio
+		result.Finished = err == nil
+		return result, err
+	}
+
+	var audio bytes.Buffer
+	collected := make(chan error, 1)
+	go func() {
+		collected <- s.tts.client.guard("websocket finish", func() error {
+			for s.Next() {
+				audio.Write(s.Bytes())
+			}
+			return s.Err()
+		})()
+	}()
+
+	var err error
+	select {
+	case err = <-collected:
+	case <-ctx.Done():
+		_ = s.Close()
+		<-collected
+		err = ctx.Err()
+	}
+
+	// Both loops exit once the connection is closed, which has happened by now
+	<-session.sendDone
+	result.UnsentTexts = session.unsent
+	for _, text := range result.UnsentTexts {
+		result.UnsentChars += len([]rune(text))
+	}
+	select {
+	case <-session.done:
+		result.Finished = session.finished && err == nil
+	default:
+	}
+	result.Audio = audio.Bytes()
+
+	return result, err
 }
 
-// Close closes the stream.
+// Close terminates the stream. It sends the stop event, closes the
+// underlying connection, and stops the background goroutines.
+// Subsequent calls to Read return ErrStreamClosed.
 func (s *WebSocketAudioStream) Close() error {
+	// Signal the sessions first so a blocked Next or Read releases the lock
+	s.chainMu.Lock()
+	s.stopped = true
+	sessions := s.chain
+	if len(sessions) == 0 && s.session != nil {
+		sessions = []*wsSession{s.session}
+	}
+	s.chainMu.Unlock()
+	for _, session := range sessions {
+		session.close()
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.closed = true
+	s.buf = nil
+	s.chunk = nil
 	return nil
 }
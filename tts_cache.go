@@ -0,0 +1,32 @@
+package fishaudio
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// TTSCache stores synthesized audio for TTS.Convert, keyed by a hash of
+// the model and every request parameter. Implementations must be safe for
+// concurrent use.
+//
+// Cache errors never fail a request: a failed Get is treated as a miss and
+// a failed Set is ignored. Sampled requests (temperature or top_p above
+// zero) return the cached audio rather than a new sample.
+type TTSCache interface {
+	// Get returns the audio stored under key and whether it was found.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores audio under key.
+	Set(ctx context.Context, key string, audio []byte) error
+}
+
+// ttsCacheKey returns the cache key of a TTS request.
+func ttsCacheKey(model Model, req *ttsRequest) string {
+	h := sha256.New()
+	h.Write([]byte(model))
+	h.Write([]byte{0})
+	// Encoding a ttsRequest can't fail
+	_ = json.NewEncoder(h).Encode(req)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,72 @@
+package fishaudio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// mapCache is an in-memory TTSCache.
+type mapCache struct {
+	mu    sync.Mutex
+	audio map[string][]byte
+}
+
+func (c *mapCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	audio, ok := c.audio[key]
+	return audio, ok, nil
+}
+
+func (c *mapCache) Set(ctx context.Context, key string, audio []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.audio[key] = audio
+	return nil
+}
+
+func TestTTSService_Convert_Cache(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte("audio"))
+	}))
+	defer server.Close()
+
+	cache := &mapCache{audio: map[string][]byte{}}
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithTTSCache(cache))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		audio, err := client.TTS.Convert(ctx, &ConvertParams{Text: "Hello", ReferenceID: "voice"})
+		if err != nil || string(audio) != "audio" {
+			t.Fatalf("Convert() = %q, %v", audio, err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1 with a cache hit", got)
+	}
+
+	_, _ = client.TTS.Convert(ctx, &ConvertParams{Text: "Hello", ReferenceID: "voice", Model: ModelS1})
+	_, _ = client.TTS.Convert(ctx, &ConvertParams{Text: "Hello", ReferenceID: "voice", Speed: 1.2})
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests = %d, want 3 for different model and speed", got)
+	}
+}
+
+func TestTTSCacheKey(t *testing.T) {
+	a := ttsCacheKey(ModelS1, &ttsRequest{Text: "Hi", Format: AudioFormatMP3})
+	if b := ttsCacheKey(ModelS1, &ttsRequest{Text: "Hi", Format: AudioFormatMP3}); a != b {
+		t.Error("equal requests have different keys")
+	}
+	if b := ttsCacheKey(ModelS1, &ttsRequest{Text: "Hi", Format: AudioFormatWAV}); a == b {
+		t.Error("different formats have the same key")
+	}
+	if b := ttsCacheKey(ModelS2Pro, &ttsRequest{Text: "Hi", Format: AudioFormatMP3}); a == b {
+		t.Error("different models have the same key")
+	}
+}
@@ -0,0 +1,244 @@
+package fishaudio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadTTSConfig reads TTS defaults from a JSON or YAML file, chosen by the
+// .json, .yaml, or .yml extension. Keys are the JSON names of TTSConfig
+// fields, such as reference_id and sample_rate.
+//
+// After parsing, ${NAME} in string values is replaced with the environment
+// variable NAME and ${NAME:-default} with default if NAME is unset or
+// empty; $$ is a literal $. Keys and comments are left alone, and a value
+// is never parsed as part of the file, so quotes or newlines in it are
+// kept as is. Unquoted YAML values are typed after expansion, so
+// "sample_rate: ${RATE}" sets a number. The file is rejected with a
+// ValidationError if it uses an unset variable without a default, has an
+// unknown key, or sets a value out of range.
+//
+// Example config.yaml:
+//
+//	reference_id: ${FISH_VOICE_ID}
+//	model: s2-pro
+//	format: ${FISH_FORMAT:-mp3}
+//	prosody:
+//	  speed: 1.1
+func LoadTTSConfig(path string) (*TTSConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := parseTTSConfig(data, filepath.Ext(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// parseTTSConfig parses a config file with the given extension.
+func parseTTSConfig(data []byte, ext string) (*TTSConfig, error) {
+	env := &envExpander{}
+	var doc interface{}
+	switch strings.ToLower(ext) {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&doc); err != nil {
+			return nil, err
+		}
+		doc = env.expandJSON(doc)
+	case ".yaml", ".yml":
+		// Convert to JSON so both formats share the JSON field names and
+		// the unknown key check
+		var root yaml.Node
+		if err := yaml.Unmarshal(data, &root); err != nil {
+			return nil, err
+		}
+		env.expandYAML(&root)
+		if err := root.Decode(&doc); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, newValidationError("unsupported config format %q: use .json, .yaml, or .yml", ext)
+	}
+	if err := env.err(); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		doc = map[string]interface{}{}
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg TTSConfig
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		if strings.HasPrefix(err.Error(), "json: unknown field") {
+			return nil, newValidationError("%s", strings.TrimPrefix(err.Error(), "json: "))
+		}
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// envExpander expands environment variables in the string values of a
+// parsed config, collecting the names of unset variables.
+type envExpander struct {
+	missing []string
+}
+
+// expandJSON expands the string values of a decoded JSON document.
+func (e *envExpander) expandJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return e.expand(v)
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = e.expandJSON(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = e.expandJSON(value)
+		}
+	}
+	return v
+}
+
+// expandYAML expands the string values of a YAML node tree. Plain scalars
+// are retyped from their expanded value, as if it had been written there.
+func (e *envExpander) expandYAML(n *yaml.Node) {
+	switch n.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range n.Content {
+			e.expandYAML(child)
+		}
+	case yaml.MappingNode:
+		// Content alternates keys and values
+		for i := 1; i < len(n.Content); i += 2 {
+			e.expandYAML(n.Content[i])
+		}
+	case yaml.ScalarNode:
+		if n.ShortTag() != "!!str" || !strings.Contains(n.Value, "$") {
+			return
+		}
+		n.Value = e.expand(n.Value)
+		if n.Style == 0 {
+			n.Tag = ""
+		}
+	}
+}
+
+// expand replaces ${NAME} and ${NAME:-default} in s with environment
+// variables and $$ with $. Any other $ is kept.
+func (e *envExpander) expand(s string) string {
+	var out strings.Builder
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '$')
+		if i < 0 || i+1 == len(s) {
+			out.WriteString(s)
+			break
+		}
+		out.WriteString(s[:i])
+		s = s[i:]
+
+		switch s[1] {
+		case '$':
+			out.WriteByte('$')
+			s = s[2:]
+			continue
+		case '{':
+			end := strings.IndexByte(s, '}')
+			if end < 0 {
+				break
+			}
+			name, def, hasDefault := strings.Cut(s[2:end], ":-")
+			value := os.Getenv(name)
+			if value == "" {
+				if !hasDefault {
+					e.missing = append(e.missing, name)
+				}
+				value = def
+			}
+			out.WriteString(value)
+			s = s[end+1:]
+			continue
+		}
+		out.WriteByte('$')
+		s = s[1:]
+	}
+	return out.String()
+}
+
+// err returns a ValidationError naming the unset variables, if any.
+func (e *envExpander) err() error {
+	if len(e.missing) == 0 {
+		return nil
+	}
+	// JSON objects are walked in random order
+	sort.Strings(e.missing)
+	return newValidationError("environment variables not set: %s", strings.Join(slices.Compact(e.missing), ", "))
+}
+
+// validate returns a ValidationError listing the fields of c that are out
+// of range. Zero values are defaults and always valid.
+func (c *TTSConfig) validate() error {
+	var problems []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	switch c.Format {
+	case "", AudioFormatMP3, AudioFormatWAV, AudioFormatPCM, AudioFormatOpus:
+	default:
+		check(false, "unknown format %q", c.Format)
+	}
+	switch c.Latency {
+	case "", LatencyNormal, LatencyBalanced:
+	default:
+		check(false, "unknown latency %q", c.Latency)
+	}
+	check(c.SampleRate >= 0, "sample_rate %d must not be negative", c.SampleRate)
+	switch c.MP3Bitrate {
+	case 0, 64, 128, 192:
+	default:
+		check(false, "mp3_bitrate %d must be 64, 128, or 192", c.MP3Bitrate)
+	}
+	switch c.OpusBitrate {
+	case 0, -1000, 24, 32, 48, 64:
+	default:
+		check(false, "opus_bitrate %d must be -1000, 24, 32, 48, or 64", c.OpusBitrate)
+	}
+	check(c.ChunkLength == 0 || c.ChunkLength >= 100 && c.ChunkLength <= 300, "chunk_length %d must be 100-300", c.ChunkLength)
+	check(c.TopP >= 0 && c.TopP <= 1, "top_p %v must be 0.0-1.0", c.TopP)
+	check(c.Temperature >= 0 && c.Temperature <= 1, "temperature %v must be 0.0-1.0", c.Temperature)
+	if p := c.Prosody; p != nil {
+		check(p.Speed == 0 || p.Speed >= 0.5 && p.Speed <= 2, "prosody.speed %v must be 0.5-2.0", p.Speed)
+		check(p.Volume >= -20 && p.Volume <= 20, "prosody.volume %v must be -20.0-20.0", p.Volume)
+	}
+	if err := c.Language.validate(); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if len(problems) > 0 {
+		return newValidationError("invalid TTS config: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
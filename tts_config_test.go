@@ -0,0 +1,172 @@
+package fishaudio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadTTSConfig(t *testing.T) {
+	t.Setenv("TEST_VOICE_ID", "voice-1")
+	t.Setenv("TEST_FORMAT", "")
+
+	yamlPath := writeConfig(t, "tts.yaml", `
+# Narration defaults
+reference_id: ${TEST_VOICE_ID}
+format: ${TEST_FORMAT:-wav}
+model: s1
+sample_rate: 24000
+prosody:
+  speed: 1.1
+language: ja
+`)
+	jsonPath := writeConfig(t, "tts.json", `{
+		"reference_id": "${TEST_VOICE_ID}",
+		"format": "${TEST_FORMAT:-wav}",
+		"model": "s1",
+		"sample_rate": 24000,
+		"prosody": {"speed": 1.1},
+		"language": "ja"
+	}`)
+
+	for _, path := range []string{yamlPath, jsonPath} {
+		cfg, err := LoadTTSConfig(path)
+		if err != nil {
+			t.Fatalf("LoadTTSConfig(%s) error = %v", filepath.Ext(path), err)
+		}
+		if cfg.ReferenceID != "voice-1" || cfg.Format != AudioFormatWAV || cfg.Model != ModelS1 || cfg.SampleRate != 24000 ||
+			cfg.Prosody == nil || cfg.Prosody.Speed != 1.1 || cfg.Language != LanguageJapanese {
+			t.Errorf("LoadTTSConfig(%s) = %+v", filepath.Ext(path), cfg)
+		}
+	}
+}
+
+func TestLoadTTSConfig_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    string
+	}{
+		{"unset variable", "a.yaml", "reference_id: ${TEST_UNSET_VOICE}", "TEST_UNSET_VOICE"},
+		{"unset variables", "a.json", `{"reference_id": "${TEST_UNSET_B}", "model": "${TEST_UNSET_A}${TEST_UNSET_B}"}`, "not set: TEST_UNSET_A, TEST_UNSET_B"},
+		{"negative sample rate", "a.yaml", "sample_rate: -1", "sample_rate -1 must not be negative"},
+		{"unknown key", "a.yaml", "voice: abc", `unknown field "voice"`},
+		{"out of range", "a.json", `{"temperature": 1.5, "chunk_length": 50, "format": "aac"}`, `unknown format "aac"; chunk_length 50 must be 100-300; temperature 1.5`},
+		{"prosody", "a.yml", "prosody: {speed: 3}", "prosody.speed 3"},
+		{"format", "a.toml", "model = 's1'", "unsupported config format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadTTSConfig(writeConfig(t, tt.file, tt.content))
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadTTSConfig() error = %v, want a ValidationError containing %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := LoadTTSConfig(filepath.Join(t.TempDir(), "missing.yaml")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("LoadTTSConfig(missing) error = %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestEnvExpander(t *testing.T) {
+	t.Setenv("TEST_NAME", "fish")
+	var env envExpander
+	got := env.expand("a ${TEST_NAME} $$HOME $5 ${TEST_UNSET_NAME:-x} ${TEST_UNSET_NAME:-} ${open $")
+	if want := "a fish $HOME $5 x  ${open $"; got != want {
+		t.Errorf("expand() = %q, want %q", got, want)
+	}
+	if err := env.err(); err != nil {
+		t.Errorf("err() = %v, want nil", err)
+	}
+}
+
+func TestLoadTTSConfig_EnvValues(t *testing.T) {
+	const voice = "a\"b\\c\nd: e # f"
+	t.Setenv("TEST_VOICE_ID", voice)
+	t.Setenv("TEST_RATE", "16000")
+	t.Setenv("TEST_INJECT", `x", "model": "s1`)
+
+	yamlPath := writeConfig(t, "tts.yaml", `
+# reference_id: ${TEST_COMMENTED_OUT}
+reference_id: ${TEST_VOICE_ID}
+sample_rate: ${TEST_RATE}
+format: "${TEST_UNSET_FORMAT:-wav}" # default ${TEST_COMMENTED_OUT}
+`)
+	jsonPath := writeConfig(t, "tts.json", `{
+		"reference_id": "${TEST_VOICE_ID}",
+		"sample_rate": 16000,
+		"format": "${TEST_UNSET_FORMAT:-wav}"
+	}`)
+	for _, path := range []string{yamlPath, jsonPath} {
+		cfg, err := LoadTTSConfig(path)
+		if err != nil {
+			t.Fatalf("LoadTTSConfig(%s) error = %v", filepath.Ext(path), err)
+		}
+		if cfg.ReferenceID != voice || cfg.SampleRate != 16000 || cfg.Format != AudioFormatWAV {
+			t.Errorf("LoadTTSConfig(%s) = %+v", filepath.Ext(path), cfg)
+		}
+	}
+
+	// A value can't add keys, in either format
+	for _, path := range []string{
+		writeConfig(t, "inject.json", `{"reference_id": "${TEST_INJECT}"}`),
+		writeConfig(t, "inject.yaml", `reference_id: ${TEST_INJECT}`),
+	} {
+		cfg, err := LoadTTSConfig(path)
+		if err != nil || cfg.ReferenceID != `x", "model": "s1` || cfg.Model != "" {
+			t.Errorf("LoadTTSConfig(%s) = %+v, %v, want the value kept as a string", filepath.Ext(path), cfg, err)
+		}
+	}
+}
+
+func TestWithConfigFile(t *testing.T) {
+	var got ttsRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = ttsRequest{}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte("audio"))
+	}))
+	defer server.Close()
+
+	path := writeConfig(t, "tts.yaml", "reference_id: voice-1\ntemperature: 0.4\nsample_rate: 16000\n")
+	presets := NewPresets(Preset{Name: "calm", Config: TTSConfig{Temperature: 0.2}})
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithConfigFile(path), WithPresets(presets))
+	ctx := context.Background()
+
+	if _, err := client.TTS.Convert(ctx, &ConvertParams{Text: "Hi", Config: &TTSConfig{SampleRate: 44100}}); err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if got.ReferenceID != "voice-1" || got.Temperature != 0.4 || got.SampleRate != 44100 {
+		t.Errorf("request = %+v, want config file defaults under Config", got)
+	}
+
+	if _, err := client.TTS.Convert(ctx, &ConvertParams{Text: "Hi", PresetName: "calm"}); err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if got.ReferenceID != "voice-1" || got.Temperature != 0.2 {
+		t.Errorf("request = %+v, want preset over config file defaults", got)
+	}
+
+	bad := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithConfigFile(filepath.Join(t.TempDir(), "missing.yaml")))
+	if _, err := bad.TTS.Convert(ctx, &ConvertParams{Text: "Hi"}); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Convert() with a missing config file error = %v, want os.ErrNotExist", err)
+	}
+}
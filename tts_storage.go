@@ -0,0 +1,240 @@
+package fishaudio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// BlobWriter uploads audio to object storage such as S3, Google Cloud
+// Storage, or Azure Blob Storage. The cloudstorage module has writers
+// built on each provider's SDK; PresignedURLWriter needs no SDK.
+type BlobWriter interface {
+	// WriteBlob uploads everything read from r as one object. It must
+	// stop reading r when it returns.
+	WriteBlob(ctx context.Context, r io.Reader, contentType string) error
+}
+
+// BlobStatusError is returned by BlobWriters when the storage service
+// rejects an upload. Rate limits and server errors are retried by
+// ConvertToStorage.
+type BlobStatusError struct {
+	StatusCode int
+	Body       string
+	// Err is the storage SDK's error, if any.
+	Err error
+}
+
+func (e *BlobStatusError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("upload failed: HTTP %d: %v", e.StatusCode, e.Err)
+	}
+	return fmt.Sprintf("upload failed: HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+func (e *BlobStatusError) Unwrap() error { return e.Err }
+
+// Temporary reports whether the upload may succeed if retried.
+func (e *BlobStatusError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// PresignedURLWriter is a BlobWriter that uploads with an HTTP PUT to a
+// presigned URL: an S3 presigned URL, a GCS signed URL, or an Azure SAS
+// URL. The audio is buffered in memory because S3 and Azure require a
+// Content-Length.
+//
+// Example for Azure:
+//
+//	writer := &fishaudio.PresignedURLWriter{
+//	    URL:    sasURL,
+//	    Header: http.Header{"X-Ms-Blob-Type": {"BlockBlob"}},
+//	}
+type PresignedURLWriter struct {
+	// URL is the presigned upload URL (required).
+	URL string
+	// Header holds extra request headers, such as those the URL was
+	// signed with.
+	Header http.Header
+	// HTTPClient sends the upload. Default: http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// WriteBlob implements BlobWriter.
+func (w *PresignedURLWriter) WriteBlob(ctx context.Context, r io.Reader, contentType string) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range w.Header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	client := w.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return &BlobStatusError{StatusCode: resp.StatusCode, Body: string(msg)}
+	}
+	return nil
+}
+
+// ConvertToFile synthesizes speech and writes the audio to the named file
+// with the client's Storage. The file is only created once synthesis has
+// succeeded.
+//
+// Example:
+//
+//	err := client.TTS.ConvertToFile(ctx, &fishaudio.ConvertParams{Text: "Hello"}, "hello.mp3")
+func (s *TTSService) ConvertToFile(ctx context.Context, params *ConvertParams, name string) error {
+	audio, err := s.Convert(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	f, err := s.client.files().Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(audio); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Retry settings of ConvertToStorage, variables for tests.
+var (
+	storageMaxRetries = 2
+	storageRetryDelay = time.Second
+)
+
+// ConvertToStorage synthesizes speech and streams the audio to object
+// storage as it is generated, without holding it all in memory.
+//
+// A failed attempt is retried up to twice when the error is transient. The
+// audio is spooled to a temporary file while it streams, so an upload
+// that fails after synthesis finished is retried from the file instead of
+// synthesizing, and paying for, the audio again.
+//
+// Example:
+//
+//	err := client.TTS.ConvertToStorage(ctx, &fishaudio.ConvertParams{
+//	    Text:   chapter,
+//	    Format: fishaudio.AudioFormatMP3,
+//	}, &fishaudio.PresignedURLWriter{URL: presignedPutURL})
+func (s *TTSService) ConvertToStorage(ctx context.Context, params *ConvertParams, w BlobWriter) error {
+	spool, err := os.CreateTemp("", "fishaudio-tts-*")
+	if err != nil {
+		return fmt.Errorf("failed to create spool file: %w", err)
+	}
+	defer func() {
+		_ = spool.Close()
+		_ = os.Remove(spool.Name())
+	}()
+
+	contentType := params.Format.ContentType()
+	if params.Format == "" && params.Config != nil {
+		contentType = params.Config.Format.ContentType()
+	}
+
+	synthesized := false
+	delay := storageRetryDelay
+	for attempt := 0; ; attempt++ {
+		if synthesized {
+			if _, err = spool.Seek(0, io.SeekStart); err == nil {
+				err = w.WriteBlob(ctx, spool, contentType)
+			}
+		} else {
+			synthesized, err = s.streamToBlob(ctx, params, w, spool, contentType)
+		}
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= storageMaxRetries || !retryableUpload(err) {
+			return err
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		delay *= 2
+	}
+}
+
+// streamToBlob synthesizes audio and uploads it while copying it to
+// spool. It reports whether the whole audio reached the spool.
+func (s *TTSService) streamToBlob(ctx context.Context, params *ConvertParams, w BlobWriter, spool *os.File, contentType string) (bool, error) {
+	if err := spool.Truncate(0); err != nil {
+		return false, err
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	stream, err := s.Stream(ctx, params.streamParams())
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = stream.Close() }()
+
+	tee := &spoolReader{r: stream, spool: spool}
+	err = w.WriteBlob(ctx, tee, contentType)
+	return tee.complete, err
+}
+
+// spoolReader copies what is read from r to spool and records whether r
+// was read to the end.
+type spoolReader struct {
+	r        io.Reader
+	spool    io.Writer
+	complete bool
+	err      error
+}
+
+func (r *spoolReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 && r.err == nil {
+		if _, werr := r.spool.Write(p[:n]); werr != nil {
+			r.err = werr
+		}
+	}
+	if err == io.EOF && r.err == nil {
+		r.complete = true
+	}
+	return n, err
+}
+
+// retryableUpload reports whether a failed synthesis or upload may
+// succeed if retried.
+func retryableUpload(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if isTransient(err) {
+		return true
+	}
+	var temporary interface{ Temporary() bool }
+	return errors.As(err, &temporary) && temporary.Temporary()
+}
@@ -0,0 +1,166 @@
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blobServer records PUT uploads, failing them with the given statuses in
+// order before accepting.
+type blobServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	failures []int
+	uploads  []string
+	header   http.Header
+}
+
+func newBlobServer(failures ...int) *blobServer {
+	s := &blobServer{failures: failures}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.uploads = append(s.uploads, string(body))
+		s.header = r.Header.Clone()
+		if len(s.failures) > 0 {
+			status := s.failures[0]
+			s.failures = s.failures[1:]
+			http.Error(w, "nope", status)
+		}
+	}))
+	return s
+}
+
+func withFastStorageRetries(t *testing.T) {
+	delay := storageRetryDelay
+	storageRetryDelay = time.Millisecond
+	t.Cleanup(func() { storageRetryDelay = delay })
+}
+
+func TestPresignedURLWriter(t *testing.T) {
+	blobs := newBlobServer()
+	defer blobs.Close()
+
+	w := &PresignedURLWriter{URL: blobs.URL + "/bucket/key", Header: http.Header{"X-Ms-Blob-Type": {"BlockBlob"}}}
+	if err := w.WriteBlob(context.Background(), strings.NewReader("audio"), "audio/mpeg"); err != nil {
+		t.Fatalf("WriteBlob() error = %v", err)
+	}
+	if blobs.uploads[0] != "audio" {
+		t.Errorf("upload = %q", blobs.uploads[0])
+	}
+	if blobs.header.Get("Content-Type") != "audio/mpeg" || blobs.header.Get("X-Ms-Blob-Type") != "BlockBlob" {
+		t.Errorf("headers = %v", blobs.header)
+	}
+}
+
+func TestPresignedURLWriter_Error(t *testing.T) {
+	blobs := newBlobServer(http.StatusForbidden)
+	defer blobs.Close()
+
+	err := (&PresignedURLWriter{URL: blobs.URL}).WriteBlob(context.Background(), strings.NewReader("audio"), "audio/mpeg")
+	var statusErr *BlobStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusForbidden || statusErr.Temporary() {
+		t.Errorf("WriteBlob() err = %v, want permanent *BlobStatusError 403", err)
+	}
+}
+
+func newCountingTTSServer(failures ...int) (*httptest.Server, *int) {
+	var mu sync.Mutex
+	requests := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		n := requests
+		mu.Unlock()
+		if n <= len(failures) {
+			w.WriteHeader(failures[n-1])
+			return
+		}
+		_, _ = w.Write([]byte("synthesized audio"))
+	})), &requests
+}
+
+func TestTTSService_ConvertToStorage_RetriesUploadFromSpool(t *testing.T) {
+	withFastStorageRetries(t)
+	tts, requests := newCountingTTSServer()
+	defer tts.Close()
+	blobs := newBlobServer(http.StatusServiceUnavailable)
+	defer blobs.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(tts.URL))
+	err := client.TTS.ConvertToStorage(context.Background(), &ConvertParams{Text: "Hi", Format: AudioFormatWAV}, &PresignedURLWriter{URL: blobs.URL})
+	if err != nil {
+		t.Fatalf("ConvertToStorage() error = %v", err)
+	}
+	if *requests != 1 {
+		t.Errorf("TTS requests = %d, want 1 (retry from spool)", *requests)
+	}
+	if len(blobs.uploads) != 2 || blobs.uploads[1] != "synthesized audio" {
+		t.Errorf("uploads = %q", blobs.uploads)
+	}
+	if got := blobs.header.Get("Content-Type"); got != "audio/wav" {
+		t.Errorf("Content-Type = %q, want audio/wav", got)
+	}
+}
+
+func TestTTSService_ConvertToStorage_RetriesSynthesis(t *testing.T) {
+	withFastStorageRetries(t)
+	tts, requests := newCountingTTSServer(http.StatusBadGateway)
+	defer tts.Close()
+	blobs := newBlobServer()
+	defer blobs.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(tts.URL))
+	err := client.TTS.ConvertToStorage(context.Background(), &ConvertParams{Text: "Hi"}, &PresignedURLWriter{URL: blobs.URL})
+	if err != nil {
+		t.Fatalf("ConvertToStorage() error = %v", err)
+	}
+	if *requests != 2 || len(blobs.uploads) != 1 || blobs.uploads[0] != "synthesized audio" {
+		t.Errorf("TTS requests = %d, uploads = %q", *requests, blobs.uploads)
+	}
+}
+
+func TestTTSService_ConvertToStorage_PermanentError(t *testing.T) {
+	withFastStorageRetries(t)
+	tts, _ := newCountingTTSServer()
+	defer tts.Close()
+	blobs := newBlobServer(http.StatusForbidden, http.StatusForbidden)
+	defer blobs.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(tts.URL))
+	err := client.TTS.ConvertToStorage(context.Background(), &ConvertParams{Text: "Hi"}, &PresignedURLWriter{URL: blobs.URL})
+	var statusErr *BlobStatusError
+	if !errors.As(err, &statusErr) {
+		t.Errorf("ConvertToStorage() err = %v, want *BlobStatusError", err)
+	}
+	if len(blobs.uploads) != 1 {
+		t.Errorf("uploads = %d, want 1 without retry", len(blobs.uploads))
+	}
+}
+
+func TestRetryableUpload(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&BlobStatusError{StatusCode: 503}, true},
+		{&BlobStatusError{StatusCode: 429}, true},
+		{&BlobStatusError{StatusCode: 400}, false},
+		{&ServerError{APIError: &APIError{StatusCode: 500}}, true},
+		{context.DeadlineExceeded, false},
+		{errors.New("boom"), false},
+	}
+	for _, tt := range tests {
+		if got := retryableUpload(tt.err); got != tt.want {
+			t.Errorf("retryableUpload(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -3,12 +3,17 @@ package fishaudio
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"testing/iotest"
 	"time"
 
+	"github.com/fishaudio/fish-audio-go/fishaudiotest"
 	"github.com/gorilla/websocket"
 	"github.com/vmihailenco/msgpack/v5"
 )
@@ -311,6 +316,40 @@ func TestTTSService_Convert(t *testing.T) {
 	}
 }
 
+func TestTTSService_ConvertFunc(t *testing.T) {
+	audioData := bytes.Repeat([]byte("chunk"), 2000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write(audioData)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	var got []byte
+	calls := 0
+	err := client.TTS.ConvertFunc(context.Background(), &ConvertParams{Text: "Hello"}, func(chunk []byte) error {
+		calls++
+		got = append(got, chunk...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ConvertFunc() error = %v", err)
+	}
+	if !bytes.Equal(got, audioData) || calls < 2 {
+		t.Errorf("got %d bytes in %d calls, want %d bytes in chunks", len(got), calls, len(audioData))
+	}
+
+	sinkErr := errors.New("sink full")
+	calls = 0
+	err = client.TTS.ConvertFunc(context.Background(), &ConvertParams{Text: "Hello"}, func(chunk []byte) error {
+		calls++
+		return sinkErr
+	})
+	if !errors.Is(err, sinkErr) || calls != 1 {
+		t.Errorf("ConvertFunc() error = %v after %d calls, want %v after 1", err, calls, sinkErr)
+	}
+}
+
 // --- WebSocketAudioStream unit tests ---
 
 func TestWebSocketAudioStream_NextAndBytes(t *testing.T) {
@@ -753,3 +792,1225 @@ func TestTTSService_StreamWebSocket_WithModel(t *testing.T) {
 		t.Fatal("test timed out")
 	}
 }
+
+func TestTTSService_StreamWebSocket_KeepalivePings(t *testing.T) {
+	pinged := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		conn.SetPingHandler(func(data string) error {
+			select {
+			case pinged <- struct{}{}:
+			default:
+			}
+			return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+		})
+
+		// Keep reading so control frames are processed, then finish after stop
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var msg map[string]interface{}
+			_ = msgpack.Unmarshal(data, &msg)
+			if msg["event"] == "stop" {
+				break
+			}
+		}
+		resp := wsResponse{Event: "finish", Reason: "stop"}
+		data, _ := msgpack.Marshal(resp)
+		_ = conn.WriteMessage(websocket.BinaryMessage, data)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	textChan := make(chan string)
+	opts := DefaultWebSocketOptions()
+	opts.PingInterval = 20 * time.Millisecond
+	opts.PingTimeout = 200 * time.Millisecond
+
+	stream, err := client.TTS.StreamWebSocket(context.Background(), textChan, nil, opts)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+
+	select {
+	case <-pinged:
+	case <-time.After(2 * time.Second):
+		t.Fatal("server never received a ping")
+	}
+	close(textChan)
+
+	if _, err := stream.Collect(); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+}
+
+func TestTTSService_StreamWebSocket_PongTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		// Swallow pings without answering
+		conn.SetPingHandler(func(string) error { return nil })
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	textChan := make(chan string)
+	defer close(textChan)
+	opts := DefaultWebSocketOptions()
+	opts.PingInterval = 20 * time.Millisecond
+	opts.PingTimeout = 20 * time.Millisecond
+
+	stream, err := client.TTS.StreamWebSocket(context.Background(), textChan, nil, opts)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+
+	_, err = stream.Collect()
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected *TimeoutError, got %T: %v", err, err)
+	}
+	if timeoutErr.Op != "pong" {
+		t.Errorf("Op = %q, want %q", timeoutErr.Op, "pong")
+	}
+}
+
+func TestTTSService_StreamWebSocket_Close(t *testing.T) {
+	gotStop := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		// Stream audio until the client sends stop, never sending finish
+		audio, _ := msgpack.Marshal(wsResponse{Event: "audio", Audio: []byte("chunk")})
+		_ = conn.WriteMessage(websocket.BinaryMessage, audio)
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var msg map[string]interface{}
+			_ = msgpack.Unmarshal(data, &msg)
+			if msg["event"] == "stop" {
+				close(gotStop)
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	// Leave the text channel open; Close alone must end the session
+	textChan := make(chan string)
+	stream, err := client.TTS.StreamWebSocket(context.Background(), textChan, nil, nil)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+
+	if !stream.Next() {
+		t.Fatalf("Next() = false, want first chunk (err = %v)", stream.Err())
+	}
+
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	select {
+	case <-gotStop:
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not receive stop event")
+	}
+
+	select {
+	case <-stream.session.done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("read loop did not exit after Close()")
+	}
+
+	if _, err := stream.Read(make([]byte, 8)); !errors.Is(err, ErrStreamClosed) {
+		t.Errorf("Read() after Close() err = %v, want %v", err, ErrStreamClosed)
+	}
+	if stream.Next() {
+		t.Error("Next() should return false after Close()")
+	}
+	if stream.Err() != nil {
+		t.Errorf("Err() = %v, want nil after Close()", stream.Err())
+	}
+}
+
+func TestTTSService_StreamWebSocket_ContextCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		// Never finish; just drain client frames
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	textChan := make(chan string)
+
+	stream, err := client.TTS.StreamWebSocket(ctx, textChan, nil, nil)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := stream.Collect()
+		done <- err
+	}()
+
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Collect() err = %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Collect() did not return after context cancellation")
+	}
+
+	select {
+	case <-stream.session.done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("read loop did not exit after context cancellation")
+	}
+}
+
+func TestTTSService_StreamWebSocket_CancelWithFullBuffer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		_, _, _ = conn.ReadMessage()
+
+		// Send more audio than the stream buffers so the reader blocks
+		data, _ := msgpack.Marshal(wsResponse{Event: "audio", Audio: []byte("a")})
+		for i := 0; i < 200; i++ {
+			if err := conn.WriteMessage(websocket.BinaryMessage, data); err != nil {
+				return
+			}
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	textChan := make(chan string)
+
+	stream, err := client.TTS.StreamWebSocket(ctx, textChan, nil, nil)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+
+	// Wait for the buffer to fill without consuming it
+	deadline := time.Now().Add(2 * time.Second)
+	for len(stream.session.audioChan) < cap(stream.session.audioChan) {
+		if time.Now().After(deadline) {
+			t.Fatal("audio buffer did not fill")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	cancel()
+
+	select {
+	case <-stream.session.done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("session did not end after context cancellation")
+	}
+
+	_, err = stream.Collect()
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Collect() err = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestTTSService_StreamWebSocket_ServerErrorStopsSender(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		_, _, _ = conn.ReadMessage()
+		data, _ := msgpack.Marshal(wsResponse{Event: "finish", Reason: "error", Message: "boom"})
+		_ = conn.WriteMessage(websocket.BinaryMessage, data)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	// The text channel is never closed, so only the error can stop the sender
+	textChan := make(chan string)
+
+	stream, err := client.TTS.StreamWebSocket(context.Background(), textChan, nil, nil)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+
+	_, err = stream.Collect()
+	var wsErr *WebSocketError
+	if !errors.As(err, &wsErr) || wsErr.Detail != "boom" {
+		t.Fatalf("Collect() err = %v, want WebSocketError with detail %q", err, "boom")
+	}
+
+	select {
+	case <-stream.session.sendDone:
+	case <-time.After(2 * time.Second):
+		t.Fatal("send loop did not exit after the server error")
+	}
+}
+
+func TestTTSService_StreamWebSocket_OnEvent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		_, _, _ = conn.ReadMessage()
+
+		for _, resp := range []wsResponse{
+			{Event: "audio", Audio: []byte("a1"), Timestamp: 1.5},
+			{Event: "checkpoint"},
+			{Event: "audio", Audio: []byte("a2")},
+			{Event: "finish", Reason: "stop"},
+		} {
+			data, _ := msgpack.Marshal(resp)
+			_ = conn.WriteMessage(websocket.BinaryMessage, data)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	var events []WebSocketEvent
+	opts := DefaultWebSocketOptions()
+	opts.OnEvent = func(evt WebSocketEvent) {
+		events = append(events, evt)
+	}
+
+	textChan := make(chan string)
+	close(textChan)
+
+	stream, err := client.TTS.StreamWebSocket(context.Background(), textChan, nil, opts)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+	if _, err := stream.Collect(); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	wantTypes := []WebSocketEventType{WebSocketEventAudio, "checkpoint", WebSocketEventAudio, WebSocketEventFinish}
+	if len(events) != len(wantTypes) {
+		t.Fatalf("got %d events, want %d", len(events), len(wantTypes))
+	}
+	for i, evt := range events {
+		if evt.Type != wantTypes[i] {
+			t.Errorf("events[%d].Type = %q, want %q", i, evt.Type, wantTypes[i])
+		}
+		if evt.Sequence != i {
+			t.Errorf("events[%d].Sequence = %d, want %d", i, evt.Sequence, i)
+		}
+		if evt.ReceivedAt.IsZero() {
+			t.Errorf("events[%d].ReceivedAt is zero", i)
+		}
+	}
+	if events[0].ServerTimestamp != 1.5 {
+		t.Errorf("ServerTimestamp = %v, want %v", events[0].ServerTimestamp, 1.5)
+	}
+	if string(events[2].Audio) != "a2" {
+		t.Errorf("events[2].Audio = %q, want %q", events[2].Audio, "a2")
+	}
+	if events[3].Reason != "stop" {
+		t.Errorf("events[3].Reason = %q, want %q", events[3].Reason, "stop")
+	}
+}
+
+func TestWSResponse_WSError(t *testing.T) {
+	tests := []struct {
+		name       string
+		resp       wsResponse
+		wantCode   string
+		wantDetail string
+	}{
+		{
+			name: "no details",
+			resp: wsResponse{Event: "finish", Reason: "error"},
+		},
+		{
+			name:       "top-level fields",
+			resp:       wsResponse{Event: "finish", Reason: "error", Code: 402, Message: "insufficient credits"},
+			wantCode:   "402",
+			wantDetail: "insufficient credits",
+		},
+		{
+			name: "nested error map",
+			resp: wsResponse{Event: "finish", Reason: "error", Error: map[string]interface{}{
+				"code":    "invalid_reference",
+				"message": "reference not found",
+			}},
+			wantCode:   "invalid_reference",
+			wantDetail: "reference not found",
+		},
+		{
+			name:       "structured detail",
+			resp:       wsResponse{Event: "finish", Reason: "error", Detail: map[string]interface{}{"field": "reference_id"}},
+			wantDetail: `{"field":"reference_id"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Round-trip through msgpack to mirror what the read loop decodes
+			data, err := msgpack.Marshal(tt.resp)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			var resp wsResponse
+			if err := msgpack.Unmarshal(data, &resp); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+
+			wsErr := resp.wsError()
+			if wsErr.Message != "stream finished with error" {
+				t.Errorf("Message = %q, want %q", wsErr.Message, "stream finished with error")
+			}
+			if wsErr.Code != tt.wantCode {
+				t.Errorf("Code = %q, want %q", wsErr.Code, tt.wantCode)
+			}
+			if wsErr.Detail != tt.wantDetail {
+				t.Errorf("Detail = %q, want %q", wsErr.Detail, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestWSResponse_WordBoundaries(t *testing.T) {
+	resp := wsResponse{Event: "audio", Words: []interface{}{
+		map[string]interface{}{"word": "Hello", "start": 0, "end": 0.42},
+		map[string]interface{}{"text": "world", "start": 0.5, "end": 1},
+		"not a word",
+		map[string]interface{}{"start": 1.2},
+	}}
+
+	tests := []struct {
+		name      string
+		marshal   func(interface{}) ([]byte, error)
+		unmarshal func([]byte, interface{}) error
+	}{
+		{"msgpack", msgpack.Marshal, msgpack.Unmarshal},
+		{"json", json.Marshal, json.Unmarshal},
+	}
+
+	want := []WordBoundary{
+		{Text: "Hello", Start: 0, End: 420 * time.Millisecond},
+		{Text: "world", Start: 500 * time.Millisecond, End: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.marshal(resp)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			var decoded wsResponse
+			if err := tt.unmarshal(data, &decoded); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+
+			got := decoded.wordBoundaries()
+			if len(got) != len(want) {
+				t.Fatalf("got %d words, want %d: %+v", len(got), len(want), got)
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Errorf("words[%d] = %+v, want %+v", i, got[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWSResponse_WordBoundaries_Absent(t *testing.T) {
+	resp := wsResponse{Event: "audio", Audio: []byte("a")}
+	if words := resp.wordBoundaries(); words != nil {
+		t.Errorf("wordBoundaries() = %v, want nil", words)
+	}
+}
+
+func TestTTSService_StreamWebSocket_JSONWireFormat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if messageType != websocket.TextMessage {
+				t.Errorf("message type = %d, want text frame", messageType)
+			}
+			var msg map[string]interface{}
+			if err := json.Unmarshal(data, &msg); err != nil {
+				t.Errorf("unmarshal client event: %v", err)
+				return
+			}
+			switch msg["event"] {
+			case "start":
+				req, _ := msg["request"].(map[string]interface{})
+				if req["reference_id"] != "voice-123" {
+					t.Errorf("reference_id = %v, want %q", req["reference_id"], "voice-123")
+				}
+			case "text":
+				_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"event":"audio","audio":"YXVkaW8="}`))
+			case "stop":
+				_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"event":"finish","reason":"stop"}`))
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	opts := DefaultWebSocketOptions()
+	opts.WireFormat = WireFormatJSON
+
+	textChan := make(chan string, 1)
+	textChan <- "Hello"
+	close(textChan)
+
+	stream, err := client.TTS.StreamWebSocket(context.Background(), textChan, &StreamParams{ReferenceID: "voice-123"}, opts)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+
+	data, err := stream.Collect()
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if string(data) != "audio" {
+		t.Errorf("Collect() = %q, want %q", data, "audio")
+	}
+}
+
+func TestTTSService_StreamWebSocket_ReadTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		// Accept frames but never respond
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	textChan := make(chan string)
+	defer close(textChan)
+	opts := DefaultWebSocketOptions()
+	opts.PingInterval = 0
+	opts.ReadTimeout = 50 * time.Millisecond
+
+	stream, err := client.TTS.StreamWebSocket(context.Background(), textChan, nil, opts)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+
+	_, err = stream.Collect()
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected *TimeoutError, got %T: %v", err, err)
+	}
+	if timeoutErr.Op != "read" {
+		t.Errorf("Op = %q, want %q", timeoutErr.Op, "read")
+	}
+}
+
+func TestWSSession_ReadWait(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     WebSocketOptions
+		wantWait time.Duration
+		wantOp   string
+	}{
+		{"disabled", WebSocketOptions{}, 0, "read"},
+		{"keepalive only", WebSocketOptions{PingInterval: time.Second, PingTimeout: time.Second}, 2 * time.Second, "pong"},
+		{"read timeout only", WebSocketOptions{ReadTimeout: time.Second}, time.Second, "read"},
+		{"read timeout shorter", WebSocketOptions{PingInterval: time.Second, PingTimeout: time.Second, ReadTimeout: time.Second}, time.Second, "read"},
+		{"pong wait shorter", WebSocketOptions{PingInterval: time.Second, ReadTimeout: 5 * time.Second}, time.Second, "pong"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			s := &wsSession{opts: &opts, keepalive: opts.PingInterval > 0}
+			wait, op := s.readWait()
+			if wait != tt.wantWait || op != tt.wantOp {
+				t.Errorf("readWait() = (%v, %q), want (%v, %q)", wait, op, tt.wantWait, tt.wantOp)
+			}
+		})
+	}
+}
+
+func TestWebSocketAudioStream_Finish(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var msg map[string]interface{}
+			_ = msgpack.Unmarshal(data, &msg)
+			switch msg["event"] {
+			case "text":
+				resp, _ := msgpack.Marshal(wsResponse{Event: "audio", Audio: []byte("a")})
+				_ = conn.WriteMessage(websocket.BinaryMessage, resp)
+			case "stop":
+				resp, _ := msgpack.Marshal(wsResponse{Event: "finish", Reason: "stop"})
+				_ = conn.WriteMessage(websocket.BinaryMessage, resp)
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	textChan := make(chan string, 4)
+	textChan <- "first"
+	stream, err := client.TTS.StreamWebSocket(context.Background(), textChan, nil, nil)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+
+	// Wait until the first text has been synthesized
+	if !stream.Next() {
+		t.Fatalf("Next() = false, err = %v", stream.Err())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	result, err := stream.Finish(ctx)
+	if err != nil {
+		t.Fatalf("Finish() error = %v", err)
+	}
+	if !result.Finished {
+		t.Error("Finished = false, want true")
+	}
+	if len(result.UnsentTexts) != 0 {
+		t.Errorf("UnsentTexts = %v, want none", result.UnsentTexts)
+	}
+}
+
+func TestDrainText(t *testing.T) {
+	textChan := make(chan string, 3)
+	textChan <- "never"
+	textChan <- "sent"
+
+	unsent := drainText(textChan)
+	if len(unsent) != 2 || unsent[0] != "never" || unsent[1] != "sent" {
+		t.Errorf("drainText() = %v, want [never sent]", unsent)
+	}
+
+	close(textChan)
+	if unsent := drainText(textChan); len(unsent) != 0 {
+		t.Errorf("drainText() on closed channel = %v, want none", unsent)
+	}
+}
+
+func TestWebSocketAudioStream_FinishDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		// Ignore stop and never send finish
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	stream, err := client.TTS.StreamWebSocket(context.Background(), make(chan string), nil, nil)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	result, err := stream.Finish(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Finish() err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if result.Finished {
+		t.Error("Finished = true, want false")
+	}
+}
+
+// newEchoLiveServer returns a live TTS server that answers each text event
+// with its text as audio and finishes on stop.
+func newEchoLiveServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var msg map[string]interface{}
+			_ = msgpack.Unmarshal(data, &msg)
+			switch msg["event"] {
+			case "text":
+				text, _ := msg["text"].(string)
+				resp, _ := msgpack.Marshal(wsResponse{Event: "audio", Audio: []byte(text)})
+				_ = conn.WriteMessage(websocket.BinaryMessage, resp)
+			case "stop":
+				resp, _ := msgpack.Marshal(wsResponse{Event: "finish", Reason: "stop"})
+				_ = conn.WriteMessage(websocket.BinaryMessage, resp)
+				return
+			}
+		}
+	}))
+}
+
+func TestTTSService_StreamWebSocketTo(t *testing.T) {
+	server := newEchoLiveServer(t)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	textChan := make(chan string, 2)
+	textChan <- "foo"
+	textChan <- "bar"
+	close(textChan)
+
+	var buf bytes.Buffer
+	if err := client.TTS.StreamWebSocketTo(context.Background(), textChan, &buf, nil, nil); err != nil {
+		t.Fatalf("StreamWebSocketTo() error = %v", err)
+	}
+	if buf.String() != "foobar" {
+		t.Errorf("written = %q, want %q", buf.String(), "foobar")
+	}
+}
+
+func TestTTSService_StreamWebSocketFunc_CallbackError(t *testing.T) {
+	server := newEchoLiveServer(t)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	textChan := make(chan string, 2)
+	textChan <- "foo"
+	textChan <- "bar"
+	close(textChan)
+
+	sinkErr := errors.New("sink full")
+	calls := 0
+	err := client.TTS.StreamWebSocketFunc(context.Background(), textChan, func(chunk []byte) error {
+		calls++
+		return sinkErr
+	}, nil, nil)
+	if !errors.Is(err, sinkErr) {
+		t.Errorf("StreamWebSocketFunc() err = %v, want %v", err, sinkErr)
+	}
+	if calls != 1 {
+		t.Errorf("callback calls = %d, want 1", calls)
+	}
+}
+
+func TestTTSService_StreamWebSocket_OnRawMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		_, _, _ = conn.ReadMessage()
+
+		usage, _ := msgpack.Marshal(map[string]interface{}{"event": "usage", "characters": 42})
+		_ = conn.WriteMessage(websocket.BinaryMessage, usage)
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"event":"words","words":["hi"]}`))
+		finish, _ := msgpack.Marshal(wsResponse{Event: "finish", Reason: "stop"})
+		_ = conn.WriteMessage(websocket.BinaryMessage, finish)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	type futureEvent struct {
+		Event      string   `json:"event" msgpack:"event"`
+		Characters int      `json:"characters" msgpack:"characters"`
+		Words      []string `json:"words" msgpack:"words"`
+	}
+	var raw []futureEvent
+	opts := DefaultWebSocketOptions()
+	opts.OnRawMessage = func(msg RawMessage) {
+		var evt futureEvent
+		if err := msg.Decode(&evt); err != nil {
+			t.Errorf("Decode() error = %v", err)
+		}
+		raw = append(raw, evt)
+	}
+
+	textChan := make(chan string)
+	close(textChan)
+
+	stream, err := client.TTS.StreamWebSocket(context.Background(), textChan, nil, opts)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+	if _, err := stream.Collect(); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	if len(raw) != 3 {
+		t.Fatalf("raw messages = %d, want 3", len(raw))
+	}
+	if raw[0].Event != "usage" || raw[0].Characters != 42 {
+		t.Errorf("raw[0] = %+v, want usage event with 42 characters", raw[0])
+	}
+	if raw[1].Event != "words" || len(raw[1].Words) != 1 {
+		t.Errorf("raw[1] = %+v, want words event", raw[1])
+	}
+	if raw[2].Event != "finish" {
+		t.Errorf("raw[2].Event = %q, want %q", raw[2].Event, "finish")
+	}
+}
+
+func TestTTSService_StreamWebSocket_CustomDialerAndHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Gateway-Token"); got != "gw-secret" {
+			t.Errorf("X-Gateway-Token = %q, want %q", got, "gw-secret")
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("Authorization = %q, want %q", got, "Bearer test-key")
+		}
+
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		_, _, _ = conn.ReadMessage()
+		resp, _ := msgpack.Marshal(wsResponse{Event: "finish", Reason: "stop"})
+		_ = conn.WriteMessage(websocket.BinaryMessage, resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	var dialed []string
+	opts := DefaultWebSocketOptions()
+	opts.Dialer = &websocket.Dialer{HandshakeTimeout: time.Second}
+	opts.NetDialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = append(dialed, addr)
+		var d net.Dialer
+		return d.DialContext(ctx, network, addr)
+	}
+	opts.Header = http.Header{
+		"X-Gateway-Token": {"gw-secret"},
+		"Authorization":   {"Bearer overridden"},
+	}
+
+	textChan := make(chan string)
+	close(textChan)
+
+	stream, err := client.TTS.StreamWebSocket(context.Background(), textChan, nil, opts)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+	if _, err := stream.Collect(); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	if len(dialed) != 1 {
+		t.Errorf("NetDialContext calls = %d, want 1", len(dialed))
+	}
+	if opts.Header.Get("Authorization") != "Bearer overridden" {
+		t.Error("caller's Header should not be modified")
+	}
+}
+
+func TestTTSService_StreamWebSocket_ConnectionCallbacks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		_, _, _ = conn.ReadMessage()
+		msg := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "overloaded")
+		_ = conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	var connected, disconnected []ConnectionEvent
+	opts := DefaultWebSocketOptions()
+	opts.OnConnect = func(evt ConnectionEvent) { connected = append(connected, evt) }
+	opts.OnDisconnect = func(evt ConnectionEvent) { disconnected = append(disconnected, evt) }
+
+	stream, err := client.TTS.StreamWebSocket(context.Background(), make(chan string), nil, opts)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+	if _, err := stream.Collect(); err == nil {
+		t.Fatal("Collect() expected close error, got nil")
+	}
+
+	if len(connected) != 1 {
+		t.Fatalf("OnConnect calls = %d, want 1", len(connected))
+	}
+	if connected[0].Duration <= 0 {
+		t.Error("OnConnect Duration should be the dial time")
+	}
+	if len(disconnected) != 1 {
+		t.Fatalf("OnDisconnect calls = %d, want 1", len(disconnected))
+	}
+	evt := disconnected[0]
+	if evt.CloseCode != websocket.CloseTryAgainLater {
+		t.Errorf("CloseCode = %d, want %d", evt.CloseCode, websocket.CloseTryAgainLater)
+	}
+	if evt.Err == nil {
+		t.Error("OnDisconnect Err should be set for an abnormal close")
+	}
+	if evt.URL != connected[0].URL {
+		t.Errorf("URL = %q, want %q", evt.URL, connected[0].URL)
+	}
+}
+
+func TestTTSService_StreamWebSocket_MessageTooLarge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		_, _, _ = conn.ReadMessage()
+		resp, _ := msgpack.Marshal(wsResponse{Event: "audio", Audio: make([]byte, 4096)})
+		_ = conn.WriteMessage(websocket.BinaryMessage, resp)
+		_, _, _ = conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	opts := DefaultWebSocketOptions()
+	opts.MaxMessageSize = 1024
+
+	stream, err := client.TTS.StreamWebSocket(context.Background(), make(chan string), nil, opts)
+	if err != nil {
+		t.Fatalf("StreamWebSocket() error = %v", err)
+	}
+
+	_, err = stream.Collect()
+	var tooLarge *MessageTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("expected *MessageTooLargeError, got %T: %v", err, err)
+	}
+	if tooLarge.Limit != 1024 {
+		t.Errorf("Limit = %d, want 1024", tooLarge.Limit)
+	}
+	if !bytes.Contains([]byte(err.Error()), []byte("increase MaxMessageSize")) {
+		t.Errorf("error = %q, want hint to increase MaxMessageSize", err.Error())
+	}
+}
+
+// newManyChunksServer returns a live server that sends n audio frames, each
+// filled with its index byte and of varying size, then finishes.
+func newManyChunksServer(n int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		_, _, _ = conn.ReadMessage()
+		for i := 0; i < n; i++ {
+			resp, _ := msgpack.Marshal(wsResponse{Event: "audio", Audio: bytes.Repeat([]byte{byte(i)}, 1+i%7*100)})
+			_ = conn.WriteMessage(websocket.BinaryMessage, resp)
+		}
+		resp, _ := msgpack.Marshal(wsResponse{Event: "finish", Reason: "stop"})
+		_ = conn.WriteMessage(websocket.BinaryMessage, resp)
+	}))
+}
+
+func TestTTSService_StreamWebSocket_ReusedChunks(t *testing.T) {
+	const n = 300
+	want := func(i int) []byte { return bytes.Repeat([]byte{byte(i)}, 1+i%7*100) }
+
+	t.Run("next", func(t *testing.T) {
+		server := newManyChunksServer(n)
+		defer server.Close()
+		client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithChunkReuse())
+
+		stream, err := client.TTS.StreamWebSocket(context.Background(), make(chan string), nil, nil)
+		if err != nil {
+			t.Fatalf("StreamWebSocket() error = %v", err)
+		}
+		defer func() { _ = stream.Close() }()
+
+		i := 0
+		for ; stream.Next(); i++ {
+			if !bytes.Equal(stream.Bytes(), want(i)) {
+				t.Fatalf("chunk %d = %d bytes of %d, want %d bytes of %d", i, len(stream.Bytes()), stream.Bytes()[0], len(want(i)), byte(i))
+			}
+		}
+		if err := stream.Err(); err != nil || i != n {
+			t.Fatalf("got %d chunks, err = %v, want %d", i, err, n)
+		}
+	})
+
+	t.Run("read", func(t *testing.T) {
+		server := newManyChunksServer(n)
+		defer server.Close()
+		client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithChunkReuse())
+
+		stream, err := client.TTS.StreamWebSocket(context.Background(), make(chan string), nil, nil)
+		if err != nil {
+			t.Fatalf("StreamWebSocket() error = %v", err)
+		}
+		defer func() { _ = stream.Close() }()
+
+		var expected bytes.Buffer
+		for i := 0; i < n; i++ {
+			expected.Write(want(i))
+		}
+		got, err := io.ReadAll(iotest.OneByteReader(stream))
+		if err != nil {
+			t.Fatalf("ReadAll() error = %v", err)
+		}
+		if !bytes.Equal(got, expected.Bytes()) {
+			t.Errorf("read %d bytes, want %d matching", len(got), expected.Len())
+		}
+	})
+
+	t.Run("events keep audio", func(t *testing.T) {
+		server := newManyChunksServer(n)
+		defer server.Close()
+		client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithChunkReuse())
+
+		var events []WebSocketEvent
+		opts := DefaultWebSocketOptions()
+		opts.OnEvent = func(evt WebSocketEvent) {
+			if evt.Type == WebSocketEventAudio {
+				events = append(events, evt)
+			}
+		}
+		stream, err := client.TTS.StreamWebSocket(context.Background(), make(chan string), nil, opts)
+		if err != nil {
+			t.Fatalf("StreamWebSocket() error = %v", err)
+		}
+		defer func() { _ = stream.Close() }()
+
+		for stream.Next() {
+		}
+		if err := stream.Err(); err != nil {
+			t.Fatalf("Err() = %v", err)
+		}
+		if len(events) != n {
+			t.Fatalf("got %d events, want %d", len(events), n)
+		}
+		for i, evt := range events {
+			if !bytes.Equal(evt.Audio, want(i)) {
+				t.Fatalf("event %d audio was overwritten", i)
+			}
+		}
+	})
+
+	t.Run("kept without reuse", func(t *testing.T) {
+		server := newManyChunksServer(n)
+		defer server.Close()
+		client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+		stream, err := client.TTS.StreamWebSocket(context.Background(), make(chan string), nil, nil)
+		if err != nil {
+			t.Fatalf("StreamWebSocket() error = %v", err)
+		}
+		defer func() { _ = stream.Close() }()
+
+		var chunks [][]byte
+		for stream.Next() {
+			chunks = append(chunks, stream.Bytes())
+		}
+		if err := stream.Err(); err != nil || len(chunks) != n {
+			t.Fatalf("got %d chunks, err = %v, want %d", len(chunks), err, n)
+		}
+		for i, chunk := range chunks {
+			if !bytes.Equal(chunk, want(i)) {
+				t.Fatalf("chunk %d was overwritten", i)
+			}
+		}
+	})
+}
+
+func TestTTSService_StreamWebSocket_ConnectionLost(t *testing.T) {
+	tests := []struct {
+		name        string
+		afterChunks int
+		wantPartial bool
+		wantChunks  int
+	}{
+		{name: "after audio", afterChunks: 2, wantPartial: true, wantChunks: 2},
+		{name: "before audio", afterChunks: 0, wantPartial: false, wantChunks: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := fishaudiotest.NewLiveServer(fishaudiotest.LiveScript{
+				ChunkSize: 2,
+				Fault:     &fishaudiotest.Fault{Kind: fishaudiotest.FaultDrop, AfterChunks: tt.afterChunks},
+			})
+			defer server.Close()
+
+			client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+			var closeCode int
+			opts := DefaultWebSocketOptions()
+			opts.OnDisconnect = func(evt ConnectionEvent) { closeCode = evt.CloseCode }
+
+			textChan := make(chan string, 1)
+			textChan <- "abcdef"
+			close(textChan)
+
+			stream, err := client.TTS.StreamWebSocket(context.Background(), textChan, nil, opts)
+			if err != nil {
+				t.Fatalf("StreamWebSocket() error = %v", err)
+			}
+			var audio []byte
+			for stream.Next() {
+				audio = append(audio, stream.Bytes()...)
+			}
+			err = stream.Err()
+
+			var lostErr *ConnectionLostError
+			if !errors.As(err, &lostErr) {
+				t.Fatalf("Collect() err = %v (%T), want *ConnectionLostError", err, err)
+			}
+			if lostErr.PartialResult != tt.wantPartial {
+				t.Errorf("PartialResult = %v, want %v", lostErr.PartialResult, tt.wantPartial)
+			}
+			if lostErr.Chunks != tt.wantChunks || lostErr.Bytes != int64(len(audio)) {
+				t.Errorf("Chunks, Bytes = %d, %d, want %d, %d", lostErr.Chunks, lostErr.Bytes, tt.wantChunks, len(audio))
+			}
+			if closeCode != websocket.CloseAbnormalClosure {
+				t.Errorf("OnDisconnect CloseCode = %d, want %d", closeCode, websocket.CloseAbnormalClosure)
+			}
+		})
+	}
+}
+
+func TestIsConnectionLost(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"abnormal closure", &websocket.CloseError{Code: websocket.CloseAbnormalClosure}, true},
+		{"close frame", &websocket.CloseError{Code: websocket.CloseTryAgainLater}, false},
+		{"reset", &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}, true},
+		{"unexpected EOF", io.ErrUnexpectedEOF, true},
+		{"timeout", &net.OpError{Op: "read", Err: timeoutErr{}}, false},
+		{"decode", errors.New("failed to decode response"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isConnectionLost(tt.err); got != tt.want {
+				t.Errorf("isConnectionLost(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+type timeoutErr struct{}
+
+func (timeoutErr) Error() string   { return "i/o timeout" }
+func (timeoutErr) Timeout() bool   { return true }
+func (timeoutErr) Temporary() bool { return true }
@@ -0,0 +1,82 @@
+package fishaudio
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+// ConvertVoiceParams contains parameters for voice conversion.
+type ConvertVoiceParams struct {
+	// ReferenceID is the voice model the speech is converted to (required).
+	ReferenceID string
+	// Format is the output audio format. Default: mp3.
+	Format AudioFormat
+}
+
+// ConvertVoice converts recorded speech to another voice, keeping its
+// words, timing, and intonation (speech-to-speech). The source audio is
+// streamed from audio as it is uploaded, and its container format is
+// detected from the first bytes. The converted audio is returned as a
+// stream.
+//
+// Example:
+//
+//	src, _ := os.Open("take.wav")
+//	defer src.Close()
+//	stream, err := client.TTS.ConvertVoice(ctx, src, &fishaudio.ConvertVoiceParams{
+//	    ReferenceID: "voice-id",
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	audio, err := stream.Collect()
+func (s *TTSService) ConvertVoice(ctx context.Context, audio io.Reader, params *ConvertVoiceParams) (*AudioStream, error) {
+	if params == nil || params.ReferenceID == "" {
+		return nil, newValidationError("ReferenceID is required for voice conversion")
+	}
+
+	audio, ext, contentType, err := sniffAudio(audio)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audio: %w", err)
+	}
+	file := newAudioFile(ext, contentType)
+	upload, err := s.client.upload(audio)
+	if err != nil {
+		return nil, err
+	}
+	defer upload.close()
+
+	budget := s.client.budget
+	if budget != nil {
+		if _, err := budget.reserve(""); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := s.client.doMultipartRequest(ctx, http.MethodPost, "/v1/vc", func(writer *multipart.Writer) error {
+		if err := writeFilePart(writer, "audio", file, upload); err != nil {
+			return fmt.Errorf("failed to write audio: %w", err)
+		}
+		if err := writer.WriteField("reference_id", params.ReferenceID); err != nil {
+			return fmt.Errorf("failed to write reference_id: %w", err)
+		}
+		if params.Format != "" {
+			if err := writer.WriteField("format", string(params.Format)); err != nil {
+				return fmt.Errorf("failed to write format: %w", err)
+			}
+		}
+		return nil
+	}, upload)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := s.client.audioStream(resp)
+	if budget != nil {
+		budget.record(stream.Usage())
+	}
+	return stream, nil
+}
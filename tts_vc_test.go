@@ -0,0 +1,87 @@
+package fishaudio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTTSService_ConvertVoice(t *testing.T) {
+	source := encodeWAV(testPCMFormat, tone(100*time.Millisecond, 1000))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/vc" {
+			t.Errorf("request = %s %s, want POST /v1/vc", r.Method, r.URL.Path)
+		}
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm error = %v", err)
+		}
+		if got := r.FormValue("reference_id"); got != "voice-1" {
+			t.Errorf("reference_id = %q, want %q", got, "voice-1")
+		}
+		if got := r.FormValue("format"); got != "wav" {
+			t.Errorf("format = %q, want %q", got, "wav")
+		}
+		file, header, err := r.FormFile("audio")
+		if err != nil {
+			t.Fatalf("FormFile error = %v", err)
+		}
+		data, _ := io.ReadAll(file)
+		if !bytes.Equal(data, source) {
+			t.Error("uploaded audio differs from source")
+		}
+		if header.Filename != "audio.wav" || header.Header.Get("Content-Type") != "audio/wav" {
+			t.Errorf("file = %q (%s), want audio.wav (audio/wav)", header.Filename, header.Header.Get("Content-Type"))
+		}
+
+		w.Header().Set("Content-Type", "audio/wav")
+		_, _ = w.Write([]byte("converted"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	stream, err := client.TTS.ConvertVoice(context.Background(), bytes.NewReader(source), &ConvertVoiceParams{
+		ReferenceID: "voice-1",
+		Format:      AudioFormatWAV,
+	})
+	if err != nil {
+		t.Fatalf("ConvertVoice() error = %v", err)
+	}
+	audio, err := stream.Collect()
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if string(audio) != "converted" {
+		t.Errorf("audio = %q, want %q", audio, "converted")
+	}
+}
+
+func TestTTSService_ConvertVoice_RequiresReference(t *testing.T) {
+	client := NewClient(WithAPIKey("test-key"))
+	for _, params := range []*ConvertVoiceParams{nil, {}} {
+		_, err := client.TTS.ConvertVoice(context.Background(), bytes.NewReader(nil), params)
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("ConvertVoice(%+v) err = %v, want *ValidationError", params, err)
+		}
+	}
+}
+
+func TestTTSService_ConvertVoice_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	_, err := client.TTS.ConvertVoice(context.Background(), bytes.NewReader([]byte("audio")), &ConvertVoiceParams{ReferenceID: "missing"})
+	var notFoundErr *NotFoundError
+	if !errors.As(err, &notFoundErr) {
+		t.Errorf("ConvertVoice() err = %v, want *NotFoundError", err)
+	}
+}
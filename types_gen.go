@@ -0,0 +1,227 @@
+// Code generated by gentypes from api/openapi.json. DO NOT EDIT.
+
+package fishaudio
+
+import "encoding/json"
+
+// AudioFormat specifies the output audio format.
+type AudioFormat string
+
+const (
+	AudioFormatMP3  AudioFormat = "mp3"
+	AudioFormatWAV  AudioFormat = "wav"
+	AudioFormatPCM  AudioFormat = "pcm"
+	AudioFormatOpus AudioFormat = "opus"
+)
+
+// LatencyMode specifies the generation latency mode.
+type LatencyMode string
+
+const (
+	LatencyNormal   LatencyMode = "normal"
+	LatencyBalanced LatencyMode = "balanced"
+)
+
+// SortOrder specifies the direction of a sorted listing.
+type SortOrder string
+
+const (
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+// VoiceSortField specifies the field voice listings are sorted by.
+type VoiceSortField string
+
+const (
+	// SortByTaskCount sorts by the number of times a voice was used.
+	SortByTaskCount VoiceSortField = "task_count"
+	// SortByCreatedAt sorts by when a voice was created.
+	SortByCreatedAt VoiceSortField = "created_at"
+	// SortByUpdatedAt sorts by when a voice was last edited.
+	SortByUpdatedAt VoiceSortField = "updated_at"
+	// SortByLikeCount sorts by the number of likes.
+	SortByLikeCount VoiceSortField = "like_count"
+)
+
+// Visibility specifies the visibility of a voice model.
+type Visibility string
+
+const (
+	VisibilityPublic  Visibility = "public"
+	VisibilityUnlist  Visibility = "unlist"
+	VisibilityPrivate Visibility = "private"
+)
+
+// TrainMode specifies the training mode for voice models.
+type TrainMode string
+
+const (
+	// TrainModeFast clones the voice in seconds from the uploaded samples.
+	TrainModeFast TrainMode = "fast"
+	// TrainModeFull runs a longer training pass for higher fidelity.
+	TrainModeFull TrainMode = "full"
+)
+
+// ModelState specifies the state of a voice model.
+type ModelState string
+
+const (
+	ModelStateCreated  ModelState = "created"
+	ModelStateTraining ModelState = "training"
+	ModelStateTrained  ModelState = "trained"
+	ModelStateFailed   ModelState = "failed"
+)
+
+// Model specifies the TTS model to use.
+type Model string
+
+const (
+	// Deprecated: Use ModelS1 or ModelS2Pro instead.
+	ModelSpeech15 Model = "speech-1.5"
+	// Deprecated: Use ModelS1 or ModelS2Pro instead.
+	ModelSpeech16 Model = "speech-1.6"
+	ModelS1       Model = "s1"
+	ModelS2Pro    Model = "s2-pro"
+)
+
+// Language is a language code, such as "en" or "zh". The constants are the
+// languages Fish Audio supports; any other well-formed code can be passed
+// by converting it, as in Language("yue").
+type Language string
+
+const (
+	LanguageEnglish    Language = "en"
+	LanguageChinese    Language = "zh"
+	LanguageJapanese   Language = "ja"
+	LanguageKorean     Language = "ko"
+	LanguageFrench     Language = "fr"
+	LanguageGerman     Language = "de"
+	LanguageSpanish    Language = "es"
+	LanguageArabic     Language = "ar"
+	LanguageRussian    Language = "ru"
+	LanguageDutch      Language = "nl"
+	LanguageItalian    Language = "it"
+	LanguagePolish     Language = "pl"
+	LanguagePortuguese Language = "pt"
+)
+
+// Sample represents a sample audio for a voice model.
+type Sample struct {
+	Title  string `json:"title"`
+	Text   string `json:"text"`
+	TaskID string `json:"task_id"`
+	Audio  string `json:"audio"`
+}
+
+// Author represents voice model author information.
+type Author struct {
+	ID       string `json:"_id"`
+	Nickname string `json:"nickname"`
+	Avatar   string `json:"avatar"`
+}
+
+// Voice represents a voice model.
+type Voice struct {
+	ID             string     `json:"_id"`
+	Type           string     `json:"type"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	CoverImage     string     `json:"cover_image"`
+	TrainMode      TrainMode  `json:"train_mode"`
+	State          ModelState `json:"state"`
+	Tags           []string   `json:"tags"`
+	Samples        []Sample   `json:"samples"`
+	CreatedAt      Timestamp  `json:"created_at"`
+	UpdatedAt      Timestamp  `json:"updated_at"`
+	Languages      []string   `json:"languages"`
+	Visibility     Visibility `json:"visibility"`
+	LockVisibility bool       `json:"lock_visibility"`
+	LikeCount      int        `json:"like_count"`
+	MarkCount      int        `json:"mark_count"`
+	SharedCount    int        `json:"shared_count"`
+	TaskCount      int        `json:"task_count"`
+	Liked          bool       `json:"liked"`
+	Marked         bool       `json:"marked"`
+	Author         Author     `json:"author"`
+
+	// Extra holds response fields not known to this SDK version.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes Voice, collecting unknown fields in Extra.
+func (v *Voice) UnmarshalJSON(data []byte) error {
+	type plain Voice
+	if err := json.Unmarshal(data, (*plain)(v)); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, v)
+	v.Extra = extra
+	return err
+}
+
+// Credits represents the user's API credit balance.
+type Credits struct {
+	ID             string    `json:"_id"`
+	UserID         string    `json:"user_id"`
+	Credit         string    `json:"credit"`
+	CreatedAt      Timestamp `json:"created_at"`
+	UpdatedAt      Timestamp `json:"updated_at"`
+	HasPhoneSHA256 *bool     `json:"has_phone_sha256,omitempty"`
+	HasFreeCredit  *bool     `json:"has_free_credit,omitempty"`
+
+	// Extra holds response fields not known to this SDK version.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes Credits, collecting unknown fields in Extra.
+func (c *Credits) UnmarshalJSON(data []byte) error {
+	type plain Credits
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, c)
+	c.Extra = extra
+	return err
+}
+
+// Package represents the user's prepaid package information.
+type Package struct {
+	ID         string     `json:"_id"`
+	UserID     string     `json:"user_id"`
+	Type       string     `json:"type"`
+	Total      int        `json:"total"`
+	Balance    int        `json:"balance"`
+	CreatedAt  Timestamp  `json:"created_at"`
+	UpdatedAt  Timestamp  `json:"updated_at"`
+	FinishedAt *Timestamp `json:"finished_at,omitempty"`
+	// ExpiresAt is when the package's remaining balance expires, if it does.
+	ExpiresAt *Timestamp `json:"expires_at,omitempty"`
+	// AutoRenew reports whether the package renews automatically when it runs
+	// out or expires. Nil if the API did not say.
+	AutoRenew *bool `json:"auto_renew,omitempty"`
+
+	// Extra holds response fields not known to this SDK version.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes Package, collecting unknown fields in Extra.
+func (p *Package) UnmarshalJSON(data []byte) error {
+	type plain Package
+	if err := json.Unmarshal(data, (*plain)(p)); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, p)
+	p.Extra = extra
+	return err
+}
+
+// ASRSegment represents a timestamped segment of transcribed text.
+type ASRSegment struct {
+	// Text is the transcribed text for this segment.
+	Text string `json:"text"`
+	// Start is the segment start time in seconds.
+	Start float64 `json:"start"`
+	// End is the segment end time in seconds.
+	End float64 `json:"end"`
+}
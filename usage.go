@@ -0,0 +1,64 @@
+package fishaudio
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Usage headers set by the API on billed TTS and ASR responses.
+const (
+	headerUsageCharacters = "X-Usage-Characters"
+	headerUsageCredits    = "X-Usage-Credits"
+	headerUsageModel      = "X-Usage-Model"
+)
+
+// RequestUsage is what a single request was billed, as reported by the
+// API's usage response headers. Use it to attribute cost per tenant or
+// feature without reconciling against the wallet history.
+type RequestUsage struct {
+	// Characters is the number of characters billed for TTS.
+	Characters int
+	// Credits is the credits consumed by the request.
+	Credits MicroCredits
+	// Model is the model that served the request, e.g. "s1".
+	Model string
+}
+
+// parseUsage reads the usage headers of a response. It returns nil if the
+// response carries none. Malformed values are left zero.
+func parseUsage(h http.Header) *RequestUsage {
+	chars, credits, model := h.Get(headerUsageCharacters), h.Get(headerUsageCredits), h.Get(headerUsageModel)
+	if chars == "" && credits == "" && model == "" {
+		return nil
+	}
+
+	u := &RequestUsage{Model: strings.TrimSpace(model)}
+	if n, err := strconv.Atoi(strings.TrimSpace(chars)); err == nil {
+		u.Characters = n
+	}
+	if c, err := ParseCredits(credits); err == nil {
+		u.Credits = c
+	}
+	return u
+}
+
+// addUsage returns the combined usage of a and b, either of which may be
+// nil. Model is kept only if both agree.
+func addUsage(a, b *RequestUsage) *RequestUsage {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	}
+	sum := &RequestUsage{
+		Characters: a.Characters + b.Characters,
+		Credits:    a.Credits + b.Credits,
+		Model:      a.Model,
+	}
+	if a.Model != b.Model {
+		sum.Model = ""
+	}
+	return sum
+}
@@ -0,0 +1,94 @@
+package fishaudio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseUsage(t *testing.T) {
+	h := http.Header{}
+	if got := parseUsage(h); got != nil {
+		t.Errorf("parseUsage(no headers) = %+v, want nil", got)
+	}
+
+	h.Set("X-Usage-Characters", "42")
+	h.Set("X-Usage-Credits", "0.0126")
+	h.Set("X-Usage-Model", "s1")
+	want := &RequestUsage{Characters: 42, Credits: 12_600, Model: "s1"}
+	if got := parseUsage(h); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseUsage() = %+v, want %+v", got, want)
+	}
+
+	h.Set("X-Usage-Characters", "many")
+	if got := parseUsage(h); got.Characters != 0 || got.Credits != 12_600 {
+		t.Errorf("parseUsage(malformed) = %+v, want Characters 0", got)
+	}
+}
+
+func TestAddUsage(t *testing.T) {
+	a := &RequestUsage{Characters: 10, Credits: 5, Model: "s1"}
+	b := &RequestUsage{Characters: 20, Credits: 7, Model: "s1"}
+	if got := addUsage(nil, nil); got != nil {
+		t.Errorf("addUsage(nil, nil) = %+v, want nil", got)
+	}
+	if got := addUsage(nil, a); got != a {
+		t.Errorf("addUsage(nil, a) = %+v, want a", got)
+	}
+	want := &RequestUsage{Characters: 30, Credits: 12, Model: "s1"}
+	if got := addUsage(a, b); !reflect.DeepEqual(got, want) {
+		t.Errorf("addUsage(a, b) = %+v, want %+v", got, want)
+	}
+	b.Model = "s2-pro"
+	if got := addUsage(a, b); got.Model != "" {
+		t.Errorf("Model = %q, want empty for mixed models", got.Model)
+	}
+}
+
+func TestAudioStream_Usage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.Header().Set("X-Usage-Characters", "5")
+		w.Header().Set("X-Usage-Credits", "0.0015")
+		w.Header().Set("X-Usage-Model", "s1")
+		_, _ = w.Write([]byte("audio"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	stream, err := client.TTS.Stream(context.Background(), &StreamParams{Text: "Hello"})
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	if _, err := stream.Collect(); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	want := &RequestUsage{Characters: 5, Credits: 1_500, Model: "s1"}
+	if got := stream.Usage(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Usage() = %+v, want %+v", got, want)
+	}
+}
+
+func TestASRResponse_Usage(t *testing.T) {
+	for _, format := range []ASRResponseFormat{"", ASRResponseSRT} {
+		t.Run(string(format), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Usage-Credits", "0.5")
+				_, _ = w.Write([]byte(`{"text":"hi"}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			result, err := client.ASR.Transcribe(context.Background(), []byte("audio"), &TranscribeParams{ResponseFormat: format})
+			if err != nil {
+				t.Fatalf("Transcribe() error = %v", err)
+			}
+			if result.Usage == nil || result.Usage.Credits != OneCredit/2 {
+				t.Errorf("Usage = %+v, want 0.5 credits", result.Usage)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package fishaudio
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// SilenceOptions configures RemoveSilence.
+type SilenceOptions struct {
+	// MinSilence is the shortest pause that is shortened. Default: 1 second.
+	MinSilence time.Duration
+
+	// Padding is the silence kept on each side of speech so word onsets and
+	// trailing sounds aren't clipped. Default: 200 milliseconds.
+	Padding time.Duration
+
+	// Threshold is the RMS level, as a fraction of full scale, below which
+	// audio counts as silence. Default: 0.01 (-40 dBFS).
+	Threshold float64
+}
+
+// TimeMap maps positions in audio returned by RemoveSilence back to the
+// original recording.
+type TimeMap struct {
+	spans []keptSpan
+}
+
+// keptSpan is a range of the original audio kept in the output.
+type keptSpan struct {
+	out  time.Duration
+	orig time.Duration
+}
+
+// Original returns the position in the original audio of position d in the
+// audio with silence removed.
+func (m *TimeMap) Original(d time.Duration) time.Duration {
+	if m == nil || len(m.spans) == 0 {
+		return d
+	}
+	i := sort.Search(len(m.spans), func(i int) bool { return m.spans[i].out > d }) - 1
+	if i < 0 {
+		i = 0
+	}
+	return m.spans[i].orig + d - m.spans[i].out
+}
+
+// Remap rewrites the segment timestamps of r, transcribed from audio with
+// silence removed, to positions in the original audio.
+//
+// Example:
+//
+//	trimmed, timeMap, _ := fishaudio.RemoveSilence(audio, nil)
+//	result, err := client.ASR.Transcribe(ctx, trimmed, nil)
+//	if err != nil {
+//	    return err
+//	}
+//	timeMap.Remap(result)
+func (m *TimeMap) Remap(r *ASRResponse) {
+	seconds := func(s float64) float64 {
+		return m.Original(time.Duration(s * float64(time.Second))).Seconds()
+	}
+	for i := range r.Segments {
+		r.Segments[i].Start = seconds(r.Segments[i].Start)
+		r.Segments[i].End = seconds(r.Segments[i].End)
+	}
+}
+
+// RemoveSilence shortens long pauses in a PCM WAV file using an energy-based
+// voice activity detector, reducing the billable duration of sparse
+// recordings such as voicemail or dictation. The returned TimeMap maps
+// timestamps in the trimmed audio back to the original.
+func RemoveSilence(wav []byte, opts *SilenceOptions) ([]byte, *TimeMap, error) {
+	var o SilenceOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.MinSilence <= 0 {
+		o.MinSilence = time.Second
+	}
+	if o.Padding <= 0 {
+		o.Padding = 200 * time.Millisecond
+	}
+	if o.Threshold <= 0 {
+		o.Threshold = 0.01
+	}
+
+	f, pcm, err := parseWAV(wav)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !validBitDepth(f.bitsPerSample) {
+		return nil, nil, errNotPCMWAV
+	}
+
+	frame := bytesFor(f, silenceFrame)
+	if frame <= 0 {
+		return wav, &TimeMap{}, nil
+	}
+	minFrames := int(o.MinSilence / silenceFrame)
+	padFrames := int(o.Padding / silenceFrame)
+
+	// Collect the byte ranges to keep, skipping the middle of each long
+	// run of silent frames
+	type byteRange struct{ start, end int }
+	var kept []byteRange
+	keep := func(start, end int) {
+		if n := len(kept); n > 0 && kept[n-1].end == start {
+			kept[n-1].end = end
+			return
+		}
+		kept = append(kept, byteRange{start, end})
+	}
+
+	frames := len(pcm) / frame
+	for i := 0; i < frames; {
+		if frameRMS(f, pcm[i*frame:(i+1)*frame]) >= o.Threshold {
+			keep(i*frame, (i+1)*frame)
+			i++
+			continue
+		}
+		j := i
+		for j < frames && frameRMS(f, pcm[j*frame:(j+1)*frame]) < o.Threshold {
+			j++
+		}
+		if j-i >= minFrames && j-i > 2*padFrames {
+			keep(i*frame, (i+padFrames)*frame)
+			keep((j-padFrames)*frame, j*frame)
+		} else {
+			keep(i*frame, j*frame)
+		}
+		i = j
+	}
+	if rest := frames * frame; rest < len(pcm) {
+		keep(rest, len(pcm))
+	}
+
+	m := &TimeMap{}
+	out := make([]byte, 0, len(pcm))
+	for _, r := range kept {
+		m.spans = append(m.spans, keptSpan{out: f.duration(len(out)), orig: f.duration(r.start)})
+		out = append(out, pcm[r.start:r.end]...)
+	}
+	return encodeWAV(f, out), m, nil
+}
+
+// frameRMS returns the RMS level of a PCM frame as a fraction of full scale.
+func frameRMS(f pcmFormat, pcm []byte) float64 {
+	size := f.bitsPerSample / 8
+	n := len(pcm) / size
+	if n == 0 {
+		return 0
+	}
+	var sum float64
+	for i := 0; i < n; i++ {
+		v := float64(sample32(pcm[i*size:], f.bitsPerSample)) / (1 << 31)
+		sum += v * v
+	}
+	return math.Sqrt(sum / float64(n))
+}
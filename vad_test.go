@@ -0,0 +1,115 @@
+package fishaudio
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRemoveSilence(t *testing.T) {
+	var pcm []byte
+	pcm = append(pcm, tone(time.Second, 1000)...)
+	pcm = append(pcm, tone(3*time.Second, 0)...)
+	pcm = append(pcm, tone(time.Second, 1000)...)
+
+	out, m, err := RemoveSilence(encodeWAV(testPCMFormat, pcm), nil)
+	if err != nil {
+		t.Fatalf("RemoveSilence() error = %v", err)
+	}
+	f, trimmed, err := parseWAV(out)
+	if err != nil {
+		t.Fatalf("parseWAV() error = %v", err)
+	}
+	if f != testPCMFormat {
+		t.Errorf("format = %+v, want %+v", f, testPCMFormat)
+	}
+	if got := f.duration(len(trimmed)); got != 2400*time.Millisecond {
+		t.Errorf("duration = %v, want 2.4s", got)
+	}
+
+	tests := []struct {
+		out, orig time.Duration
+	}{
+		{0, 0},
+		{500 * time.Millisecond, 500 * time.Millisecond},
+		{1100 * time.Millisecond, 1100 * time.Millisecond},
+		{1200 * time.Millisecond, 3800 * time.Millisecond},
+		{1500 * time.Millisecond, 4100 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		if got := m.Original(tt.out); got != tt.orig {
+			t.Errorf("Original(%v) = %v, want %v", tt.out, got, tt.orig)
+		}
+	}
+}
+
+func TestRemoveSilence_ShortPausesKept(t *testing.T) {
+	var pcm []byte
+	pcm = append(pcm, tone(time.Second, 1000)...)
+	pcm = append(pcm, tone(500*time.Millisecond, 0)...)
+	pcm = append(pcm, tone(time.Second, 1000)...)
+
+	out, m, err := RemoveSilence(encodeWAV(testPCMFormat, pcm), nil)
+	if err != nil {
+		t.Fatalf("RemoveSilence() error = %v", err)
+	}
+	if _, trimmed, _ := parseWAV(out); len(trimmed) != len(pcm) {
+		t.Errorf("len = %d, want %d (unchanged)", len(trimmed), len(pcm))
+	}
+	if got := m.Original(2 * time.Second); got != 2*time.Second {
+		t.Errorf("Original(2s) = %v, want 2s", got)
+	}
+}
+
+func TestRemoveSilence_Options(t *testing.T) {
+	var pcm []byte
+	pcm = append(pcm, tone(time.Second, 1000)...)
+	pcm = append(pcm, tone(time.Second, 200)...) // ~-44 dBFS
+	pcm = append(pcm, tone(time.Second, 1000)...)
+
+	out, _, err := RemoveSilence(encodeWAV(testPCMFormat, pcm), &SilenceOptions{
+		MinSilence: 500 * time.Millisecond,
+		Padding:    100 * time.Millisecond,
+		Threshold:  0.001,
+	})
+	if err != nil {
+		t.Fatalf("RemoveSilence() error = %v", err)
+	}
+	if _, trimmed, _ := parseWAV(out); len(trimmed) != len(pcm) {
+		t.Errorf("quiet speech above the threshold was removed")
+	}
+
+	out, _, err = RemoveSilence(encodeWAV(testPCMFormat, pcm), &SilenceOptions{
+		MinSilence: 500 * time.Millisecond,
+		Padding:    100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("RemoveSilence() error = %v", err)
+	}
+	if _, trimmed, _ := parseWAV(out); testPCMFormat.duration(len(trimmed)) != 2200*time.Millisecond {
+		t.Errorf("duration = %v, want 2.2s", testPCMFormat.duration(len(trimmed)))
+	}
+}
+
+func TestRemoveSilence_NotWAV(t *testing.T) {
+	if _, _, err := RemoveSilence([]byte("not a wav"), nil); !errors.Is(err, errNotPCMWAV) {
+		t.Errorf("RemoveSilence() err = %v, want %v", err, errNotPCMWAV)
+	}
+}
+
+func TestTimeMap_Remap(t *testing.T) {
+	m := &TimeMap{spans: []keptSpan{{out: 0, orig: 0}, {out: time.Second, orig: 5 * time.Second}}}
+	r := &ASRResponse{Segments: []ASRSegment{
+		{Text: "a", Start: 0.25, End: 0.75},
+		{Text: "b", Start: 1.5, End: 2},
+	}}
+	m.Remap(r)
+
+	want := []ASRSegment{{Text: "a", Start: 0.25, End: 0.75}, {Text: "b", Start: 5.5, End: 6}}
+	for i, seg := range r.Segments {
+		if math.Abs(seg.Start-want[i].Start) > 1e-9 || math.Abs(seg.End-want[i].End) > 1e-9 {
+			t.Errorf("Segments[%d] = %+v, want %+v", i, seg, want[i])
+		}
+	}
+}
@@ -3,56 +3,16 @@ package fishaudio
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
-	"time"
-)
-
-// Sample represents a sample audio for a voice model.
-type Sample struct {
-	Title  string `json:"title"`
-	Text   string `json:"text"`
-	TaskID string `json:"task_id"`
-	Audio  string `json:"audio"`
-}
-
-// Author represents voice model author information.
-type Author struct {
-	ID       string `json:"_id"`
-	Nickname string `json:"nickname"`
-	Avatar   string `json:"avatar"`
-}
 
-// Voice represents a voice model.
-type Voice struct {
-	ID             string     `json:"_id"`
-	Type           string     `json:"type"`
-	Title          string     `json:"title"`
-	Description    string     `json:"description"`
-	CoverImage     string     `json:"cover_image"`
-	TrainMode      TrainMode  `json:"train_mode"`
-	State          ModelState `json:"state"`
-	Tags           []string   `json:"tags"`
-	Samples        []Sample   `json:"samples"`
-	CreatedAt      time.Time  `json:"created_at"`
-	UpdatedAt      time.Time  `json:"updated_at"`
-	Languages      []string   `json:"languages"`
-	Visibility     Visibility `json:"visibility"`
-	LockVisibility bool       `json:"lock_visibility"`
-	LikeCount      int        `json:"like_count"`
-	MarkCount      int        `json:"mark_count"`
-	SharedCount    int        `json:"shared_count"`
-	TaskCount      int        `json:"task_count"`
-	Liked          bool       `json:"liked"`
-	Marked         bool       `json:"marked"`
-	Author         Author     `json:"author"`
-}
+	"golang.org/x/sync/errgroup"
+)
 
 // ListVoicesParams contains parameters for listing voices.
 type ListVoicesParams struct {
@@ -72,8 +32,18 @@ type ListVoicesParams struct {
 	Language []string
 	// TitleLanguage filters by title language(s).
 	TitleLanguage []string
-	// SortBy is the sort field. Options: "task_count", "created_at". Default: "task_count".
-	SortBy string
+	// SortBy is the sort field, e.g. SortByLikeCount. Default: SortByTaskCount.
+	SortBy VoiceSortField
+	// SortOrder is the sort direction. Server default if empty.
+	SortOrder SortOrder
+	// Query is free text matched against voice titles and descriptions.
+	Query string
+	// State filters by training state, e.g. ModelStateTraining.
+	State ModelState
+	// Visibility filters by visibility.
+	Visibility Visibility
+	// TrainMode filters by training mode.
+	TrainMode TrainMode
 }
 
 // CreateVoiceParams contains parameters for creating a voice.
@@ -84,18 +54,26 @@ type CreateVoiceParams struct {
 	Voices [][]byte
 	// Description is the voice description.
 	Description string
-	// Texts are transcripts for voice samples.
+	// Texts are transcripts for voice samples. If set, Texts[i] is the
+	// transcript of Voices[i], so it must have one entry per voice.
 	Texts []string
 	// Tags are tags for categorization.
 	Tags []string
-	// CoverImage is the cover image bytes.
+	// CoverImage is the cover image bytes: JPEG, PNG, or WebP, at most
+	// MaxCoverImageSize bytes and MaxCoverImageDimension pixels per side.
 	CoverImage []byte
 	// Visibility is the visibility setting. Default: "private".
 	Visibility Visibility
-	// TrainMode is the training mode. Default: "fast".
+	// TrainMode is the training mode. Default: "fast". Modes the SDK
+	// doesn't know (see TrainMode.Known) are sent as is, so newer modes work
+	// before the SDK lists them; the API rejects modes it doesn't support.
 	TrainMode TrainMode
 	// EnhanceAudioQuality indicates whether to enhance audio quality. Default: true.
 	EnhanceAudioQuality *bool
+	// SkipAudioValidation disables the client-side checks of Voices
+	// (format, duration, total size, and silence), leaving validation to
+	// the server.
+	SkipAudioValidation bool
 }
 
 // UpdateVoiceParams contains parameters for updating a voice.
@@ -104,7 +82,8 @@ type UpdateVoiceParams struct {
 	Title string
 	// Description is the new description.
 	Description string
-	// CoverImage is the new cover image bytes.
+	// CoverImage is the new cover image bytes, with the same limits as
+	// CreateVoiceParams.CoverImage.
 	CoverImage []byte
 	// Visibility is the new visibility setting.
 	Visibility Visibility
@@ -122,6 +101,15 @@ func (s *VoicesService) List(ctx context.Context, params *ListVoicesParams) (*Pa
 	if params == nil {
 		params = &ListVoicesParams{}
 	}
+	if err := validateLanguages(params.Language); err != nil {
+		return nil, err
+	}
+	if err := validateLanguages(params.TitleLanguage); err != nil {
+		return nil, err
+	}
+	if err := validateVoiceSort(params.SortBy, params.SortOrder); err != nil {
+		return nil, err
+	}
 
 	// Build query parameters
 	query := url.Values{}
@@ -165,9 +153,25 @@ func (s *VoicesService) List(ctx context.Context, params *ListVoicesParams) (*Pa
 
 	sortBy := params.SortBy
 	if sortBy == "" {
-		sortBy = "task_count"
+		sortBy = SortByTaskCount
+	}
+	query.Set("sort_by", string(sortBy))
+
+	if params.SortOrder != "" {
+		query.Set("sort_order", string(params.SortOrder))
+	}
+	if params.Query != "" {
+		query.Set("query", params.Query)
+	}
+	if params.State != "" {
+		query.Set("state", string(params.State))
+	}
+	if params.Visibility != "" {
+		query.Set("visibility", string(params.Visibility))
+	}
+	if params.TrainMode != "" {
+		query.Set("train_mode", string(params.TrainMode))
 	}
-	query.Set("sort_by", sortBy)
 
 	// Make request
 	path := "/model?" + query.Encode()
@@ -175,10 +179,50 @@ func (s *VoicesService) List(ctx context.Context, params *ListVoicesParams) (*Pa
 	if err := s.client.doJSONRequest(ctx, http.MethodGet, path, nil, &result, nil); err != nil {
 		return nil, err
 	}
+	result.PageSize = pageSize
+	result.PageNumber = pageNumber
 
 	return &result, nil
 }
 
+// Pager returns a Pager over the voices matching params, starting at
+// params.PageNumber.
+//
+// Example:
+//
+//	voices, err := client.Voices.Pager(&fishaudio.ListVoicesParams{
+//	    PageSize: 100,
+//	    SelfOnly: true,
+//	}).All(ctx)
+func (s *VoicesService) Pager(params *ListVoicesParams) *Pager[Voice] {
+	var p ListVoicesParams
+	if params != nil {
+		p = *params
+	}
+	return NewPager(p.PageNumber, func(ctx context.Context, pageNumber int) (*PaginatedResponse[Voice], error) {
+		p.PageNumber = pageNumber
+		return s.List(ctx, &p)
+	})
+}
+
+// Search returns voices whose title or description matches query. Other
+// filters and paging are taken from params, which may be nil.
+//
+// Example:
+//
+//	results, err := client.Voices.Search(ctx, "narrator", &fishaudio.ListVoicesParams{
+//	    PageSize: 5,
+//	    Language: []string{"en"},
+//	})
+func (s *VoicesService) Search(ctx context.Context, query string, params *ListVoicesParams) (*PaginatedResponse[Voice], error) {
+	var p ListVoicesParams
+	if params != nil {
+		p = *params
+	}
+	p.Query = query
+	return s.List(ctx, &p)
+}
+
 // Get returns a voice by ID.
 func (s *VoicesService) Get(ctx context.Context, voiceID string) (*Voice, error) {
 	var result Voice
@@ -194,19 +238,88 @@ func (s *VoicesService) Create(ctx context.Context, params *CreateVoiceParams) (
 		return nil, fmt.Errorf("voices are required")
 	}
 
-	// Build multipart form
-	var buf bytes.Buffer
-	writer := multipart.NewWriter(&buf)
+	if len(params.Texts) > 0 && len(params.Texts) != len(params.Voices) {
+		return nil, newValidationError("got %d texts for %d voices; each voice needs one transcript", len(params.Texts), len(params.Voices))
+	}
+
+	if !params.SkipAudioValidation {
+		if err := validateVoiceSamples(params.Voices); err != nil {
+			return nil, err
+		}
+	}
+
+	trainMode, err := normalizeTrainMode(params.TrainMode)
+	if err != nil {
+		return nil, err
+	}
+	p := *params
+	p.TrainMode = trainMode
+	params = &p
+
+	var cover formFile
+	if len(params.CoverImage) > 0 {
+		if cover, err = coverImageFile(params.CoverImage); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := s.client.doMultipartRequest(ctx, http.MethodPost, "/model", func(writer *multipart.Writer) error {
+		return writeCreateVoiceForm(writer, params, cover)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var result Voice
+	if err := decodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// normalizeTrainMode lowercases mode and checks that it is a plain
+// identifier. The empty mode becomes TrainModeFast. Unknown modes pass
+// through, as TrainMode.Known describes.
+func normalizeTrainMode(mode TrainMode) (TrainMode, error) {
+	if mode == "" {
+		return TrainModeFast, nil
+	}
+	normalized := TrainMode(strings.ToLower(strings.TrimSpace(string(mode))))
+	if normalized == "" || strings.Trim(string(normalized), "abcdefghijklmnopqrstuvwxyz0123456789_-") != "" {
+		return "", newValidationError("invalid train mode %q: must be a mode such as %q or %q", mode, TrainModeFast, TrainModeFull)
+	}
+	return normalized, nil
+}
 
+// validateVoiceSort checks that by and order are sort options the API
+// accepts. Empty values are defaults and always valid.
+func validateVoiceSort(by VoiceSortField, order SortOrder) error {
+	switch by {
+	case "", SortByTaskCount, SortByCreatedAt, SortByUpdatedAt, SortByLikeCount:
+	default:
+		return newValidationError("unknown sort field %q: must be task_count, created_at, updated_at, or like_count", by)
+	}
+	switch order {
+	case "", SortAscending, SortDescending:
+	default:
+		return newValidationError("unknown sort order %q: must be asc or desc", order)
+	}
+	return nil
+}
+
+// writeCreateVoiceForm writes the multipart fields for Create.
+func writeCreateVoiceForm(writer *multipart.Writer, params *CreateVoiceParams, cover formFile) error {
 	// Add title
 	if err := writer.WriteField("title", params.Title); err != nil {
-		return nil, err
+		return err
 	}
 
 	// Add description
 	if params.Description != "" {
 		if err := writer.WriteField("description", params.Description); err != nil {
-			return nil, err
+			return err
 		}
 	}
 
@@ -216,21 +329,17 @@ func (s *VoicesService) Create(ctx context.Context, params *CreateVoiceParams) (
 		visibility = VisibilityPrivate
 	}
 	if err := writer.WriteField("visibility", string(visibility)); err != nil {
-		return nil, err
+		return err
 	}
 
 	// Add type
 	if err := writer.WriteField("type", "tts"); err != nil {
-		return nil, err
+		return err
 	}
 
 	// Add train_mode
-	trainMode := params.TrainMode
-	if trainMode == "" {
-		trainMode = TrainModeFast
-	}
-	if err := writer.WriteField("train_mode", string(trainMode)); err != nil {
-		return nil, err
+	if err := writer.WriteField("train_mode", string(params.TrainMode)); err != nil {
+		return err
 	}
 
 	// Add enhance_audio_quality
@@ -239,89 +348,76 @@ func (s *VoicesService) Create(ctx context.Context, params *CreateVoiceParams) (
 		enhanceQuality = *params.EnhanceAudioQuality
 	}
 	if err := writer.WriteField("enhance_audio_quality", strconv.FormatBool(enhanceQuality)); err != nil {
-		return nil, err
+		return err
 	}
 
-	// Add texts
-	if len(params.Texts) > 0 {
-		if err := writer.WriteField("texts", strings.Join(params.Texts, ",")); err != nil {
-			return nil, err
+	// Add texts as repeated fields, in voice order, since a transcript may
+	// itself contain commas
+	for _, text := range params.Texts {
+		if err := writer.WriteField("texts", text); err != nil {
+			return err
 		}
 	}
 
 	// Add tags
 	if len(params.Tags) > 0 {
 		if err := writer.WriteField("tags", strings.Join(params.Tags, ",")); err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	// Add voice files
 	for i, voice := range params.Voices {
-		part, err := writer.CreateFormFile("voices", fmt.Sprintf("voice_%d.wav", i))
-		if err != nil {
-			return nil, err
-		}
-		if _, err := part.Write(voice); err != nil {
-			return nil, err
+		if err := writeFilePart(writer, "voices", voiceSampleFile(i, voice), bytes.NewReader(voice)); err != nil {
+			return err
 		}
 	}
 
 	// Add cover image
 	if len(params.CoverImage) > 0 {
-		part, err := writer.CreateFormFile("cover_image", "cover.png")
-		if err != nil {
-			return nil, err
-		}
-		if _, err := part.Write(params.CoverImage); err != nil {
-			return nil, err
+		if err := writeFilePart(writer, "cover_image", cover, bytes.NewReader(params.CoverImage)); err != nil {
+			return err
 		}
 	}
 
-	if err := writer.Close(); err != nil {
-		return nil, err
-	}
-
-	// Create request
-	urlStr := s.client.baseURL + "/model"
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, urlStr, &buf)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Authorization", "Bearer "+s.client.apiKey)
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.Header.Set("User-Agent", "fish-audio/go/"+Version)
+	return nil
+}
 
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return nil, err
+// Update updates voice metadata.
+func (s *VoicesService) Update(ctx context.Context, voiceID string, params *UpdateVoiceParams) error {
+	if params == nil {
+		return nil
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode >= 400 {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, newAPIError(resp.StatusCode, resp.Status, string(bodyBytes))
+	var cover formFile
+	if len(params.CoverImage) > 0 {
+		var err error
+		if cover, err = coverImageFile(params.CoverImage); err != nil {
+			return err
+		}
 	}
 
-	var result Voice
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+	resp, err := s.client.doMultipartRequest(ctx, http.MethodPatch, "/model/"+voiceID, func(writer *multipart.Writer) error {
+		return writeUpdateVoiceForm(writer, params, cover)
+	})
+	if err != nil {
+		return err
 	}
-
-	return &result, nil
+	_ = resp.Body.Close()
+	return nil
 }
 
-// Update updates voice metadata.
-func (s *VoicesService) Update(ctx context.Context, voiceID string, params *UpdateVoiceParams) error {
-	if params == nil {
-		return nil
+// voiceSampleFile names training sample i after its detected format.
+func voiceSampleFile(i int, voice []byte) formFile {
+	ext, contentType := detectAudioFormat(voice)
+	if ext == "" {
+		return formFile{name: fmt.Sprintf("voice_%d.wav", i), contentType: "application/octet-stream"}
 	}
+	return formFile{name: fmt.Sprintf("voice_%d.%s", i, ext), contentType: contentType}
+}
 
-	// Build multipart form
-	var buf bytes.Buffer
-	writer := multipart.NewWriter(&buf)
-
+// writeUpdateVoiceForm writes the multipart fields for Update.
+func writeUpdateVoiceForm(writer *multipart.Writer, params *UpdateVoiceParams, cover formFile) error {
 	if params.Title != "" {
 		if err := writer.WriteField("title", params.Title); err != nil {
 			return err
@@ -347,47 +443,111 @@ func (s *VoicesService) Update(ctx context.Context, voiceID string, params *Upda
 	}
 
 	if len(params.CoverImage) > 0 {
-		part, err := writer.CreateFormFile("cover_image", "cover.png")
-		if err != nil {
-			return err
-		}
-		if _, err := part.Write(params.CoverImage); err != nil {
+		if err := writeFilePart(writer, "cover_image", cover, bytes.NewReader(params.CoverImage)); err != nil {
 			return err
 		}
 	}
 
-	if err := writer.Close(); err != nil {
-		return err
-	}
+	return nil
+}
 
-	// Create request
-	urlStr := s.client.baseURL + "/model/" + voiceID
-	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, urlStr, &buf)
+// Delete deletes a voice.
+func (s *VoicesService) Delete(ctx context.Context, voiceID string) error {
+	resp, err := s.client.doRequest(ctx, http.MethodDelete, "/model/"+voiceID, nil, nil)
 	if err != nil {
 		return err
 	}
+	_ = resp.Body.Close()
+	return nil
+}
 
-	req.Header.Set("Authorization", "Bearer "+s.client.apiKey)
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.Header.Set("User-Agent", "fish-audio/go/"+Version)
+// DeleteResult is the outcome of deleting one voice with DeleteMany.
+type DeleteResult struct {
+	// ID is the voice ID.
+	ID string
+	// NotFound reports that the voice did not exist. This is not an error,
+	// so retrying a partially completed cleanup succeeds.
+	NotFound bool
+	// Err is the error from the delete, if it failed.
+	Err error
+}
 
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer func() { _ = resp.Body.Close() }()
+// DeleteMany deletes voices concurrently and returns one result per ID, in
+// order. A failed delete doesn't stop the others. The error is non-nil only
+// if ctx is cancelled, in which case unfinished deletes report ctx's error,
+// or if a worker panics, reported as a *PanicError.
+//
+// Example:
+//
+//	results, err := client.Voices.DeleteMany(ctx, staleIDs)
+//	for _, r := range results {
+//	    if r.Err != nil {
+//	        log.Printf("delete %s: %v", r.ID, r.Err)
+//	    }
+//	}
+func (s *VoicesService) DeleteMany(ctx context.Context, voiceIDs []string) ([]DeleteResult, error) {
+	results := make([]DeleteResult, len(voiceIDs))
+
+	var g errgroup.Group
+	g.SetLimit(4)
+	for i, id := range voiceIDs {
+		g.Go(s.client.guard("delete worker", func() error {
+			results[i] = DeleteResult{ID: id}
+			if err := ctx.Err(); err != nil {
+				results[i].Err = err
+				return nil
+			}
+			err := s.Delete(ctx, id)
+			var notFound *NotFoundError
+			if errors.As(err, &notFound) {
+				results[i].NotFound = true
+				err = nil
+			}
+			results[i].Err = err
+			return nil
+		}))
+	}
+	if err := g.Wait(); err != nil {
+		return results, err
+	}
+
+	return results, ctx.Err()
+}
 
-	if resp.StatusCode >= 400 {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return newAPIError(resp.StatusCode, resp.Status, string(bodyBytes))
+// Exists reports whether a voice exists. A missing voice returns
+// (false, nil); other failures return the error.
+func (s *VoicesService) Exists(ctx context.Context, voiceID string) (bool, error) {
+	_, err := s.Get(ctx, voiceID)
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		return false, nil
 	}
+	return err == nil, err
+}
 
-	return nil
+// Like likes a voice on behalf of the current user.
+func (s *VoicesService) Like(ctx context.Context, voiceID string) error {
+	return s.toggle(ctx, http.MethodPost, voiceID, "like")
 }
 
-// Delete deletes a voice.
-func (s *VoicesService) Delete(ctx context.Context, voiceID string) error {
-	resp, err := s.client.doRequest(ctx, http.MethodDelete, "/model/"+voiceID, nil, nil)
+// Unlike removes the current user's like from a voice.
+func (s *VoicesService) Unlike(ctx context.Context, voiceID string) error {
+	return s.toggle(ctx, http.MethodDelete, voiceID, "like")
+}
+
+// Mark bookmarks a voice for the current user.
+func (s *VoicesService) Mark(ctx context.Context, voiceID string) error {
+	return s.toggle(ctx, http.MethodPost, voiceID, "mark")
+}
+
+// Unmark removes a voice from the current user's bookmarks.
+func (s *VoicesService) Unmark(ctx context.Context, voiceID string) error {
+	return s.toggle(ctx, http.MethodDelete, voiceID, "mark")
+}
+
+// toggle sets (POST) or clears (DELETE) a per-user flag on a voice.
+func (s *VoicesService) toggle(ctx context.Context, method, voiceID, action string) error {
+	resp, err := s.client.doRequest(ctx, method, "/model/"+voiceID+"/"+action, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,55 @@
+package fishaudio
+
+import (
+	"context"
+	"fmt"
+)
+
+// AuthorProfile describes a voice creator.
+type AuthorProfile struct {
+	Author
+	// VoiceCount is the number of the author's voices visible to the
+	// current user.
+	VoiceCount int
+}
+
+// GetAuthor returns the profile of a voice author. The API has no author
+// endpoint, so the profile is read from the author's voice listing; an
+// author with no visible voices returns a *NotFoundError.
+//
+// Example:
+//
+//	author, err := client.Voices.GetAuthor(ctx, voice.Author.ID)
+//	fmt.Printf("%s has %d voices\n", author.Nickname, author.VoiceCount)
+func (s *VoicesService) GetAuthor(ctx context.Context, authorID string) (*AuthorProfile, error) {
+	page, err := s.ListByAuthor(ctx, authorID, &ListVoicesParams{PageSize: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(page.Items) == 0 {
+		return nil, &NotFoundError{APIError: &APIError{Message: fmt.Sprintf("no voices found for author %s", authorID)}}
+	}
+
+	author := page.Items[0].Author
+	if author.ID == "" {
+		author.ID = authorID
+	}
+	return &AuthorProfile{Author: author, VoiceCount: page.Total}, nil
+}
+
+// ListByAuthor returns a page of an author's voices. Other filters and
+// paging are taken from params, which may be nil.
+//
+// Example:
+//
+//	voices, err := client.Voices.ListByAuthor(ctx, authorID, &fishaudio.ListVoicesParams{
+//	    SortBy: fishaudio.SortByCreatedAt,
+//	})
+func (s *VoicesService) ListByAuthor(ctx context.Context, authorID string, params *ListVoicesParams) (*PaginatedResponse[Voice], error) {
+	var p ListVoicesParams
+	if params != nil {
+		p = *params
+	}
+	p.AuthorID = authorID
+	return s.List(ctx, &p)
+}
@@ -0,0 +1,73 @@
+package fishaudio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVoicesService_GetAuthor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("author_id") != "author-1" {
+			t.Errorf("author_id = %q, want %q", query.Get("author_id"), "author-1")
+		}
+		if query.Get("page_size") != "1" {
+			t.Errorf("page_size = %q, want %q", query.Get("page_size"), "1")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(PaginatedResponse[Voice]{
+			Total: 12,
+			Items: []Voice{{ID: "v", Author: Author{ID: "author-1", Nickname: "Ada", Avatar: "ada.png"}}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	author, err := client.Voices.GetAuthor(context.Background(), "author-1")
+	if err != nil {
+		t.Fatalf("GetAuthor() error = %v", err)
+	}
+	if author.Nickname != "Ada" || author.Avatar != "ada.png" || author.VoiceCount != 12 {
+		t.Errorf("GetAuthor() = %+v", author)
+	}
+}
+
+func TestVoicesService_GetAuthor_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(PaginatedResponse[Voice]{Items: []Voice{}})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	_, err := client.Voices.GetAuthor(context.Background(), "nobody")
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("GetAuthor() error = %v, want *NotFoundError", err)
+	}
+}
+
+func TestVoicesService_ListByAuthor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("author_id") != "author-1" || query.Get("sort_by") != "created_at" {
+			t.Errorf("query = %v, want author_id and sort_by", query)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(PaginatedResponse[Voice]{Items: []Voice{}})
+	}))
+	defer server.Close()
+
+	params := &ListVoicesParams{SortBy: "created_at", AuthorID: "ignored"}
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if _, err := client.Voices.ListByAuthor(context.Background(), "author-1", params); err != nil {
+		t.Fatalf("ListByAuthor() error = %v", err)
+	}
+	if params.AuthorID != "ignored" {
+		t.Error("ListByAuthor() should not modify params")
+	}
+}
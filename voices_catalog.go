@@ -0,0 +1,95 @@
+package fishaudio
+
+import (
+	"context"
+	"sort"
+)
+
+// CatalogParams configures VoicesService.Catalog.
+type CatalogParams struct {
+	// Filters restricts which voices are sampled, e.g. SelfOnly or
+	// Language. Paging fields set the page size and first page.
+	Filters *ListVoicesParams
+
+	// MaxPages is the maximum number of pages sampled. Default: 5.
+	MaxPages int
+}
+
+// FacetCount is a tag or language and the number of sampled voices with it.
+type FacetCount struct {
+	Value string
+	Count int
+}
+
+// VoiceCatalog lists the tags and languages in use, most common first.
+type VoiceCatalog struct {
+	Tags      []FacetCount
+	Languages []FacetCount
+	// Sampled is the number of voices the counts were built from.
+	Sampled int
+	// Complete reports whether every matching voice was sampled.
+	Complete bool
+}
+
+// Catalog returns the tags and languages used by voices matching the
+// filters, so filter dropdowns can be built from live data. The API has no
+// dedicated discovery endpoint, so the catalog is built from up to MaxPages
+// pages of the voice listing, sorted by the default sort (most used).
+//
+// Example:
+//
+//	catalog, err := client.Voices.Catalog(ctx, &fishaudio.CatalogParams{
+//	    Filters: &fishaudio.ListVoicesParams{PageSize: 100},
+//	})
+//	for _, tag := range catalog.Tags {
+//	    fmt.Printf("%s (%d)\n", tag.Value, tag.Count)
+//	}
+func (s *VoicesService) Catalog(ctx context.Context, params *CatalogParams) (*VoiceCatalog, error) {
+	var p CatalogParams
+	if params != nil {
+		p = *params
+	}
+	if p.MaxPages <= 0 {
+		p.MaxPages = 5
+	}
+
+	tags := map[string]int{}
+	languages := map[string]int{}
+	catalog := &VoiceCatalog{}
+
+	pager := s.Pager(p.Filters)
+	for pages := 0; pages < p.MaxPages && pager.Next(ctx); pages++ {
+		for _, voice := range pager.Page().Items {
+			catalog.Sampled++
+			for _, tag := range voice.Tags {
+				tags[tag]++
+			}
+			for _, lang := range voice.Languages {
+				languages[lang]++
+			}
+		}
+	}
+	if err := pager.Err(); err != nil {
+		return nil, err
+	}
+	catalog.Complete = pager.Page() == nil || !pager.Page().HasNext()
+
+	catalog.Tags = facetCounts(tags)
+	catalog.Languages = facetCounts(languages)
+	return catalog, nil
+}
+
+// facetCounts sorts counts by descending count, then by value.
+func facetCounts(counts map[string]int) []FacetCount {
+	out := make([]FacetCount, 0, len(counts))
+	for value, count := range counts {
+		out = append(out, FacetCount{Value: value, Count: count})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Value < out[j].Value
+	})
+	return out
+}
@@ -0,0 +1,81 @@
+package fishaudio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestVoicesService_Catalog(t *testing.T) {
+	all := []Voice{
+		{Tags: []string{"male", "narration"}, Languages: []string{"en"}},
+		{Tags: []string{"female"}, Languages: []string{"en", "zh"}},
+		{Tags: []string{"narration", "female"}, Languages: []string{"ja"}},
+		{Tags: []string{"narration"}, Languages: []string{"en"}},
+		{Tags: []string{"unseen"}, Languages: []string{"fr"}},
+	}
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("self") != "true" {
+			t.Errorf("self = %q, want %q", r.URL.Query().Get("self"), "true")
+		}
+		number, _ := strconv.Atoi(r.URL.Query().Get("page_number"))
+		start := (number - 1) * 2
+		end := start + 2
+		if end > len(all) {
+			end = len(all)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(PaginatedResponse[Voice]{Total: len(all), Items: all[start:end]})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	catalog, err := client.Voices.Catalog(context.Background(), &CatalogParams{
+		Filters:  &ListVoicesParams{PageSize: 2, SelfOnly: true},
+		MaxPages: 2,
+	})
+	if err != nil {
+		t.Fatalf("Catalog() error = %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+	if catalog.Sampled != 4 || catalog.Complete {
+		t.Errorf("Sampled = %d, Complete = %v, want 4 and false", catalog.Sampled, catalog.Complete)
+	}
+	wantTags := []FacetCount{{"narration", 3}, {"female", 2}, {"male", 1}}
+	if !reflect.DeepEqual(catalog.Tags, wantTags) {
+		t.Errorf("Tags = %v, want %v", catalog.Tags, wantTags)
+	}
+	wantLangs := []FacetCount{{"en", 3}, {"ja", 1}, {"zh", 1}}
+	if !reflect.DeepEqual(catalog.Languages, wantLangs) {
+		t.Errorf("Languages = %v, want %v", catalog.Languages, wantLangs)
+	}
+}
+
+func TestVoicesService_Catalog_Complete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(PaginatedResponse[Voice]{
+			Total: 1,
+			Items: []Voice{{Tags: []string{"calm"}}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	catalog, err := client.Voices.Catalog(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Catalog() error = %v", err)
+	}
+	if !catalog.Complete || catalog.Sampled != 1 {
+		t.Errorf("Complete = %v, Sampled = %d, want true and 1", catalog.Complete, catalog.Sampled)
+	}
+}
@@ -0,0 +1,101 @@
+package fishaudio
+
+import (
+	"context"
+	"fmt"
+)
+
+// CloneVoiceParams overrides the metadata of a cloned voice. Empty fields
+// keep the source voice's values.
+type CloneVoiceParams struct {
+	// Title is the new voice's title. Default: the source title with
+	// " (copy)" appended.
+	Title string
+	// Description is the new voice's description.
+	Description string
+	// Tags are the new voice's tags.
+	Tags []string
+	// CoverImage is the new voice's cover image. The source cover is not
+	// copied.
+	CoverImage []byte
+	// Visibility is the new voice's visibility. Default: "private".
+	Visibility Visibility
+	// TrainMode is the training mode. Default: the source voice's mode.
+	TrainMode TrainMode
+	// EnhanceAudioQuality indicates whether to enhance audio quality. Default: true.
+	EnhanceAudioQuality *bool
+}
+
+// Clone creates a new voice trained on the samples of an existing one, so
+// a base voice can be forked with new metadata or visibility. The API has
+// no duplication endpoint, so the source's sample audio is downloaded and
+// passed to Create along with its transcripts.
+//
+// Example:
+//
+//	fork, err := client.Voices.Clone(ctx, baseVoiceID, &fishaudio.CloneVoiceParams{
+//	    Title:      "Support Agent (EU)",
+//	    Visibility: fishaudio.VisibilityPrivate,
+//	})
+func (s *VoicesService) Clone(ctx context.Context, voiceID string, overrides *CloneVoiceParams) (*Voice, error) {
+	var o CloneVoiceParams
+	if overrides != nil {
+		o = *overrides
+	}
+
+	source, err := s.Get(ctx, voiceID)
+	if err != nil {
+		return nil, err
+	}
+	if len(source.Samples) == 0 {
+		return nil, newValidationError("voice %s has no samples to clone", voiceID)
+	}
+
+	params := &CreateVoiceParams{
+		Title:               o.Title,
+		Description:         o.Description,
+		Tags:                o.Tags,
+		CoverImage:          o.CoverImage,
+		Visibility:          o.Visibility,
+		TrainMode:           o.TrainMode,
+		EnhanceAudioQuality: o.EnhanceAudioQuality,
+	}
+	if params.Title == "" {
+		params.Title = source.Title + " (copy)"
+	}
+	if params.Description == "" {
+		params.Description = source.Description
+	}
+	if params.Tags == nil {
+		params.Tags = source.Tags
+	}
+	if params.TrainMode == "" {
+		params.TrainMode = source.TrainMode
+	}
+
+	haveTexts := true
+	for i, sample := range source.Samples {
+		audio, err := s.client.download(ctx, sample.Audio)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download sample %d: %w", i, err)
+		}
+		params.Voices = append(params.Voices, audio)
+		params.Texts = append(params.Texts, sample.Text)
+		haveTexts = haveTexts && sample.Text != ""
+	}
+	// Transcripts are per sample, so send them only if every sample has one
+	if !haveTexts {
+		params.Texts = nil
+	}
+
+	return s.Create(ctx, params)
+}
+
+// download fetches a file by URL with DownloadAudio.
+func (c *Client) download(ctx context.Context, url string) ([]byte, error) {
+	stream, err := c.DownloadAudio(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return stream.Collect()
+}
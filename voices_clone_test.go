@@ -0,0 +1,117 @@
+package fishaudio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestVoicesService_Clone(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/model/base":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(Voice{
+				ID:          "base",
+				Title:       "Base",
+				Description: "Original",
+				Tags:        []string{"calm"},
+				TrainMode:   TrainModeFull,
+				Samples: []Sample{
+					{Text: "first, sample", Audio: server.URL + "/cdn/0.wav"},
+					{Text: "second", Audio: "/cdn/1.wav"},
+				},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/cdn/0.wav":
+			if r.Header.Get("Authorization") != "" {
+				t.Error("absolute sample URLs should be fetched without credentials")
+			}
+			_, _ = w.Write(testVoiceSample())
+		case r.Method == http.MethodGet && r.URL.Path == "/cdn/1.wav":
+			if r.Header.Get("Authorization") != "Bearer test-key" {
+				t.Error("relative sample URLs should be fetched with credentials")
+			}
+			_, _ = w.Write(testVoiceSample())
+		case r.Method == http.MethodPost && r.URL.Path == "/model":
+			if err := r.ParseMultipartForm(10 << 20); err != nil {
+				t.Fatalf("ParseMultipartForm error = %v", err)
+			}
+			want := map[string]string{
+				"title":       "Fork",
+				"description": "Original",
+				"tags":        "calm",
+				"train_mode":  "full",
+				"visibility":  "unlist",
+			}
+			for key, value := range want {
+				if got := r.FormValue(key); got != value {
+					t.Errorf("%s = %q, want %q", key, got, value)
+				}
+			}
+			if texts := r.MultipartForm.Value["texts"]; !reflect.DeepEqual(texts, []string{"first, sample", "second"}) {
+				t.Errorf("texts = %q", texts)
+			}
+			if files := r.MultipartForm.File["voices"]; len(files) != 2 {
+				t.Errorf("got %d voice files, want 2", len(files))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(Voice{ID: "fork"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	voice, err := client.Voices.Clone(context.Background(), "base", &CloneVoiceParams{
+		Title:      "Fork",
+		Visibility: VisibilityUnlist,
+	})
+	if err != nil {
+		t.Fatalf("Clone() error = %v", err)
+	}
+	if voice.ID != "fork" {
+		t.Errorf("voice.ID = %q, want %q", voice.ID, "fork")
+	}
+}
+
+func TestVoicesService_Clone_NoSamples(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Voice{ID: "empty"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	_, err := client.Voices.Clone(context.Background(), "empty", nil)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("Clone() error = %v, want *ValidationError", err)
+	}
+}
+
+func TestVoicesService_Clone_DownloadError(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/model/base" {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(Voice{ID: "base", Samples: []Sample{{Audio: server.URL + "/gone.wav"}}})
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	_, err := client.Voices.Clone(context.Background(), "base", nil)
+	var permErr *PermissionError
+	if !errors.As(err, &permErr) {
+		t.Errorf("Clone() error = %v, want *PermissionError", err)
+	}
+}
@@ -0,0 +1,379 @@
+package fishaudio
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// libraryManifest is the name of the file listing a VoiceLibrary's voices.
+const libraryManifest = "voices.json"
+
+// LibraryVoice is a reference voice kept in a VoiceLibrary.
+type LibraryVoice struct {
+	// Name identifies the voice in the library and prefixes its sample
+	// files. It may contain letters, digits, '-', and '_'.
+	Name string `json:"name"`
+	// Title is the display name used when the voice is created on Fish
+	// Audio. Default: Name.
+	Title string `json:"title,omitempty"`
+	// Description describes the voice.
+	Description string `json:"description,omitempty"`
+	// Tags are tags for categorization.
+	Tags []string `json:"tags,omitempty"`
+	// Language is the language spoken in the samples.
+	Language Language `json:"language,omitempty"`
+	// Metadata holds free-form details such as the speaker's consent
+	// record or the recording session.
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// VoiceID is the ID of the voice model created from the samples, if
+	// any, so the library can map names to models.
+	VoiceID string `json:"voice_id,omitempty"`
+	// Samples are the voice's reference recordings. They are managed with
+	// AddSample; SetVoice ignores them.
+	Samples []LibrarySample `json:"samples"`
+}
+
+// LibrarySample is a reference recording of a LibraryVoice.
+type LibrarySample struct {
+	// File is the name of the audio file in the library directory.
+	File string `json:"file"`
+	// Text is the transcript of the recording.
+	Text string `json:"text"`
+}
+
+// VoiceLibrary manages reference voices on disk: audio samples with their
+// transcripts and metadata, listed in a voices.json manifest in one
+// directory. It turns a voice into []ReferenceAudio for instant cloning or
+// into CreateVoiceParams for training, so a team's voices can be reviewed
+// and versioned like any other asset.
+//
+// Files are read and written through a Storage. The manifest is read on
+// every call and replaced atomically on OSStorage, so a library can be
+// edited by hand or by other processes between calls. A VoiceLibrary is
+// safe for concurrent use.
+type VoiceLibrary struct {
+	storage Storage
+	dir     string
+	mu      sync.Mutex
+}
+
+// NewVoiceLibrary returns the library in dir of storage, which must exist.
+// A nil storage uses OSStorage. The manifest is created when the first
+// sample is added.
+func NewVoiceLibrary(storage Storage, dir string) *VoiceLibrary {
+	if storage == nil {
+		storage = OSStorage{}
+	}
+	return &VoiceLibrary{storage: storage, dir: dir}
+}
+
+// VoiceLibrary returns the library in dir of the client's Storage.
+//
+// Example:
+//
+//	lib := client.VoiceLibrary("voices")
+//	err := lib.AddSample("narrator", recording, "The quick brown fox jumps over the lazy dog.")
+//	refs, err := lib.References("narrator")
+//	audio, err := client.TTS.Convert(ctx, &fishaudio.ConvertParams{Text: "Hello", References: refs})
+func (c *Client) VoiceLibrary(dir string) *VoiceLibrary {
+	return NewVoiceLibrary(c.files(), dir)
+}
+
+// Voices returns the voices in the library, sorted by name.
+func (l *VoiceLibrary) Voices() ([]LibraryVoice, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.load()
+}
+
+// Voice returns the named voice. If there is none, the error wraps
+// fs.ErrNotExist.
+func (l *VoiceLibrary) Voice(name string) (*LibraryVoice, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	voices, err := l.load()
+	if err != nil {
+		return nil, err
+	}
+	i, ok := findLibraryVoice(voices, name)
+	if !ok {
+		return nil, fmt.Errorf("voice %q: %w", name, fs.ErrNotExist)
+	}
+	return &voices[i], nil
+}
+
+// SetVoice adds a voice or updates the details of an existing one,
+// keeping its samples.
+func (l *VoiceLibrary) SetVoice(voice LibraryVoice) error {
+	if err := validateLibraryName(voice.Name); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	voices, err := l.load()
+	if err != nil {
+		return err
+	}
+	if i, ok := findLibraryVoice(voices, voice.Name); ok {
+		voice.Samples = voices[i].Samples
+		voices[i] = voice
+	} else {
+		voice.Samples = nil
+		voices = append(voices, voice)
+	}
+	return l.save(voices)
+}
+
+// AddSample validates audio as a training sample and stores it with its
+// transcript as a sample of the named voice, adding the voice if needed.
+// The audio must be WAV, MP3, Ogg, or FLAC; WAV samples are also checked
+// for duration and silence.
+func (l *VoiceLibrary) AddSample(name string, audio []byte, text string) error {
+	if err := validateLibraryName(name); err != nil {
+		return err
+	}
+	if reason := checkVoiceSample(audio); reason != "" {
+		return newValidationError("invalid sample for voice %q: %s", name, reason)
+	}
+	if strings.TrimSpace(text) == "" {
+		return newValidationError("sample for voice %q needs a transcript", name)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	voices, err := l.load()
+	if err != nil {
+		return err
+	}
+	i, ok := findLibraryVoice(voices, name)
+	if !ok {
+		voices = append(voices, LibraryVoice{Name: name})
+		i = len(voices) - 1
+	}
+	voice := &voices[i]
+
+	// Number the sample after the highest number in the voice's files, and
+	// skip names the manifest uses or storage has, so a hand-edited manifest
+	// pointing at a missing file never ends up sharing it
+	ext, _ := detectAudioFormat(audio)
+	for n := lastSampleNumber(voice) + 1; ; n++ {
+		file := fmt.Sprintf("%s-%d.%s", name, n, ext)
+		if libraryFileUsed(voices, file) {
+			continue
+		}
+		if _, err := l.storage.Stat(path.Join(l.dir, file)); errors.Is(err, fs.ErrNotExist) {
+			if err := l.writeFile(file, audio); err != nil {
+				return err
+			}
+			voice.Samples = append(voice.Samples, LibrarySample{File: file, Text: text})
+			return l.save(voices)
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// Validate checks that the named voice has samples, that every sample has
+// a transcript and readable audio that passes the training checks, and
+// that the samples fit in one upload. It returns a ValidationError listing
+// every problem.
+func (l *VoiceLibrary) Validate(name string) error {
+	_, _, err := l.readSamples(name)
+	return err
+}
+
+// References returns the validated samples of the named voice for instant
+// voice cloning with ConvertParams.References.
+func (l *VoiceLibrary) References(name string) ([]ReferenceAudio, error) {
+	voice, audio, err := l.readSamples(name)
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]ReferenceAudio, len(audio))
+	for i, data := range audio {
+		refs[i] = ReferenceAudio{Audio: data, Text: voice.Samples[i].Text}
+	}
+	return refs, nil
+}
+
+// CreateParams returns parameters that create a voice model from the
+// validated samples of the named voice, with its title, description, and
+// tags. Set Visibility and TrainMode on the result as needed, and record
+// the new model's ID with SetVoice.
+//
+// Example:
+//
+//	params, err := lib.CreateParams("narrator")
+//	if err != nil {
+//	    return err
+//	}
+//	voice, err := client.Voices.Create(ctx, params)
+func (l *VoiceLibrary) CreateParams(name string) (*CreateVoiceParams, error) {
+	voice, audio, err := l.readSamples(name)
+	if err != nil {
+		return nil, err
+	}
+	params := &CreateVoiceParams{
+		Title:       voice.Title,
+		Voices:      audio,
+		Description: voice.Description,
+		Tags:        voice.Tags,
+	}
+	if params.Title == "" {
+		params.Title = voice.Name
+	}
+	for _, sample := range voice.Samples {
+		params.Texts = append(params.Texts, sample.Text)
+	}
+	return params, nil
+}
+
+// readSamples returns the named voice and the audio of its samples, or a
+// ValidationError listing every sample problem.
+func (l *VoiceLibrary) readSamples(name string) (*LibraryVoice, [][]byte, error) {
+	voice, err := l.Voice(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var problems []string
+	if len(voice.Samples) == 0 {
+		problems = append(problems, "no samples")
+	}
+	audio := make([][]byte, len(voice.Samples))
+	total := 0
+	for i, sample := range voice.Samples {
+		data, err := l.readFile(sample.File)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", sample.File, err))
+			continue
+		}
+		audio[i] = data
+		total += len(data)
+		if reason := checkVoiceSample(data); reason != "" {
+			problems = append(problems, fmt.Sprintf("%s: %s", sample.File, reason))
+		}
+		if strings.TrimSpace(sample.Text) == "" {
+			problems = append(problems, fmt.Sprintf("%s: no transcript", sample.File))
+		}
+	}
+	if total > MaxVoiceUploadSize {
+		problems = append(problems, fmt.Sprintf("total size %d bytes exceeds the %d byte limit", total, MaxVoiceUploadSize))
+	}
+	if len(problems) > 0 {
+		return nil, nil, newValidationError("voice %q: %s", name, strings.Join(problems, "; "))
+	}
+	return voice, audio, nil
+}
+
+// load reads the manifest. A missing manifest is an empty library.
+func (l *VoiceLibrary) load() ([]LibraryVoice, error) {
+	data, err := l.readFile(libraryManifest)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var voices []LibraryVoice
+	if err := json.Unmarshal(data, &voices); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path.Join(l.dir, libraryManifest), err)
+	}
+	sort.Slice(voices, func(i, j int) bool { return voices[i].Name < voices[j].Name })
+	return voices, nil
+}
+
+// save writes the manifest, sorted by name.
+func (l *VoiceLibrary) save(voices []LibraryVoice) error {
+	sort.Slice(voices, func(i, j int) bool { return voices[i].Name < voices[j].Name })
+	data, err := json.MarshalIndent(voices, "", "  ")
+	if err != nil {
+		return err
+	}
+	return l.writeFile(libraryManifest, append(data, '\n'))
+}
+
+// readFile reads a file of the library.
+func (l *VoiceLibrary) readFile(name string) ([]byte, error) {
+	f, err := l.storage.Open(path.Join(l.dir, name))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return io.ReadAll(f)
+}
+
+// writeFile creates or replaces a file of the library.
+func (l *VoiceLibrary) writeFile(name string, data []byte) error {
+	w, err := l.storage.Create(path.Join(l.dir, name))
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// lastSampleNumber returns the highest N of the voice's sample files named
+// name-N.ext, or 0 if there are none.
+func lastSampleNumber(voice *LibraryVoice) int {
+	last := 0
+	for _, sample := range voice.Samples {
+		base := strings.TrimSuffix(sample.File, path.Ext(sample.File))
+		if n, err := strconv.Atoi(strings.TrimPrefix(base, voice.Name+"-")); err == nil && strings.HasPrefix(base, voice.Name+"-") {
+			last = max(last, n)
+		}
+	}
+	return last
+}
+
+// libraryFileUsed reports whether any sample in the manifest uses file.
+func libraryFileUsed(voices []LibraryVoice, file string) bool {
+	for _, voice := range voices {
+		for _, sample := range voice.Samples {
+			if sample.File == file {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// findLibraryVoice returns the index of the named voice.
+func findLibraryVoice(voices []LibraryVoice, name string) (int, bool) {
+	for i := range voices {
+		if voices[i].Name == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// validateLibraryName returns a ValidationError if name can't be used in
+// sample file names.
+func validateLibraryName(name string) error {
+	if name == "" || !isLibraryName(name) {
+		return newValidationError("invalid voice name %q: use letters, digits, '-', and '_'", name)
+	}
+	return nil
+}
+
+func isLibraryName(name string) bool {
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,130 @@
+package fishaudio
+
+import (
+	"errors"
+	"io/fs"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// sampleWAV returns a two second tone as a 16 kHz WAV training sample.
+func sampleWAV(freq float64) []byte {
+	return encodeWAV(pcmFormat{sampleRate: 16000, channels: 1, bitsPerSample: 16}, sinePCM(freq, 0.3, 16000, 1, 2))
+}
+
+func TestVoiceLibrary(t *testing.T) {
+	files := NewMemStorage()
+	client := NewClient(WithAPIKey("test-key"), WithStorage(files))
+	lib := client.VoiceLibrary("lib")
+
+	if voices, err := lib.Voices(); err != nil || len(voices) != 0 {
+		t.Fatalf("Voices() = %v, %v, want an empty library", voices, err)
+	}
+
+	one, two := sampleWAV(220), sampleWAV(330)
+	if err := lib.AddSample("narrator", one, "First sample."); err != nil {
+		t.Fatalf("AddSample() error = %v", err)
+	}
+	if err := lib.AddSample("narrator", two, "Second sample."); err != nil {
+		t.Fatalf("AddSample() error = %v", err)
+	}
+	if err := lib.SetVoice(LibraryVoice{
+		Name:     "narrator",
+		Title:    "Warm Narrator",
+		Tags:     []string{"warm"},
+		Language: LanguageEnglish,
+		Metadata: map[string]string{"consent": "2026-01-05"},
+		Samples:  []LibrarySample{{File: "ignored.wav"}},
+	}); err != nil {
+		t.Fatalf("SetVoice() error = %v", err)
+	}
+
+	voice, err := lib.Voice("narrator")
+	if err != nil {
+		t.Fatalf("Voice() error = %v", err)
+	}
+	wantSamples := []LibrarySample{{File: "narrator-1.wav", Text: "First sample."}, {File: "narrator-2.wav", Text: "Second sample."}}
+	if !reflect.DeepEqual(voice.Samples, wantSamples) || voice.Title != "Warm Narrator" || voice.Metadata["consent"] != "2026-01-05" {
+		t.Errorf("Voice() = %+v", voice)
+	}
+	if data, err := files.ReadFile("lib/narrator-2.wav"); err != nil || string(data) != string(two) {
+		t.Errorf("lib/narrator-2.wav = %d bytes, %v", len(data), err)
+	}
+	if manifest, _ := files.ReadFile("lib/voices.json"); !strings.Contains(string(manifest), `"language": "en"`) {
+		t.Errorf("voices.json = %s", manifest)
+	}
+
+	refs, err := lib.References("narrator")
+	if err != nil {
+		t.Fatalf("References() error = %v", err)
+	}
+	if len(refs) != 2 || string(refs[0].Audio) != string(one) || refs[1].Text != "Second sample." {
+		t.Errorf("References() = %d refs", len(refs))
+	}
+
+	params, err := lib.CreateParams("narrator")
+	if err != nil {
+		t.Fatalf("CreateParams() error = %v", err)
+	}
+	if params.Title != "Warm Narrator" || len(params.Voices) != 2 || !reflect.DeepEqual(params.Texts, []string{"First sample.", "Second sample."}) ||
+		!reflect.DeepEqual(params.Tags, []string{"warm"}) {
+		t.Errorf("CreateParams() = %+v", params)
+	}
+}
+
+func TestVoiceLibrary_Errors(t *testing.T) {
+	files := NewMemStorage()
+	lib := NewVoiceLibrary(files, "lib")
+	var validationErr *ValidationError
+
+	if err := lib.AddSample("../escape", sampleWAV(220), "text"); !errors.As(err, &validationErr) {
+		t.Errorf("AddSample(bad name) error = %v, want *ValidationError", err)
+	}
+	if err := lib.AddSample("quiet", make([]byte, 100), "text"); !errors.As(err, &validationErr) {
+		t.Errorf("AddSample(bad audio) error = %v, want *ValidationError", err)
+	}
+	if err := lib.AddSample("quiet", sampleWAV(220), " "); !errors.As(err, &validationErr) {
+		t.Errorf("AddSample(no text) error = %v, want *ValidationError", err)
+	}
+	if _, err := lib.Voice("missing"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Voice(missing) error = %v, want fs.ErrNotExist", err)
+	}
+
+	// A voice without samples, and one whose file went missing
+	_ = lib.SetVoice(LibraryVoice{Name: "empty"})
+	_ = lib.AddSample("lost", sampleWAV(220), "text")
+	files.WriteFile("lib/voices.json", []byte(`[{"name":"lost","samples":[{"file":"lost-1.wav","text":"text"},{"file":"gone.wav","text":""}]}]`))
+
+	err := lib.Validate("lost")
+	if !errors.As(err, &validationErr) || !strings.Contains(err.Error(), "gone.wav") {
+		t.Errorf("Validate(lost) error = %v, want a ValidationError naming gone.wav", err)
+	}
+	if _, err := lib.References("empty"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("References(empty) error = %v, want fs.ErrNotExist after the manifest was replaced", err)
+	}
+
+	// Sample numbers skip files that already exist
+	files.WriteFile("lib/voices.json", []byte(`[]`))
+	if err := lib.AddSample("lost", sampleWAV(330), "again"); err != nil {
+		t.Fatalf("AddSample() error = %v", err)
+	}
+	if voice, _ := lib.Voice("lost"); voice.Samples[0].File != "lost-2.wav" {
+		t.Errorf("sample file = %q, want lost-2.wav", voice.Samples[0].File)
+	}
+
+	// A hand-edited manifest pointing at missing files doesn't get its
+	// names reused
+	files.WriteFile("lib/voices.json", []byte(`[{"name":"lost","samples":[{"file":"lost-1.wav","text":"a"},{"file":"lost-3.wav","text":"b"}]}]`))
+	if err := lib.AddSample("lost", sampleWAV(440), "next"); err != nil {
+		t.Fatalf("AddSample() error = %v", err)
+	}
+	if voice, _ := lib.Voice("lost"); len(voice.Samples) != 3 || voice.Samples[2].File != "lost-4.wav" {
+		t.Errorf("samples = %+v, want the new one in lost-4.wav", voice.Samples)
+	}
+
+	files.WriteFile("lib/voices.json", []byte(`{`))
+	if _, err := lib.Voices(); err == nil {
+		t.Error("Voices() with a corrupt manifest error = nil, want error")
+	}
+}
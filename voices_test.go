@@ -3,8 +3,10 @@ package fishaudio
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -26,6 +28,11 @@ func TestVoicesService_List_DefaultParams(t *testing.T) {
 		if query.Get("sort_by") != "task_count" {
 			t.Errorf("sort_by = %q, want %q", query.Get("sort_by"), "task_count")
 		}
+		for _, key := range []string{"sort_order", "query", "state", "visibility", "train_mode"} {
+			if query.Has(key) {
+				t.Errorf("%s should be omitted by default", key)
+			}
+		}
 
 		w.Header().Set("Content-Type", "application/json")
 		_ = json.NewEncoder(w).Encode(PaginatedResponse[Voice]{
@@ -64,6 +71,9 @@ func TestVoicesService_List_WithParams(t *testing.T) {
 		if query.Get("sort_by") != "created_at" {
 			t.Errorf("sort_by = %q, want %q", query.Get("sort_by"), "created_at")
 		}
+		if query.Get("sort_order") != "asc" {
+			t.Errorf("sort_order = %q, want %q", query.Get("sort_order"), "asc")
+		}
 
 		// Check multi-value params
 		tags := query["tag"]
@@ -94,12 +104,102 @@ func TestVoicesService_List_WithParams(t *testing.T) {
 		AuthorID:   "author-123",
 		Language:   []string{"en", "zh"},
 		SortBy:     "created_at",
+		SortOrder:  SortAscending,
+	})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+}
+
+func TestVoicesService_List_Filters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		want := map[string]string{"self": "true", "state": "training", "visibility": "private", "train_mode": "fast"}
+		for key, value := range want {
+			if query.Get(key) != value {
+				t.Errorf("%s = %q, want %q", key, query.Get(key), value)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(PaginatedResponse[Voice]{Items: []Voice{}})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	_, err := client.Voices.List(context.Background(), &ListVoicesParams{
+		SelfOnly:   true,
+		State:      ModelStateTraining,
+		Visibility: VisibilityPrivate,
+		TrainMode:  TrainModeFast,
 	})
 	if err != nil {
 		t.Fatalf("List() error = %v", err)
 	}
 }
 
+func TestVoicesService_List_Sort(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		query := r.URL.Query()
+		if query.Get("sort_by") != "like_count" || query.Get("sort_order") != "desc" {
+			t.Errorf("sort_by = %q, sort_order = %q, want like_count desc", query.Get("sort_by"), query.Get("sort_order"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(PaginatedResponse[Voice]{Items: []Voice{}})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if _, err := client.Voices.List(context.Background(), &ListVoicesParams{SortBy: SortByLikeCount, SortOrder: SortDescending}); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	for _, params := range []*ListVoicesParams{{SortBy: "popularity"}, {SortOrder: "DESC"}} {
+		_, err := client.Voices.List(context.Background(), params)
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("List(%+v) error = %v, want *ValidationError", params, err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, want invalid sorts rejected before sending", requests)
+	}
+}
+
+func TestVoicesService_Search(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("query") != "calm narrator" {
+			t.Errorf("query = %q, want %q", query.Get("query"), "calm narrator")
+		}
+		if query.Get("page_size") != "5" {
+			t.Errorf("page_size = %q, want %q", query.Get("page_size"), "5")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(PaginatedResponse[Voice]{
+			Total: 1,
+			Items: []Voice{{ID: "voice-1"}},
+		})
+	}))
+	defer server.Close()
+
+	params := &ListVoicesParams{PageSize: 5, Query: "ignored"}
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	result, err := client.Voices.Search(context.Background(), "calm narrator", params)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(result.Items) != 1 {
+		t.Errorf("got %d items, want 1", len(result.Items))
+	}
+	if params.Query != "ignored" {
+		t.Error("Search() should not modify params")
+	}
+}
+
 func TestVoicesService_List_Response(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -161,6 +261,92 @@ func TestVoicesService_Get(t *testing.T) {
 	}
 }
 
+func TestVoicesService_Create_TrainMode(t *testing.T) {
+	tests := []struct {
+		name string
+		mode TrainMode
+		want string
+	}{
+		{"default", "", "fast"},
+		{"full", TrainModeFull, "full"},
+		{"normalized", " Full ", "full"},
+		{"unknown passed through", "studio_v2", "studio_v2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseMultipartForm(10 << 20); err != nil {
+					t.Fatalf("ParseMultipartForm error = %v", err)
+				}
+				if got := r.FormValue("train_mode"); got != tt.want {
+					t.Errorf("train_mode = %q, want %q", got, tt.want)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(Voice{ID: "new-voice"})
+			}))
+			defer server.Close()
+
+			params := &CreateVoiceParams{Title: "Test", Voices: [][]byte{testVoiceSample()}, TrainMode: tt.mode}
+			client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			if _, err := client.Voices.Create(context.Background(), params); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if params.TrainMode != tt.mode {
+				t.Error("Create() should not modify params")
+			}
+		})
+	}
+}
+
+func TestVoicesService_Create_InvalidTrainMode(t *testing.T) {
+	client := NewClient(WithAPIKey("test-key"))
+	for _, mode := range []TrainMode{"   ", "fast mode", "fast&x=1"} {
+		_, err := client.Voices.Create(context.Background(), &CreateVoiceParams{
+			Title:     "Test",
+			Voices:    [][]byte{testVoiceSample()},
+			TrainMode: mode,
+		})
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Create() with train mode %q error = %v, want *ValidationError", mode, err)
+		}
+	}
+}
+
+func TestVoicesService_CoverImageValidation(t *testing.T) {
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL("http://127.0.0.1:0"))
+
+	_, err := client.Voices.Create(context.Background(), &CreateVoiceParams{
+		Title:      "Test",
+		Voices:     [][]byte{testVoiceSample()},
+		CoverImage: []byte("not an image"),
+	})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("Create() error = %v, want *ValidationError", err)
+	}
+
+	err = client.Voices.Update(context.Background(), "voice-123", &UpdateVoiceParams{
+		CoverImage: testPNG(t, MaxCoverImageDimension+1, 1),
+	})
+	if !errors.As(err, &validationErr) {
+		t.Errorf("Update() error = %v, want *ValidationError", err)
+	}
+}
+
+func TestVoicesService_Create_TextsMismatch(t *testing.T) {
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL("http://127.0.0.1:0"))
+	_, err := client.Voices.Create(context.Background(), &CreateVoiceParams{
+		Title:  "Test",
+		Voices: [][]byte{testVoiceSample(), testVoiceSample()},
+		Texts:  []string{"only one"},
+	})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("Create() error = %v, want *ValidationError", err)
+	}
+}
+
 func TestVoicesService_Create_RequiresVoices(t *testing.T) {
 	client := NewClient(WithAPIKey("test-key"))
 
@@ -214,7 +400,7 @@ func TestVoicesService_Create_Defaults(t *testing.T) {
 	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
 	_, err := client.Voices.Create(context.Background(), &CreateVoiceParams{
 		Title:  "Test Voice",
-		Voices: [][]byte{[]byte("audio data")},
+		Voices: [][]byte{testVoiceSample()},
 	})
 	if err != nil {
 		t.Fatalf("Create() error = %v", err)
@@ -270,8 +456,8 @@ func TestVoicesService_Create_AllFields(t *testing.T) {
 		if r.FormValue("visibility") != "public" {
 			t.Errorf("visibility = %q, want %q", r.FormValue("visibility"), "public")
 		}
-		if r.FormValue("texts") != "hello,world" {
-			t.Errorf("texts = %q, want %q", r.FormValue("texts"), "hello,world")
+		if texts := r.MultipartForm.Value["texts"]; !reflect.DeepEqual(texts, []string{"hello, there", "world"}) {
+			t.Errorf("texts = %q, want %q", texts, []string{"hello, there", "world"})
 		}
 		if r.FormValue("tags") != "english,female" {
 			t.Errorf("tags = %q, want %q", r.FormValue("tags"), "english,female")
@@ -301,6 +487,9 @@ func TestVoicesService_Create_AllFields(t *testing.T) {
 		if coverHeader.Filename != "cover.png" {
 			t.Errorf("cover filename = %q, want %q", coverHeader.Filename, "cover.png")
 		}
+		if ct := coverHeader.Header.Get("Content-Type"); ct != "image/png" {
+			t.Errorf("cover content type = %q, want %q", ct, "image/png")
+		}
 
 		w.Header().Set("Content-Type", "application/json")
 		_ = json.NewEncoder(w).Encode(Voice{ID: "new-voice", Title: "My Voice"})
@@ -312,10 +501,10 @@ func TestVoicesService_Create_AllFields(t *testing.T) {
 		Title:               "My Voice",
 		Description:         "A test voice",
 		Visibility:          VisibilityPublic,
-		Voices:              [][]byte{[]byte("audio data")},
-		Texts:               []string{"hello", "world"},
+		Voices:              [][]byte{testVoiceSample(), testVoiceSample()},
+		Texts:               []string{"hello, there", "world"},
 		Tags:                []string{"english", "female"},
-		CoverImage:          []byte("image data"),
+		CoverImage:          testPNG(t, 16, 16),
 		EnhanceAudioQuality: &enhanceQuality,
 	})
 	if err != nil {
@@ -353,7 +542,7 @@ func TestVoicesService_Create_MultipleVoices(t *testing.T) {
 	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
 	voice, err := client.Voices.Create(context.Background(), &CreateVoiceParams{
 		Title:  "Multi Voice",
-		Voices: [][]byte{[]byte("audio1"), []byte("audio2")},
+		Voices: [][]byte{testVoiceSample(), testVoiceSample()},
 	})
 	if err != nil {
 		t.Fatalf("Create() error = %v", err)
@@ -384,7 +573,7 @@ func TestVoicesService_Create_ErrorResponse(t *testing.T) {
 			client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
 			_, err := client.Voices.Create(context.Background(), &CreateVoiceParams{
 				Title:  "Test",
-				Voices: [][]byte{[]byte("audio")},
+				Voices: [][]byte{testVoiceSample()},
 			})
 			if err == nil {
 				t.Fatal("expected error, got nil")
@@ -421,11 +610,14 @@ func TestVoicesService_Update_AllFields(t *testing.T) {
 		}
 
 		// Check cover image
-		cover, _, err := r.FormFile("cover_image")
+		cover, coverHeader, err := r.FormFile("cover_image")
 		if err != nil {
 			t.Fatalf("FormFile(cover_image) error = %v", err)
 		}
 		defer func() { _ = cover.Close() }()
+		if coverHeader.Filename != "cover.jpg" {
+			t.Errorf("cover filename = %q, want %q", coverHeader.Filename, "cover.jpg")
+		}
 
 		w.WriteHeader(http.StatusOK)
 	}))
@@ -437,7 +629,7 @@ func TestVoicesService_Update_AllFields(t *testing.T) {
 		Description: "Updated desc",
 		Visibility:  VisibilityPublic,
 		Tags:        []string{"tag1", "tag2"},
-		CoverImage:  []byte("new image"),
+		CoverImage:  testJPEG(t, 16, 16),
 	})
 	if err != nil {
 		t.Fatalf("Update() error = %v", err)
@@ -504,3 +696,141 @@ func TestVoicesService_Update_ErrorResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestVoicesService_LikeMark(t *testing.T) {
+	tests := []struct {
+		name   string
+		call   func(*VoicesService) error
+		method string
+		path   string
+	}{
+		{"Like", func(s *VoicesService) error { return s.Like(context.Background(), "voice-123") }, http.MethodPost, "/model/voice-123/like"},
+		{"Unlike", func(s *VoicesService) error { return s.Unlike(context.Background(), "voice-123") }, http.MethodDelete, "/model/voice-123/like"},
+		{"Mark", func(s *VoicesService) error { return s.Mark(context.Background(), "voice-123") }, http.MethodPost, "/model/voice-123/mark"},
+		{"Unmark", func(s *VoicesService) error { return s.Unmark(context.Background(), "voice-123") }, http.MethodDelete, "/model/voice-123/mark"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != tt.method {
+					t.Errorf("Method = %q, want %q", r.Method, tt.method)
+				}
+				if r.URL.Path != tt.path {
+					t.Errorf("Path = %q, want %q", r.URL.Path, tt.path)
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			if err := tt.call(client.Voices); err != nil {
+				t.Fatalf("%s() error = %v", tt.name, err)
+			}
+		})
+	}
+}
+
+func TestVoicesService_Like_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	err := client.Voices.Like(context.Background(), "missing")
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("Like() error = %v, want *NotFoundError", err)
+	}
+}
+
+func TestVoicesService_DeleteMany(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("Method = %q, want %q", r.Method, http.MethodDelete)
+		}
+		switch r.URL.Path {
+		case "/model/gone":
+			w.WriteHeader(http.StatusNotFound)
+		case "/model/locked":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	results, err := client.Voices.DeleteMany(context.Background(), []string{"a", "gone", "locked", "b"})
+	if err != nil {
+		t.Fatalf("DeleteMany() error = %v", err)
+	}
+
+	if len(results) != 4 {
+		t.Fatalf("got %d results, want 4", len(results))
+	}
+	for i, id := range []string{"a", "gone", "locked", "b"} {
+		if results[i].ID != id {
+			t.Errorf("results[%d].ID = %q, want %q", i, results[i].ID, id)
+		}
+	}
+	if results[0].Err != nil || results[3].Err != nil {
+		t.Errorf("successful deletes reported errors: %v, %v", results[0].Err, results[3].Err)
+	}
+	if !results[1].NotFound || results[1].Err != nil {
+		t.Errorf("results[1] = %+v, want NotFound without error", results[1])
+	}
+	var permErr *PermissionError
+	if !errors.As(results[2].Err, &permErr) {
+		t.Errorf("results[2].Err = %v, want *PermissionError", results[2].Err)
+	}
+}
+
+func TestVoicesService_DeleteMany_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL("http://127.0.0.1:0"))
+	results, err := client.Voices.DeleteMany(ctx, []string{"a", "b"})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("DeleteMany() error = %v, want context.Canceled", err)
+	}
+	for _, r := range results {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("result %q error = %v, want context.Canceled", r.ID, r.Err)
+		}
+	}
+}
+
+func TestVoicesService_Exists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/model/present":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(Voice{ID: "present"})
+		case "/model/missing":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	tests := []struct {
+		id      string
+		want    bool
+		wantErr bool
+	}{
+		{"present", true, false},
+		{"missing", false, false},
+		{"broken", false, true},
+	}
+	for _, tt := range tests {
+		got, err := client.Voices.Exists(context.Background(), tt.id)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("Exists(%q) = %v, %v, want %v (error: %v)", tt.id, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package fishaudio
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Training audio limits checked by VoicesService.Create before upload.
+const (
+	// MinVoiceSampleDuration is the shortest accepted training sample.
+	MinVoiceSampleDuration = time.Second
+	// MaxVoiceSampleDuration is the longest accepted training sample.
+	MaxVoiceSampleDuration = 10 * time.Minute
+	// MaxVoiceUploadSize is the largest accepted total size of all
+	// training samples, in bytes.
+	MaxVoiceUploadSize = 100 << 20
+)
+
+// silenceThreshold is the RMS level, as a fraction of full scale, below
+// which a training sample frame counts as silent (-40 dBFS).
+const silenceThreshold = 0.01
+
+// validateVoiceSamples checks training samples against the upload limits
+// and returns a ValidationError listing every sample that fails. Duration
+// and silence are only checked for WAV samples, whose length is known
+// without decoding.
+func validateVoiceSamples(voices [][]byte) error {
+	var problems []string
+	total := 0
+	for i, voice := range voices {
+		total += len(voice)
+		if reason := checkVoiceSample(voice); reason != "" {
+			problems = append(problems, fmt.Sprintf("voice %d: %s", i, reason))
+		}
+	}
+	if total > MaxVoiceUploadSize {
+		problems = append(problems, fmt.Sprintf("total size %d bytes exceeds the %d byte limit", total, MaxVoiceUploadSize))
+	}
+	if len(problems) > 0 {
+		return newValidationError("invalid training audio: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// checkVoiceSample returns why a training sample is rejected, or "".
+func checkVoiceSample(voice []byte) string {
+	if len(voice) == 0 {
+		return "empty"
+	}
+	ext, _ := detectAudioFormat(voice)
+	if ext == "" {
+		return "unsupported format; use WAV, MP3, Ogg, or FLAC"
+	}
+	if ext != "wav" {
+		return ""
+	}
+
+	f, pcm, err := parseWAV(voice)
+	if err != nil {
+		return "not a valid PCM WAV file"
+	}
+	if d := f.duration(len(pcm)); d < MinVoiceSampleDuration {
+		return fmt.Sprintf("duration %v is shorter than %v", d.Round(time.Millisecond), MinVoiceSampleDuration)
+	} else if d > MaxVoiceSampleDuration {
+		return fmt.Sprintf("duration %v is longer than %v", d.Round(time.Millisecond), MaxVoiceSampleDuration)
+	}
+	if validBitDepth(f.bitsPerSample) && isSilent(f, pcm) {
+		return "no audible content"
+	}
+	return ""
+}
+
+// isSilent reports whether no frame of pcm rises above silenceThreshold.
+func isSilent(f pcmFormat, pcm []byte) bool {
+	frame := bytesFor(f, silenceFrame)
+	if frame <= 0 {
+		frame = len(pcm)
+	}
+	for pos := 0; pos < len(pcm); pos += frame {
+		end := pos + frame
+		if end > len(pcm) {
+			end = len(pcm)
+		}
+		if frameRMS(f, pcm[pos:end]) >= silenceThreshold {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package fishaudio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testVoiceSample returns a two-second audible WAV training sample.
+func testVoiceSample() []byte {
+	return encodeWAV(testPCMFormat, tone(2*time.Second, 1000))
+}
+
+func TestValidateVoiceSamples(t *testing.T) {
+	tests := []struct {
+		name   string
+		voices [][]byte
+		want   []string
+	}{
+		{"valid wav", [][]byte{testVoiceSample()}, nil},
+		{"mp3 not decoded", [][]byte{[]byte("ID3\x04rest of mp3")}, nil},
+		{"empty", [][]byte{{}}, []string{"voice 0: empty"}},
+		{"unknown format", [][]byte{[]byte("hello")}, []string{"voice 0: unsupported format"}},
+		{"too short", [][]byte{encodeWAV(testPCMFormat, tone(500*time.Millisecond, 1000))}, []string{"voice 0: duration 500ms is shorter"}},
+		{"silent", [][]byte{encodeWAV(testPCMFormat, tone(2*time.Second, 10))}, []string{"voice 0: no audible content"}},
+		{"broken wav", [][]byte{[]byte("RIFF\x00\x00\x00\x00WAVEjunk")}, []string{"voice 0: not a valid PCM WAV file"}},
+		{
+			"lists every failure",
+			[][]byte{testVoiceSample(), []byte("hello"), encodeWAV(testPCMFormat, tone(2*time.Second, 0))},
+			[]string{"voice 1: unsupported format", "voice 2: no audible content"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateVoiceSamples(tt.voices)
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("validateVoiceSamples() error = %v", err)
+				}
+				return
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("validateVoiceSamples() error = %v, want *ValidationError", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateVoiceSamples_TotalSize(t *testing.T) {
+	big := append([]byte("ID3"), make([]byte, MaxVoiceUploadSize/2)...)
+	err := validateVoiceSamples([][]byte{big, big, big})
+	if err == nil || !strings.Contains(err.Error(), "total size") {
+		t.Errorf("validateVoiceSamples() error = %v, want total size error", err)
+	}
+}
+
+func TestVoicesService_Create_ValidatesAudio(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"_id":"new-voice"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	params := &CreateVoiceParams{Title: "Test", Voices: [][]byte{[]byte("not audio")}}
+
+	var validationErr *ValidationError
+	if _, err := client.Voices.Create(context.Background(), params); !errors.As(err, &validationErr) {
+		t.Errorf("Create() error = %v, want *ValidationError", err)
+	}
+	if requests != 0 {
+		t.Errorf("requests = %d, want 0 for rejected audio", requests)
+	}
+
+	params.SkipAudioValidation = true
+	if _, err := client.Voices.Create(context.Background(), params); err != nil {
+		t.Errorf("Create() with SkipAudioValidation error = %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
+
+func TestVoiceSampleFile(t *testing.T) {
+	if got := voiceSampleFile(0, testVoiceSample()); got.name != "voice_0.wav" || got.contentType != "audio/wav" {
+		t.Errorf("voiceSampleFile(wav) = %+v", got)
+	}
+	if got := voiceSampleFile(2, []byte("ID3....")); got.name != "voice_2.mp3" || got.contentType != "audio/mpeg" {
+		t.Errorf("voiceSampleFile(mp3) = %+v", got)
+	}
+}
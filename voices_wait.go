@@ -0,0 +1,105 @@
+package fishaudio
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PollOptions configures VoicesService.WaitForTrained.
+type PollOptions struct {
+	// Interval is the wait before the second poll, doubled after each poll
+	// up to MaxInterval. Default: 2 seconds.
+	Interval time.Duration
+
+	// MaxInterval caps the wait between polls. Default: 30 seconds.
+	MaxInterval time.Duration
+
+	// Timeout is the maximum total wait. Zero waits until ctx is done.
+	Timeout time.Duration
+
+	// OnStateChange, if set, is called with the voice whenever its state
+	// differs from the previous poll, including after the first poll.
+	OnStateChange func(*Voice)
+}
+
+// WaitForTrained polls a voice until its training finishes. It returns the
+// trained voice, or a *TrainingFailedError if training failed. Transient
+// errors (rate limits, server errors, and network failures) are retried at
+// the next poll. If the wait times out or ctx is cancelled, the last
+// fetched voice is returned with an error wrapping ctx's error.
+//
+// Example:
+//
+//	voice, err := client.Voices.Create(ctx, params)
+//	if err != nil {
+//	    return err
+//	}
+//	voice, err = client.Voices.WaitForTrained(ctx, voice.ID, &fishaudio.PollOptions{
+//	    Timeout: 10 * time.Minute,
+//	    OnStateChange: func(v *fishaudio.Voice) {
+//	        log.Printf("voice %s is %s", v.ID, v.State)
+//	    },
+//	})
+func (s *VoicesService) WaitForTrained(ctx context.Context, voiceID string, opts *PollOptions) (*Voice, error) {
+	var o PollOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Interval <= 0 {
+		o.Interval = 2 * time.Second
+	}
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = 30 * time.Second
+	}
+	if o.MaxInterval < o.Interval {
+		o.MaxInterval = o.Interval
+	}
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+
+	var last *Voice
+	delay := o.Interval
+	for {
+		voice, err := s.Get(ctx, voiceID)
+		switch {
+		case err == nil:
+			if last == nil || voice.State != last.State {
+				if o.OnStateChange != nil {
+					o.OnStateChange(voice)
+				}
+			}
+			last = voice
+
+			switch voice.State {
+			case ModelStateTrained:
+				return voice, nil
+			case ModelStateFailed:
+				return voice, &TrainingFailedError{Voice: voice}
+			}
+		case ctx.Err() != nil:
+			// The error is from the cancelled request; report ctx below
+		case !isTransient(err):
+			return last, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			if last == nil {
+				return nil, fmt.Errorf("waiting for voice %s: %w", voiceID, ctx.Err())
+			}
+			return last, fmt.Errorf("voice %s still %s: %w", voiceID, last.State, ctx.Err())
+		}
+
+		delay *= 2
+		if delay > o.MaxInterval {
+			delay = o.MaxInterval
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package fishaudio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// voiceStates serves GET /model/{id} with the given states in turn, where
+// an empty state responds with 503. The last state repeats.
+func voiceStates(t *testing.T, polls *atomic.Int32, states ...ModelState) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(polls.Add(1)) - 1
+		if i >= len(states) {
+			i = len(states) - 1
+		}
+		if states[i] == "" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Voice{ID: "voice-123", State: states[i]})
+	}))
+}
+
+func TestVoicesService_WaitForTrained(t *testing.T) {
+	var polls atomic.Int32
+	server := voiceStates(t, &polls,
+		ModelStateCreated, ModelStateTraining, "", ModelStateTraining, ModelStateTrained)
+	defer server.Close()
+
+	var changes []ModelState
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	voice, err := client.Voices.WaitForTrained(context.Background(), "voice-123", &PollOptions{
+		Interval:      time.Millisecond,
+		OnStateChange: func(v *Voice) { changes = append(changes, v.State) },
+	})
+	if err != nil {
+		t.Fatalf("WaitForTrained() error = %v", err)
+	}
+	if voice.State != ModelStateTrained {
+		t.Errorf("State = %q, want %q", voice.State, ModelStateTrained)
+	}
+	if polls.Load() != 5 {
+		t.Errorf("polls = %d, want 5", polls.Load())
+	}
+	want := []ModelState{ModelStateCreated, ModelStateTraining, ModelStateTrained}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("state changes = %v, want %v", changes, want)
+	}
+}
+
+func TestVoicesService_WaitForTrained_Failed(t *testing.T) {
+	var polls atomic.Int32
+	server := voiceStates(t, &polls, ModelStateTraining, ModelStateFailed)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	voice, err := client.Voices.WaitForTrained(context.Background(), "voice-123", &PollOptions{Interval: time.Millisecond})
+	var failed *TrainingFailedError
+	if !errors.As(err, &failed) {
+		t.Fatalf("WaitForTrained() error = %v, want *TrainingFailedError", err)
+	}
+	if voice == nil || voice.State != ModelStateFailed || failed.Voice != voice {
+		t.Errorf("voice = %+v, want the failed voice", voice)
+	}
+}
+
+func TestVoicesService_WaitForTrained_Timeout(t *testing.T) {
+	var polls atomic.Int32
+	server := voiceStates(t, &polls, ModelStateTraining)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	voice, err := client.Voices.WaitForTrained(context.Background(), "voice-123", &PollOptions{
+		Interval:    time.Millisecond,
+		MaxInterval: 5 * time.Millisecond,
+		Timeout:     50 * time.Millisecond,
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("WaitForTrained() error = %v, want context.DeadlineExceeded", err)
+	}
+	if voice == nil || voice.State != ModelStateTraining {
+		t.Errorf("voice = %+v, want the last fetched voice", voice)
+	}
+	if polls.Load() < 3 {
+		t.Errorf("polls = %d, want several", polls.Load())
+	}
+}
+
+func TestVoicesService_WaitForTrained_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	_, err := client.Voices.WaitForTrained(context.Background(), "missing", &PollOptions{Interval: time.Millisecond})
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("WaitForTrained() error = %v, want *NotFoundError", err)
+	}
+}
@@ -0,0 +1,200 @@
+package fishaudio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultPCMSampleRate is the sample rate used for PCM output when none is requested.
+const DefaultPCMSampleRate = 44100
+
+// streamingSize is the RIFF and data chunk size written for audio of unknown
+// length. Browsers and most decoders treat it as "until end of stream".
+const streamingSize = 0xFFFFFFFF
+
+// pcmFormat describes uncompressed little-endian PCM audio.
+type pcmFormat struct {
+	sampleRate    int
+	channels      int
+	bitsPerSample int
+}
+
+// blockAlign returns the size of one sample frame across all channels.
+func (f pcmFormat) blockAlign() int {
+	return f.channels * f.bitsPerSample / 8
+}
+
+// bytesPerSecond returns the data rate of the audio.
+func (f pcmFormat) bytesPerSecond() int {
+	return f.sampleRate * f.blockAlign()
+}
+
+// duration returns the playback duration of n bytes of audio.
+func (f pcmFormat) duration(n int) time.Duration {
+	if f.bytesPerSecond() == 0 {
+		return 0
+	}
+	return time.Duration(float64(n) / float64(f.bytesPerSecond()) * float64(time.Second))
+}
+
+// wavHeader returns a 44-byte header for 16-bit mono PCM of unknown length.
+func wavHeader(sampleRate int) []byte {
+	if sampleRate <= 0 {
+		sampleRate = DefaultPCMSampleRate
+	}
+	return encodeWAVHeader(pcmFormat{sampleRate: sampleRate, channels: 1, bitsPerSample: 16}, streamingSize-36)
+}
+
+// encodeWAVHeader returns a 44-byte header for dataSize bytes of PCM.
+func encodeWAVHeader(f pcmFormat, dataSize uint32) []byte {
+	riffSize := uint32(streamingSize)
+	if dataSize < streamingSize-36 {
+		riffSize = dataSize + 36
+	}
+
+	h := make([]byte, 44)
+	copy(h[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(h[4:8], riffSize)
+	copy(h[8:12], "WAVE")
+	copy(h[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(h[16:20], 16)
+	binary.LittleEndian.PutUint16(h[20:22], 1) // PCM
+	binary.LittleEndian.PutUint16(h[22:24], uint16(f.channels))
+	binary.LittleEndian.PutUint32(h[24:28], uint32(f.sampleRate))
+	binary.LittleEndian.PutUint32(h[28:32], uint32(f.bytesPerSecond()))
+	binary.LittleEndian.PutUint16(h[32:34], uint16(f.blockAlign()))
+	binary.LittleEndian.PutUint16(h[34:36], uint16(f.bitsPerSample))
+	copy(h[36:40], "data")
+	binary.LittleEndian.PutUint32(h[40:44], dataSize)
+	return h
+}
+
+// encodeWAV wraps PCM data in a WAV container.
+func encodeWAV(f pcmFormat, pcm []byte) []byte {
+	out := make([]byte, 0, 44+len(pcm))
+	out = append(out, encodeWAVHeader(f, uint32(len(pcm)))...)
+	return append(out, pcm...)
+}
+
+// errNotPCMWAV is returned when audio is not an uncompressed PCM WAV file.
+var errNotPCMWAV = errors.New("audio is not a PCM WAV file")
+
+// parseWAV returns the format and PCM data of a WAV file. A data chunk whose
+// size runs past the end of the file, as written by streaming encoders, is
+// truncated to the available bytes.
+func parseWAV(data []byte) (pcmFormat, []byte, error) {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return pcmFormat{}, nil, errNotPCMWAV
+	}
+
+	var f pcmFormat
+	haveFormat := false
+	for pos := 12; pos+8 <= len(data); {
+		id := string(data[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(data[pos+4 : pos+8]))
+		body := data[pos+8:]
+		if size < 0 || size > len(body) {
+			size = len(body)
+		}
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return pcmFormat{}, nil, errNotPCMWAV
+			}
+			// 1 is PCM, 0xFFFE is WAVE_FORMAT_EXTENSIBLE, used for PCM with
+			// more than two channels or 24-bit samples
+			if tag := binary.LittleEndian.Uint16(body[0:2]); tag != 1 && tag != 0xFFFE {
+				return pcmFormat{}, nil, fmt.Errorf("%w: unsupported encoding %d", errNotPCMWAV, tag)
+			}
+			f = pcmFormat{
+				channels:      int(binary.LittleEndian.Uint16(body[2:4])),
+				sampleRate:    int(binary.LittleEndian.Uint32(body[4:8])),
+				bitsPerSample: int(binary.LittleEndian.Uint16(body[14:16])),
+			}
+			if f.blockAlign() == 0 || f.sampleRate == 0 {
+				return pcmFormat{}, nil, errNotPCMWAV
+			}
+			haveFormat = true
+		case "data":
+			if !haveFormat {
+				return pcmFormat{}, nil, errNotPCMWAV
+			}
+			pcm := body[:size]
+			return f, pcm[:len(pcm)-len(pcm)%f.blockAlign()], nil
+		}
+
+		// Chunks are padded to an even size
+		pos += 8 + size + size%2
+	}
+	return pcmFormat{}, nil, errNotPCMWAV
+}
+
+// NewWAVReader returns a reader that yields a streaming WAV header followed
+// by the raw 16-bit mono PCM read from r. Use it with a live stream started
+// with Format set to AudioFormatPCM so clients can play audio progressively.
+// A sampleRate of zero uses DefaultPCMSampleRate.
+//
+// Example:
+//
+//	stream, _ := client.TTS.StreamWebSocket(ctx, textChan, &fishaudio.StreamParams{
+//	    Format: fishaudio.AudioFormatPCM,
+//	    Config: &fishaudio.TTSConfig{SampleRate: 24000},
+//	}, nil)
+//	w.Header().Set("Content-Type", "audio/wav")
+//	io.Copy(w, fishaudio.NewWAVReader(stream, 24000))
+func NewWAVReader(r io.Reader, sampleRate int) io.Reader {
+	return io.MultiReader(bytes.NewReader(wavHeader(sampleRate)), r)
+}
+
+// WAVWriter writes raw 16-bit mono PCM to an underlying writer as a
+// streaming WAV file. The header is written before the first chunk, and the
+// writer is flushed after every chunk if it implements http.Flusher.
+//
+// Example:
+//
+//	ww := fishaudio.NewWAVWriter(w, 24000)
+//	err := client.TTS.StreamWebSocketTo(ctx, textChan, ww, &fishaudio.StreamParams{
+//	    Format: fishaudio.AudioFormatPCM,
+//	    Config: &fishaudio.TTSConfig{SampleRate: 24000},
+//	}, nil)
+type WAVWriter struct {
+	w           io.Writer
+	sampleRate  int
+	wroteHeader bool
+}
+
+// NewWAVWriter creates a WAVWriter. A sampleRate of zero uses DefaultPCMSampleRate.
+func NewWAVWriter(w io.Writer, sampleRate int) *WAVWriter {
+	return &WAVWriter{w: w, sampleRate: sampleRate}
+}
+
+// WriteHeader writes the WAV header if it hasn't been written yet. Call it
+// to start the response before any audio arrives.
+func (ww *WAVWriter) WriteHeader() error {
+	if ww.wroteHeader {
+		return nil
+	}
+	if _, err := ww.w.Write(wavHeader(ww.sampleRate)); err != nil {
+		return err
+	}
+	ww.wroteHeader = true
+	return nil
+}
+
+// Write writes a chunk of PCM audio, preceded by the header on first use.
+func (ww *WAVWriter) Write(p []byte) (int, error) {
+	if err := ww.WriteHeader(); err != nil {
+		return 0, err
+	}
+	n, err := ww.w.Write(p)
+	if f, ok := ww.w.(http.Flusher); ok && err == nil {
+		f.Flush()
+	}
+	return n, err
+}
@@ -0,0 +1,191 @@
+package fishaudio
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWAVHeader(t *testing.T) {
+	h := wavHeader(24000)
+	if len(h) != 44 {
+		t.Fatalf("len(header) = %d, want 44", len(h))
+	}
+	if string(h[0:4]) != "RIFF" || string(h[8:12]) != "WAVE" || string(h[36:40]) != "data" {
+		t.Errorf("unexpected chunk IDs in header %q", h)
+	}
+	if got := binary.LittleEndian.Uint32(h[24:28]); got != 24000 {
+		t.Errorf("sample rate = %d, want 24000", got)
+	}
+	if got := binary.LittleEndian.Uint32(h[28:32]); got != 48000 {
+		t.Errorf("byte rate = %d, want 48000", got)
+	}
+	if got := binary.LittleEndian.Uint16(h[34:36]); got != 16 {
+		t.Errorf("bits per sample = %d, want 16", got)
+	}
+}
+
+func TestWAVHeader_DefaultSampleRate(t *testing.T) {
+	h := wavHeader(0)
+	if got := binary.LittleEndian.Uint32(h[24:28]); got != DefaultPCMSampleRate {
+		t.Errorf("sample rate = %d, want %d", got, DefaultPCMSampleRate)
+	}
+}
+
+func TestNewWAVReader(t *testing.T) {
+	data, err := io.ReadAll(NewWAVReader(strings.NewReader("pcm"), 16000))
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if !bytes.Equal(data[:44], wavHeader(16000)) {
+		t.Error("output does not start with the WAV header")
+	}
+	if string(data[44:]) != "pcm" {
+		t.Errorf("payload = %q, want %q", data[44:], "pcm")
+	}
+}
+
+func TestWAVWriter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	ww := NewWAVWriter(rec, 16000)
+
+	for _, chunk := range []string{"ab", "cd"} {
+		n, err := ww.Write([]byte(chunk))
+		if err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		if n != len(chunk) {
+			t.Errorf("Write() = %d, want %d", n, len(chunk))
+		}
+	}
+
+	body := rec.Body.Bytes()
+	if !bytes.Equal(body[:44], wavHeader(16000)) {
+		t.Error("output does not start with the WAV header")
+	}
+	if string(body[44:]) != "abcd" {
+		t.Errorf("payload = %q, want %q", body[44:], "abcd")
+	}
+	if !rec.Flushed {
+		t.Error("expected the writer to be flushed")
+	}
+}
+
+func TestWAVWriter_WriteHeaderOnce(t *testing.T) {
+	var buf bytes.Buffer
+	ww := NewWAVWriter(&buf, 0)
+	if err := ww.WriteHeader(); err != nil {
+		t.Fatalf("WriteHeader() error = %v", err)
+	}
+	if err := ww.WriteHeader(); err != nil {
+		t.Fatalf("WriteHeader() error = %v", err)
+	}
+	if buf.Len() != 44 {
+		t.Errorf("wrote %d bytes, want 44", buf.Len())
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("write failed") }
+
+func TestWAVWriter_HeaderError(t *testing.T) {
+	ww := NewWAVWriter(failingWriter{}, 0)
+	if _, err := ww.Write([]byte("pcm")); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+func TestTTSService_StreamWebSocketTo_WAV(t *testing.T) {
+	server := newEchoLiveServer(t)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	textChan := make(chan string, 1)
+	textChan <- "pcm"
+	close(textChan)
+
+	var buf bytes.Buffer
+	err := client.TTS.StreamWebSocketTo(context.Background(), textChan, NewWAVWriter(&buf, 0), &StreamParams{Format: AudioFormatPCM}, nil)
+	if err != nil {
+		t.Fatalf("StreamWebSocketTo() error = %v", err)
+	}
+	if buf.Len() != 44+len("pcm") || string(buf.Bytes()[44:]) != "pcm" {
+		t.Errorf("output = %q, want header followed by %q", buf.Bytes(), "pcm")
+	}
+}
+
+func TestParseWAV_RoundTrip(t *testing.T) {
+	format := pcmFormat{sampleRate: 16000, channels: 2, bitsPerSample: 16}
+	pcm := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+
+	gotFormat, gotPCM, err := parseWAV(encodeWAV(format, pcm))
+	if err != nil {
+		t.Fatalf("parseWAV() error = %v", err)
+	}
+	if gotFormat != format {
+		t.Errorf("format = %+v, want %+v", gotFormat, format)
+	}
+	if !bytes.Equal(gotPCM, pcm) {
+		t.Errorf("pcm = %v, want %v", gotPCM, pcm)
+	}
+}
+
+func TestParseWAV_SkipsChunksAndTruncates(t *testing.T) {
+	format := pcmFormat{sampleRate: 8000, channels: 1, bitsPerSample: 16}
+	header := encodeWAVHeader(format, streamingSize-36)
+
+	// Insert an odd-sized LIST chunk before data, padded to an even size
+	var buf bytes.Buffer
+	buf.Write(header[:36])
+	buf.WriteString("LIST")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(3))
+	buf.Write([]byte{'a', 'b', 'c', 0})
+	buf.Write(header[36:])
+	buf.Write([]byte{1, 2, 3, 4, 5}) // trailing partial sample
+
+	_, pcm, err := parseWAV(buf.Bytes())
+	if err != nil {
+		t.Fatalf("parseWAV() error = %v", err)
+	}
+	if !bytes.Equal(pcm, []byte{1, 2, 3, 4}) {
+		t.Errorf("pcm = %v, want [1 2 3 4]", pcm)
+	}
+}
+
+func TestParseWAV_Invalid(t *testing.T) {
+	compressed := encodeWAV(pcmFormat{sampleRate: 8000, channels: 1, bitsPerSample: 16}, []byte{0, 0})
+	binary.LittleEndian.PutUint16(compressed[20:22], 85) // MPEG Layer 3
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"mp3", []byte("ID3\x04\x00\x00\x00\x00\x00\x00\x00\x00")},
+		{"compressed", compressed},
+		{"no data chunk", encodeWAVHeader(pcmFormat{sampleRate: 8000, channels: 1, bitsPerSample: 16}, 0)[:36]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := parseWAV(tt.data); !errors.Is(err, errNotPCMWAV) {
+				t.Errorf("parseWAV() err = %v, want %v", err, errNotPCMWAV)
+			}
+		})
+	}
+}
+
+func TestPCMFormat_Duration(t *testing.T) {
+	format := pcmFormat{sampleRate: 16000, channels: 1, bitsPerSample: 16}
+	if got := format.duration(32000); got != time.Second {
+		t.Errorf("duration(32000) = %v, want %v", got, time.Second)
+	}
+}
@@ -0,0 +1,203 @@
+package fishaudio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// wirePayloadLimit is the number of bytes of each payload shown in the
+// wire log.
+const wirePayloadLimit = 256
+
+// redacted replaces credentials in the wire log.
+const redacted = "[REDACTED]"
+
+// WithWireLog writes a trace of the client's traffic to w, for debugging
+// protocol issues: a line for each HTTP request and response with its
+// headers, and for each WebSocket frame with its event, size, and the start
+// of its payload. Audio is shown by size only. Credentials, such as the API
+// key and presigned URL signatures, are redacted, so traces can be shared
+// with Fish Audio support.
+//
+// Example:
+//
+//	client := fishaudio.NewClient(fishaudio.WithWireLog(os.Stderr))
+func WithWireLog(w io.Writer) ClientOption {
+	return func(c *Client) {
+		c.wireLog = &wireLogger{w: w}
+	}
+}
+
+// wireLogger writes the wire log. A nil *wireLogger logs nothing.
+type wireLogger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// wire returns the client's wire logger, or nil if there is none. c may be
+// nil.
+func (c *Client) wire() *wireLogger {
+	if c == nil {
+		return nil
+	}
+	return c.wireLog
+}
+
+func (l *wireLogger) printf(format string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = fmt.Fprintf(l.w, time.Now().Format("15:04:05.000")+" "+format+"\n", args...)
+}
+
+// request logs an outgoing request. The body is shown if the request can
+// replay it.
+func (l *wireLogger) request(protocol string, req *http.Request) {
+	if l == nil {
+		return
+	}
+	body := ""
+	if req.GetBody != nil && req.ContentLength != 0 {
+		if r, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(io.LimitReader(r, wirePayloadLimit+1))
+			_ = r.Close()
+			body = " " + truncatePayload(data)
+		}
+	}
+	l.printf("%s > %s %s%s%s", protocol, req.Method, redactURL(req.URL), formatHeaders(req.Header), body)
+}
+
+// response logs the response to a request sent at start, or the error.
+func (l *wireLogger) response(protocol, method string, u *url.URL, start time.Time, resp *http.Response, err error) {
+	if l == nil {
+		return
+	}
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if resp == nil {
+		l.printf("%s < %s %s failed after %s: %v", protocol, method, redactURL(u), elapsed, err)
+		return
+	}
+	l.printf("%s < %s %s %s in %s%s", protocol, resp.Status, method, redactURL(u), elapsed, formatHeaders(resp.Header))
+}
+
+// frame logs a WebSocket frame. dir is ">" for sent frames and "<" for
+// received ones.
+func (l *wireLogger) frame(dir string, messageType int, data []byte) {
+	if l == nil {
+		return
+	}
+	kind := "binary"
+	var v interface{}
+	payload := data
+	if messageType == websocket.TextMessage {
+		kind = "text"
+		_ = json.Unmarshal(data, &v)
+	} else if checkMsgpack(data) == nil && msgpack.Unmarshal(data, &v) == nil {
+		// Show msgpack as JSON, with binary values by size
+		var b bytes.Buffer
+		enc := json.NewEncoder(&b)
+		enc.SetEscapeHTML(false)
+		if enc.Encode(summarizeBytes(v)) == nil {
+			payload = bytes.TrimSuffix(b.Bytes(), []byte("\n"))
+		}
+	}
+	event := ""
+	if m, ok := v.(map[string]interface{}); ok {
+		if name, ok := m["event"].(string); ok {
+			event = " event=" + name
+		}
+	}
+	l.printf("ws %s %s %dB%s %s", dir, kind, len(data), event, truncatePayload(payload))
+}
+
+// summarizeBytes replaces the binary values in a decoded msgpack value
+// with their sizes.
+func summarizeBytes(v interface{}) interface{} {
+	switch v := v.(type) {
+	case []byte:
+		return fmt.Sprintf("<%d bytes>", len(v))
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			out[k] = summarizeBytes(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = summarizeBytes(item)
+		}
+		return out
+	}
+	return v
+}
+
+// truncatePayload quotes up to wirePayloadLimit bytes of data.
+func truncatePayload(data []byte) string {
+	if len(data) > wirePayloadLimit {
+		return fmt.Sprintf("%q...", data[:wirePayloadLimit])
+	}
+	return fmt.Sprintf("%q", data)
+}
+
+// sensitive reports whether a header or query parameter may carry a
+// credential.
+func sensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range []string{"authorization", "key", "token", "secret", "signature", "credential", "cookie", "password"} {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatHeaders formats headers sorted by name, with credentials redacted.
+func formatHeaders(header http.Header) string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if sensitive(name) {
+			value = redacted
+		}
+		fmt.Fprintf(&b, " %s=%q", name, value)
+	}
+	return b.String()
+}
+
+// redactURL returns u with credentials in its query redacted.
+func redactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	query := u.Query()
+	changed := false
+	for name := range query {
+		if sensitive(name) {
+			query.Set(name, redacted)
+			changed = true
+		}
+	}
+	if !changed {
+		return u.Redacted()
+	}
+	copied := *u
+	copied.RawQuery = query.Encode()
+	return copied.Redacted()
+}
@@ -0,0 +1,52 @@
+package fishaudio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// headerWorkspace scopes a request to a workspace. See WithWorkspace.
+const headerWorkspace = "X-Workspace-Id"
+
+// Workspace is a workspace the API key can access.
+type Workspace struct {
+	ID   string `json:"_id"`
+	Name string `json:"name"`
+	// Role is the key owner's role in the workspace, e.g. "owner" or
+	// "member".
+	Role      string    `json:"role"`
+	CreatedAt Timestamp `json:"created_at"`
+
+	// Extra holds response fields not known to this SDK version.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a Workspace, collecting unknown fields in Extra.
+func (w *Workspace) UnmarshalJSON(data []byte) error {
+	type workspace Workspace
+	if err := json.Unmarshal(data, (*workspace)(w)); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, w)
+	w.Extra = extra
+	return err
+}
+
+// ListWorkspaces returns the workspaces the API key can access.
+//
+// Example:
+//
+//	workspaces, err := client.Account.ListWorkspaces(ctx)
+//	for _, ws := range workspaces {
+//	    credits, _ := client.InWorkspace(ws.ID).Account.GetCredits(ctx, nil)
+//	    fmt.Printf("%s: %s credits\n", ws.Name, credits.Credit)
+//	}
+func (s *AccountService) ListWorkspaces(ctx context.Context) ([]Workspace, error) {
+	var result PaginatedResponse[Workspace]
+	if err := s.client.doJSONRequest(ctx, http.MethodGet, "/workspace", nil, &result, nil); err != nil {
+		return nil, err
+	}
+
+	return result.Items, nil
+}
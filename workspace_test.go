@@ -0,0 +1,84 @@
+package fishaudio
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithWorkspace(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("X-Workspace-Id"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithWorkspace("ws-1"))
+	ctx := context.Background()
+
+	if _, err := client.Account.GetCredits(ctx, nil); err != nil {
+		t.Fatalf("GetCredits() error = %v", err)
+	}
+	write := func(w *multipart.Writer) error { return w.WriteField("a", "b") }
+	if _, err := client.ASR.send(ctx, "", write); err != nil {
+		t.Fatalf("send() error = %v", err)
+	}
+	if _, err := client.InWorkspace("ws-2").Account.GetPackage(ctx); err != nil {
+		t.Fatalf("GetPackage() error = %v", err)
+	}
+	if _, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL)).Account.GetPackage(ctx); err != nil {
+		t.Fatalf("GetPackage() error = %v", err)
+	}
+
+	want := []string{"ws-1", "ws-1", "ws-2", ""}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("workspace headers = %q, want %q", got, want)
+	}
+}
+
+func TestClient_InWorkspace_LeavesOriginal(t *testing.T) {
+	client := NewClient(WithAPIKey("test-key"), WithWorkspace("ws-1"))
+	scoped := client.InWorkspace("ws-2")
+
+	if client.workspace != "ws-1" || scoped.workspace != "ws-2" {
+		t.Errorf("workspaces = %q, %q; want ws-1, ws-2", client.workspace, scoped.workspace)
+	}
+	if scoped.Voices.client != scoped || scoped.TTS.client != scoped {
+		t.Error("scoped services point at the original client")
+	}
+	if scoped.httpClient != client.httpClient {
+		t.Error("scoped client does not share the HTTP client")
+	}
+}
+
+func TestAccountService_ListWorkspaces(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/workspace" {
+			t.Errorf("Path = %q, want %q", r.URL.Path, "/workspace")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"total":2,"items":[
+			{"_id":"ws-1","name":"Personal","role":"owner"},
+			{"_id":"ws-2","name":"Studio","role":"member","seats":5}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	workspaces, err := client.Account.ListWorkspaces(context.Background())
+	if err != nil {
+		t.Fatalf("ListWorkspaces() error = %v", err)
+	}
+	if len(workspaces) != 2 || workspaces[1].Name != "Studio" || workspaces[1].Role != "member" {
+		t.Fatalf("ListWorkspaces() = %+v", workspaces)
+	}
+	if !bytes.Equal(workspaces[1].Extra["seats"], []byte("5")) {
+		t.Errorf("Extra = %v, want seats", workspaces[1].Extra)
+	}
+}